@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"net/http"
 	"os"
 	"os/signal"
@@ -14,7 +15,16 @@ import (
 
 	"github.com/javiacuna/kinesio-backend/internal/config"
 	"github.com/javiacuna/kinesio-backend/internal/db"
+	exercisePlanGorm "github.com/javiacuna/kinesio-backend/internal/exerciseplans/infra/gorm"
+	exercisePlanUC "github.com/javiacuna/kinesio-backend/internal/exerciseplans/usecase"
 	httpapi "github.com/javiacuna/kinesio-backend/internal/http"
+	"github.com/javiacuna/kinesio-backend/internal/jobs"
+	materialDomain "github.com/javiacuna/kinesio-backend/internal/materials/domain"
+	materialGorm "github.com/javiacuna/kinesio-backend/internal/materials/infra/gorm"
+	materialNotifier "github.com/javiacuna/kinesio-backend/internal/materials/infra/notifier"
+	materialPatients "github.com/javiacuna/kinesio-backend/internal/materials/infra/patients"
+	materialUC "github.com/javiacuna/kinesio-backend/internal/materials/usecase"
+	patientGorm "github.com/javiacuna/kinesio-backend/internal/patients/infra/gorm"
 )
 
 func main() {
@@ -40,6 +50,53 @@ func main() {
 
 	router := httpapi.NewRouter(cfg, gormDB)
 
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	closeExpiredPlans := exercisePlanUC.NewCloseExpiredPlansUseCase(exercisePlanGorm.NewRepository(gormDB))
+
+	var notifier materialDomain.Notifier = materialNotifier.NewLogNotifier()
+	if cfg.LoanNotifyWebhookURL != "" {
+		notifier = materialNotifier.NewWebhookNotifier(cfg.LoanNotifyWebhookURL)
+	}
+	notifyOverdueLoans := materialUC.NewNotifyOverdueLoansUseCase(
+		materialGorm.NewRepository(gormDB),
+		materialPatients.NewGateway(patientGorm.New(gormDB)),
+		notifier,
+	)
+	notifyLowStock := materialUC.NewNotifyLowStockUseCase(materialGorm.NewRepository(gormDB), notifier)
+
+	jobs.Start(jobsCtx, jobs.Job{
+		Name:     "close_expired_plans",
+		Interval: cfg.JobsInterval,
+		Run: func(ctx context.Context) error {
+			n, err := closeExpiredPlans.Execute(ctx, time.Now())
+			if err == nil && n > 0 {
+				log.Info().Int64("closed", n).Msg("expired exercise plans closed")
+			}
+			return err
+		},
+	}, jobs.Job{
+		Name:     "notify_overdue_loans",
+		Interval: cfg.JobsInterval,
+		Run: func(ctx context.Context) error {
+			n, err := notifyOverdueLoans.Execute(ctx, time.Now())
+			if n > 0 {
+				log.Info().Int("notified", n).Msg("overdue material loans notified")
+			}
+			return err
+		},
+	}, jobs.Job{
+		Name:     "notify_low_stock",
+		Interval: cfg.JobsInterval,
+		Run: func(ctx context.Context) error {
+			n, err := notifyLowStock.Execute(ctx, time.Now())
+			if n > 0 {
+				log.Info().Int("notified", n).Msg("low stock materials notified")
+			}
+			return err
+		},
+	})
+
 	srv := &http.Server{
 		Addr:              ":" + cfg.HTTPPort,
 		Handler:           router,
@@ -58,6 +115,7 @@ func main() {
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
 	<-stop
 	log.Info().Msg("shutting down")
+	stopJobs()
 
 	ctx, cancel := config.ShutdownContext()
 	defer cancel()
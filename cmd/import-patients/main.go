@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/javiacuna/kinesio-backend/internal/config"
+	"github.com/javiacuna/kinesio-backend/internal/db"
+	patientsRepo "github.com/javiacuna/kinesio-backend/internal/patients/infra/gorm"
+	"github.com/javiacuna/kinesio-backend/internal/patients/infra/spreadsheet"
+	patientsUC "github.com/javiacuna/kinesio-backend/internal/patients/usecase"
+)
+
+// Importación masiva de pacientes desde CSV/XLSX.
+//
+//	go run ./cmd/import-patients -file pacientes.xlsx -dry-run
+//	go run ./cmd/import-patients -file pacientes.xlsx -report reporte.csv
+func main() {
+	file := flag.String("file", "", "archivo .csv o .xlsx a importar (requerido)")
+	dryRun := flag.Bool("dry-run", false, "solo valida, no inserta")
+	batchSize := flag.Int("batch-size", 100, "filas por lote/transacción")
+	report := flag.String("report", "", "ruta del reporte CSV (por defecto stdout)")
+	flag.Parse()
+
+	_ = godotenv.Load()
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
+
+	if *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg := config.MustLoad()
+
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to open file")
+	}
+	defer f.Close()
+
+	records, err := spreadsheet.Read(f, *file)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to parse file")
+	}
+
+	gormDB, err := db.OpenPostgres(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to connect database")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	uc := patientsUC.NewImportPatientsUseCase(patientsRepo.New(gormDB))
+	rep, err := uc.Execute(ctx, patientsUC.ImportPatientsInput{
+		Rows:      spreadsheet.ToImportRows(records),
+		DryRun:    *dryRun,
+		BatchSize: *batchSize,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("import failed")
+	}
+
+	var out io.Writer = os.Stdout
+	if *report != "" {
+		rf, err := os.Create(*report)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to create report")
+		}
+		defer rf.Close()
+		out = rf
+	}
+	if err := spreadsheet.WriteReportCSV(out, rep); err != nil {
+		log.Fatal().Err(err).Msg("failed to write report")
+	}
+
+	log.Info().
+		Bool("dry_run", rep.DryRun).
+		Int("total", rep.Total).
+		Int("valid", rep.Valid).
+		Int("invalid", rep.Invalid).
+		Int("created", rep.Created).
+		Int("failed", rep.Failed).
+		Msg("import finished")
+}
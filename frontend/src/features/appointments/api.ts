@@ -23,7 +23,7 @@ export function createAppointment(input: CreateAppointmentInput) {
 
 export type UpdateAppointmentInput = {
   id: string;
-  status?: "scheduled" | "cancelled";
+  status?: "scheduled" | "cancelled" | "attended";
   cancelled_reason?: string;
   notes?: string;
   start_at?: string;
@@ -4,6 +4,6 @@ export type Appointment = {
   kinesiologist_id: string;
   start_at: string;
   end_at: string;
-  status: "scheduled" | "cancelled";
+  status: "scheduled" | "cancelled" | "attended";
   notes?: string | null;
 };
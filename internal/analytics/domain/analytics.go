@@ -0,0 +1,43 @@
+package domain
+
+import "github.com/javiacuna/kinesio-backend/internal/analytics/trends"
+
+type Metric string
+
+const (
+	MetricPain     Metric = "pain"
+	MetricOswestry Metric = "oswestry"
+	MetricDASH     Metric = "dash"
+	MetricLysholm  Metric = "lysholm"
+)
+
+// OutcomeMetrics son los cuestionarios con puntaje total (KOOS se informa por subescala y no entra).
+var OutcomeMetrics = []Metric{MetricOswestry, MetricDASH, MetricLysholm}
+
+func (m Metric) IsOutcome() bool {
+	for _, v := range OutcomeMetrics {
+		if m == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Direction: en dolor, Oswestry y DASH un valor menor es mejor; en Lysholm, mayor.
+func (m Metric) Direction() trends.Direction {
+	if m == MetricLysholm {
+		return trends.HigherIsBetter
+	}
+	return trends.LowerIsBetter
+}
+
+type MetricTrend struct {
+	Metric Metric
+	Target *float64
+	Trend  trends.Trend
+}
+
+type PatientAnalytics struct {
+	Pain     MetricTrend
+	Outcomes []MetricTrend
+}
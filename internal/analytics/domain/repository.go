@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/analytics/trends"
+)
+
+type Repository interface {
+	// PainSeries: dolor de cada evolución vigente (última versión), en orden cronológico.
+	PainSeries(ctx context.Context, patientID uuid.UUID) ([]trends.Point, error)
+	// OutcomeSeries: puntaje total del cuestionario por medición, en orden cronológico.
+	OutcomeSeries(ctx context.Context, patientID uuid.UUID, metric Metric) ([]trends.Point, error)
+}
@@ -0,0 +1,113 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/javiacuna/kinesio-backend/internal/analytics/domain"
+	"github.com/javiacuna/kinesio-backend/internal/analytics/trends"
+	"github.com/javiacuna/kinesio-backend/internal/analytics/usecase"
+)
+
+type Handler struct {
+	getUC *usecase.GetPatientAnalyticsUseCase
+}
+
+func NewHandler(getUC *usecase.GetPatientAnalyticsUseCase) *Handler {
+	return &Handler{getUC: getUC}
+}
+
+// Un punto por sesión: valor registrado + promedio móvil, listo para graficar.
+type pointResponse struct {
+	At            string  `json:"at"`
+	Value         float64 `json:"value"`
+	MovingAverage float64 `json:"moving_average"`
+}
+
+type alertResponse struct {
+	FromAt    string  `json:"from_at"`
+	ToAt      string  `json:"to_at"`
+	FromValue float64 `json:"from_value"`
+	ToValue   float64 `json:"to_value"`
+	Delta     float64 `json:"delta"`
+}
+
+type trendResponse struct {
+	Metric           string          `json:"metric"`
+	HigherIsBetter   bool            `json:"higher_is_better"`
+	Target           *float64        `json:"target,omitempty"`
+	Baseline         *float64        `json:"baseline"`
+	Latest           *float64        `json:"latest"`
+	ImprovementPct   *float64        `json:"improvement_pct"`
+	SessionsToTarget *int            `json:"sessions_to_target"`
+	Series           []pointResponse `json:"series"`
+	Alerts           []alertResponse `json:"alerts"`
+}
+
+// Get: ?window=3&pain_target=2&min_delta=1&metrics=oswestry,dash&targets=oswestry:20
+func (h *Handler) Get(c *gin.Context) {
+	out, validation, err := h.getUC.Execute(c.Request.Context(), c.Param("patient_id"), usecase.GetPatientAnalyticsInput{
+		Window:     queryPtr(c, "window"),
+		PainTarget: queryPtr(c, "pain_target"),
+		MinDelta:   queryPtr(c, "min_delta"),
+		Metrics:    queryPtr(c, "metrics"),
+		Targets:    queryPtr(c, "targets"),
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrValidation) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	outcomes := make([]trendResponse, 0, len(out.Outcomes))
+	for _, m := range out.Outcomes {
+		outcomes = append(outcomes, toTrendResponse(m))
+	}
+	c.JSON(http.StatusOK, gin.H{"pain": toTrendResponse(out.Pain), "outcomes": outcomes})
+}
+
+func toTrendResponse(m domain.MetricTrend) trendResponse {
+	t := m.Trend
+	out := trendResponse{
+		Metric:           string(m.Metric),
+		HigherIsBetter:   m.Metric.Direction() == trends.HigherIsBetter,
+		Target:           m.Target,
+		Baseline:         t.Baseline,
+		Latest:           t.Latest,
+		ImprovementPct:   t.ImprovementPct,
+		SessionsToTarget: t.SessionsToTarget,
+		Series:           make([]pointResponse, 0, len(t.Points)),
+		Alerts:           make([]alertResponse, 0, len(t.Alerts)),
+	}
+	for i, p := range t.Points {
+		out.Series = append(out.Series, pointResponse{
+			At:            p.At.UTC().Format(time.RFC3339),
+			Value:         p.Value,
+			MovingAverage: t.MovingAverage[i],
+		})
+	}
+	for _, a := range t.Alerts {
+		out.Alerts = append(out.Alerts, alertResponse{
+			FromAt:    a.From.At.UTC().Format(time.RFC3339),
+			ToAt:      a.To.At.UTC().Format(time.RFC3339),
+			FromValue: a.From.Value,
+			ToValue:   a.To.Value,
+			Delta:     a.Delta,
+		})
+	}
+	return out
+}
+
+func queryPtr(c *gin.Context, key string) *string {
+	v, ok := c.GetQuery(key)
+	if !ok {
+		return nil
+	}
+	return &v
+}
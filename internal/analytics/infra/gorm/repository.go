@@ -0,0 +1,67 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/javiacuna/kinesio-backend/internal/analytics/domain"
+	"github.com/javiacuna/kinesio-backend/internal/analytics/trends"
+)
+
+var _ domain.Repository = (*Repository)(nil)
+
+// Repository lee evoluciones y mediciones directamente (solo lectura).
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+type pointRow struct {
+	At    time.Time
+	Value float64
+}
+
+// PainSeries toma el dolor de la versión vigente de cada evolución, fechado con la
+// versión 1 (root): una corrección no mueve la sesión al día en que se corrigió.
+func (r *Repository) PainSeries(ctx context.Context, patientID uuid.UUID) ([]trends.Point, error) {
+	var rows []pointRow
+	err := r.db.WithContext(ctx).
+		Table("patient_evolutions e").
+		Joins("JOIN patient_evolutions r ON r.id = e.root_id").
+		Select("r.created_at AS at, e.pain_level::float8 AS value").
+		Where("e.patient_id = ? AND e.is_latest AND e.pain_level IS NOT NULL", patientID.String()).
+		Order("r.created_at asc").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return toPoints(rows), nil
+}
+
+func (r *Repository) OutcomeSeries(ctx context.Context, patientID uuid.UUID, metric domain.Metric) ([]trends.Point, error) {
+	var rows []pointRow
+	err := r.db.WithContext(ctx).
+		Table("evolution_measurements").
+		Select("measured_at AS at, value").
+		Where("patient_id = ? AND metric = ? AND value IS NOT NULL", patientID.String(), string(metric)).
+		Order("measured_at asc").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return toPoints(rows), nil
+}
+
+func toPoints(rows []pointRow) []trends.Point {
+	out := make([]trends.Point, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, trends.Point{At: row.At, Value: row.Value})
+	}
+	return out
+}
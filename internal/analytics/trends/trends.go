@@ -0,0 +1,152 @@
+// Package trends calcula indicadores de evolución a partir de series de valores por sesión
+// (dolor, puntajes de cuestionarios). No accede a la base: recibe los puntos ya ordenados.
+package trends
+
+import (
+	"math"
+	"time"
+)
+
+// Point es un valor registrado en una sesión.
+type Point struct {
+	At    time.Time
+	Value float64
+}
+
+type Direction int
+
+const (
+	LowerIsBetter  Direction = iota // dolor, Oswestry, DASH
+	HigherIsBetter                  // Lysholm, KOOS, ROM
+)
+
+// MovingAverage es el promedio móvil "hacia atrás": el punto i promedia los últimos window valores
+// hasta i inclusive (al principio de la serie promedia los que haya).
+func MovingAverage(values []float64, window int) []float64 {
+	if window < 1 {
+		window = 1
+	}
+	out := make([]float64, len(values))
+	sum := 0.0
+	for i, v := range values {
+		sum += v
+		if i >= window {
+			sum -= values[i-window]
+		}
+		n := min(i+1, window)
+		out[i] = round2(sum / float64(n))
+	}
+	return out
+}
+
+// ImprovementPct es la mejora porcentual de current respecto de baseline (positivo = mejoró).
+// Devuelve false si baseline es 0 (no hay referencia para calcular un porcentaje).
+func ImprovementPct(baseline, current float64, dir Direction) (float64, bool) {
+	if baseline == 0 {
+		return 0, false
+	}
+	delta := current - baseline
+	if dir == LowerIsBetter {
+		delta = -delta
+	}
+	return round2(delta / math.Abs(baseline) * 100), true
+}
+
+// SessionsToTarget devuelve en qué sesión (1 = la primera) se alcanzó el objetivo por primera vez.
+func SessionsToTarget(points []Point, target float64, dir Direction) (int, bool) {
+	for i, p := range points {
+		if reached(p.Value, target, dir) {
+			return i + 1, true
+		}
+	}
+	return 0, false
+}
+
+// Alert: el valor empeoró entre dos sesiones consecutivas.
+type Alert struct {
+	From  Point
+	To    Point
+	Delta float64 // siempre positivo: cuánto empeoró
+}
+
+// WorseningAlerts marca cada par de sesiones consecutivas en que el valor empeoró al menos minDelta.
+func WorseningAlerts(points []Point, minDelta float64, dir Direction) []Alert {
+	var out []Alert
+	for i := 1; i < len(points); i++ {
+		delta := points[i].Value - points[i-1].Value
+		if dir == HigherIsBetter {
+			delta = -delta
+		}
+		if delta > 0 && delta >= minDelta {
+			out = append(out, Alert{From: points[i-1], To: points[i], Delta: round2(delta)})
+		}
+	}
+	return out
+}
+
+type Options struct {
+	Direction Direction
+	Window    int      // promedio móvil (por defecto 3)
+	Target    *float64 // objetivo opcional (p. ej. dolor <= 2)
+	MinDelta  float64  // empeoramiento mínimo para alertar (por defecto 1)
+}
+
+// Trend resume una serie; MovingAverage tiene un valor por punto para graficarlo junto a Points.
+type Trend struct {
+	Points           []Point
+	MovingAverage    []float64
+	Baseline         *float64
+	Latest           *float64
+	ImprovementPct   *float64
+	SessionsToTarget *int
+	Alerts           []Alert
+}
+
+// Analyze calcula todos los indicadores de una serie ordenada cronológicamente.
+func Analyze(points []Point, opts Options) Trend {
+	if opts.Window < 1 {
+		opts.Window = 3
+	}
+	if opts.MinDelta <= 0 {
+		opts.MinDelta = 1
+	}
+
+	values := make([]float64, len(points))
+	for i, p := range points {
+		values[i] = p.Value
+	}
+
+	t := Trend{
+		Points:        points,
+		MovingAverage: MovingAverage(values, opts.Window),
+		Alerts:        WorseningAlerts(points, opts.MinDelta, opts.Direction),
+	}
+	if len(points) == 0 {
+		return t
+	}
+
+	baseline := points[0].Value
+	latest := points[len(points)-1].Value
+	t.Baseline = &baseline
+	t.Latest = &latest
+	if pct, ok := ImprovementPct(baseline, latest, opts.Direction); ok {
+		t.ImprovementPct = &pct
+	}
+	if opts.Target != nil {
+		if n, ok := SessionsToTarget(points, *opts.Target, opts.Direction); ok {
+			t.SessionsToTarget = &n
+		}
+	}
+	return t
+}
+
+func reached(v, target float64, dir Direction) bool {
+	if dir == LowerIsBetter {
+		return v <= target
+	}
+	return v >= target
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,153 @@
+package trends
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func series(values ...float64) []Point {
+	start := time.Date(2025, 3, 3, 10, 0, 0, 0, time.UTC)
+	out := make([]Point, len(values))
+	for i, v := range values {
+		out[i] = Point{At: start.AddDate(0, 0, 7*i), Value: v}
+	}
+	return out
+}
+
+func TestMovingAverage(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		window int
+		want   []float64
+	}{
+		{"vacía", nil, 3, []float64{}},
+		{"ventana 1 devuelve los mismos valores", []float64{8, 6, 5}, 1, []float64{8, 6, 5}},
+		{"arranque con menos valores que la ventana", []float64{8, 6, 4, 2}, 3, []float64{8, 7, 6, 4}},
+		{"redondeo a 2 decimales", []float64{1, 2, 2}, 3, []float64{1, 1.5, 1.67}},
+		{"ventana inválida se toma como 1", []float64{3, 4}, 0, []float64{3, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MovingAverage(tt.values, tt.window)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("MovingAverage(%v, %d) = %v, want %v", tt.values, tt.window, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImprovementPct(t *testing.T) {
+	tests := []struct {
+		name              string
+		baseline, current float64
+		dir               Direction
+		want              float64
+		ok                bool
+	}{
+		{"dolor que baja es mejora", 8, 2, LowerIsBetter, 75, true},
+		{"dolor que sube es empeoramiento", 4, 6, LowerIsBetter, -50, true},
+		{"escala donde más es mejor", 40, 70, HigherIsBetter, 75, true},
+		{"sin cambios", 5, 5, LowerIsBetter, 0, true},
+		{"línea de base en cero", 0, 3, LowerIsBetter, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ImprovementPct(tt.baseline, tt.current, tt.dir)
+			if got != tt.want || ok != tt.ok {
+				t.Fatalf("ImprovementPct(%v, %v) = (%v, %v), want (%v, %v)", tt.baseline, tt.current, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestSessionsToTarget(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []Point
+		target float64
+		dir    Direction
+		want   int
+		ok     bool
+	}{
+		{"alcanzado en la tercera sesión", series(8, 5, 2, 1), 2, LowerIsBetter, 3, true},
+		{"alcanzado desde el inicio", series(1, 3), 2, LowerIsBetter, 1, true},
+		{"no alcanzado", series(8, 7, 6), 2, LowerIsBetter, 0, false},
+		{"más es mejor", series(30, 60, 85), 80, HigherIsBetter, 3, true},
+		{"serie vacía", nil, 2, LowerIsBetter, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := SessionsToTarget(tt.points, tt.target, tt.dir)
+			if got != tt.want || ok != tt.ok {
+				t.Fatalf("SessionsToTarget = (%d, %v), want (%d, %v)", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestWorseningAlerts(t *testing.T) {
+	tests := []struct {
+		name     string
+		points   []Point
+		minDelta float64
+		dir      Direction
+		want     []float64 // deltas esperados
+	}{
+		{"sin subas no hay alertas", series(8, 6, 6, 3), 1, LowerIsBetter, nil},
+		{"una suba de dolor", series(8, 4, 6, 5), 1, LowerIsBetter, []float64{2}},
+		{"subas por debajo del umbral se ignoran", series(5, 6, 4, 7), 2, LowerIsBetter, []float64{3}},
+		{"más es mejor: alerta cuando baja", series(50, 70, 60), 1, HigherIsBetter, []float64{10}},
+		{"un solo punto", series(5), 1, LowerIsBetter, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alerts := WorseningAlerts(tt.points, tt.minDelta, tt.dir)
+			var got []float64
+			for _, a := range alerts {
+				got = append(got, a.Delta)
+				if !a.To.At.After(a.From.At) {
+					t.Fatalf("alerta con sesiones fuera de orden: %+v", a)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("deltas = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	target := 2.0
+	tr := Analyze(series(8, 6, 7, 3, 2), Options{Direction: LowerIsBetter, Target: &target})
+
+	if tr.Baseline == nil || *tr.Baseline != 8 {
+		t.Fatalf("baseline = %v, want 8", tr.Baseline)
+	}
+	if tr.Latest == nil || *tr.Latest != 2 {
+		t.Fatalf("latest = %v, want 2", tr.Latest)
+	}
+	if tr.ImprovementPct == nil || *tr.ImprovementPct != 75 {
+		t.Fatalf("improvement = %v, want 75", tr.ImprovementPct)
+	}
+	if tr.SessionsToTarget == nil || *tr.SessionsToTarget != 5 {
+		t.Fatalf("sessions to target = %v, want 5", tr.SessionsToTarget)
+	}
+	if want := []float64{8, 7, 7, 5.33, 4}; !reflect.DeepEqual(tr.MovingAverage, want) {
+		t.Fatalf("moving average = %v, want %v", tr.MovingAverage, want)
+	}
+	if len(tr.Alerts) != 1 || tr.Alerts[0].From.Value != 6 || tr.Alerts[0].To.Value != 7 {
+		t.Fatalf("alerts = %+v, want una alerta 6 -> 7", tr.Alerts)
+	}
+}
+
+func TestAnalyzeEmpty(t *testing.T) {
+	tr := Analyze(nil, Options{})
+	if tr.Baseline != nil || tr.Latest != nil || tr.ImprovementPct != nil || tr.SessionsToTarget != nil {
+		t.Fatalf("serie vacía no debería tener indicadores: %+v", tr)
+	}
+	if len(tr.MovingAverage) != 0 || len(tr.Alerts) != 0 {
+		t.Fatalf("serie vacía: %+v", tr)
+	}
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/analytics/domain"
+	"github.com/javiacuna/kinesio-backend/internal/analytics/trends"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+// Objetivo de dolor por defecto (EVA <= 2: dolor leve).
+const defaultPainTarget = 2.0
+
+type GetPatientAnalyticsInput struct {
+	Window     *string // promedio móvil, en sesiones
+	PainTarget *string
+	MinDelta   *string // suba mínima (en puntos) para alertar
+	Metrics    *string // oswestry,dash,lysholm (por defecto todos)
+	Targets    *string // objetivo por cuestionario: oswestry:20,lysholm:84
+}
+
+type GetPatientAnalyticsUseCase struct {
+	repo domain.Repository
+}
+
+func NewGetPatientAnalyticsUseCase(repo domain.Repository) *GetPatientAnalyticsUseCase {
+	return &GetPatientAnalyticsUseCase{repo: repo}
+}
+
+func (uc *GetPatientAnalyticsUseCase) Execute(ctx context.Context, patientID string, in GetPatientAnalyticsInput) (domain.PatientAnalytics, *validation.Errors, error) {
+	validation := validation.New()
+
+	pid, err := uuid.Parse(strings.TrimSpace(patientID))
+	if err != nil {
+		validation.Add("patient_id", "invalid_uuid")
+	}
+
+	window := 3
+	if v := parseNumber("window", in.Window, validation); v != nil {
+		if *v < 1 || *v != float64(int(*v)) {
+			validation.Add("window", "must_be_positive_integer")
+		} else {
+			window = int(*v)
+		}
+	}
+	painTarget := defaultPainTarget
+	if v := parseNumber("pain_target", in.PainTarget, validation); v != nil {
+		if *v < 0 || *v > 10 {
+			validation.Add("pain_target", "must_be_between_0_and_10")
+		} else {
+			painTarget = *v
+		}
+	}
+	minDelta := 1.0
+	if v := parseNumber("min_delta", in.MinDelta, validation); v != nil {
+		if *v <= 0 {
+			validation.Add("min_delta", "must_be_positive")
+		} else {
+			minDelta = *v
+		}
+	}
+
+	metrics := domain.OutcomeMetrics
+	if in.Metrics != nil && strings.TrimSpace(*in.Metrics) != "" {
+		metrics = nil
+		for _, s := range strings.Split(*in.Metrics, ",") {
+			m := domain.Metric(strings.ToLower(strings.TrimSpace(s)))
+			if !m.IsOutcome() {
+				validation.Add("metrics", "invalid_metric")
+				break
+			}
+			metrics = append(metrics, m)
+		}
+	}
+	targets := parseTargets(in.Targets, validation)
+
+	if !validation.Empty() {
+		return domain.PatientAnalytics{}, validation, domain.ErrValidation
+	}
+
+	pain, err := uc.repo.PainSeries(ctx, pid)
+	if err != nil {
+		return domain.PatientAnalytics{}, nil, err
+	}
+	out := domain.PatientAnalytics{
+		Pain: domain.MetricTrend{
+			Metric: domain.MetricPain,
+			Target: &painTarget,
+			Trend: trends.Analyze(pain, trends.Options{
+				Direction: domain.MetricPain.Direction(),
+				Window:    window,
+				Target:    &painTarget,
+				MinDelta:  minDelta,
+			}),
+		},
+		Outcomes: make([]domain.MetricTrend, 0, len(metrics)),
+	}
+
+	for _, m := range metrics {
+		points, err := uc.repo.OutcomeSeries(ctx, pid, m)
+		if err != nil {
+			return domain.PatientAnalytics{}, nil, err
+		}
+		target := targets[m]
+		out.Outcomes = append(out.Outcomes, domain.MetricTrend{
+			Metric: m,
+			Target: target,
+			Trend: trends.Analyze(points, trends.Options{
+				Direction: m.Direction(),
+				Window:    window,
+				Target:    target,
+				MinDelta:  minDelta,
+			}),
+		})
+	}
+	return out, nil, nil
+}
+
+func parseNumber(field string, s *string, validation *validation.Errors) *float64 {
+	if s == nil || strings.TrimSpace(*s) == "" {
+		return nil
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(*s), 64)
+	if err != nil {
+		validation.Add(field, "must_be_number")
+		return nil
+	}
+	return &v
+}
+
+func parseTargets(s *string, validation *validation.Errors) map[domain.Metric]*float64 {
+	out := map[domain.Metric]*float64{}
+	if s == nil || strings.TrimSpace(*s) == "" {
+		return out
+	}
+	for _, pair := range strings.Split(*s, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		m := domain.Metric(strings.ToLower(strings.TrimSpace(name)))
+		if !ok || !m.IsOutcome() {
+			validation.Add("targets", "invalid_format_(metric:value)")
+			return out
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			validation.Add("targets", "invalid_format_(metric:value)")
+			return out
+		}
+		out[m] = &v
+	}
+	return out
+}
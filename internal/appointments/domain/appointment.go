@@ -11,8 +11,12 @@ type Status string
 const (
 	StatusScheduled Status = "scheduled"
 	StatusCancelled Status = "cancelled"
+	StatusAttended  Status = "attended"
 )
 
+// WarningSessionNotConsumed: el turno quedó atendido pero no había una orden vigente con cupo.
+const WarningSessionNotConsumed = "session_not_consumed"
+
 type Appointment struct {
 	ID              uuid.UUID
 	PatientID       uuid.UUID
@@ -45,7 +45,7 @@ type createReq struct {
 type updateReq struct {
 	StartAt         *string `json:"start_at,omitempty"`
 	EndAt           *string `json:"end_at,omitempty"`
-	Status          *string `json:"status,omitempty"` // scheduled|cancelled
+	Status          *string `json:"status,omitempty"` // scheduled|cancelled|attended
 	CancelledReason *string `json:"cancelled_reason,omitempty"`
 	Notes           *string `json:"notes,omitempty"`
 }
@@ -63,6 +63,12 @@ type resp struct {
 	UpdatedAt       string  `json:"updated_at"`
 }
 
+// warningsResp: el turno más los avisos de cobertura para recepción.
+type warningsResp struct {
+	resp
+	Warnings []string `json:"warnings,omitempty"`
+}
+
 func (h *Handler) Create(c *gin.Context) {
 	if !isReceptionist(c.GetHeader("Authorization")) {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
@@ -95,7 +101,7 @@ func (h *Handler) Create(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, toResp(out))
+	c.JSON(http.StatusCreated, warningsResp{resp: toResp(out.Appointment), Warnings: out.Warnings})
 }
 
 func (h *Handler) ListDay(c *gin.Context) {
@@ -155,7 +161,7 @@ func (h *Handler) Update(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, toResp(out))
+	c.JSON(http.StatusOK, warningsResp{resp: toResp(out.Appointment), Warnings: out.Warnings})
 }
 
 func toResp(a domain.Appointment) resp {
@@ -78,10 +78,10 @@ func (r *Repository) read(ctx context.Context, id uuid.UUID) (domain.Appointment
 }
 
 func (r *Repository) HasOverlap(ctx context.Context, kinesiologistID uuid.UUID, startAt, endAt time.Time, excludeID *uuid.UUID) (bool, error) {
-	// Solapamiento: start < existing_end AND end > existing_start (solo scheduled)
+	// Solapamiento: start < existing_end AND end > existing_start (scheduled o attended)
 	q := r.db.WithContext(ctx).Model(&AppointmentModel{}).
 		Where("kinesiologist_id = ?", kinesiologistID).
-		Where("status = ?", string(domain.StatusScheduled)).
+		Where("status IN ?", []string{string(domain.StatusScheduled), string(domain.StatusAttended)}).
 		Where("? < end_at AND ? > start_at", startAt, endAt)
 
 	if excludeID != nil {
@@ -22,3 +22,12 @@ type Repository interface {
 	ListByPatientAndRange(ctx context.Context, patientID uuid.UUID,
 		from time.Time, to time.Time) ([]domain.Appointment, error)
 }
+
+// PrescriptionChecker: cobertura de órdenes médicas (lo implementa el módulo de prescripciones).
+type PrescriptionChecker interface {
+	// CoverageWarnings devuelve avisos para recepción (sin orden vigente, sesiones agotadas, etc.).
+	CoverageWarnings(ctx context.Context, patientID uuid.UUID, at time.Time) ([]string, error)
+	// ConsumeSession descuenta una sesión al marcar el turno como atendido (idempotente por turno).
+	ConsumeSession(ctx context.Context, patientID, appointmentID uuid.UUID, at time.Time) (bool, error)
+	ReleaseSession(ctx context.Context, appointmentID uuid.UUID) error
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/javiacuna/kinesio-backend/internal/appointments/domain"
 	"github.com/javiacuna/kinesio-backend/internal/appointments/ports"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
 )
 
 type CreateAppointmentInput struct {
@@ -18,48 +19,55 @@ type CreateAppointmentInput struct {
 	Notes           *string
 }
 
+type CreateAppointmentResult struct {
+	Appointment domain.Appointment
+	// Warnings: avisos de cobertura (no bloquean el turno).
+	Warnings []string
+}
+
 type CreateAppointmentUseCase struct {
-	repo ports.Repository
+	repo          ports.Repository
+	prescriptions ports.PrescriptionChecker
 }
 
-func NewCreateAppointmentUseCase(repo ports.Repository) *CreateAppointmentUseCase {
-	return &CreateAppointmentUseCase{repo: repo}
+func NewCreateAppointmentUseCase(repo ports.Repository, prescriptions ports.PrescriptionChecker) *CreateAppointmentUseCase {
+	return &CreateAppointmentUseCase{repo: repo, prescriptions: prescriptions}
 }
 
-func (uc *CreateAppointmentUseCase) Execute(ctx context.Context, in CreateAppointmentInput) (domain.Appointment, map[string]string, error) {
-	errs := map[string]string{}
+func (uc *CreateAppointmentUseCase) Execute(ctx context.Context, in CreateAppointmentInput) (CreateAppointmentResult, *validation.Errors, error) {
+	errs := validation.New()
 
 	pid, err := uuid.Parse(strings.TrimSpace(in.PatientID))
 	if err != nil {
-		errs["patient_id"] = "UUID inválido"
+		errs.Add("patient_id", "invalid_uuid")
 	}
 	kid, err := uuid.Parse(strings.TrimSpace(in.KinesiologistID))
 	if err != nil {
-		errs["kinesiologist_id"] = "UUID inválido"
+		errs.Add("kinesiologist_id", "invalid_uuid")
 	}
 
 	startAt, err := time.Parse(time.RFC3339, strings.TrimSpace(in.StartAt))
 	if err != nil {
-		errs["start_at"] = "Formato inválido (usar RFC3339)"
+		errs.Add("start_at", "invalid_datetime_(RFC3339)")
 	}
 	endAt, err := time.Parse(time.RFC3339, strings.TrimSpace(in.EndAt))
 	if err != nil {
-		errs["end_at"] = "Formato inválido (usar RFC3339)"
+		errs.Add("end_at", "invalid_datetime_(RFC3339)")
 	}
 	if err == nil && !endAt.After(startAt) {
-		errs["end_at"] = "Debe ser mayor a start_at"
+		errs.Add("end_at", "must_be_>_start_at")
 	}
 
-	if len(errs) > 0 {
-		return domain.Appointment{}, errs, domain.ErrValidation
+	if !errs.Empty() {
+		return CreateAppointmentResult{}, errs, domain.ErrValidation
 	}
 
 	overlap, err := uc.repo.HasOverlap(ctx, kid, startAt.UTC(), endAt.UTC(), nil)
 	if err != nil {
-		return domain.Appointment{}, nil, err
+		return CreateAppointmentResult{}, nil, err
 	}
 	if overlap {
-		return domain.Appointment{}, nil, domain.ErrOverlap
+		return CreateAppointmentResult{}, nil, domain.ErrOverlap
 	}
 
 	a := domain.Appointment{
@@ -73,9 +81,14 @@ func (uc *CreateAppointmentUseCase) Execute(ctx context.Context, in CreateAppoin
 	}
 	created, err := uc.repo.Create(ctx, a)
 	if err != nil {
-		return domain.Appointment{}, nil, err
+		return CreateAppointmentResult{}, nil, err
+	}
+
+	warnings, err := uc.prescriptions.CoverageWarnings(ctx, created.PatientID, created.StartAt)
+	if err != nil {
+		return CreateAppointmentResult{}, nil, err
 	}
-	return created, nil, nil
+	return CreateAppointmentResult{Appointment: created, Warnings: warnings}, nil, nil
 }
 
 func trimPtr(s *string) *string {
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/javiacuna/kinesio-backend/internal/appointments/domain"
 	"github.com/javiacuna/kinesio-backend/internal/appointments/ports"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
 )
 
 type ListAppointmentsDayUseCase struct {
@@ -19,20 +20,20 @@ func NewListAppointmentsDayUseCase(repo ports.Repository) *ListAppointmentsDayUs
 }
 
 // date: YYYY-MM-DD; retorna [date 00:00, next day 00:00) en UTC (simple para empezar)
-func (uc *ListAppointmentsDayUseCase) Execute(ctx context.Context, kinesiologistID string, date string) ([]domain.Appointment, map[string]string, error) {
-	errs := map[string]string{}
+func (uc *ListAppointmentsDayUseCase) Execute(ctx context.Context, kinesiologistID string, date string) ([]domain.Appointment, *validation.Errors, error) {
+	errs := validation.New()
 
 	kid, err := uuid.Parse(strings.TrimSpace(kinesiologistID))
 	if err != nil {
-		errs["kinesiologist_id"] = "UUID inválido"
+		errs.Add("kinesiologist_id", "invalid_uuid")
 	}
 
 	day, err := time.Parse("2006-01-02", strings.TrimSpace(date))
 	if err != nil {
-		errs["date"] = "Formato inválido (usar YYYY-MM-DD)"
+		errs.Add("date", "invalid_date_(YYYY-MM-DD)")
 	}
 
-	if len(errs) > 0 {
+	if !errs.Empty() {
 		return nil, errs, domain.ErrValidation
 	}
 
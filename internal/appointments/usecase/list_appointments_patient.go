@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/javiacuna/kinesio-backend/internal/appointments/domain"
 	"github.com/javiacuna/kinesio-backend/internal/appointments/ports"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
 )
 
 type ListAppointmentsByPatientUseCase struct {
@@ -23,26 +24,26 @@ func (uc *ListAppointmentsByPatientUseCase) Execute(
 	patientID string,
 	from string,
 	to string,
-) ([]domain.Appointment, map[string]string, error) {
+) ([]domain.Appointment, *validation.Errors, error) {
 
-	errs := map[string]string{}
+	errs := validation.New()
 
 	pid, err := uuid.Parse(strings.TrimSpace(patientID))
 	if err != nil {
-		errs["patient_id"] = "UUID inválido"
+		errs.Add("patient_id", "invalid_uuid")
 	}
 
 	start, err := time.Parse(time.RFC3339, strings.TrimSpace(from))
 	if err != nil {
-		errs["from"] = "Formato inválido (RFC3339)"
+		errs.Add("from", "invalid_datetime_(RFC3339)")
 	}
 
 	end, err := time.Parse(time.RFC3339, strings.TrimSpace(to))
 	if err != nil {
-		errs["to"] = "Formato inválido (RFC3339)"
+		errs.Add("to", "invalid_datetime_(RFC3339)")
 	}
 
-	if len(errs) > 0 {
+	if !errs.Empty() {
 		return nil, errs, domain.ErrValidation
 	}
 
@@ -8,48 +8,57 @@ import (
 	"github.com/google/uuid"
 	"github.com/javiacuna/kinesio-backend/internal/appointments/domain"
 	"github.com/javiacuna/kinesio-backend/internal/appointments/ports"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
 )
 
 type UpdateAppointmentInput struct {
 	StartAt         *string // RFC3339 (opcional)
 	EndAt           *string // RFC3339 (opcional)
-	Status          *string // "scheduled" | "cancelled" (opcional)
+	Status          *string // "scheduled" | "cancelled" | "attended" (opcional)
 	CancelledReason *string // opcional
 	Notes           *string // opcional
 }
 
+type UpdateAppointmentResult struct {
+	Appointment domain.Appointment
+	// Warnings: avisos de cobertura si al atender no se pudo descontar la sesión.
+	Warnings []string
+}
+
 type UpdateAppointmentUseCase struct {
-	repo ports.Repository
+	repo          ports.Repository
+	prescriptions ports.PrescriptionChecker
 }
 
-func NewUpdateAppointmentUseCase(repo ports.Repository) *UpdateAppointmentUseCase {
-	return &UpdateAppointmentUseCase{repo: repo}
+func NewUpdateAppointmentUseCase(repo ports.Repository, prescriptions ports.PrescriptionChecker) *UpdateAppointmentUseCase {
+	return &UpdateAppointmentUseCase{repo: repo, prescriptions: prescriptions}
 }
 
-func (uc *UpdateAppointmentUseCase) Execute(ctx context.Context, id string, in UpdateAppointmentInput) (domain.Appointment, map[string]string, error) {
-	errs := map[string]string{}
+func (uc *UpdateAppointmentUseCase) Execute(ctx context.Context, id string, in UpdateAppointmentInput) (UpdateAppointmentResult, *validation.Errors, error) {
+	errs := validation.New()
 
 	aid, err := uuid.Parse(strings.TrimSpace(id))
 	if err != nil {
-		errs["id"] = "UUID inválido"
-		return domain.Appointment{}, errs, domain.ErrValidation
+		errs.Add("id", "invalid_uuid")
+		return UpdateAppointmentResult{}, errs, domain.ErrValidation
 	}
 
 	current, found, err := uc.repo.GetByID(ctx, aid)
 	if err != nil {
-		return domain.Appointment{}, nil, err
+		return UpdateAppointmentResult{}, nil, err
 	}
 	if !found {
-		return domain.Appointment{}, nil, domain.ErrNotFound
+		return UpdateAppointmentResult{}, nil, domain.ErrNotFound
 	}
+	previous := current
 
 	// Status
 	if in.Status != nil {
 		switch domain.Status(strings.TrimSpace(*in.Status)) {
-		case domain.StatusScheduled, domain.StatusCancelled:
+		case domain.StatusScheduled, domain.StatusCancelled, domain.StatusAttended:
 			current.Status = domain.Status(strings.TrimSpace(*in.Status))
 		default:
-			errs["status"] = "Valor inválido (scheduled|cancelled)"
+			errs.AddMessage("status", "must_be_scheduled_cancelled_or_attended", "Valor inválido (scheduled|cancelled|attended)")
 		}
 	}
 
@@ -69,7 +78,7 @@ func (uc *UpdateAppointmentUseCase) Execute(ctx context.Context, id string, in U
 	if in.StartAt != nil {
 		tm, e := time.Parse(time.RFC3339, strings.TrimSpace(*in.StartAt))
 		if e != nil {
-			errs["start_at"] = "Formato inválido (RFC3339)"
+			errs.Add("start_at", "invalid_datetime_(RFC3339)")
 		} else {
 			newStart = tm.UTC()
 		}
@@ -77,17 +86,17 @@ func (uc *UpdateAppointmentUseCase) Execute(ctx context.Context, id string, in U
 	if in.EndAt != nil {
 		tm, e := time.Parse(time.RFC3339, strings.TrimSpace(*in.EndAt))
 		if e != nil {
-			errs["end_at"] = "Formato inválido (RFC3339)"
+			errs.Add("end_at", "invalid_datetime_(RFC3339)")
 		} else {
 			newEnd = tm.UTC()
 		}
 	}
 	if (in.StartAt != nil || in.EndAt != nil) && !newEnd.After(newStart) {
-		errs["end_at"] = "Debe ser mayor a start_at"
+		errs.Add("end_at", "must_be_>_start_at")
 	}
 
-	if len(errs) > 0 {
-		return domain.Appointment{}, errs, domain.ErrValidation
+	if !errs.Empty() {
+		return UpdateAppointmentResult{}, errs, domain.ErrValidation
 	}
 
 	// Si se reprogramó, validar solapamiento (excluyéndose)
@@ -95,10 +104,10 @@ func (uc *UpdateAppointmentUseCase) Execute(ctx context.Context, id string, in U
 		ex := current.ID
 		overlap, err := uc.repo.HasOverlap(ctx, current.KinesiologistID, newStart, newEnd, &ex)
 		if err != nil {
-			return domain.Appointment{}, nil, err
+			return UpdateAppointmentResult{}, nil, err
 		}
 		if overlap {
-			return domain.Appointment{}, nil, domain.ErrOverlap
+			return UpdateAppointmentResult{}, nil, domain.ErrOverlap
 		}
 		current.StartAt = newStart
 		current.EndAt = newEnd
@@ -106,7 +115,45 @@ func (uc *UpdateAppointmentUseCase) Execute(ctx context.Context, id string, in U
 
 	updated, err := uc.repo.Update(ctx, current)
 	if err != nil {
-		return domain.Appointment{}, nil, err
+		return UpdateAppointmentResult{}, nil, err
+	}
+
+	warnings, err := uc.syncSession(ctx, previous, updated, in.Status != nil)
+	if err != nil {
+		return UpdateAppointmentResult{}, nil, err
+	}
+	return UpdateAppointmentResult{Appointment: updated, Warnings: warnings}, nil, nil
+}
+
+// syncSession consume la sesión de la orden médica al atender y la devuelve si se revierte.
+// Solo actúa si el PATCH trae status (un cambio o el reintento explícito de status=attended,
+// ambos idempotentes por turno) o si reprograma un turno atendido, que mueve la sesión al día
+// nuevo. Editar notas no toca la orden.
+func (uc *UpdateAppointmentUseCase) syncSession(ctx context.Context, previous, updated domain.Appointment, statusSent bool) ([]string, error) {
+	rescheduled := !updated.StartAt.Equal(previous.StartAt)
+
+	if updated.Status != domain.StatusAttended {
+		if !statusSent {
+			return nil, nil
+		}
+		return nil, uc.prescriptions.ReleaseSession(ctx, updated.ID)
+	}
+	if !statusSent && !rescheduled {
+		return nil, nil
+	}
+	if rescheduled && previous.Status == domain.StatusAttended {
+		if err := uc.prescriptions.ReleaseSession(ctx, updated.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	consumed, err := uc.prescriptions.ConsumeSession(ctx, updated.PatientID, updated.ID, updated.StartAt)
+	if err != nil || consumed {
+		return nil, err
+	}
+	coverage, err := uc.prescriptions.CoverageWarnings(ctx, updated.PatientID, updated.StartAt)
+	if err != nil {
+		return nil, err
 	}
-	return updated, nil, nil
+	return append([]string{domain.WarningSessionNotConsumed}, coverage...), nil
 }
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Kind string
+
+const (
+	KindXRay         Kind = "xray"
+	KindMRI          Kind = "mri"
+	KindUltrasound   Kind = "ultrasound"
+	KindMedicalOrder Kind = "medical_order"
+	KindReport       Kind = "report"
+	KindPhoto        Kind = "photo"
+	KindOther        Kind = "other"
+)
+
+func (k Kind) Valid() bool {
+	switch k {
+	case KindXRay, KindMRI, KindUltrasound, KindMedicalOrder, KindReport, KindPhoto, KindOther:
+		return true
+	}
+	return false
+}
+
+// Tamaño máximo por archivo si no se configura otro.
+const DefaultMaxSizeBytes int64 = 20 << 20 // 20 MB
+
+// Tipos aceptados. Se valida contra el contenido real (sniffing), no contra lo que declara el cliente.
+var allowedContentTypes = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+}
+
+func AllowedContentType(ct string) bool {
+	_, ok := allowedContentTypes[ct]
+	return ok
+}
+
+func ExtensionFor(ct string) string {
+	return allowedContentTypes[ct]
+}
+
+type Attachment struct {
+	ID            uuid.UUID
+	PatientID     uuid.UUID
+	EvolutionID   *uuid.UUID
+	AppointmentID *uuid.UUID
+
+	Kind        Kind
+	FileName    string
+	ContentType string
+	SizeBytes   int64
+	Checksum    string // sha256 hex
+	StorageKey  string
+	Description *string
+
+	CreatedAt time.Time
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "errors"
+
+var (
+	ErrValidation = errors.New("validation_error")
+	ErrNotFound   = errors.New("not_found")
+	// ErrDuplicate: el paciente ya tiene un adjunto con el mismo checksum (carrera entre dos subidas).
+	ErrDuplicate = errors.New("duplicate_attachment")
+	// ErrAlreadyLinked: el archivo ya existe vinculado a otra evolución o turno.
+	ErrAlreadyLinked = errors.New("attachment_already_linked")
+)
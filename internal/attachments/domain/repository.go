@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+type ListFilter struct {
+	EvolutionID   *uuid.UUID
+	AppointmentID *uuid.UUID
+	Kind          *Kind
+	Limit         int
+}
+
+type Repository interface {
+	Create(ctx context.Context, a Attachment) (Attachment, error)
+	GetByID(ctx context.Context, id uuid.UUID) (Attachment, bool, error)
+	ListByPatient(ctx context.Context, patientID uuid.UUID, f ListFilter) ([]Attachment, error)
+	FindByChecksum(ctx context.Context, patientID uuid.UUID, checksum string) (Attachment, bool, error)
+	// SetLinks vincula el adjunto a la evolución / turno que vengan (nil no cambia nada).
+	SetLinks(ctx context.Context, id uuid.UUID, evolutionID, appointmentID *uuid.UUID) (Attachment, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// CountByStorageKey: los adjuntos anteriores a las keys por adjunto comparten el blob
+	// entre pacientes con el mismo checksum; solo se borra cuando no queda ninguna referencia.
+	CountByStorageKey(ctx context.Context, key string) (int64, error)
+}
+
+// Storage abstrae dónde quedan los binarios (disco local hoy, S3 compatible más adelante).
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Links resuelve a qué paciente pertenecen la evolución / turno que se quieren vincular.
+type Links interface {
+	PatientExists(ctx context.Context, patientID uuid.UUID) (bool, error)
+	EvolutionPatient(ctx context.Context, evolutionID uuid.UUID) (uuid.UUID, bool, error)
+	AppointmentPatient(ctx context.Context, appointmentID uuid.UUID) (uuid.UUID, bool, error)
+}
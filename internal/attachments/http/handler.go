@@ -0,0 +1,217 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/attachments/domain"
+	"github.com/javiacuna/kinesio-backend/internal/attachments/usecase"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+// Margen para los campos del multipart además del archivo.
+const multipartOverheadBytes = 1 << 20
+
+type Handler struct {
+	uploadUC   *usecase.UploadAttachmentUseCase
+	listUC     *usecase.ListAttachmentsByPatientUseCase
+	downloadUC *usecase.DownloadAttachmentUseCase
+	deleteUC   *usecase.DeleteAttachmentUseCase
+	maxBytes   int64
+}
+
+func NewHandler(uploadUC *usecase.UploadAttachmentUseCase, listUC *usecase.ListAttachmentsByPatientUseCase,
+	downloadUC *usecase.DownloadAttachmentUseCase, deleteUC *usecase.DeleteAttachmentUseCase, maxBytes int64) *Handler {
+	if maxBytes <= 0 {
+		maxBytes = domain.DefaultMaxSizeBytes
+	}
+	return &Handler{uploadUC: uploadUC, listUC: listUC, downloadUC: downloadUC, deleteUC: deleteUC, maxBytes: maxBytes}
+}
+
+type attachmentResponse struct {
+	ID            string  `json:"id"`
+	PatientID     string  `json:"patient_id"`
+	EvolutionID   *string `json:"evolution_id,omitempty"`
+	AppointmentID *string `json:"appointment_id,omitempty"`
+	Kind          string  `json:"kind"`
+	FileName      string  `json:"file_name"`
+	ContentType   string  `json:"content_type"`
+	SizeBytes     int64   `json:"size_bytes"`
+	Checksum      string  `json:"checksum"`
+	Description   *string `json:"description,omitempty"`
+	CreatedAt     string  `json:"created_at"`
+}
+
+type uploadResponse struct {
+	attachmentResponse
+	Duplicate bool `json:"duplicate"`
+}
+
+// Upload recibe multipart: file (obligatorio), kind, description, evolution_id, appointment_id.
+func (h *Handler) Upload(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxBytes+multipartOverheadBytes)
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file_too_large"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing_file"})
+		return
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_file"})
+		return
+	}
+	defer f.Close()
+
+	out, validation, err := h.uploadUC.Execute(c.Request.Context(), usecase.UploadAttachmentInput{
+		PatientID:     c.Param("patient_id"),
+		EvolutionID:   formPtr(c, "evolution_id"),
+		AppointmentID: formPtr(c, "appointment_id"),
+		Kind:          c.PostForm("kind"),
+		Description:   formPtr(c, "description"),
+		FileName:      fh.Filename,
+		Content:       f,
+	})
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+
+	status := http.StatusCreated
+	if out.Duplicate {
+		status = http.StatusOK
+	}
+	c.JSON(status, uploadResponse{attachmentResponse: toResponse(out.Attachment), Duplicate: out.Duplicate})
+}
+
+func (h *Handler) ListByPatient(c *gin.Context) {
+	limit := 50
+	if s := strings.TrimSpace(c.Query("limit")); s != "" {
+		if n, err := strconv.Atoi(s); err == nil {
+			limit = n
+		}
+	}
+
+	items, validation, err := h.listUC.Execute(c.Request.Context(), c.Param("patient_id"), usecase.ListAttachmentsInput{
+		EvolutionID:   queryPtr(c, "evolution_id"),
+		AppointmentID: queryPtr(c, "appointment_id"),
+		Kind:          queryPtr(c, "kind"),
+		Limit:         limit,
+	})
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+
+	out := make([]attachmentResponse, 0, len(items))
+	for _, a := range items {
+		out = append(out, toResponse(a))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+func (h *Handler) Download(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	a, rc, err := h.downloadUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		writeError(c, err, nil)
+		return
+	}
+	defer rc.Close()
+
+	disposition := "attachment"
+	if c.Query("inline") == "true" {
+		disposition = "inline"
+	}
+	c.DataFromReader(http.StatusOK, a.SizeBytes, a.ContentType, rc, map[string]string{
+		"Content-Disposition":    disposition + `; filename="` + a.FileName + `"`,
+		"X-Content-Type-Options": "nosniff",
+		"ETag":                   `"` + a.Checksum + `"`,
+	})
+}
+
+func (h *Handler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	if err := h.deleteUC.Execute(c.Request.Context(), id); err != nil {
+		writeError(c, err, nil)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func writeError(c *gin.Context, err error, validation *validation.Errors) {
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+	case errors.Is(err, domain.ErrAlreadyLinked):
+		c.JSON(http.StatusConflict, gin.H{"error": "attachment_already_linked"})
+	case errors.Is(err, domain.ErrDuplicate):
+		c.JSON(http.StatusConflict, gin.H{"error": "duplicate_attachment"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+	}
+}
+
+func toResponse(a domain.Attachment) attachmentResponse {
+	return attachmentResponse{
+		ID:            a.ID.String(),
+		PatientID:     a.PatientID.String(),
+		EvolutionID:   uuidPtrToString(a.EvolutionID),
+		AppointmentID: uuidPtrToString(a.AppointmentID),
+		Kind:          string(a.Kind),
+		FileName:      a.FileName,
+		ContentType:   a.ContentType,
+		SizeBytes:     a.SizeBytes,
+		Checksum:      a.Checksum,
+		Description:   a.Description,
+		CreatedAt:     a.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func formPtr(c *gin.Context, key string) *string {
+	v, ok := c.GetPostForm(key)
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+func queryPtr(c *gin.Context, key string) *string {
+	v, ok := c.GetQuery(key)
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+func uuidPtrToString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
@@ -0,0 +1,20 @@
+package gorm
+
+import "time"
+
+type AttachmentModel struct {
+	ID            string  `gorm:"type:uuid;primaryKey"`
+	PatientID     string  `gorm:"type:uuid;not null"`
+	EvolutionID   *string `gorm:"type:uuid"`
+	AppointmentID *string `gorm:"type:uuid"`
+	Kind          string  `gorm:"not null"`
+	FileName      string  `gorm:"not null"`
+	ContentType   string  `gorm:"not null"`
+	SizeBytes     int64   `gorm:"not null"`
+	Checksum      string  `gorm:"not null"`
+	StorageKey    string  `gorm:"not null"`
+	Description   *string
+	CreatedAt     time.Time
+}
+
+func (AttachmentModel) TableName() string { return "patient_attachments" }
@@ -0,0 +1,171 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/javiacuna/kinesio-backend/internal/attachments/domain"
+)
+
+var _ domain.Repository = (*Repository)(nil)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(ctx context.Context, a domain.Attachment) (domain.Attachment, error) {
+	m := toModel(a)
+	if err := r.db.WithContext(ctx).Create(&m).Error; err != nil {
+		if isDuplicateChecksum(err) {
+			return domain.Attachment{}, domain.ErrDuplicate
+		}
+		return domain.Attachment{}, err
+	}
+	return toDomain(m), nil
+}
+
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (domain.Attachment, bool, error) {
+	var m AttachmentModel
+	err := r.db.WithContext(ctx).First(&m, "id = ?", id.String()).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.Attachment{}, false, nil
+		}
+		return domain.Attachment{}, false, err
+	}
+	return toDomain(m), true, nil
+}
+
+func (r *Repository) ListByPatient(ctx context.Context, patientID uuid.UUID, f domain.ListFilter) ([]domain.Attachment, error) {
+	tx := r.db.WithContext(ctx).Where("patient_id = ?", patientID.String())
+	if f.EvolutionID != nil {
+		tx = tx.Where("evolution_id = ?", f.EvolutionID.String())
+	}
+	if f.AppointmentID != nil {
+		tx = tx.Where("appointment_id = ?", f.AppointmentID.String())
+	}
+	if f.Kind != nil {
+		tx = tx.Where("kind = ?", string(*f.Kind))
+	}
+
+	var ms []AttachmentModel
+	if err := tx.Order("created_at desc").Limit(f.Limit).Find(&ms).Error; err != nil {
+		return nil, err
+	}
+
+	out := make([]domain.Attachment, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, toDomain(m))
+	}
+	return out, nil
+}
+
+func (r *Repository) FindByChecksum(ctx context.Context, patientID uuid.UUID, checksum string) (domain.Attachment, bool, error) {
+	var m AttachmentModel
+	err := r.db.WithContext(ctx).
+		Where("patient_id = ? AND checksum = ?", patientID.String(), checksum).
+		First(&m).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.Attachment{}, false, nil
+		}
+		return domain.Attachment{}, false, err
+	}
+	return toDomain(m), true, nil
+}
+
+func (r *Repository) SetLinks(ctx context.Context, id uuid.UUID, evolutionID, appointmentID *uuid.UUID) (domain.Attachment, error) {
+	updates := map[string]any{}
+	if evolutionID != nil {
+		updates["evolution_id"] = evolutionID.String()
+	}
+	if appointmentID != nil {
+		updates["appointment_id"] = appointmentID.String()
+	}
+	if len(updates) > 0 {
+		err := r.db.WithContext(ctx).Model(&AttachmentModel{}).Where("id = ?", id.String()).Updates(updates).Error
+		if err != nil {
+			return domain.Attachment{}, err
+		}
+	}
+	a, found, err := r.GetByID(ctx, id)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	if !found {
+		return domain.Attachment{}, domain.ErrNotFound
+	}
+	return a, nil
+}
+
+func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&AttachmentModel{}, "id = ?", id.String()).Error
+}
+
+func (r *Repository) CountByStorageKey(ctx context.Context, key string) (int64, error) {
+	var n int64
+	err := r.db.WithContext(ctx).Model(&AttachmentModel{}).Where("storage_key = ?", key).Count(&n).Error
+	return n, err
+}
+
+func toModel(a domain.Attachment) AttachmentModel {
+	return AttachmentModel{
+		ID:            a.ID.String(),
+		PatientID:     a.PatientID.String(),
+		EvolutionID:   uuidPtrToString(a.EvolutionID),
+		AppointmentID: uuidPtrToString(a.AppointmentID),
+		Kind:          string(a.Kind),
+		FileName:      a.FileName,
+		ContentType:   a.ContentType,
+		SizeBytes:     a.SizeBytes,
+		Checksum:      a.Checksum,
+		StorageKey:    a.StorageKey,
+		Description:   a.Description,
+		CreatedAt:     a.CreatedAt,
+	}
+}
+
+func toDomain(m AttachmentModel) domain.Attachment {
+	return domain.Attachment{
+		ID:            uuid.MustParse(m.ID),
+		PatientID:     uuid.MustParse(m.PatientID),
+		EvolutionID:   stringPtrToUUID(m.EvolutionID),
+		AppointmentID: stringPtrToUUID(m.AppointmentID),
+		Kind:          domain.Kind(m.Kind),
+		FileName:      m.FileName,
+		ContentType:   m.ContentType,
+		SizeBytes:     m.SizeBytes,
+		Checksum:      m.Checksum,
+		StorageKey:    m.StorageKey,
+		Description:   m.Description,
+		CreatedAt:     m.CreatedAt,
+	}
+}
+
+func uuidPtrToString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func stringPtrToUUID(s *string) *uuid.UUID {
+	if s == nil || *s == "" {
+		return nil
+	}
+	id := uuid.MustParse(*s)
+	return &id
+}
+
+func isDuplicateChecksum(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "ux_patient_attachments_checksum")
+}
@@ -0,0 +1,46 @@
+package links
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	apptPorts "github.com/javiacuna/kinesio-backend/internal/appointments/ports"
+	"github.com/javiacuna/kinesio-backend/internal/attachments/domain"
+	evoDomain "github.com/javiacuna/kinesio-backend/internal/evolutions/domain"
+	patientPorts "github.com/javiacuna/kinesio-backend/internal/patients/ports"
+)
+
+var _ domain.Links = (*Gateway)(nil)
+
+// Gateway resuelve el paciente dueño de evoluciones y turnos usando los repos de cada módulo.
+type Gateway struct {
+	patients     patientPorts.Repository
+	evolutions   evoDomain.Repository
+	appointments apptPorts.Repository
+}
+
+func NewGateway(patients patientPorts.Repository, evolutions evoDomain.Repository, appointments apptPorts.Repository) *Gateway {
+	return &Gateway{patients: patients, evolutions: evolutions, appointments: appointments}
+}
+
+func (g *Gateway) PatientExists(ctx context.Context, patientID uuid.UUID) (bool, error) {
+	_, found, err := g.patients.GetByID(ctx, patientID.String())
+	return found, err
+}
+
+func (g *Gateway) EvolutionPatient(ctx context.Context, evolutionID uuid.UUID) (uuid.UUID, bool, error) {
+	e, found, err := g.evolutions.GetByID(ctx, evolutionID)
+	if err != nil || !found {
+		return uuid.Nil, false, err
+	}
+	return e.PatientID, true, nil
+}
+
+func (g *Gateway) AppointmentPatient(ctx context.Context, appointmentID uuid.UUID) (uuid.UUID, bool, error) {
+	a, found, err := g.appointments.GetByID(ctx, appointmentID)
+	if err != nil || !found {
+		return uuid.Nil, false, err
+	}
+	return a.PatientID, true, nil
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/attachments/domain"
+)
+
+type DeleteAttachmentUseCase struct {
+	repo    domain.Repository
+	storage domain.Storage
+}
+
+func NewDeleteAttachmentUseCase(repo domain.Repository, storage domain.Storage) *DeleteAttachmentUseCase {
+	return &DeleteAttachmentUseCase{repo: repo, storage: storage}
+}
+
+func (uc *DeleteAttachmentUseCase) Execute(ctx context.Context, id uuid.UUID) error {
+	a, found, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return domain.ErrNotFound
+	}
+
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	// Las keys nuevas son por adjunto; las anteriores se compartían entre pacientes con
+	// el mismo archivo y ya no reciben referencias nuevas, así que contar alcanza.
+	refs, err := uc.repo.CountByStorageKey(ctx, a.StorageKey)
+	if err != nil {
+		return err
+	}
+	if refs == 0 {
+		return uc.storage.Delete(ctx, a.StorageKey)
+	}
+	return nil
+}
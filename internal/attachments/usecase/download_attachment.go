@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/attachments/domain"
+)
+
+type DownloadAttachmentUseCase struct {
+	repo    domain.Repository
+	storage domain.Storage
+}
+
+func NewDownloadAttachmentUseCase(repo domain.Repository, storage domain.Storage) *DownloadAttachmentUseCase {
+	return &DownloadAttachmentUseCase{repo: repo, storage: storage}
+}
+
+// Execute devuelve la metadata y el contenido; quien llama debe cerrar el reader.
+func (uc *DownloadAttachmentUseCase) Execute(ctx context.Context, id uuid.UUID) (domain.Attachment, io.ReadCloser, error) {
+	a, found, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+	if !found {
+		return domain.Attachment{}, nil, domain.ErrNotFound
+	}
+
+	rc, err := uc.storage.Open(ctx, a.StorageKey)
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+	return a, rc, nil
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/attachments/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+type ListAttachmentsInput struct {
+	EvolutionID   *string
+	AppointmentID *string
+	Kind          *string
+	Limit         int
+}
+
+type ListAttachmentsByPatientUseCase struct {
+	repo domain.Repository
+}
+
+func NewListAttachmentsByPatientUseCase(repo domain.Repository) *ListAttachmentsByPatientUseCase {
+	return &ListAttachmentsByPatientUseCase{repo: repo}
+}
+
+func (uc *ListAttachmentsByPatientUseCase) Execute(ctx context.Context, patientID string, in ListAttachmentsInput) ([]domain.Attachment, *validation.Errors, error) {
+	validation := validation.New()
+
+	pid, err := uuid.Parse(strings.TrimSpace(patientID))
+	if err != nil {
+		validation.Add("patient_id", "invalid_uuid")
+	}
+
+	f := domain.ListFilter{
+		EvolutionID:   validation.OptionalUUID("evolution_id", in.EvolutionID),
+		AppointmentID: validation.OptionalUUID("appointment_id", in.AppointmentID),
+		Limit:         in.Limit,
+	}
+	if k := trimPtr(in.Kind); k != nil {
+		kind := domain.Kind(*k)
+		if !kind.Valid() {
+			validation.Add("kind", "invalid")
+		}
+		f.Kind = &kind
+	}
+	if f.Limit <= 0 || f.Limit > 200 {
+		f.Limit = 50
+	}
+
+	if !validation.Empty() {
+		return nil, validation, domain.ErrValidation
+	}
+
+	items, err := uc.repo.ListByPatient(ctx, pid, f)
+	if err != nil {
+		return nil, nil, err
+	}
+	return items, nil, nil
+}
@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/attachments/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+type UploadAttachmentInput struct {
+	PatientID     string
+	EvolutionID   *string
+	AppointmentID *string
+	Kind          string
+	Description   *string
+
+	FileName string
+	Content  io.Reader
+}
+
+type UploadAttachmentResult struct {
+	Attachment domain.Attachment
+	// Duplicate: el paciente ya tenía un adjunto con el mismo contenido; se devuelve ese,
+	// con la evolución / turno del pedido si no tenía.
+	Duplicate bool
+}
+
+type UploadAttachmentUseCase struct {
+	repo     domain.Repository
+	storage  domain.Storage
+	links    domain.Links
+	maxBytes int64
+}
+
+func NewUploadAttachmentUseCase(repo domain.Repository, storage domain.Storage, links domain.Links, maxBytes int64) *UploadAttachmentUseCase {
+	if maxBytes <= 0 {
+		maxBytes = domain.DefaultMaxSizeBytes
+	}
+	return &UploadAttachmentUseCase{repo: repo, storage: storage, links: links, maxBytes: maxBytes}
+}
+
+func (uc *UploadAttachmentUseCase) Execute(ctx context.Context, in UploadAttachmentInput) (UploadAttachmentResult, *validation.Errors, error) {
+	validation := validation.New()
+
+	patientID, err := uuid.Parse(strings.TrimSpace(in.PatientID))
+	if err != nil {
+		validation.Add("patient_id", "invalid_uuid")
+	}
+	evolutionID := validation.OptionalUUID("evolution_id", in.EvolutionID)
+	appointmentID := validation.OptionalUUID("appointment_id", in.AppointmentID)
+
+	kind := domain.Kind(strings.TrimSpace(in.Kind))
+	if kind == "" {
+		kind = domain.KindOther
+	}
+	if !kind.Valid() {
+		validation.Add("kind", "invalid")
+	}
+
+	name := sanitizeFileName(in.FileName)
+	if name == "" {
+		validation.Add("file", "required")
+	}
+	if !validation.Empty() {
+		return UploadAttachmentResult{}, validation, domain.ErrValidation
+	}
+
+	exists, err := uc.links.PatientExists(ctx, patientID)
+	if err != nil {
+		return UploadAttachmentResult{}, nil, err
+	}
+	if !exists {
+		return UploadAttachmentResult{}, nil, domain.ErrNotFound
+	}
+
+	// Se lee con un byte de margen para detectar archivos que exceden el límite.
+	data, err := io.ReadAll(io.LimitReader(in.Content, uc.maxBytes+1))
+	if err != nil {
+		return UploadAttachmentResult{}, nil, err
+	}
+	switch {
+	case len(data) == 0:
+		validation.Add("file", "empty")
+	case int64(len(data)) > uc.maxBytes:
+		validation.Add("file", "too_large")
+	}
+	contentType := sniffContentType(data)
+	if len(data) > 0 && !domain.AllowedContentType(contentType) {
+		validation.Add("content_type", "unsupported")
+	}
+
+	if validation.Empty() {
+		if err := uc.checkLinks(ctx, patientID, evolutionID, appointmentID, validation); err != nil {
+			return UploadAttachmentResult{}, nil, err
+		}
+	}
+	if !validation.Empty() {
+		return UploadAttachmentResult{}, validation, domain.ErrValidation
+	}
+
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+
+	existing, found, err := uc.repo.FindByChecksum(ctx, patientID, checksum)
+	if err != nil {
+		return UploadAttachmentResult{}, nil, err
+	}
+	if found {
+		return uc.duplicate(ctx, existing, evolutionID, appointmentID)
+	}
+
+	id := uuid.New()
+	key := storageKey(patientID, id, contentType)
+	if err := uc.storage.Put(ctx, key, bytes.NewReader(data)); err != nil {
+		return UploadAttachmentResult{}, nil, err
+	}
+
+	a := domain.Attachment{
+		ID:            id,
+		PatientID:     patientID,
+		EvolutionID:   evolutionID,
+		AppointmentID: appointmentID,
+		Kind:          kind,
+		FileName:      name,
+		ContentType:   contentType,
+		SizeBytes:     int64(len(data)),
+		Checksum:      checksum,
+		StorageKey:    key,
+		Description:   trimPtr(in.Description),
+		CreatedAt:     time.Now().UTC(),
+	}
+
+	out, err := uc.repo.Create(ctx, a)
+	if err != nil {
+		// El blob es solo de este adjunto: si no se guardó la fila, se borra.
+		_ = uc.storage.Delete(ctx, key)
+		if !errors.Is(err, domain.ErrDuplicate) {
+			return UploadAttachmentResult{}, nil, err
+		}
+		// Otra subida del mismo archivo se guardó primero.
+		existing, found, err := uc.repo.FindByChecksum(ctx, patientID, checksum)
+		if err != nil {
+			return UploadAttachmentResult{}, nil, err
+		}
+		if !found {
+			return UploadAttachmentResult{}, nil, domain.ErrDuplicate
+		}
+		return uc.duplicate(ctx, existing, evolutionID, appointmentID)
+	}
+	return UploadAttachmentResult{Attachment: out}, nil, nil
+}
+
+// duplicate devuelve el adjunto que ya existía y le aplica la evolución / turno pedidos.
+// Si ya estaba vinculado a otros no se pisan: se rechaza con ErrAlreadyLinked.
+func (uc *UploadAttachmentUseCase) duplicate(ctx context.Context, a domain.Attachment, evolutionID, appointmentID *uuid.UUID) (UploadAttachmentResult, *validation.Errors, error) {
+	if linkedElsewhere(a.EvolutionID, evolutionID) || linkedElsewhere(a.AppointmentID, appointmentID) {
+		return UploadAttachmentResult{}, nil, domain.ErrAlreadyLinked
+	}
+	if (evolutionID != nil && a.EvolutionID == nil) || (appointmentID != nil && a.AppointmentID == nil) {
+		out, err := uc.repo.SetLinks(ctx, a.ID, evolutionID, appointmentID)
+		if err != nil {
+			return UploadAttachmentResult{}, nil, err
+		}
+		a = out
+	}
+	return UploadAttachmentResult{Attachment: a, Duplicate: true}, nil, nil
+}
+
+func linkedElsewhere(current, requested *uuid.UUID) bool {
+	return current != nil && requested != nil && *current != *requested
+}
+
+func (uc *UploadAttachmentUseCase) checkLinks(ctx context.Context, patientID uuid.UUID, evolutionID, appointmentID *uuid.UUID, validation *validation.Errors) error {
+	if evolutionID != nil {
+		owner, ok, err := uc.links.EvolutionPatient(ctx, *evolutionID)
+		if err != nil {
+			return err
+		}
+		if !ok || owner != patientID {
+			validation.Add("evolution_id", "not_found")
+		}
+	}
+	if appointmentID != nil {
+		owner, ok, err := uc.links.AppointmentPatient(ctx, *appointmentID)
+		if err != nil {
+			return err
+		}
+		if !ok || owner != patientID {
+			validation.Add("appointment_id", "not_found")
+		}
+	}
+	return nil
+}
+
+func sniffContentType(data []byte) string {
+	ct := http.DetectContentType(data)
+	if i := strings.Index(ct, ";"); i >= 0 {
+		ct = ct[:i]
+	}
+	return strings.TrimSpace(ct)
+}
+
+// storageKey: un blob por adjunto, agrupado por paciente. No se comparte entre adjuntos,
+// así borrar uno nunca deja a otro apuntando a un archivo que ya no está.
+func storageKey(patientID, attachmentID uuid.UUID, contentType string) string {
+	return patientID.String() + "/" + attachmentID.String() + domain.ExtensionFor(contentType)
+}
+
+func sanitizeFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(strings.TrimSpace(name), "\\", "/"))
+	if name == "." || name == "/" {
+		return ""
+	}
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	if len(name) > 255 {
+		name = name[len(name)-255:]
+	}
+	return name
+}
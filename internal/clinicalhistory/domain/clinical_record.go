@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Laterality string
+
+const (
+	LateralityLeft          Laterality = "left"
+	LateralityRight         Laterality = "right"
+	LateralityBilateral     Laterality = "bilateral"
+	LateralityNotApplicable Laterality = "not_applicable"
+)
+
+func (l Laterality) Valid() bool {
+	switch l {
+	case LateralityLeft, LateralityRight, LateralityBilateral, LateralityNotApplicable:
+		return true
+	}
+	return false
+}
+
+type DiagnosisStatus string
+
+const (
+	DiagnosisActive   DiagnosisStatus = "active"
+	DiagnosisResolved DiagnosisStatus = "resolved"
+)
+
+type AllergySeverity string
+
+const (
+	AllergyMild     AllergySeverity = "mild"
+	AllergyModerate AllergySeverity = "moderate"
+	AllergySevere   AllergySeverity = "severe"
+)
+
+type ContraindicationKind string
+
+const (
+	ContraindicationAbsolute ContraindicationKind = "absolute"
+	ContraindicationRelative ContraindicationKind = "relative"
+)
+
+// CIE-10 / ICD-10: letra + 2 dígitos (+ subcategoría opcional). Ej: M54.5, S83.5, M17.11
+var icd10Re = regexp.MustCompile(`^[A-Z][0-9][0-9A-Z](\.[0-9A-Z]{1,4})?$`)
+
+func NormalizeICD10(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func ValidICD10(code string) bool {
+	return icd10Re.MatchString(code)
+}
+
+type Diagnosis struct {
+	ID                   uuid.UUID
+	PatientID            uuid.UUID
+	ICD10Code            string
+	Description          string
+	Status               DiagnosisStatus
+	DiagnosedAt          *time.Time
+	ReferringPhysicianID *uuid.UUID
+	Notes                *string
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+type Injury struct {
+	ID          uuid.UUID
+	PatientID   uuid.UUID
+	DiagnosisID *uuid.UUID
+	Site        string // región/estructura: "rodilla", "hombro", "columna lumbar"...
+	Laterality  Laterality
+	OnsetDate   *time.Time
+	Mechanism   *string
+	Notes       *string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type Surgery struct {
+	ID          uuid.UUID
+	PatientID   uuid.UUID
+	Procedure   string
+	Laterality  *Laterality
+	PerformedAt *time.Time
+	Surgeon     *string
+	Notes       *string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type Medication struct {
+	ID        uuid.UUID
+	PatientID uuid.UUID
+	Name      string
+	Dose      *string
+	Frequency *string
+	StartDate *time.Time
+	EndDate   *time.Time
+	Notes     *string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Allergy struct {
+	ID        uuid.UUID
+	PatientID uuid.UUID
+	Substance string
+	Reaction  *string
+	Severity  AllergySeverity
+	Notes     *string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Contraindication struct {
+	ID          uuid.UUID
+	PatientID   uuid.UUID
+	Description string
+	Kind        ContraindicationKind
+	Notes       *string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type ReferringPhysician struct {
+	ID            uuid.UUID
+	PatientID     uuid.UUID
+	FullName      string
+	LicenseNumber *string
+	Specialty     *string
+	Phone         *string
+	Email         *string
+	Notes         *string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// ClinicalRecord agrupa toda la historia clínica estructurada de un paciente.
+type ClinicalRecord struct {
+	PatientID           uuid.UUID
+	Diagnoses           []Diagnosis
+	Injuries            []Injury
+	Surgeries           []Surgery
+	Medications         []Medication
+	Allergies           []Allergy
+	Contraindications   []Contraindication
+	ReferringPhysicians []ReferringPhysician
+}
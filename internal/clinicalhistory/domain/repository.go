@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	CreateDiagnosis(ctx context.Context, d Diagnosis) (Diagnosis, error)
+	UpdateDiagnosis(ctx context.Context, d Diagnosis) (Diagnosis, error)
+	GetDiagnosis(ctx context.Context, id uuid.UUID) (Diagnosis, bool, error)
+	ListDiagnoses(ctx context.Context, patientID uuid.UUID) ([]Diagnosis, error)
+
+	CreateInjury(ctx context.Context, i Injury) (Injury, error)
+	UpdateInjury(ctx context.Context, i Injury) (Injury, error)
+	GetInjury(ctx context.Context, id uuid.UUID) (Injury, bool, error)
+	ListInjuries(ctx context.Context, patientID uuid.UUID) ([]Injury, error)
+
+	CreateSurgery(ctx context.Context, s Surgery) (Surgery, error)
+	UpdateSurgery(ctx context.Context, s Surgery) (Surgery, error)
+	GetSurgery(ctx context.Context, id uuid.UUID) (Surgery, bool, error)
+	ListSurgeries(ctx context.Context, patientID uuid.UUID) ([]Surgery, error)
+
+	CreateMedication(ctx context.Context, m Medication) (Medication, error)
+	UpdateMedication(ctx context.Context, m Medication) (Medication, error)
+	GetMedication(ctx context.Context, id uuid.UUID) (Medication, bool, error)
+	ListMedications(ctx context.Context, patientID uuid.UUID) ([]Medication, error)
+
+	CreateAllergy(ctx context.Context, a Allergy) (Allergy, error)
+	UpdateAllergy(ctx context.Context, a Allergy) (Allergy, error)
+	GetAllergy(ctx context.Context, id uuid.UUID) (Allergy, bool, error)
+	ListAllergies(ctx context.Context, patientID uuid.UUID) ([]Allergy, error)
+
+	CreateContraindication(ctx context.Context, c Contraindication) (Contraindication, error)
+	UpdateContraindication(ctx context.Context, c Contraindication) (Contraindication, error)
+	GetContraindication(ctx context.Context, id uuid.UUID) (Contraindication, bool, error)
+	ListContraindications(ctx context.Context, patientID uuid.UUID) ([]Contraindication, error)
+
+	CreateReferringPhysician(ctx context.Context, p ReferringPhysician) (ReferringPhysician, error)
+	UpdateReferringPhysician(ctx context.Context, p ReferringPhysician) (ReferringPhysician, error)
+	GetReferringPhysician(ctx context.Context, id uuid.UUID) (ReferringPhysician, bool, error)
+	ListReferringPhysicians(ctx context.Context, patientID uuid.UUID) ([]ReferringPhysician, error)
+}
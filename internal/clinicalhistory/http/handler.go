@@ -0,0 +1,443 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/clinicalhistory/domain"
+	"github.com/javiacuna/kinesio-backend/internal/clinicalhistory/usecase"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+// UseCases agrupa los casos de uso de la historia clínica (son muchos para pasarlos sueltos).
+type UseCases struct {
+	CreateDiagnosis          *usecase.CreateDiagnosisUseCase
+	UpdateDiagnosis          *usecase.UpdateDiagnosisUseCase
+	ListDiagnoses            *usecase.ListDiagnosesUseCase
+	CreateInjury             *usecase.CreateInjuryUseCase
+	UpdateInjury             *usecase.UpdateInjuryUseCase
+	ListInjuries             *usecase.ListInjuriesUseCase
+	CreateSurgery            *usecase.CreateSurgeryUseCase
+	UpdateSurgery            *usecase.UpdateSurgeryUseCase
+	ListSurgeries            *usecase.ListSurgeriesUseCase
+	CreateMedication         *usecase.CreateMedicationUseCase
+	UpdateMedication         *usecase.UpdateMedicationUseCase
+	ListMedications          *usecase.ListMedicationsUseCase
+	CreateAllergy            *usecase.CreateAllergyUseCase
+	UpdateAllergy            *usecase.UpdateAllergyUseCase
+	ListAllergies            *usecase.ListAllergiesUseCase
+	CreateContraindication   *usecase.CreateContraindicationUseCase
+	UpdateContraindication   *usecase.UpdateContraindicationUseCase
+	ListContraindications    *usecase.ListContraindicationsUseCase
+	CreateReferringPhysician *usecase.CreateReferringPhysicianUseCase
+	UpdateReferringPhysician *usecase.UpdateReferringPhysicianUseCase
+	ListReferringPhysicians  *usecase.ListReferringPhysiciansUseCase
+	GetRecord                *usecase.GetClinicalRecordUseCase
+}
+
+type Handler struct {
+	uc UseCases
+}
+
+func NewHandler(uc UseCases) *Handler {
+	return &Handler{uc: uc}
+}
+
+func (h *Handler) GetRecord(c *gin.Context) {
+	pid, err := uuid.Parse(c.Param("patient_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_patient_id"})
+		return
+	}
+	rec, err := h.uc.GetRecord.Execute(c.Request.Context(), pid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+	c.JSON(http.StatusOK, toClinicalRecordResp(rec))
+}
+
+func writeError(c *gin.Context, err error, validation *validation.Errors) {
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+	}
+}
+
+// ---------- Diagnoses
+
+func (h *Handler) CreateDiagnosis(c *gin.Context) {
+	var req usecase.DiagnosisInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+	out, validation, err := h.uc.CreateDiagnosis.Execute(c.Request.Context(), c.Param("patient_id"), req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusCreated, toDiagnosisResp(out))
+}
+
+func (h *Handler) UpdateDiagnosis(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+	var req usecase.DiagnosisInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+	out, validation, err := h.uc.UpdateDiagnosis.Execute(c.Request.Context(), id, req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusOK, toDiagnosisResp(out))
+}
+
+func (h *Handler) ListDiagnoses(c *gin.Context) {
+	pid, err := uuid.Parse(c.Param("patient_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_patient_id"})
+		return
+	}
+	items, err := h.uc.ListDiagnoses.Execute(c.Request.Context(), pid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+	out := make([]diagnosisResponse, 0, len(items))
+	for _, it := range items {
+		out = append(out, toDiagnosisResp(it))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// ---------- Injuries
+
+func (h *Handler) CreateInjury(c *gin.Context) {
+	var req usecase.InjuryInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+	out, validation, err := h.uc.CreateInjury.Execute(c.Request.Context(), c.Param("patient_id"), req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusCreated, toInjuryResp(out))
+}
+
+func (h *Handler) UpdateInjury(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+	var req usecase.InjuryInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+	out, validation, err := h.uc.UpdateInjury.Execute(c.Request.Context(), id, req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusOK, toInjuryResp(out))
+}
+
+func (h *Handler) ListInjuries(c *gin.Context) {
+	pid, err := uuid.Parse(c.Param("patient_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_patient_id"})
+		return
+	}
+	items, err := h.uc.ListInjuries.Execute(c.Request.Context(), pid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+	out := make([]injuryResponse, 0, len(items))
+	for _, it := range items {
+		out = append(out, toInjuryResp(it))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// ---------- Surgeries
+
+func (h *Handler) CreateSurgery(c *gin.Context) {
+	var req usecase.SurgeryInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+	out, validation, err := h.uc.CreateSurgery.Execute(c.Request.Context(), c.Param("patient_id"), req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusCreated, toSurgeryResp(out))
+}
+
+func (h *Handler) UpdateSurgery(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+	var req usecase.SurgeryInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+	out, validation, err := h.uc.UpdateSurgery.Execute(c.Request.Context(), id, req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusOK, toSurgeryResp(out))
+}
+
+func (h *Handler) ListSurgeries(c *gin.Context) {
+	pid, err := uuid.Parse(c.Param("patient_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_patient_id"})
+		return
+	}
+	items, err := h.uc.ListSurgeries.Execute(c.Request.Context(), pid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+	out := make([]surgeryResponse, 0, len(items))
+	for _, it := range items {
+		out = append(out, toSurgeryResp(it))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// ---------- Medications
+
+func (h *Handler) CreateMedication(c *gin.Context) {
+	var req usecase.MedicationInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+	out, validation, err := h.uc.CreateMedication.Execute(c.Request.Context(), c.Param("patient_id"), req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusCreated, toMedicationResp(out))
+}
+
+func (h *Handler) UpdateMedication(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+	var req usecase.MedicationInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+	out, validation, err := h.uc.UpdateMedication.Execute(c.Request.Context(), id, req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusOK, toMedicationResp(out))
+}
+
+func (h *Handler) ListMedications(c *gin.Context) {
+	pid, err := uuid.Parse(c.Param("patient_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_patient_id"})
+		return
+	}
+	items, err := h.uc.ListMedications.Execute(c.Request.Context(), pid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+	out := make([]medicationResponse, 0, len(items))
+	for _, it := range items {
+		out = append(out, toMedicationResp(it))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// ---------- Allergies
+
+func (h *Handler) CreateAllergy(c *gin.Context) {
+	var req usecase.AllergyInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+	out, validation, err := h.uc.CreateAllergy.Execute(c.Request.Context(), c.Param("patient_id"), req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusCreated, toAllergyResp(out))
+}
+
+func (h *Handler) UpdateAllergy(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+	var req usecase.AllergyInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+	out, validation, err := h.uc.UpdateAllergy.Execute(c.Request.Context(), id, req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusOK, toAllergyResp(out))
+}
+
+func (h *Handler) ListAllergies(c *gin.Context) {
+	pid, err := uuid.Parse(c.Param("patient_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_patient_id"})
+		return
+	}
+	items, err := h.uc.ListAllergies.Execute(c.Request.Context(), pid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+	out := make([]allergyResponse, 0, len(items))
+	for _, it := range items {
+		out = append(out, toAllergyResp(it))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// ---------- Contraindications
+
+func (h *Handler) CreateContraindication(c *gin.Context) {
+	var req usecase.ContraindicationInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+	out, validation, err := h.uc.CreateContraindication.Execute(c.Request.Context(), c.Param("patient_id"), req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusCreated, toContraindicationResp(out))
+}
+
+func (h *Handler) UpdateContraindication(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+	var req usecase.ContraindicationInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+	out, validation, err := h.uc.UpdateContraindication.Execute(c.Request.Context(), id, req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusOK, toContraindicationResp(out))
+}
+
+func (h *Handler) ListContraindications(c *gin.Context) {
+	pid, err := uuid.Parse(c.Param("patient_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_patient_id"})
+		return
+	}
+	items, err := h.uc.ListContraindications.Execute(c.Request.Context(), pid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+	out := make([]contraindicationResponse, 0, len(items))
+	for _, it := range items {
+		out = append(out, toContraindicationResp(it))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// ---------- ReferringPhysicians
+
+func (h *Handler) CreateReferringPhysician(c *gin.Context) {
+	var req usecase.ReferringPhysicianInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+	out, validation, err := h.uc.CreateReferringPhysician.Execute(c.Request.Context(), c.Param("patient_id"), req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusCreated, toReferringPhysicianResp(out))
+}
+
+func (h *Handler) UpdateReferringPhysician(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+	var req usecase.ReferringPhysicianInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+	out, validation, err := h.uc.UpdateReferringPhysician.Execute(c.Request.Context(), id, req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusOK, toReferringPhysicianResp(out))
+}
+
+func (h *Handler) ListReferringPhysicians(c *gin.Context) {
+	pid, err := uuid.Parse(c.Param("patient_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_patient_id"})
+		return
+	}
+	items, err := h.uc.ListReferringPhysicians.Execute(c.Request.Context(), pid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+	out := make([]referringPhysicianResponse, 0, len(items))
+	for _, it := range items {
+		out = append(out, toReferringPhysicianResp(it))
+	}
+	c.JSON(http.StatusOK, out)
+}
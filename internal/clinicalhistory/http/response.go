@@ -0,0 +1,262 @@
+package http
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/clinicalhistory/domain"
+)
+
+type diagnosisResponse struct {
+	ID                   string  `json:"id"`
+	PatientID            string  `json:"patient_id"`
+	ICD10Code            string  `json:"icd10_code"`
+	Description          string  `json:"description"`
+	Status               string  `json:"status"`
+	DiagnosedAt          *string `json:"diagnosed_at,omitempty"`
+	ReferringPhysicianID *string `json:"referring_physician_id,omitempty"`
+	Notes                *string `json:"notes,omitempty"`
+	CreatedAt            string  `json:"created_at"`
+	UpdatedAt            string  `json:"updated_at"`
+}
+
+type injuryResponse struct {
+	ID          string  `json:"id"`
+	PatientID   string  `json:"patient_id"`
+	DiagnosisID *string `json:"diagnosis_id,omitempty"`
+	Site        string  `json:"site"`
+	Laterality  string  `json:"laterality"`
+	OnsetDate   *string `json:"onset_date,omitempty"`
+	Mechanism   *string `json:"mechanism,omitempty"`
+	Notes       *string `json:"notes,omitempty"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+}
+
+type surgeryResponse struct {
+	ID          string  `json:"id"`
+	PatientID   string  `json:"patient_id"`
+	Procedure   string  `json:"procedure"`
+	Laterality  *string `json:"laterality,omitempty"`
+	PerformedAt *string `json:"performed_at,omitempty"`
+	Surgeon     *string `json:"surgeon,omitempty"`
+	Notes       *string `json:"notes,omitempty"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+}
+
+type medicationResponse struct {
+	ID        string  `json:"id"`
+	PatientID string  `json:"patient_id"`
+	Name      string  `json:"name"`
+	Dose      *string `json:"dose,omitempty"`
+	Frequency *string `json:"frequency,omitempty"`
+	StartDate *string `json:"start_date,omitempty"`
+	EndDate   *string `json:"end_date,omitempty"`
+	Notes     *string `json:"notes,omitempty"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+type allergyResponse struct {
+	ID        string  `json:"id"`
+	PatientID string  `json:"patient_id"`
+	Substance string  `json:"substance"`
+	Reaction  *string `json:"reaction,omitempty"`
+	Severity  string  `json:"severity"`
+	Notes     *string `json:"notes,omitempty"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+type contraindicationResponse struct {
+	ID          string  `json:"id"`
+	PatientID   string  `json:"patient_id"`
+	Description string  `json:"description"`
+	Kind        string  `json:"kind"`
+	Notes       *string `json:"notes,omitempty"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+}
+
+type referringPhysicianResponse struct {
+	ID            string  `json:"id"`
+	PatientID     string  `json:"patient_id"`
+	FullName      string  `json:"full_name"`
+	LicenseNumber *string `json:"license_number,omitempty"`
+	Specialty     *string `json:"specialty,omitempty"`
+	Phone         *string `json:"phone,omitempty"`
+	Email         *string `json:"email,omitempty"`
+	Notes         *string `json:"notes,omitempty"`
+	CreatedAt     string  `json:"created_at"`
+	UpdatedAt     string  `json:"updated_at"`
+}
+
+type clinicalRecordResponse struct {
+	PatientID           string                       `json:"patient_id"`
+	Diagnoses           []diagnosisResponse          `json:"diagnoses"`
+	Injuries            []injuryResponse             `json:"injuries"`
+	Surgeries           []surgeryResponse            `json:"surgeries"`
+	Medications         []medicationResponse         `json:"medications"`
+	Allergies           []allergyResponse            `json:"allergies"`
+	Contraindications   []contraindicationResponse   `json:"contraindications"`
+	ReferringPhysicians []referringPhysicianResponse `json:"referring_physicians"`
+}
+
+func fmtDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format("2006-01-02")
+	return &s
+}
+
+func fmtUUID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func fmtTS(t time.Time) string { return t.UTC().Format(time.RFC3339) }
+
+func toDiagnosisResp(d domain.Diagnosis) diagnosisResponse {
+	return diagnosisResponse{
+		ID:                   d.ID.String(),
+		PatientID:            d.PatientID.String(),
+		ICD10Code:            d.ICD10Code,
+		Description:          d.Description,
+		Status:               string(d.Status),
+		DiagnosedAt:          fmtDate(d.DiagnosedAt),
+		ReferringPhysicianID: fmtUUID(d.ReferringPhysicianID),
+		Notes:                d.Notes,
+		CreatedAt:            fmtTS(d.CreatedAt),
+		UpdatedAt:            fmtTS(d.UpdatedAt),
+	}
+}
+
+func toInjuryResp(i domain.Injury) injuryResponse {
+	return injuryResponse{
+		ID:          i.ID.String(),
+		PatientID:   i.PatientID.String(),
+		DiagnosisID: fmtUUID(i.DiagnosisID),
+		Site:        i.Site,
+		Laterality:  string(i.Laterality),
+		OnsetDate:   fmtDate(i.OnsetDate),
+		Mechanism:   i.Mechanism,
+		Notes:       i.Notes,
+		CreatedAt:   fmtTS(i.CreatedAt),
+		UpdatedAt:   fmtTS(i.UpdatedAt),
+	}
+}
+
+func toSurgeryResp(s domain.Surgery) surgeryResponse {
+	var lat *string
+	if s.Laterality != nil {
+		v := string(*s.Laterality)
+		lat = &v
+	}
+	return surgeryResponse{
+		ID:          s.ID.String(),
+		PatientID:   s.PatientID.String(),
+		Procedure:   s.Procedure,
+		Laterality:  lat,
+		PerformedAt: fmtDate(s.PerformedAt),
+		Surgeon:     s.Surgeon,
+		Notes:       s.Notes,
+		CreatedAt:   fmtTS(s.CreatedAt),
+		UpdatedAt:   fmtTS(s.UpdatedAt),
+	}
+}
+
+func toMedicationResp(m domain.Medication) medicationResponse {
+	return medicationResponse{
+		ID:        m.ID.String(),
+		PatientID: m.PatientID.String(),
+		Name:      m.Name,
+		Dose:      m.Dose,
+		Frequency: m.Frequency,
+		StartDate: fmtDate(m.StartDate),
+		EndDate:   fmtDate(m.EndDate),
+		Notes:     m.Notes,
+		CreatedAt: fmtTS(m.CreatedAt),
+		UpdatedAt: fmtTS(m.UpdatedAt),
+	}
+}
+
+func toAllergyResp(a domain.Allergy) allergyResponse {
+	return allergyResponse{
+		ID:        a.ID.String(),
+		PatientID: a.PatientID.String(),
+		Substance: a.Substance,
+		Reaction:  a.Reaction,
+		Severity:  string(a.Severity),
+		Notes:     a.Notes,
+		CreatedAt: fmtTS(a.CreatedAt),
+		UpdatedAt: fmtTS(a.UpdatedAt),
+	}
+}
+
+func toContraindicationResp(c domain.Contraindication) contraindicationResponse {
+	return contraindicationResponse{
+		ID:          c.ID.String(),
+		PatientID:   c.PatientID.String(),
+		Description: c.Description,
+		Kind:        string(c.Kind),
+		Notes:       c.Notes,
+		CreatedAt:   fmtTS(c.CreatedAt),
+		UpdatedAt:   fmtTS(c.UpdatedAt),
+	}
+}
+
+func toReferringPhysicianResp(p domain.ReferringPhysician) referringPhysicianResponse {
+	return referringPhysicianResponse{
+		ID:            p.ID.String(),
+		PatientID:     p.PatientID.String(),
+		FullName:      p.FullName,
+		LicenseNumber: p.LicenseNumber,
+		Specialty:     p.Specialty,
+		Phone:         p.Phone,
+		Email:         p.Email,
+		Notes:         p.Notes,
+		CreatedAt:     fmtTS(p.CreatedAt),
+		UpdatedAt:     fmtTS(p.UpdatedAt),
+	}
+}
+
+func toClinicalRecordResp(rec domain.ClinicalRecord) clinicalRecordResponse {
+	out := clinicalRecordResponse{
+		PatientID:           rec.PatientID.String(),
+		Diagnoses:           make([]diagnosisResponse, 0, len(rec.Diagnoses)),
+		Injuries:            make([]injuryResponse, 0, len(rec.Injuries)),
+		Surgeries:           make([]surgeryResponse, 0, len(rec.Surgeries)),
+		Medications:         make([]medicationResponse, 0, len(rec.Medications)),
+		Allergies:           make([]allergyResponse, 0, len(rec.Allergies)),
+		Contraindications:   make([]contraindicationResponse, 0, len(rec.Contraindications)),
+		ReferringPhysicians: make([]referringPhysicianResponse, 0, len(rec.ReferringPhysicians)),
+	}
+	for _, d := range rec.Diagnoses {
+		out.Diagnoses = append(out.Diagnoses, toDiagnosisResp(d))
+	}
+	for _, i := range rec.Injuries {
+		out.Injuries = append(out.Injuries, toInjuryResp(i))
+	}
+	for _, s := range rec.Surgeries {
+		out.Surgeries = append(out.Surgeries, toSurgeryResp(s))
+	}
+	for _, m := range rec.Medications {
+		out.Medications = append(out.Medications, toMedicationResp(m))
+	}
+	for _, a := range rec.Allergies {
+		out.Allergies = append(out.Allergies, toAllergyResp(a))
+	}
+	for _, c := range rec.Contraindications {
+		out.Contraindications = append(out.Contraindications, toContraindicationResp(c))
+	}
+	for _, p := range rec.ReferringPhysicians {
+		out.ReferringPhysicians = append(out.ReferringPhysicians, toReferringPhysicianResp(p))
+	}
+	return out
+}
@@ -0,0 +1,231 @@
+package gorm
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/clinicalhistory/domain"
+)
+
+func uuidPtrToString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func stringPtrToUUID(s *string) *uuid.UUID {
+	if s == nil || *s == "" {
+		return nil
+	}
+	id := uuid.MustParse(*s)
+	return &id
+}
+
+func toDiagnosisModel(d domain.Diagnosis) DiagnosisModel {
+	return DiagnosisModel{
+		ID:                   d.ID.String(),
+		PatientID:            d.PatientID.String(),
+		ICD10Code:            d.ICD10Code,
+		Description:          d.Description,
+		Status:               string(d.Status),
+		DiagnosedAt:          d.DiagnosedAt,
+		ReferringPhysicianID: uuidPtrToString(d.ReferringPhysicianID),
+		Notes:                d.Notes,
+		CreatedAt:            d.CreatedAt,
+		UpdatedAt:            d.UpdatedAt,
+	}
+}
+
+func toDiagnosisDomain(m DiagnosisModel) domain.Diagnosis {
+	return domain.Diagnosis{
+		ID:                   uuid.MustParse(m.ID),
+		PatientID:            uuid.MustParse(m.PatientID),
+		ICD10Code:            m.ICD10Code,
+		Description:          m.Description,
+		Status:               domain.DiagnosisStatus(m.Status),
+		DiagnosedAt:          m.DiagnosedAt,
+		ReferringPhysicianID: stringPtrToUUID(m.ReferringPhysicianID),
+		Notes:                m.Notes,
+		CreatedAt:            m.CreatedAt,
+		UpdatedAt:            m.UpdatedAt,
+	}
+}
+
+func toInjuryModel(i domain.Injury) InjuryModel {
+	return InjuryModel{
+		ID:          i.ID.String(),
+		PatientID:   i.PatientID.String(),
+		DiagnosisID: uuidPtrToString(i.DiagnosisID),
+		Site:        i.Site,
+		Laterality:  string(i.Laterality),
+		OnsetDate:   i.OnsetDate,
+		Mechanism:   i.Mechanism,
+		Notes:       i.Notes,
+		CreatedAt:   i.CreatedAt,
+		UpdatedAt:   i.UpdatedAt,
+	}
+}
+
+func toInjuryDomain(m InjuryModel) domain.Injury {
+	return domain.Injury{
+		ID:          uuid.MustParse(m.ID),
+		PatientID:   uuid.MustParse(m.PatientID),
+		DiagnosisID: stringPtrToUUID(m.DiagnosisID),
+		Site:        m.Site,
+		Laterality:  domain.Laterality(m.Laterality),
+		OnsetDate:   m.OnsetDate,
+		Mechanism:   m.Mechanism,
+		Notes:       m.Notes,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+func toSurgeryModel(s domain.Surgery) SurgeryModel {
+	var lat *string
+	if s.Laterality != nil {
+		v := string(*s.Laterality)
+		lat = &v
+	}
+	return SurgeryModel{
+		ID:          s.ID.String(),
+		PatientID:   s.PatientID.String(),
+		Procedure:   s.Procedure,
+		Laterality:  lat,
+		PerformedAt: s.PerformedAt,
+		Surgeon:     s.Surgeon,
+		Notes:       s.Notes,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
+
+func toSurgeryDomain(m SurgeryModel) domain.Surgery {
+	var lat *domain.Laterality
+	if m.Laterality != nil {
+		v := domain.Laterality(*m.Laterality)
+		lat = &v
+	}
+	return domain.Surgery{
+		ID:          uuid.MustParse(m.ID),
+		PatientID:   uuid.MustParse(m.PatientID),
+		Procedure:   m.Procedure,
+		Laterality:  lat,
+		PerformedAt: m.PerformedAt,
+		Surgeon:     m.Surgeon,
+		Notes:       m.Notes,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+func toMedicationModel(m domain.Medication) MedicationModel {
+	return MedicationModel{
+		ID:        m.ID.String(),
+		PatientID: m.PatientID.String(),
+		Name:      m.Name,
+		Dose:      m.Dose,
+		Frequency: m.Frequency,
+		StartDate: m.StartDate,
+		EndDate:   m.EndDate,
+		Notes:     m.Notes,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func toMedicationDomain(m MedicationModel) domain.Medication {
+	return domain.Medication{
+		ID:        uuid.MustParse(m.ID),
+		PatientID: uuid.MustParse(m.PatientID),
+		Name:      m.Name,
+		Dose:      m.Dose,
+		Frequency: m.Frequency,
+		StartDate: m.StartDate,
+		EndDate:   m.EndDate,
+		Notes:     m.Notes,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func toAllergyModel(a domain.Allergy) AllergyModel {
+	return AllergyModel{
+		ID:        a.ID.String(),
+		PatientID: a.PatientID.String(),
+		Substance: a.Substance,
+		Reaction:  a.Reaction,
+		Severity:  string(a.Severity),
+		Notes:     a.Notes,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
+}
+
+func toAllergyDomain(m AllergyModel) domain.Allergy {
+	return domain.Allergy{
+		ID:        uuid.MustParse(m.ID),
+		PatientID: uuid.MustParse(m.PatientID),
+		Substance: m.Substance,
+		Reaction:  m.Reaction,
+		Severity:  domain.AllergySeverity(m.Severity),
+		Notes:     m.Notes,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func toContraindicationModel(c domain.Contraindication) ContraindicationModel {
+	return ContraindicationModel{
+		ID:          c.ID.String(),
+		PatientID:   c.PatientID.String(),
+		Description: c.Description,
+		Kind:        string(c.Kind),
+		Notes:       c.Notes,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+func toContraindicationDomain(m ContraindicationModel) domain.Contraindication {
+	return domain.Contraindication{
+		ID:          uuid.MustParse(m.ID),
+		PatientID:   uuid.MustParse(m.PatientID),
+		Description: m.Description,
+		Kind:        domain.ContraindicationKind(m.Kind),
+		Notes:       m.Notes,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+func toReferringPhysicianModel(p domain.ReferringPhysician) ReferringPhysicianModel {
+	return ReferringPhysicianModel{
+		ID:            p.ID.String(),
+		PatientID:     p.PatientID.String(),
+		FullName:      p.FullName,
+		LicenseNumber: p.LicenseNumber,
+		Specialty:     p.Specialty,
+		Phone:         p.Phone,
+		Email:         p.Email,
+		Notes:         p.Notes,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+	}
+}
+
+func toReferringPhysicianDomain(m ReferringPhysicianModel) domain.ReferringPhysician {
+	return domain.ReferringPhysician{
+		ID:            uuid.MustParse(m.ID),
+		PatientID:     uuid.MustParse(m.PatientID),
+		FullName:      m.FullName,
+		LicenseNumber: m.LicenseNumber,
+		Specialty:     m.Specialty,
+		Phone:         m.Phone,
+		Email:         m.Email,
+		Notes:         m.Notes,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
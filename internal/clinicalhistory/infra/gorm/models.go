@@ -0,0 +1,102 @@
+package gorm
+
+import "time"
+
+type DiagnosisModel struct {
+	ID                   string     `gorm:"type:uuid;primaryKey"`
+	PatientID            string     `gorm:"type:uuid;not null"`
+	ICD10Code            string     `gorm:"column:icd10_code;not null"`
+	Description          string     `gorm:"not null"`
+	Status               string     `gorm:"not null"`
+	DiagnosedAt          *time.Time `gorm:"type:date"`
+	ReferringPhysicianID *string    `gorm:"type:uuid"`
+	Notes                *string
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+func (DiagnosisModel) TableName() string { return "patient_diagnoses" }
+
+type InjuryModel struct {
+	ID          string     `gorm:"type:uuid;primaryKey"`
+	PatientID   string     `gorm:"type:uuid;not null"`
+	DiagnosisID *string    `gorm:"type:uuid"`
+	Site        string     `gorm:"not null"`
+	Laterality  string     `gorm:"not null"`
+	OnsetDate   *time.Time `gorm:"type:date"`
+	Mechanism   *string
+	Notes       *string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (InjuryModel) TableName() string { return "patient_injuries" }
+
+type SurgeryModel struct {
+	ID          string `gorm:"type:uuid;primaryKey"`
+	PatientID   string `gorm:"type:uuid;not null"`
+	Procedure   string `gorm:"not null"`
+	Laterality  *string
+	PerformedAt *time.Time `gorm:"type:date"`
+	Surgeon     *string
+	Notes       *string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (SurgeryModel) TableName() string { return "patient_surgeries" }
+
+type MedicationModel struct {
+	ID        string `gorm:"type:uuid;primaryKey"`
+	PatientID string `gorm:"type:uuid;not null"`
+	Name      string `gorm:"not null"`
+	Dose      *string
+	Frequency *string
+	StartDate *time.Time `gorm:"type:date"`
+	EndDate   *time.Time `gorm:"type:date"`
+	Notes     *string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (MedicationModel) TableName() string { return "patient_medications" }
+
+type AllergyModel struct {
+	ID        string `gorm:"type:uuid;primaryKey"`
+	PatientID string `gorm:"type:uuid;not null"`
+	Substance string `gorm:"not null"`
+	Reaction  *string
+	Severity  string `gorm:"not null"`
+	Notes     *string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (AllergyModel) TableName() string { return "patient_allergies" }
+
+type ContraindicationModel struct {
+	ID          string `gorm:"type:uuid;primaryKey"`
+	PatientID   string `gorm:"type:uuid;not null"`
+	Description string `gorm:"not null"`
+	Kind        string `gorm:"not null"`
+	Notes       *string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (ContraindicationModel) TableName() string { return "patient_contraindications" }
+
+type ReferringPhysicianModel struct {
+	ID            string `gorm:"type:uuid;primaryKey"`
+	PatientID     string `gorm:"type:uuid;not null"`
+	FullName      string `gorm:"not null"`
+	LicenseNumber *string
+	Specialty     *string
+	Phone         *string
+	Email         *string
+	Notes         *string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (ReferringPhysicianModel) TableName() string { return "patient_referring_physicians" }
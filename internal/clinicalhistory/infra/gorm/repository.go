@@ -0,0 +1,336 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/javiacuna/kinesio-backend/internal/clinicalhistory/domain"
+)
+
+var _ domain.Repository = (*Repository)(nil)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// -------- Diagnoses
+
+func (r *Repository) CreateDiagnosis(ctx context.Context, d domain.Diagnosis) (domain.Diagnosis, error) {
+	m := toDiagnosisModel(d)
+	if err := r.db.WithContext(ctx).Create(&m).Error; err != nil {
+		return domain.Diagnosis{}, err
+	}
+	return toDiagnosisDomain(m), nil
+}
+
+func (r *Repository) UpdateDiagnosis(ctx context.Context, d domain.Diagnosis) (domain.Diagnosis, error) {
+	m := toDiagnosisModel(d)
+	if err := r.db.WithContext(ctx).Save(&m).Error; err != nil {
+		return domain.Diagnosis{}, err
+	}
+	return toDiagnosisDomain(m), nil
+}
+
+func (r *Repository) GetDiagnosis(ctx context.Context, id uuid.UUID) (domain.Diagnosis, bool, error) {
+	var m DiagnosisModel
+	err := r.db.WithContext(ctx).First(&m, "id = ?", id.String()).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.Diagnosis{}, false, nil
+		}
+		return domain.Diagnosis{}, false, err
+	}
+	return toDiagnosisDomain(m), true, nil
+}
+
+func (r *Repository) ListDiagnoses(ctx context.Context, patientID uuid.UUID) ([]domain.Diagnosis, error) {
+	var ms []DiagnosisModel
+	if err := r.db.WithContext(ctx).
+		Order("diagnosed_at desc nulls last, created_at desc").
+		Find(&ms, "patient_id = ?", patientID.String()).
+		Error; err != nil {
+		return nil, err
+	}
+	out := make([]domain.Diagnosis, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, toDiagnosisDomain(m))
+	}
+	return out, nil
+}
+
+// -------- Injuries
+
+func (r *Repository) CreateInjury(ctx context.Context, i domain.Injury) (domain.Injury, error) {
+	m := toInjuryModel(i)
+	if err := r.db.WithContext(ctx).Create(&m).Error; err != nil {
+		return domain.Injury{}, err
+	}
+	return toInjuryDomain(m), nil
+}
+
+func (r *Repository) UpdateInjury(ctx context.Context, i domain.Injury) (domain.Injury, error) {
+	m := toInjuryModel(i)
+	if err := r.db.WithContext(ctx).Save(&m).Error; err != nil {
+		return domain.Injury{}, err
+	}
+	return toInjuryDomain(m), nil
+}
+
+func (r *Repository) GetInjury(ctx context.Context, id uuid.UUID) (domain.Injury, bool, error) {
+	var m InjuryModel
+	err := r.db.WithContext(ctx).First(&m, "id = ?", id.String()).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.Injury{}, false, nil
+		}
+		return domain.Injury{}, false, err
+	}
+	return toInjuryDomain(m), true, nil
+}
+
+func (r *Repository) ListInjuries(ctx context.Context, patientID uuid.UUID) ([]domain.Injury, error) {
+	var ms []InjuryModel
+	if err := r.db.WithContext(ctx).
+		Order("onset_date desc nulls last, created_at desc").
+		Find(&ms, "patient_id = ?", patientID.String()).
+		Error; err != nil {
+		return nil, err
+	}
+	out := make([]domain.Injury, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, toInjuryDomain(m))
+	}
+	return out, nil
+}
+
+// -------- Surgeries
+
+func (r *Repository) CreateSurgery(ctx context.Context, s domain.Surgery) (domain.Surgery, error) {
+	m := toSurgeryModel(s)
+	if err := r.db.WithContext(ctx).Create(&m).Error; err != nil {
+		return domain.Surgery{}, err
+	}
+	return toSurgeryDomain(m), nil
+}
+
+func (r *Repository) UpdateSurgery(ctx context.Context, s domain.Surgery) (domain.Surgery, error) {
+	m := toSurgeryModel(s)
+	if err := r.db.WithContext(ctx).Save(&m).Error; err != nil {
+		return domain.Surgery{}, err
+	}
+	return toSurgeryDomain(m), nil
+}
+
+func (r *Repository) GetSurgery(ctx context.Context, id uuid.UUID) (domain.Surgery, bool, error) {
+	var m SurgeryModel
+	err := r.db.WithContext(ctx).First(&m, "id = ?", id.String()).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.Surgery{}, false, nil
+		}
+		return domain.Surgery{}, false, err
+	}
+	return toSurgeryDomain(m), true, nil
+}
+
+func (r *Repository) ListSurgeries(ctx context.Context, patientID uuid.UUID) ([]domain.Surgery, error) {
+	var ms []SurgeryModel
+	if err := r.db.WithContext(ctx).
+		Order("performed_at desc nulls last, created_at desc").
+		Find(&ms, "patient_id = ?", patientID.String()).
+		Error; err != nil {
+		return nil, err
+	}
+	out := make([]domain.Surgery, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, toSurgeryDomain(m))
+	}
+	return out, nil
+}
+
+// -------- Medications
+
+func (r *Repository) CreateMedication(ctx context.Context, med domain.Medication) (domain.Medication, error) {
+	m := toMedicationModel(med)
+	if err := r.db.WithContext(ctx).Create(&m).Error; err != nil {
+		return domain.Medication{}, err
+	}
+	return toMedicationDomain(m), nil
+}
+
+func (r *Repository) UpdateMedication(ctx context.Context, med domain.Medication) (domain.Medication, error) {
+	m := toMedicationModel(med)
+	if err := r.db.WithContext(ctx).Save(&m).Error; err != nil {
+		return domain.Medication{}, err
+	}
+	return toMedicationDomain(m), nil
+}
+
+func (r *Repository) GetMedication(ctx context.Context, id uuid.UUID) (domain.Medication, bool, error) {
+	var m MedicationModel
+	err := r.db.WithContext(ctx).First(&m, "id = ?", id.String()).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.Medication{}, false, nil
+		}
+		return domain.Medication{}, false, err
+	}
+	return toMedicationDomain(m), true, nil
+}
+
+func (r *Repository) ListMedications(ctx context.Context, patientID uuid.UUID) ([]domain.Medication, error) {
+	var ms []MedicationModel
+	if err := r.db.WithContext(ctx).
+		Order("start_date desc nulls last, created_at desc").
+		Find(&ms, "patient_id = ?", patientID.String()).
+		Error; err != nil {
+		return nil, err
+	}
+	out := make([]domain.Medication, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, toMedicationDomain(m))
+	}
+	return out, nil
+}
+
+// -------- Allergies
+
+func (r *Repository) CreateAllergy(ctx context.Context, a domain.Allergy) (domain.Allergy, error) {
+	m := toAllergyModel(a)
+	if err := r.db.WithContext(ctx).Create(&m).Error; err != nil {
+		return domain.Allergy{}, err
+	}
+	return toAllergyDomain(m), nil
+}
+
+func (r *Repository) UpdateAllergy(ctx context.Context, a domain.Allergy) (domain.Allergy, error) {
+	m := toAllergyModel(a)
+	if err := r.db.WithContext(ctx).Save(&m).Error; err != nil {
+		return domain.Allergy{}, err
+	}
+	return toAllergyDomain(m), nil
+}
+
+func (r *Repository) GetAllergy(ctx context.Context, id uuid.UUID) (domain.Allergy, bool, error) {
+	var m AllergyModel
+	err := r.db.WithContext(ctx).First(&m, "id = ?", id.String()).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.Allergy{}, false, nil
+		}
+		return domain.Allergy{}, false, err
+	}
+	return toAllergyDomain(m), true, nil
+}
+
+func (r *Repository) ListAllergies(ctx context.Context, patientID uuid.UUID) ([]domain.Allergy, error) {
+	var ms []AllergyModel
+	if err := r.db.WithContext(ctx).
+		Order("created_at desc").
+		Find(&ms, "patient_id = ?", patientID.String()).
+		Error; err != nil {
+		return nil, err
+	}
+	out := make([]domain.Allergy, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, toAllergyDomain(m))
+	}
+	return out, nil
+}
+
+// -------- Contraindications
+
+func (r *Repository) CreateContraindication(ctx context.Context, c domain.Contraindication) (domain.Contraindication, error) {
+	m := toContraindicationModel(c)
+	if err := r.db.WithContext(ctx).Create(&m).Error; err != nil {
+		return domain.Contraindication{}, err
+	}
+	return toContraindicationDomain(m), nil
+}
+
+func (r *Repository) UpdateContraindication(ctx context.Context, c domain.Contraindication) (domain.Contraindication, error) {
+	m := toContraindicationModel(c)
+	if err := r.db.WithContext(ctx).Save(&m).Error; err != nil {
+		return domain.Contraindication{}, err
+	}
+	return toContraindicationDomain(m), nil
+}
+
+func (r *Repository) GetContraindication(ctx context.Context, id uuid.UUID) (domain.Contraindication, bool, error) {
+	var m ContraindicationModel
+	err := r.db.WithContext(ctx).First(&m, "id = ?", id.String()).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.Contraindication{}, false, nil
+		}
+		return domain.Contraindication{}, false, err
+	}
+	return toContraindicationDomain(m), true, nil
+}
+
+func (r *Repository) ListContraindications(ctx context.Context, patientID uuid.UUID) ([]domain.Contraindication, error) {
+	var ms []ContraindicationModel
+	if err := r.db.WithContext(ctx).
+		Order("created_at desc").
+		Find(&ms, "patient_id = ?", patientID.String()).
+		Error; err != nil {
+		return nil, err
+	}
+	out := make([]domain.Contraindication, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, toContraindicationDomain(m))
+	}
+	return out, nil
+}
+
+// -------- ReferringPhysicians
+
+func (r *Repository) CreateReferringPhysician(ctx context.Context, p domain.ReferringPhysician) (domain.ReferringPhysician, error) {
+	m := toReferringPhysicianModel(p)
+	if err := r.db.WithContext(ctx).Create(&m).Error; err != nil {
+		return domain.ReferringPhysician{}, err
+	}
+	return toReferringPhysicianDomain(m), nil
+}
+
+func (r *Repository) UpdateReferringPhysician(ctx context.Context, p domain.ReferringPhysician) (domain.ReferringPhysician, error) {
+	m := toReferringPhysicianModel(p)
+	if err := r.db.WithContext(ctx).Save(&m).Error; err != nil {
+		return domain.ReferringPhysician{}, err
+	}
+	return toReferringPhysicianDomain(m), nil
+}
+
+func (r *Repository) GetReferringPhysician(ctx context.Context, id uuid.UUID) (domain.ReferringPhysician, bool, error) {
+	var m ReferringPhysicianModel
+	err := r.db.WithContext(ctx).First(&m, "id = ?", id.String()).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ReferringPhysician{}, false, nil
+		}
+		return domain.ReferringPhysician{}, false, err
+	}
+	return toReferringPhysicianDomain(m), true, nil
+}
+
+func (r *Repository) ListReferringPhysicians(ctx context.Context, patientID uuid.UUID) ([]domain.ReferringPhysician, error) {
+	var ms []ReferringPhysicianModel
+	if err := r.db.WithContext(ctx).
+		Order("created_at desc").
+		Find(&ms, "patient_id = ?", patientID.String()).
+		Error; err != nil {
+		return nil, err
+	}
+	out := make([]domain.ReferringPhysician, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, toReferringPhysicianDomain(m))
+	}
+	return out, nil
+}
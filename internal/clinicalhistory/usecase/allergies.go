@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/clinicalhistory/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+type AllergyInput struct {
+	Substance string  `json:"substance"`
+	Reaction  *string `json:"reaction"`
+	Severity  string  `json:"severity"` // mild|moderate|severe
+	Notes     *string `json:"notes"`
+}
+
+func (in AllergyInput) apply(a *domain.Allergy, validation *validation.Errors) {
+	a.Substance = strings.TrimSpace(in.Substance)
+	if a.Substance == "" {
+		validation.Add("substance", "required")
+	}
+
+	a.Severity = domain.AllergySeverity(strings.TrimSpace(in.Severity))
+	switch a.Severity {
+	case domain.AllergyMild, domain.AllergyModerate, domain.AllergySevere:
+	default:
+		validation.Add("severity", "must_be_mild_moderate_or_severe")
+	}
+
+	a.Reaction = trimPtr(in.Reaction)
+	a.Notes = trimPtr(in.Notes)
+}
+
+type CreateAllergyUseCase struct {
+	repo domain.Repository
+}
+
+func NewCreateAllergyUseCase(repo domain.Repository) *CreateAllergyUseCase {
+	return &CreateAllergyUseCase{repo: repo}
+}
+
+func (uc *CreateAllergyUseCase) Execute(ctx context.Context, patientID string, in AllergyInput) (domain.Allergy, *validation.Errors, error) {
+	validation := validation.New()
+
+	a := domain.Allergy{ID: uuid.New(), PatientID: parsePatientID(patientID, validation)}
+	in.apply(&a, validation)
+	if !validation.Empty() {
+		return domain.Allergy{}, validation, domain.ErrValidation
+	}
+
+	now := time.Now().UTC()
+	a.CreatedAt, a.UpdatedAt = now, now
+
+	out, err := uc.repo.CreateAllergy(ctx, a)
+	if err != nil {
+		return domain.Allergy{}, nil, err
+	}
+	return out, nil, nil
+}
+
+type UpdateAllergyUseCase struct {
+	repo domain.Repository
+}
+
+func NewUpdateAllergyUseCase(repo domain.Repository) *UpdateAllergyUseCase {
+	return &UpdateAllergyUseCase{repo: repo}
+}
+
+func (uc *UpdateAllergyUseCase) Execute(ctx context.Context, id uuid.UUID, in AllergyInput) (domain.Allergy, *validation.Errors, error) {
+	a, found, err := uc.repo.GetAllergy(ctx, id)
+	if err != nil {
+		return domain.Allergy{}, nil, err
+	}
+	if !found {
+		return domain.Allergy{}, nil, domain.ErrNotFound
+	}
+
+	validation := validation.New()
+	in.apply(&a, validation)
+	if !validation.Empty() {
+		return domain.Allergy{}, validation, domain.ErrValidation
+	}
+
+	a.UpdatedAt = time.Now().UTC()
+	out, err := uc.repo.UpdateAllergy(ctx, a)
+	if err != nil {
+		return domain.Allergy{}, nil, err
+	}
+	return out, nil, nil
+}
+
+type ListAllergiesUseCase struct {
+	repo domain.Repository
+}
+
+func NewListAllergiesUseCase(repo domain.Repository) *ListAllergiesUseCase {
+	return &ListAllergiesUseCase{repo: repo}
+}
+
+func (uc *ListAllergiesUseCase) Execute(ctx context.Context, patientID uuid.UUID) ([]domain.Allergy, error) {
+	return uc.repo.ListAllergies(ctx, patientID)
+}
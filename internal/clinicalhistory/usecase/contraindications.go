@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/clinicalhistory/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+type ContraindicationInput struct {
+	Description string  `json:"description"`
+	Kind        string  `json:"kind"` // absolute|relative
+	Notes       *string `json:"notes"`
+}
+
+func (in ContraindicationInput) apply(c *domain.Contraindication, validation *validation.Errors) {
+	c.Description = strings.TrimSpace(in.Description)
+	if c.Description == "" {
+		validation.Add("description", "required")
+	}
+
+	c.Kind = domain.ContraindicationKind(strings.TrimSpace(in.Kind))
+	if c.Kind != domain.ContraindicationAbsolute && c.Kind != domain.ContraindicationRelative {
+		validation.Add("kind", "must_be_absolute_or_relative")
+	}
+
+	c.Notes = trimPtr(in.Notes)
+}
+
+type CreateContraindicationUseCase struct {
+	repo domain.Repository
+}
+
+func NewCreateContraindicationUseCase(repo domain.Repository) *CreateContraindicationUseCase {
+	return &CreateContraindicationUseCase{repo: repo}
+}
+
+func (uc *CreateContraindicationUseCase) Execute(ctx context.Context, patientID string, in ContraindicationInput) (domain.Contraindication, *validation.Errors, error) {
+	validation := validation.New()
+
+	c := domain.Contraindication{ID: uuid.New(), PatientID: parsePatientID(patientID, validation)}
+	in.apply(&c, validation)
+	if !validation.Empty() {
+		return domain.Contraindication{}, validation, domain.ErrValidation
+	}
+
+	now := time.Now().UTC()
+	c.CreatedAt, c.UpdatedAt = now, now
+
+	out, err := uc.repo.CreateContraindication(ctx, c)
+	if err != nil {
+		return domain.Contraindication{}, nil, err
+	}
+	return out, nil, nil
+}
+
+type UpdateContraindicationUseCase struct {
+	repo domain.Repository
+}
+
+func NewUpdateContraindicationUseCase(repo domain.Repository) *UpdateContraindicationUseCase {
+	return &UpdateContraindicationUseCase{repo: repo}
+}
+
+func (uc *UpdateContraindicationUseCase) Execute(ctx context.Context, id uuid.UUID, in ContraindicationInput) (domain.Contraindication, *validation.Errors, error) {
+	c, found, err := uc.repo.GetContraindication(ctx, id)
+	if err != nil {
+		return domain.Contraindication{}, nil, err
+	}
+	if !found {
+		return domain.Contraindication{}, nil, domain.ErrNotFound
+	}
+
+	validation := validation.New()
+	in.apply(&c, validation)
+	if !validation.Empty() {
+		return domain.Contraindication{}, validation, domain.ErrValidation
+	}
+
+	c.UpdatedAt = time.Now().UTC()
+	out, err := uc.repo.UpdateContraindication(ctx, c)
+	if err != nil {
+		return domain.Contraindication{}, nil, err
+	}
+	return out, nil, nil
+}
+
+type ListContraindicationsUseCase struct {
+	repo domain.Repository
+}
+
+func NewListContraindicationsUseCase(repo domain.Repository) *ListContraindicationsUseCase {
+	return &ListContraindicationsUseCase{repo: repo}
+}
+
+func (uc *ListContraindicationsUseCase) Execute(ctx context.Context, patientID uuid.UUID) ([]domain.Contraindication, error) {
+	return uc.repo.ListContraindications(ctx, patientID)
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/clinicalhistory/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+type DiagnosisInput struct {
+	ICD10Code            string  `json:"icd10_code"`
+	Description          string  `json:"description"`
+	Status               string  `json:"status"`       // active|resolved (default active)
+	DiagnosedAt          *string `json:"diagnosed_at"` // YYYY-MM-DD
+	ReferringPhysicianID *string `json:"referring_physician_id"`
+	Notes                *string `json:"notes"`
+}
+
+func (in DiagnosisInput) apply(d *domain.Diagnosis, validation *validation.Errors) {
+	d.ICD10Code = domain.NormalizeICD10(in.ICD10Code)
+	if d.ICD10Code == "" {
+		validation.Add("icd10_code", "required")
+	} else if !domain.ValidICD10(d.ICD10Code) {
+		validation.Add("icd10_code", "invalid_icd10_code")
+	}
+
+	d.Description = strings.TrimSpace(in.Description)
+	if d.Description == "" {
+		validation.Add("description", "required")
+	}
+
+	d.Status = domain.DiagnosisStatus(strings.TrimSpace(in.Status))
+	if d.Status == "" {
+		d.Status = domain.DiagnosisActive
+	}
+	if d.Status != domain.DiagnosisActive && d.Status != domain.DiagnosisResolved {
+		validation.Add("status", "must_be_active_or_resolved")
+	}
+
+	d.DiagnosedAt = parseDate("diagnosed_at", in.DiagnosedAt, validation)
+	d.ReferringPhysicianID = validation.OptionalUUID("referring_physician_id", in.ReferringPhysicianID)
+	d.Notes = trimPtr(in.Notes)
+}
+
+type CreateDiagnosisUseCase struct {
+	repo domain.Repository
+}
+
+func NewCreateDiagnosisUseCase(repo domain.Repository) *CreateDiagnosisUseCase {
+	return &CreateDiagnosisUseCase{repo: repo}
+}
+
+func (uc *CreateDiagnosisUseCase) Execute(ctx context.Context, patientID string, in DiagnosisInput) (domain.Diagnosis, *validation.Errors, error) {
+	validation := validation.New()
+
+	d := domain.Diagnosis{ID: uuid.New(), PatientID: parsePatientID(patientID, validation)}
+	in.apply(&d, validation)
+	if validation.Empty() {
+		if err := checkPhysician(ctx, uc.repo, d.ReferringPhysicianID, d.PatientID, validation); err != nil {
+			return domain.Diagnosis{}, nil, err
+		}
+	}
+	if !validation.Empty() {
+		return domain.Diagnosis{}, validation, domain.ErrValidation
+	}
+
+	now := time.Now().UTC()
+	d.CreatedAt, d.UpdatedAt = now, now
+
+	out, err := uc.repo.CreateDiagnosis(ctx, d)
+	if err != nil {
+		return domain.Diagnosis{}, nil, err
+	}
+	return out, nil, nil
+}
+
+type UpdateDiagnosisUseCase struct {
+	repo domain.Repository
+}
+
+func NewUpdateDiagnosisUseCase(repo domain.Repository) *UpdateDiagnosisUseCase {
+	return &UpdateDiagnosisUseCase{repo: repo}
+}
+
+func (uc *UpdateDiagnosisUseCase) Execute(ctx context.Context, id uuid.UUID, in DiagnosisInput) (domain.Diagnosis, *validation.Errors, error) {
+	d, found, err := uc.repo.GetDiagnosis(ctx, id)
+	if err != nil {
+		return domain.Diagnosis{}, nil, err
+	}
+	if !found {
+		return domain.Diagnosis{}, nil, domain.ErrNotFound
+	}
+
+	validation := validation.New()
+	in.apply(&d, validation)
+	if validation.Empty() {
+		if err := checkPhysician(ctx, uc.repo, d.ReferringPhysicianID, d.PatientID, validation); err != nil {
+			return domain.Diagnosis{}, nil, err
+		}
+	}
+	if !validation.Empty() {
+		return domain.Diagnosis{}, validation, domain.ErrValidation
+	}
+
+	d.UpdatedAt = time.Now().UTC()
+	out, err := uc.repo.UpdateDiagnosis(ctx, d)
+	if err != nil {
+		return domain.Diagnosis{}, nil, err
+	}
+	return out, nil, nil
+}
+
+type ListDiagnosesUseCase struct {
+	repo domain.Repository
+}
+
+func NewListDiagnosesUseCase(repo domain.Repository) *ListDiagnosesUseCase {
+	return &ListDiagnosesUseCase{repo: repo}
+}
+
+func (uc *ListDiagnosesUseCase) Execute(ctx context.Context, patientID uuid.UUID) ([]domain.Diagnosis, error) {
+	return uc.repo.ListDiagnoses(ctx, patientID)
+}
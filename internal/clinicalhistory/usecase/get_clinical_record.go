@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/clinicalhistory/domain"
+)
+
+type GetClinicalRecordUseCase struct {
+	repo domain.Repository
+}
+
+func NewGetClinicalRecordUseCase(repo domain.Repository) *GetClinicalRecordUseCase {
+	return &GetClinicalRecordUseCase{repo: repo}
+}
+
+func (uc *GetClinicalRecordUseCase) Execute(ctx context.Context, patientID uuid.UUID) (domain.ClinicalRecord, error) {
+	rec := domain.ClinicalRecord{PatientID: patientID}
+	var err error
+
+	if rec.Diagnoses, err = uc.repo.ListDiagnoses(ctx, patientID); err != nil {
+		return domain.ClinicalRecord{}, err
+	}
+	if rec.Injuries, err = uc.repo.ListInjuries(ctx, patientID); err != nil {
+		return domain.ClinicalRecord{}, err
+	}
+	if rec.Surgeries, err = uc.repo.ListSurgeries(ctx, patientID); err != nil {
+		return domain.ClinicalRecord{}, err
+	}
+	if rec.Medications, err = uc.repo.ListMedications(ctx, patientID); err != nil {
+		return domain.ClinicalRecord{}, err
+	}
+	if rec.Allergies, err = uc.repo.ListAllergies(ctx, patientID); err != nil {
+		return domain.ClinicalRecord{}, err
+	}
+	if rec.Contraindications, err = uc.repo.ListContraindications(ctx, patientID); err != nil {
+		return domain.ClinicalRecord{}, err
+	}
+	if rec.ReferringPhysicians, err = uc.repo.ListReferringPhysicians(ctx, patientID); err != nil {
+		return domain.ClinicalRecord{}, err
+	}
+	return rec, nil
+}
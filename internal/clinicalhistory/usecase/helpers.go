@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/clinicalhistory/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+func trimPtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := strings.TrimSpace(*s)
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+// parseDate parsea una fecha opcional YYYY-MM-DD; si es inválida deja el error en validation[field].
+func parseDate(field string, s *string, validation *validation.Errors) *time.Time {
+	v := trimPtr(s)
+	if v == nil {
+		return nil
+	}
+	tm, err := time.Parse("2006-01-02", *v)
+	if err != nil {
+		validation.Add(field, "invalid_date_(YYYY-MM-DD)")
+		return nil
+	}
+	utc := tm.UTC()
+	return &utc
+}
+
+func parsePatientID(patientID string, validation *validation.Errors) uuid.UUID {
+	id, err := uuid.Parse(strings.TrimSpace(patientID))
+	if err != nil {
+		validation.Add("patient_id", "invalid_uuid")
+	}
+	return id
+}
+
+// checkPhysician verifica que el médico derivante exista y sea del mismo paciente.
+func checkPhysician(ctx context.Context, repo domain.Repository, id *uuid.UUID, patientID uuid.UUID, validation *validation.Errors) error {
+	if id == nil {
+		return nil
+	}
+	p, found, err := repo.GetReferringPhysician(ctx, *id)
+	if err != nil {
+		return err
+	}
+	if !found || p.PatientID != patientID {
+		validation.Add("referring_physician_id", "not_found")
+	}
+	return nil
+}
+
+// checkDiagnosis verifica que el diagnóstico exista y sea del mismo paciente.
+func checkDiagnosis(ctx context.Context, repo domain.Repository, id *uuid.UUID, patientID uuid.UUID, validation *validation.Errors) error {
+	if id == nil {
+		return nil
+	}
+	d, found, err := repo.GetDiagnosis(ctx, *id)
+	if err != nil {
+		return err
+	}
+	if !found || d.PatientID != patientID {
+		validation.Add("diagnosis_id", "not_found")
+	}
+	return nil
+}
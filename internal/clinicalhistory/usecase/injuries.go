@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/clinicalhistory/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+type InjuryInput struct {
+	DiagnosisID *string `json:"diagnosis_id"`
+	Site        string  `json:"site"`
+	Laterality  string  `json:"laterality"` // left|right|bilateral|not_applicable
+	OnsetDate   *string `json:"onset_date"` // YYYY-MM-DD
+	Mechanism   *string `json:"mechanism"`
+	Notes       *string `json:"notes"`
+}
+
+func (in InjuryInput) apply(i *domain.Injury, validation *validation.Errors) {
+	i.DiagnosisID = validation.OptionalUUID("diagnosis_id", in.DiagnosisID)
+
+	i.Site = strings.TrimSpace(in.Site)
+	if i.Site == "" {
+		validation.Add("site", "required")
+	}
+
+	i.Laterality = domain.Laterality(strings.TrimSpace(in.Laterality))
+	if !i.Laterality.Valid() {
+		validation.Add("laterality", "must_be_left_right_bilateral_or_not_applicable")
+	}
+
+	i.OnsetDate = parseDate("onset_date", in.OnsetDate, validation)
+	if i.OnsetDate != nil && i.OnsetDate.After(time.Now().UTC()) {
+		validation.Add("onset_date", "must_not_be_in_the_future")
+	}
+	i.Mechanism = trimPtr(in.Mechanism)
+	i.Notes = trimPtr(in.Notes)
+}
+
+type CreateInjuryUseCase struct {
+	repo domain.Repository
+}
+
+func NewCreateInjuryUseCase(repo domain.Repository) *CreateInjuryUseCase {
+	return &CreateInjuryUseCase{repo: repo}
+}
+
+func (uc *CreateInjuryUseCase) Execute(ctx context.Context, patientID string, in InjuryInput) (domain.Injury, *validation.Errors, error) {
+	validation := validation.New()
+
+	i := domain.Injury{ID: uuid.New(), PatientID: parsePatientID(patientID, validation)}
+	in.apply(&i, validation)
+	if validation.Empty() {
+		if err := checkDiagnosis(ctx, uc.repo, i.DiagnosisID, i.PatientID, validation); err != nil {
+			return domain.Injury{}, nil, err
+		}
+	}
+	if !validation.Empty() {
+		return domain.Injury{}, validation, domain.ErrValidation
+	}
+
+	now := time.Now().UTC()
+	i.CreatedAt, i.UpdatedAt = now, now
+
+	out, err := uc.repo.CreateInjury(ctx, i)
+	if err != nil {
+		return domain.Injury{}, nil, err
+	}
+	return out, nil, nil
+}
+
+type UpdateInjuryUseCase struct {
+	repo domain.Repository
+}
+
+func NewUpdateInjuryUseCase(repo domain.Repository) *UpdateInjuryUseCase {
+	return &UpdateInjuryUseCase{repo: repo}
+}
+
+func (uc *UpdateInjuryUseCase) Execute(ctx context.Context, id uuid.UUID, in InjuryInput) (domain.Injury, *validation.Errors, error) {
+	i, found, err := uc.repo.GetInjury(ctx, id)
+	if err != nil {
+		return domain.Injury{}, nil, err
+	}
+	if !found {
+		return domain.Injury{}, nil, domain.ErrNotFound
+	}
+
+	validation := validation.New()
+	in.apply(&i, validation)
+	if validation.Empty() {
+		if err := checkDiagnosis(ctx, uc.repo, i.DiagnosisID, i.PatientID, validation); err != nil {
+			return domain.Injury{}, nil, err
+		}
+	}
+	if !validation.Empty() {
+		return domain.Injury{}, validation, domain.ErrValidation
+	}
+
+	i.UpdatedAt = time.Now().UTC()
+	out, err := uc.repo.UpdateInjury(ctx, i)
+	if err != nil {
+		return domain.Injury{}, nil, err
+	}
+	return out, nil, nil
+}
+
+type ListInjuriesUseCase struct {
+	repo domain.Repository
+}
+
+func NewListInjuriesUseCase(repo domain.Repository) *ListInjuriesUseCase {
+	return &ListInjuriesUseCase{repo: repo}
+}
+
+func (uc *ListInjuriesUseCase) Execute(ctx context.Context, patientID uuid.UUID) ([]domain.Injury, error) {
+	return uc.repo.ListInjuries(ctx, patientID)
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/clinicalhistory/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+type MedicationInput struct {
+	Name      string  `json:"name"`
+	Dose      *string `json:"dose"`
+	Frequency *string `json:"frequency"`
+	StartDate *string `json:"start_date"` // YYYY-MM-DD
+	EndDate   *string `json:"end_date"`   // YYYY-MM-DD
+	Notes     *string `json:"notes"`
+}
+
+func (in MedicationInput) apply(m *domain.Medication, validation *validation.Errors) {
+	m.Name = strings.TrimSpace(in.Name)
+	if m.Name == "" {
+		validation.Add("name", "required")
+	}
+
+	m.Dose = trimPtr(in.Dose)
+	m.Frequency = trimPtr(in.Frequency)
+	m.StartDate = parseDate("start_date", in.StartDate, validation)
+	m.EndDate = parseDate("end_date", in.EndDate, validation)
+	if m.StartDate != nil && m.EndDate != nil && m.EndDate.Before(*m.StartDate) {
+		validation.Add("end_date", "must_be_>=_start_date")
+	}
+	m.Notes = trimPtr(in.Notes)
+}
+
+type CreateMedicationUseCase struct {
+	repo domain.Repository
+}
+
+func NewCreateMedicationUseCase(repo domain.Repository) *CreateMedicationUseCase {
+	return &CreateMedicationUseCase{repo: repo}
+}
+
+func (uc *CreateMedicationUseCase) Execute(ctx context.Context, patientID string, in MedicationInput) (domain.Medication, *validation.Errors, error) {
+	validation := validation.New()
+
+	m := domain.Medication{ID: uuid.New(), PatientID: parsePatientID(patientID, validation)}
+	in.apply(&m, validation)
+	if !validation.Empty() {
+		return domain.Medication{}, validation, domain.ErrValidation
+	}
+
+	now := time.Now().UTC()
+	m.CreatedAt, m.UpdatedAt = now, now
+
+	out, err := uc.repo.CreateMedication(ctx, m)
+	if err != nil {
+		return domain.Medication{}, nil, err
+	}
+	return out, nil, nil
+}
+
+type UpdateMedicationUseCase struct {
+	repo domain.Repository
+}
+
+func NewUpdateMedicationUseCase(repo domain.Repository) *UpdateMedicationUseCase {
+	return &UpdateMedicationUseCase{repo: repo}
+}
+
+func (uc *UpdateMedicationUseCase) Execute(ctx context.Context, id uuid.UUID, in MedicationInput) (domain.Medication, *validation.Errors, error) {
+	m, found, err := uc.repo.GetMedication(ctx, id)
+	if err != nil {
+		return domain.Medication{}, nil, err
+	}
+	if !found {
+		return domain.Medication{}, nil, domain.ErrNotFound
+	}
+
+	validation := validation.New()
+	in.apply(&m, validation)
+	if !validation.Empty() {
+		return domain.Medication{}, validation, domain.ErrValidation
+	}
+
+	m.UpdatedAt = time.Now().UTC()
+	out, err := uc.repo.UpdateMedication(ctx, m)
+	if err != nil {
+		return domain.Medication{}, nil, err
+	}
+	return out, nil, nil
+}
+
+type ListMedicationsUseCase struct {
+	repo domain.Repository
+}
+
+func NewListMedicationsUseCase(repo domain.Repository) *ListMedicationsUseCase {
+	return &ListMedicationsUseCase{repo: repo}
+}
+
+func (uc *ListMedicationsUseCase) Execute(ctx context.Context, patientID uuid.UUID) ([]domain.Medication, error) {
+	return uc.repo.ListMedications(ctx, patientID)
+}
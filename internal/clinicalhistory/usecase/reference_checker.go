@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/clinicalhistory/domain"
+)
+
+// ReferenceChecker lo usan planes de ejercicio y evoluciones para validar
+// que el diagnóstico / lesión que referencian pertenezca al mismo paciente.
+type ReferenceChecker struct {
+	repo domain.Repository
+}
+
+func NewReferenceChecker(repo domain.Repository) *ReferenceChecker {
+	return &ReferenceChecker{repo: repo}
+}
+
+func (c *ReferenceChecker) DiagnosisBelongsTo(ctx context.Context, diagnosisID, patientID uuid.UUID) (bool, error) {
+	d, found, err := c.repo.GetDiagnosis(ctx, diagnosisID)
+	if err != nil || !found {
+		return false, err
+	}
+	return d.PatientID == patientID, nil
+}
+
+func (c *ReferenceChecker) InjuryBelongsTo(ctx context.Context, injuryID, patientID uuid.UUID) (bool, error) {
+	i, found, err := c.repo.GetInjury(ctx, injuryID)
+	if err != nil || !found {
+		return false, err
+	}
+	return i.PatientID == patientID, nil
+}
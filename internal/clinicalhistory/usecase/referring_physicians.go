@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/clinicalhistory/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+type ReferringPhysicianInput struct {
+	FullName      string  `json:"full_name"`
+	LicenseNumber *string `json:"license_number"`
+	Specialty     *string `json:"specialty"`
+	Phone         *string `json:"phone"`
+	Email         *string `json:"email"`
+	Notes         *string `json:"notes"`
+}
+
+func (in ReferringPhysicianInput) apply(p *domain.ReferringPhysician, validation *validation.Errors) {
+	p.FullName = strings.TrimSpace(in.FullName)
+	if p.FullName == "" {
+		validation.Add("full_name", "required")
+	}
+
+	p.Email = trimPtr(in.Email)
+	if p.Email != nil {
+		lower := strings.ToLower(*p.Email)
+		if !strings.Contains(lower, "@") {
+			validation.Add("email", "invalid_format")
+		}
+		p.Email = &lower
+	}
+
+	p.LicenseNumber = trimPtr(in.LicenseNumber)
+	p.Specialty = trimPtr(in.Specialty)
+	p.Phone = trimPtr(in.Phone)
+	p.Notes = trimPtr(in.Notes)
+}
+
+type CreateReferringPhysicianUseCase struct {
+	repo domain.Repository
+}
+
+func NewCreateReferringPhysicianUseCase(repo domain.Repository) *CreateReferringPhysicianUseCase {
+	return &CreateReferringPhysicianUseCase{repo: repo}
+}
+
+func (uc *CreateReferringPhysicianUseCase) Execute(ctx context.Context, patientID string, in ReferringPhysicianInput) (domain.ReferringPhysician, *validation.Errors, error) {
+	validation := validation.New()
+
+	p := domain.ReferringPhysician{ID: uuid.New(), PatientID: parsePatientID(patientID, validation)}
+	in.apply(&p, validation)
+	if !validation.Empty() {
+		return domain.ReferringPhysician{}, validation, domain.ErrValidation
+	}
+
+	now := time.Now().UTC()
+	p.CreatedAt, p.UpdatedAt = now, now
+
+	out, err := uc.repo.CreateReferringPhysician(ctx, p)
+	if err != nil {
+		return domain.ReferringPhysician{}, nil, err
+	}
+	return out, nil, nil
+}
+
+type UpdateReferringPhysicianUseCase struct {
+	repo domain.Repository
+}
+
+func NewUpdateReferringPhysicianUseCase(repo domain.Repository) *UpdateReferringPhysicianUseCase {
+	return &UpdateReferringPhysicianUseCase{repo: repo}
+}
+
+func (uc *UpdateReferringPhysicianUseCase) Execute(ctx context.Context, id uuid.UUID, in ReferringPhysicianInput) (domain.ReferringPhysician, *validation.Errors, error) {
+	p, found, err := uc.repo.GetReferringPhysician(ctx, id)
+	if err != nil {
+		return domain.ReferringPhysician{}, nil, err
+	}
+	if !found {
+		return domain.ReferringPhysician{}, nil, domain.ErrNotFound
+	}
+
+	validation := validation.New()
+	in.apply(&p, validation)
+	if !validation.Empty() {
+		return domain.ReferringPhysician{}, validation, domain.ErrValidation
+	}
+
+	p.UpdatedAt = time.Now().UTC()
+	out, err := uc.repo.UpdateReferringPhysician(ctx, p)
+	if err != nil {
+		return domain.ReferringPhysician{}, nil, err
+	}
+	return out, nil, nil
+}
+
+type ListReferringPhysiciansUseCase struct {
+	repo domain.Repository
+}
+
+func NewListReferringPhysiciansUseCase(repo domain.Repository) *ListReferringPhysiciansUseCase {
+	return &ListReferringPhysiciansUseCase{repo: repo}
+}
+
+func (uc *ListReferringPhysiciansUseCase) Execute(ctx context.Context, patientID uuid.UUID) ([]domain.ReferringPhysician, error) {
+	return uc.repo.ListReferringPhysicians(ctx, patientID)
+}
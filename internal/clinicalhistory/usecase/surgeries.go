@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/clinicalhistory/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+type SurgeryInput struct {
+	Procedure   string  `json:"procedure"`
+	Laterality  *string `json:"laterality"`
+	PerformedAt *string `json:"performed_at"` // YYYY-MM-DD
+	Surgeon     *string `json:"surgeon"`
+	Notes       *string `json:"notes"`
+}
+
+func (in SurgeryInput) apply(s *domain.Surgery, validation *validation.Errors) {
+	s.Procedure = strings.TrimSpace(in.Procedure)
+	if s.Procedure == "" {
+		validation.Add("procedure", "required")
+	}
+
+	s.Laterality = nil
+	if v := trimPtr(in.Laterality); v != nil {
+		l := domain.Laterality(*v)
+		if !l.Valid() {
+			validation.Add("laterality", "must_be_left_right_bilateral_or_not_applicable")
+		}
+		s.Laterality = &l
+	}
+
+	s.PerformedAt = parseDate("performed_at", in.PerformedAt, validation)
+	s.Surgeon = trimPtr(in.Surgeon)
+	s.Notes = trimPtr(in.Notes)
+}
+
+type CreateSurgeryUseCase struct {
+	repo domain.Repository
+}
+
+func NewCreateSurgeryUseCase(repo domain.Repository) *CreateSurgeryUseCase {
+	return &CreateSurgeryUseCase{repo: repo}
+}
+
+func (uc *CreateSurgeryUseCase) Execute(ctx context.Context, patientID string, in SurgeryInput) (domain.Surgery, *validation.Errors, error) {
+	validation := validation.New()
+
+	s := domain.Surgery{ID: uuid.New(), PatientID: parsePatientID(patientID, validation)}
+	in.apply(&s, validation)
+	if !validation.Empty() {
+		return domain.Surgery{}, validation, domain.ErrValidation
+	}
+
+	now := time.Now().UTC()
+	s.CreatedAt, s.UpdatedAt = now, now
+
+	out, err := uc.repo.CreateSurgery(ctx, s)
+	if err != nil {
+		return domain.Surgery{}, nil, err
+	}
+	return out, nil, nil
+}
+
+type UpdateSurgeryUseCase struct {
+	repo domain.Repository
+}
+
+func NewUpdateSurgeryUseCase(repo domain.Repository) *UpdateSurgeryUseCase {
+	return &UpdateSurgeryUseCase{repo: repo}
+}
+
+func (uc *UpdateSurgeryUseCase) Execute(ctx context.Context, id uuid.UUID, in SurgeryInput) (domain.Surgery, *validation.Errors, error) {
+	s, found, err := uc.repo.GetSurgery(ctx, id)
+	if err != nil {
+		return domain.Surgery{}, nil, err
+	}
+	if !found {
+		return domain.Surgery{}, nil, domain.ErrNotFound
+	}
+
+	validation := validation.New()
+	in.apply(&s, validation)
+	if !validation.Empty() {
+		return domain.Surgery{}, validation, domain.ErrValidation
+	}
+
+	s.UpdatedAt = time.Now().UTC()
+	out, err := uc.repo.UpdateSurgery(ctx, s)
+	if err != nil {
+		return domain.Surgery{}, nil, err
+	}
+	return out, nil, nil
+}
+
+type ListSurgeriesUseCase struct {
+	repo domain.Repository
+}
+
+func NewListSurgeriesUseCase(repo domain.Repository) *ListSurgeriesUseCase {
+	return &ListSurgeriesUseCase{repo: repo}
+}
+
+func (uc *ListSurgeriesUseCase) Execute(ctx context.Context, patientID uuid.UUID) ([]domain.Surgery, error) {
+	return uc.repo.ListSurgeries(ctx, patientID)
+}
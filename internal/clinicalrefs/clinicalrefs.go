@@ -0,0 +1,40 @@
+// Package clinicalrefs valida las referencias a la historia clínica (diagnóstico
+// y lesión) que comparten evoluciones y planes de ejercicio.
+package clinicalrefs
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+// Checker: lo que implementa el gateway a la historia clínica de cada módulo.
+type Checker interface {
+	DiagnosisBelongsTo(ctx context.Context, diagnosisID, patientID uuid.UUID) (bool, error)
+	InjuryBelongsTo(ctx context.Context, injuryID, patientID uuid.UUID) (bool, error)
+}
+
+// Check valida que diagnóstico / lesión existan y sean del mismo paciente.
+func Check(ctx context.Context, clinical Checker, patientID uuid.UUID, diagnosisID, injuryID *uuid.UUID, validation *validation.Errors) error {
+	if diagnosisID != nil {
+		ok, err := clinical.DiagnosisBelongsTo(ctx, *diagnosisID, patientID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			validation.Add("diagnosis_id", "not_found")
+		}
+	}
+	if injuryID != nil {
+		ok, err := clinical.InjuryBelongsTo(ctx, *injuryID, patientID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			validation.Add("injury_id", "not_found")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+// Package clinictime resuelve días calendario en la zona horaria del consultorio.
+// Los timestamps se guardan en UTC y las fechas sin hora (vigencias, registros, sesiones)
+// como 00:00 UTC: pasar de uno a otro sin la zona corre el día a la noche.
+package clinictime
+
+import "time"
+
+// Day devuelve el día del consultorio en que cae t, como fecha a las 00:00 UTC.
+// Sin zona (nil) se usa UTC, nunca la del servidor.
+func Day(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Today es el día de hoy en el consultorio.
+func Today(loc *time.Location) time.Time {
+	return Day(time.Now(), loc)
+}
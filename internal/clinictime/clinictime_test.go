@@ -0,0 +1,35 @@
+package clinictime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDay(t *testing.T) {
+	ba, err := time.LoadLocation("America/Argentina/Buenos_Aires")
+	if err != nil {
+		t.Fatal(err)
+	}
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name string
+		at   time.Time
+		loc  *time.Location
+		want time.Time
+	}{
+		{name: "mediodía", at: time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC), loc: ba, want: date(2026, 3, 2)},
+		{name: "noche en el consultorio ya es el día siguiente en UTC", at: time.Date(2026, 3, 3, 1, 30, 0, 0, time.UTC), loc: ba, want: date(2026, 3, 2)},
+		{name: "medianoche local", at: time.Date(2026, 3, 3, 3, 0, 0, 0, time.UTC), loc: ba, want: date(2026, 3, 3)},
+		{name: "sin zona usa UTC", at: time.Date(2026, 3, 3, 1, 30, 0, 0, time.UTC), loc: nil, want: date(2026, 3, 3)},
+		{name: "fecha ya truncada en UTC", at: date(2026, 3, 2), loc: nil, want: date(2026, 3, 2)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Day(tt.at, tt.loc)
+			if !got.Equal(tt.want) || got.Location() != time.UTC {
+				t.Fatalf("Day = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
+	_ "time/tzdata" // la imagen puede no traer la base de zonas horarias
 )
 
 type Config struct {
@@ -20,21 +22,60 @@ type Config struct {
 	DBSSLMode  string
 
 	FirebaseProjectID string
+
+	AttachmentsDir      string
+	AttachmentsMaxBytes int64
+
+	// Encabezado de los documentos impresos (planes de ejercicio).
+	ClinicName    string
+	ClinicAddress string
+	ClinicPhone   string
+	// Zona horaria del consultorio: define a qué día corresponde un turno.
+	ClinicLocation *time.Location
+
+	// URL a la que se publican los avisos de préstamos vencidos; vacía = solo se loguean.
+	LoanNotifyWebhookURL string
+
+	// Cada cuánto corren los jobs periódicos (cierre de planes vencidos, etc.).
+	JobsInterval time.Duration
 }
 
 func MustLoad() Config {
 	cfg := Config{
-		AppName:           getenv("APP_NAME", "kinesio-app"),
-		Env:               getenv("ENV", "local"),
-		HTTPPort:          getenv("HTTP_PORT", "8080"),
-		DBHost:            getenv("DB_HOST", "localhost"),
-		DBPort:            getenv("DB_PORT", "5432"),
-		DBName:            getenv("DB_NAME", "kinesio"),
-		DBUser:            getenv("DB_USER", "kinesio"),
-		DBPassword:        getenv("DB_PASSWORD", "kinesio"),
-		DBSSLMode:         getenv("DB_SSLMODE", "disable"),
-		FirebaseProjectID: getenv("FIREBASE_PROJECT_ID", ""),
+		AppName:              getenv("APP_NAME", "kinesio-app"),
+		Env:                  getenv("ENV", "local"),
+		HTTPPort:             getenv("HTTP_PORT", "8080"),
+		DBHost:               getenv("DB_HOST", "localhost"),
+		DBPort:               getenv("DB_PORT", "5432"),
+		DBName:               getenv("DB_NAME", "kinesio"),
+		DBUser:               getenv("DB_USER", "kinesio"),
+		DBPassword:           getenv("DB_PASSWORD", "kinesio"),
+		DBSSLMode:            getenv("DB_SSLMODE", "disable"),
+		FirebaseProjectID:    getenv("FIREBASE_PROJECT_ID", ""),
+		AttachmentsDir:       getenv("ATTACHMENTS_DIR", "data/attachments"),
+		ClinicName:           getenv("CLINIC_NAME", "Kinesio App"),
+		ClinicAddress:        getenv("CLINIC_ADDRESS", ""),
+		ClinicPhone:          getenv("CLINIC_PHONE", ""),
+		LoanNotifyWebhookURL: getenv("LOAN_NOTIFY_WEBHOOK_URL", ""),
+	}
+
+	maxMB, err := strconv.Atoi(getenv("ATTACHMENTS_MAX_MB", "20"))
+	if err != nil || maxMB <= 0 {
+		panic("ATTACHMENTS_MAX_MB must be a positive integer")
+	}
+	cfg.AttachmentsMaxBytes = int64(maxMB) << 20
+
+	loc, err := time.LoadLocation(getenv("CLINIC_TIMEZONE", "America/Argentina/Buenos_Aires"))
+	if err != nil {
+		panic("CLINIC_TIMEZONE must be a valid IANA time zone")
+	}
+	cfg.ClinicLocation = loc
+
+	jobsMin, err := strconv.Atoi(getenv("JOBS_INTERVAL_MINUTES", "60"))
+	if err != nil || jobsMin <= 0 {
+		panic("JOBS_INTERVAL_MINUTES must be a positive integer")
 	}
+	cfg.JobsInterval = time.Duration(jobsMin) * time.Minute
 
 	// Validaciones mínimas
 	if cfg.HTTPPort == "" {
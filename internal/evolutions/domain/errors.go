@@ -5,4 +5,12 @@ import "errors"
 var (
 	ErrValidation = errors.New("validation_error")
 	ErrNotFound   = errors.New("not_found")
+
+	ErrSigned    = errors.New("evolution_signed")
+	ErrNotSigned = errors.New("evolution_not_signed")
+	ErrNotLatest = errors.New("not_latest_version")
+	// ErrPendingVersion: ya hay una corrección en borrador de esa evolución.
+	ErrPendingVersion = errors.New("pending_version_exists")
+
+	ErrPrimaryExists = errors.New("primary_evolution_exists")
 )
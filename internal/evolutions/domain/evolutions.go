@@ -1,20 +1,100 @@
 package domain
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type Status string
+
+const (
+	StatusDraft  Status = "draft"
+	StatusSigned Status = "signed"
+)
+
+// PatientEvolution: los borradores se pueden editar; una vez firmada la evolución es inmutable
+// y las correcciones se hacen con una nueva versión (misma RootID) o con addendas.
 type PatientEvolution struct {
 	ID              uuid.UUID
 	PatientID       uuid.UUID
 	KinesiologistID uuid.UUID
 	AppointmentID   *uuid.UUID
+	IsPrimary       bool // evolución principal del turno (a lo sumo una por turno)
+
+	// Vínculo opcional con la historia clínica estructurada
+	DiagnosisID *uuid.UUID
+	InjuryID    *uuid.UUID
 
 	PainLevel *int   // 0..10
-	Notes     string // requerido
+	Notes     string // texto libre; si se carga SOAP y no viene, se arma a partir de las secciones
+
+	// Nota SOAP (opcional, las evoluciones viejas solo tienen Notes)
+	SOAP *SOAPNote
+
+	// Plantilla por tipo de tratamiento y valores cargados (key => valor)
+	TemplateID     *uuid.UUID
+	TemplateValues map[string]any
+
+	Status   Status
+	SignedAt *time.Time
+
+	// Versionado: RootID es el ID de la versión 1; cada corrección apunta a la anterior.
+	RootID            uuid.UUID
+	Version           int
+	PreviousVersionID *uuid.UUID
+	CorrectionReason  *string
+	IsLatest          bool
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+func (e *PatientEvolution) Sign(at time.Time) {
+	e.Status = StatusSigned
+	e.SignedAt = &at
+}
+
+// Addendum es una nota agregada a una evolución firmada (no modifica el original).
+type Addendum struct {
+	ID              uuid.UUID
+	EvolutionID     uuid.UUID
+	RootID          uuid.UUID
+	KinesiologistID uuid.UUID
+	Text            string
+	CreatedAt       time.Time
+}
+
+// EvolutionHistory es la evolución pedida junto con toda su cadena de versiones y addendas.
+type EvolutionHistory struct {
+	Evolution PatientEvolution
+	Versions  []PatientEvolution // ordenadas por versión
+	Addenda   []Addendum
+}
+
+type SOAPNote struct {
+	Subjective *string
+	Objective  *string
+	Assessment *string
+	Plan       *string
+}
+
+func (n SOAPNote) IsEmpty() bool {
+	return n.Subjective == nil && n.Objective == nil && n.Assessment == nil && n.Plan == nil
+}
+
+// Summary arma un texto libre con las secciones cargadas (compatibilidad con clientes que solo leen notes).
+func (n SOAPNote) Summary() string {
+	var parts []string
+	add := func(label string, v *string) {
+		if v != nil {
+			parts = append(parts, label+": "+*v)
+		}
+	}
+	add("S", n.Subjective)
+	add("O", n.Objective)
+	add("A", n.Assessment)
+	add("P", n.Plan)
+	return strings.Join(parts, "\n")
+}
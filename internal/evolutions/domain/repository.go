@@ -2,12 +2,62 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Repository interface {
+	// Create devuelve ErrPrimaryExists si el turno ya tiene una evolución principal.
 	Create(ctx context.Context, e PatientEvolution) (PatientEvolution, error)
 	GetByID(ctx context.Context, id uuid.UUID) (PatientEvolution, bool, error)
+	// ListByPatient devuelve solo la última versión de cada evolución.
 	ListByPatient(ctx context.Context, patientID uuid.UUID, limit int) ([]PatientEvolution, error)
+
+	// UpdateDraft reescribe el contenido de un borrador. Devuelve ErrSigned si ya estaba firmado.
+	UpdateDraft(ctx context.Context, e PatientEvolution) (PatientEvolution, error)
+	// Sign firma un borrador. Devuelve ErrSigned si ya estaba firmado. Si es una corrección,
+	// pasa a ser la versión vigente en la misma transacción (ErrNotLatest si la anterior ya fue reemplazada).
+	Sign(ctx context.Context, id uuid.UUID, at time.Time) (PatientEvolution, error)
+	// CreateVersion inserta una nueva versión. Si viene firmada (IsLatest) marca la anterior como
+	// no vigente, atómicamente; si es un borrador la anterior sigue vigente hasta la firma.
+	// Devuelve ErrNotLatest si la anterior ya fue reemplazada y ErrPendingVersion si ya hay otra corrección en curso.
+	CreateVersion(ctx context.Context, e PatientEvolution) (PatientEvolution, error)
+	ListVersions(ctx context.Context, rootID uuid.UUID) ([]PatientEvolution, error)
+	// HasPrimaryForAppointment indica si el turno ya tiene una evolución principal (última versión).
+	HasPrimaryForAppointment(ctx context.Context, appointmentID uuid.UUID) (bool, error)
+
+	CreateAddendum(ctx context.Context, a Addendum) (Addendum, error)
+	ListAddenda(ctx context.Context, rootID uuid.UUID) ([]Addendum, error)
+
+	CreateTemplate(ctx context.Context, t EvolutionTemplate) (EvolutionTemplate, error)
+	GetTemplate(ctx context.Context, id uuid.UUID) (EvolutionTemplate, bool, error)
+	ListTemplates(ctx context.Context, treatmentType string, includeInactive bool) ([]EvolutionTemplate, error)
+	ExistsTemplateCode(ctx context.Context, code string) (bool, error)
+}
+
+// ClinicalRecord valida referencias a la historia clínica del paciente.
+type ClinicalRecord interface {
+	DiagnosisBelongsTo(ctx context.Context, diagnosisID, patientID uuid.UUID) (bool, error)
+	InjuryBelongsTo(ctx context.Context, injuryID, patientID uuid.UUID) (bool, error)
+}
+
+// AppointmentInfo: lo que una evolución necesita saber del turno al que se asocia.
+type AppointmentInfo struct {
+	PatientID       uuid.UUID
+	KinesiologistID uuid.UUID
+	Cancelled       bool
+	Attended        bool
+}
+
+// References valida paciente, kinesiólogo y turno contra sus propios módulos.
+type References interface {
+	PatientExists(ctx context.Context, patientID uuid.UUID) (bool, error)
+	// Kinesiologist devuelve si existe y si está activo.
+	Kinesiologist(ctx context.Context, kinesiologistID uuid.UUID) (active bool, found bool, err error)
+	Appointment(ctx context.Context, appointmentID uuid.UUID) (AppointmentInfo, bool, error)
+	// MarkAppointmentAttended pasa el turno a atendido (consume la sesión de la orden médica).
+	MarkAppointmentAttended(ctx context.Context, appointmentID uuid.UUID) error
+	// UnmarkAppointmentAttended vuelve el turno a programado y devuelve la sesión.
+	UnmarkAppointmentAttended(ctx context.Context, appointmentID uuid.UUID) error
 }
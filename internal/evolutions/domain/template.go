@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+type FieldType string
+
+const (
+	FieldNumber  FieldType = "number"
+	FieldInteger FieldType = "integer"
+	FieldText    FieldType = "text"
+	FieldBoolean FieldType = "boolean"
+	FieldSelect  FieldType = "select"
+)
+
+type SOAPSection string
+
+const (
+	SectionSubjective SOAPSection = "subjective"
+	SectionObjective  SOAPSection = "objective"
+	SectionAssessment SOAPSection = "assessment"
+	SectionPlan       SOAPSection = "plan"
+)
+
+// TemplateField es un campo estructurado de una plantilla (ej: "flexion_rodilla", number, 0..150).
+type TemplateField struct {
+	Key      string      `json:"key"`
+	Label    string      `json:"label"`
+	Section  SOAPSection `json:"section"`
+	Type     FieldType   `json:"type"`
+	Required bool        `json:"required"`
+	Min      *float64    `json:"min,omitempty"`
+	Max      *float64    `json:"max,omitempty"`
+	Options  []string    `json:"options,omitempty"`
+	Unit     *string     `json:"unit,omitempty"`
+}
+
+// EvolutionTemplate define los campos a relevar según el tipo de tratamiento
+// (post quirúrgico de LCA, lumbalgia, etc.).
+type EvolutionTemplate struct {
+	ID            uuid.UUID
+	Code          string
+	Name          string
+	TreatmentType string
+	Description   *string
+	Fields        []TemplateField
+	Active        bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+var templateKeyRe = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// ValidateDefinition revisa la definición de la plantilla (no los valores cargados).
+func (t EvolutionTemplate) ValidateDefinition(validation *validation.Errors) {
+	if !templateKeyRe.MatchString(t.Code) {
+		validation.Add("code", "invalid_code_(snake_case)")
+	}
+	if strings.TrimSpace(t.Name) == "" {
+		validation.Add("name", "required")
+	}
+	if strings.TrimSpace(t.TreatmentType) == "" {
+		validation.Add("treatment_type", "required")
+	}
+	if len(t.Fields) == 0 {
+		validation.Add("fields", "required")
+	}
+
+	seen := map[string]bool{}
+	for i, f := range t.Fields {
+		prefix := fmt.Sprintf("fields[%d]", i)
+		if !templateKeyRe.MatchString(f.Key) {
+			validation.Add(prefix+".key", "invalid_key_(snake_case)")
+		} else if seen[f.Key] {
+			validation.Add(prefix+".key", "duplicated")
+		}
+		seen[f.Key] = true
+
+		if strings.TrimSpace(f.Label) == "" {
+			validation.Add(prefix+".label", "required")
+		}
+		switch f.Section {
+		case SectionSubjective, SectionObjective, SectionAssessment, SectionPlan:
+		default:
+			validation.Add(prefix+".section", "must_be_subjective_objective_assessment_or_plan")
+		}
+		switch f.Type {
+		case FieldNumber, FieldInteger:
+			if f.Min != nil && f.Max != nil && *f.Min > *f.Max {
+				validation.Add(prefix+".max", "must_be_>=_min")
+			}
+		case FieldText, FieldBoolean:
+		case FieldSelect:
+			if len(f.Options) == 0 {
+				validation.Add(prefix+".options", "required")
+			}
+		default:
+			validation.Add(prefix+".type", "invalid_type")
+		}
+	}
+}
+
+// ValidateValues valida los valores cargados en una evolución contra la plantilla.
+// Los errores quedan como template_values.<key>.
+func (t EvolutionTemplate) ValidateValues(values map[string]any, validation *validation.Errors) {
+	known := map[string]bool{}
+	for _, f := range t.Fields {
+		known[f.Key] = true
+		field := "template_values." + f.Key
+
+		v, ok := values[f.Key]
+		if !ok || v == nil || isBlank(v) {
+			if f.Required {
+				validation.Add(field, "required")
+			}
+			continue
+		}
+
+		switch f.Type {
+		case FieldNumber, FieldInteger:
+			n, ok := v.(float64)
+			if !ok {
+				validation.Add(field, "must_be_number")
+				continue
+			}
+			if f.Type == FieldInteger && n != math.Trunc(n) {
+				validation.Add(field, "must_be_integer")
+				continue
+			}
+			if f.Min != nil && n < *f.Min {
+				validation.Add(field, fmt.Sprintf("must_be_>=_%g", *f.Min))
+			}
+			if f.Max != nil && n > *f.Max {
+				validation.Add(field, fmt.Sprintf("must_be_<=_%g", *f.Max))
+			}
+		case FieldText:
+			if _, ok := v.(string); !ok {
+				validation.Add(field, "must_be_text")
+			}
+		case FieldBoolean:
+			if _, ok := v.(bool); !ok {
+				validation.Add(field, "must_be_boolean")
+			}
+		case FieldSelect:
+			s, ok := v.(string)
+			if !ok || !contains(f.Options, s) {
+				validation.Add(field, "must_be_one_of_"+strings.Join(f.Options, "|"))
+			}
+		}
+	}
+
+	for k := range values {
+		if !known[k] {
+			validation.Add("template_values."+k, "unknown_field")
+		}
+	}
+}
+
+func isBlank(v any) bool {
+	s, ok := v.(string)
+	return ok && strings.TrimSpace(s) == ""
+}
+
+func contains(xs []string, s string) bool {
+	for _, x := range xs {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
@@ -1,7 +1,6 @@
 package http
 
 import (
-	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -18,17 +17,41 @@ type Handler struct {
 	createUC *usecase.CreateEvolutionUseCase
 	listUC   *usecase.ListEvolutionsByPatientUseCase
 	getUC    *usecase.GetEvolutionByIDUseCase
+
+	updateUC   *usecase.UpdateEvolutionUseCase
+	signUC     *usecase.SignEvolutionUseCase
+	amendUC    *usecase.AmendEvolutionUseCase
+	addendumUC *usecase.AddAddendumUseCase
+
+	createTemplateUC *usecase.CreateTemplateUseCase
+	listTemplatesUC  *usecase.ListTemplatesUseCase
+	getTemplateUC    *usecase.GetTemplateUseCase
 }
 
-func NewHandler(createUC *usecase.CreateEvolutionUseCase, listUC *usecase.ListEvolutionsByPatientUseCase, getUC *usecase.GetEvolutionByIDUseCase) *Handler {
-	return &Handler{createUC: createUC, listUC: listUC, getUC: getUC}
+func NewHandler(createUC *usecase.CreateEvolutionUseCase, listUC *usecase.ListEvolutionsByPatientUseCase, getUC *usecase.GetEvolutionByIDUseCase,
+	updateUC *usecase.UpdateEvolutionUseCase, signUC *usecase.SignEvolutionUseCase, amendUC *usecase.AmendEvolutionUseCase, addendumUC *usecase.AddAddendumUseCase,
+	createTemplateUC *usecase.CreateTemplateUseCase, listTemplatesUC *usecase.ListTemplatesUseCase, getTemplateUC *usecase.GetTemplateUseCase) *Handler {
+	return &Handler{createUC: createUC, listUC: listUC, getUC: getUC,
+		updateUC: updateUC, signUC: signUC, amendUC: amendUC, addendumUC: addendumUC,
+		createTemplateUC: createTemplateUC, listTemplatesUC: listTemplatesUC, getTemplateUC: getTemplateUC}
 }
 
 type createEvolutionRequest struct {
 	KinesiologistID string  `json:"kinesiologist_id"`
 	AppointmentID   *string `json:"appointment_id,omitempty"`
-	PainLevel       *int    `json:"pain_level,omitempty"`
-	Notes           string  `json:"notes"`
+	IsPrimary       *bool   `json:"is_primary,omitempty"`
+	MarkAttended    bool    `json:"mark_attended,omitempty"`
+
+	usecase.EvolutionContentInput
+
+	Sign bool `json:"sign,omitempty"`
+}
+
+type soapResponse struct {
+	Subjective *string `json:"subjective,omitempty"`
+	Objective  *string `json:"objective,omitempty"`
+	Assessment *string `json:"assessment,omitempty"`
+	Plan       *string `json:"plan,omitempty"`
 }
 
 type evolutionResponse struct {
@@ -36,10 +59,41 @@ type evolutionResponse struct {
 	PatientID       string  `json:"patient_id"`
 	KinesiologistID string  `json:"kinesiologist_id"`
 	AppointmentID   *string `json:"appointment_id,omitempty"`
+	IsPrimary       bool    `json:"is_primary"`
+	DiagnosisID     *string `json:"diagnosis_id,omitempty"`
+	InjuryID        *string `json:"injury_id,omitempty"`
 	PainLevel       *int    `json:"pain_level,omitempty"`
 	Notes           string  `json:"notes"`
-	CreatedAt       string  `json:"created_at"`
-	UpdatedAt       string  `json:"updated_at"`
+	// soap / template_* solo vienen en evoluciones estructuradas; las de texto libre siguen igual.
+	SOAP           *soapResponse  `json:"soap,omitempty"`
+	TemplateID     *string        `json:"template_id,omitempty"`
+	TemplateValues map[string]any `json:"template_values,omitempty"`
+
+	Status            string  `json:"status"`
+	SignedAt          *string `json:"signed_at,omitempty"`
+	RootID            string  `json:"root_id"`
+	Version           int     `json:"version"`
+	PreviousVersionID *string `json:"previous_version_id,omitempty"`
+	CorrectionReason  *string `json:"correction_reason,omitempty"`
+	IsLatest          bool    `json:"is_latest"`
+
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type addendumResponse struct {
+	ID              string `json:"id"`
+	EvolutionID     string `json:"evolution_id"`
+	KinesiologistID string `json:"kinesiologist_id"`
+	Text            string `json:"text"`
+	CreatedAt       string `json:"created_at"`
+}
+
+// evolutionDetailResponse: la evolución pedida + su historial completo.
+type evolutionDetailResponse struct {
+	evolutionResponse
+	Versions []evolutionResponse `json:"versions"`
+	Addenda  []addendumResponse  `json:"addenda"`
 }
 
 func (h *Handler) CreateForPatient(c *gin.Context) {
@@ -55,16 +109,15 @@ func (h *Handler) CreateForPatient(c *gin.Context) {
 		PatientID:       patientID,
 		KinesiologistID: req.KinesiologistID,
 		AppointmentID:   req.AppointmentID,
-		PainLevel:       req.PainLevel,
-		Notes:           req.Notes,
+		IsPrimary:       req.IsPrimary,
+		MarkAttended:    req.MarkAttended,
+
+		EvolutionContentInput: req.EvolutionContentInput,
+		Sign:                  req.Sign,
 	})
 
 	if err != nil {
-		if errors.Is(err, domain.ErrValidation) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		writeError(c, err, validation)
 		return
 	}
 
@@ -105,7 +158,7 @@ func (h *Handler) GetByID(c *gin.Context) {
 		return
 	}
 
-	e, found, err := h.getUC.Execute(c.Request.Context(), id)
+	hist, found, err := h.getUC.Execute(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
 		return
@@ -115,24 +168,78 @@ func (h *Handler) GetByID(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, toResponse(e))
+	out := evolutionDetailResponse{
+		evolutionResponse: toResponse(hist.Evolution),
+		Versions:          make([]evolutionResponse, 0, len(hist.Versions)),
+		Addenda:           make([]addendumResponse, 0, len(hist.Addenda)),
+	}
+	for _, v := range hist.Versions {
+		out.Versions = append(out.Versions, toResponse(v))
+	}
+	for _, a := range hist.Addenda {
+		out.Addenda = append(out.Addenda, toAddendumResponse(a))
+	}
+	c.JSON(http.StatusOK, out)
 }
 
 func toResponse(e domain.PatientEvolution) evolutionResponse {
-	var appt *string
-	if e.AppointmentID != nil {
-		s := e.AppointmentID.String()
-		appt = &s
+	var soap *soapResponse
+	if e.SOAP != nil {
+		soap = &soapResponse{
+			Subjective: e.SOAP.Subjective,
+			Objective:  e.SOAP.Objective,
+			Assessment: e.SOAP.Assessment,
+			Plan:       e.SOAP.Plan,
+		}
+	}
+
+	var signedAt *string
+	if e.SignedAt != nil {
+		s := e.SignedAt.UTC().Format(time.RFC3339)
+		signedAt = &s
 	}
 
 	return evolutionResponse{
 		ID:              e.ID.String(),
 		PatientID:       e.PatientID.String(),
 		KinesiologistID: e.KinesiologistID.String(),
-		AppointmentID:   appt,
+		AppointmentID:   uuidPtrToString(e.AppointmentID),
+		IsPrimary:       e.IsPrimary,
+		DiagnosisID:     uuidPtrToString(e.DiagnosisID),
+		InjuryID:        uuidPtrToString(e.InjuryID),
 		PainLevel:       e.PainLevel,
 		Notes:           e.Notes,
-		CreatedAt:       e.CreatedAt.UTC().Format(time.RFC3339),
-		UpdatedAt:       e.UpdatedAt.UTC().Format(time.RFC3339),
+		SOAP:            soap,
+		TemplateID:      uuidPtrToString(e.TemplateID),
+		TemplateValues:  e.TemplateValues,
+
+		Status:            string(e.Status),
+		SignedAt:          signedAt,
+		RootID:            e.RootID.String(),
+		Version:           e.Version,
+		PreviousVersionID: uuidPtrToString(e.PreviousVersionID),
+		CorrectionReason:  e.CorrectionReason,
+		IsLatest:          e.IsLatest,
+
+		CreatedAt: e.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt: e.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func toAddendumResponse(a domain.Addendum) addendumResponse {
+	return addendumResponse{
+		ID:              a.ID.String(),
+		EvolutionID:     a.EvolutionID.String(),
+		KinesiologistID: a.KinesiologistID.String(),
+		Text:            a.Text,
+		CreatedAt:       a.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func uuidPtrToString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
 	}
+	s := id.String()
+	return &s
 }
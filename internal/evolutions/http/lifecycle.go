@@ -0,0 +1,131 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/evolutions/domain"
+	"github.com/javiacuna/kinesio-backend/internal/evolutions/usecase"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+type signEvolutionRequest struct {
+	KinesiologistID string `json:"kinesiologist_id"`
+}
+
+// Update: PATCH /evolutions/:evolution_id (solo borradores).
+func (h *Handler) Update(c *gin.Context) {
+	id, ok := parseEvolutionID(c)
+	if !ok {
+		return
+	}
+
+	var req usecase.EvolutionContentInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	out, validation, err := h.updateUC.Execute(c.Request.Context(), id, req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusOK, toResponse(out))
+}
+
+// Sign: POST /evolutions/:evolution_id/sign
+func (h *Handler) Sign(c *gin.Context) {
+	id, ok := parseEvolutionID(c)
+	if !ok {
+		return
+	}
+
+	var req signEvolutionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	out, validation, err := h.signUC.Execute(c.Request.Context(), id, req.KinesiologistID)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusOK, toResponse(out))
+}
+
+// CreateVersion: POST /evolutions/:evolution_id/versions (corrige una evolución firmada).
+func (h *Handler) CreateVersion(c *gin.Context) {
+	id, ok := parseEvolutionID(c)
+	if !ok {
+		return
+	}
+
+	var req usecase.AmendEvolutionInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	out, validation, err := h.amendUC.Execute(c.Request.Context(), id, req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusCreated, toResponse(out))
+}
+
+// AddAddendum: POST /evolutions/:evolution_id/addenda
+func (h *Handler) AddAddendum(c *gin.Context) {
+	id, ok := parseEvolutionID(c)
+	if !ok {
+		return
+	}
+
+	var req usecase.AddAddendumInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	out, validation, err := h.addendumUC.Execute(c.Request.Context(), id, req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusCreated, toAddendumResponse(out))
+}
+
+func parseEvolutionID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("evolution_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_evolution_id"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func writeError(c *gin.Context, err error, validation *validation.Errors) {
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+	case errors.Is(err, domain.ErrSigned):
+		c.JSON(http.StatusConflict, gin.H{"error": "evolution_signed"})
+	case errors.Is(err, domain.ErrNotSigned):
+		c.JSON(http.StatusConflict, gin.H{"error": "evolution_not_signed"})
+	case errors.Is(err, domain.ErrNotLatest):
+		c.JSON(http.StatusConflict, gin.H{"error": "not_latest_version"})
+	case errors.Is(err, domain.ErrPendingVersion):
+		c.JSON(http.StatusConflict, gin.H{"error": "pending_version_exists"})
+	case errors.Is(err, domain.ErrPrimaryExists):
+		c.JSON(http.StatusConflict, gin.H{"error": "primary_evolution_exists"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+	}
+}
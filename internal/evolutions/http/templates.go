@@ -0,0 +1,94 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/evolutions/domain"
+	"github.com/javiacuna/kinesio-backend/internal/evolutions/usecase"
+)
+
+type templateResponse struct {
+	ID            string                 `json:"id"`
+	Code          string                 `json:"code"`
+	Name          string                 `json:"name"`
+	TreatmentType string                 `json:"treatment_type"`
+	Description   *string                `json:"description,omitempty"`
+	Fields        []domain.TemplateField `json:"fields"`
+	Active        bool                   `json:"active"`
+	CreatedAt     string                 `json:"created_at"`
+	UpdatedAt     string                 `json:"updated_at"`
+}
+
+func (h *Handler) CreateTemplate(c *gin.Context) {
+	var req usecase.CreateTemplateInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	out, validation, err := h.createTemplateUC.Execute(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, domain.ErrValidation) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, toTemplateResponse(out))
+}
+
+// ListTemplates: ?treatment_type=lumbalgia&include_inactive=true
+func (h *Handler) ListTemplates(c *gin.Context) {
+	items, err := h.listTemplatesUC.Execute(c.Request.Context(), c.Query("treatment_type"), c.Query("include_inactive") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	out := make([]templateResponse, 0, len(items))
+	for _, t := range items {
+		out = append(out, toTemplateResponse(t))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+func (h *Handler) GetTemplate(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("template_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_template_id"})
+		return
+	}
+
+	t, found, err := h.getTemplateUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toTemplateResponse(t))
+}
+
+func toTemplateResponse(t domain.EvolutionTemplate) templateResponse {
+	return templateResponse{
+		ID:            t.ID.String(),
+		Code:          t.Code,
+		Name:          t.Name,
+		TreatmentType: t.TreatmentType,
+		Description:   t.Description,
+		Fields:        t.Fields,
+		Active:        t.Active,
+		CreatedAt:     t.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:     t.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
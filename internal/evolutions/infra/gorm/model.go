@@ -7,10 +7,53 @@ type PatientEvolutionModel struct {
 	PatientID       string  `gorm:"type:uuid;not null"`
 	KinesiologistID string  `gorm:"type:uuid;not null"`
 	AppointmentID   *string `gorm:"type:uuid"`
+	IsPrimary       bool    `gorm:"not null"`
+	DiagnosisID     *string `gorm:"type:uuid"`
+	InjuryID        *string `gorm:"type:uuid"`
 	PainLevel       *int
 	Notes           string `gorm:"not null"`
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
+	Subjective      *string
+	Objective       *string
+	Assessment      *string
+	Plan            *string
+	TemplateID      *string `gorm:"type:uuid"`
+	TemplateValues  *string `gorm:"type:jsonb"`
+
+	Status            string `gorm:"not null"`
+	SignedAt          *time.Time
+	RootID            string  `gorm:"type:uuid;not null"`
+	Version           int     `gorm:"not null"`
+	PreviousVersionID *string `gorm:"type:uuid"`
+	CorrectionReason  *string
+	IsLatest          bool `gorm:"not null"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 func (PatientEvolutionModel) TableName() string { return "patient_evolutions" }
+
+type EvolutionTemplateModel struct {
+	ID            string `gorm:"type:uuid;primaryKey"`
+	Code          string `gorm:"not null;uniqueIndex"`
+	Name          string `gorm:"not null"`
+	TreatmentType string `gorm:"not null"`
+	Description   *string
+	Fields        string `gorm:"type:jsonb;not null"`
+	Active        bool   `gorm:"not null"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (EvolutionTemplateModel) TableName() string { return "evolution_templates" }
+
+type EvolutionAddendumModel struct {
+	ID              string `gorm:"type:uuid;primaryKey"`
+	EvolutionID     string `gorm:"type:uuid;not null"`
+	RootID          string `gorm:"type:uuid;not null"`
+	KinesiologistID string `gorm:"type:uuid;not null"`
+	Text            string `gorm:"not null"`
+	CreatedAt       time.Time
+}
+
+func (EvolutionAddendumModel) TableName() string { return "evolution_addenda" }
@@ -2,6 +2,7 @@ package gorm
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -18,9 +19,15 @@ func NewRepository(db *gorm.DB) *Repository {
 }
 
 func (r *Repository) Create(ctx context.Context, e domain.PatientEvolution) (domain.PatientEvolution, error) {
-	m := toModel(e)
+	m, err := toModel(e)
+	if err != nil {
+		return domain.PatientEvolution{}, err
+	}
 
 	if err := r.db.WithContext(ctx).Create(&m).Error; err != nil {
+		if isDuplicatePrimary(err) {
+			return domain.PatientEvolution{}, domain.ErrPrimaryExists
+		}
 		return domain.PatientEvolution{}, err
 	}
 
@@ -46,6 +53,7 @@ func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (domain.PatientE
 func (r *Repository) ListByPatient(ctx context.Context, patientID uuid.UUID, limit int) ([]domain.PatientEvolution, error) {
 	var ms []PatientEvolutionModel
 	if err := r.db.WithContext(ctx).
+		Where("is_latest = ?", true).
 		Order("created_at desc").
 		Limit(limit).
 		Find(&ms, "patient_id = ?", patientID.String()).
@@ -60,23 +68,54 @@ func (r *Repository) ListByPatient(ctx context.Context, patientID uuid.UUID, lim
 	return out, nil
 }
 
-func toModel(e domain.PatientEvolution) PatientEvolutionModel {
+func toModel(e domain.PatientEvolution) (PatientEvolutionModel, error) {
 	var appt *string
 	if e.AppointmentID != nil {
 		s := e.AppointmentID.String()
 		appt = &s
 	}
 
-	return PatientEvolutionModel{
+	var values *string
+	if len(e.TemplateValues) > 0 {
+		b, err := json.Marshal(e.TemplateValues)
+		if err != nil {
+			return PatientEvolutionModel{}, err
+		}
+		s := string(b)
+		values = &s
+	}
+
+	m := PatientEvolutionModel{
 		ID:              e.ID.String(),
 		PatientID:       e.PatientID.String(),
 		KinesiologistID: e.KinesiologistID.String(),
 		AppointmentID:   appt,
+		IsPrimary:       e.IsPrimary,
+		DiagnosisID:     uuidPtrToString(e.DiagnosisID),
+		InjuryID:        uuidPtrToString(e.InjuryID),
 		PainLevel:       e.PainLevel,
 		Notes:           e.Notes,
-		CreatedAt:       e.CreatedAt,
-		UpdatedAt:       e.UpdatedAt,
+		TemplateID:      uuidPtrToString(e.TemplateID),
+		TemplateValues:  values,
+
+		Status:            string(e.Status),
+		SignedAt:          e.SignedAt,
+		RootID:            e.RootID.String(),
+		Version:           e.Version,
+		PreviousVersionID: uuidPtrToString(e.PreviousVersionID),
+		CorrectionReason:  e.CorrectionReason,
+		IsLatest:          e.IsLatest,
+
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
 	}
+	if e.SOAP != nil {
+		m.Subjective = e.SOAP.Subjective
+		m.Objective = e.SOAP.Objective
+		m.Assessment = e.SOAP.Assessment
+		m.Plan = e.SOAP.Plan
+	}
+	return m, nil
 }
 
 func toDomain(m PatientEvolutionModel) domain.PatientEvolution {
@@ -86,14 +125,53 @@ func toDomain(m PatientEvolutionModel) domain.PatientEvolution {
 		appt = &id
 	}
 
-	return domain.PatientEvolution{
+	e := domain.PatientEvolution{
 		ID:              uuid.MustParse(m.ID),
 		PatientID:       uuid.MustParse(m.PatientID),
 		KinesiologistID: uuid.MustParse(m.KinesiologistID),
 		AppointmentID:   appt,
+		IsPrimary:       m.IsPrimary,
+		DiagnosisID:     stringPtrToUUID(m.DiagnosisID),
+		InjuryID:        stringPtrToUUID(m.InjuryID),
 		PainLevel:       m.PainLevel,
 		Notes:           m.Notes,
-		CreatedAt:       m.CreatedAt,
-		UpdatedAt:       m.UpdatedAt,
+		TemplateID:      stringPtrToUUID(m.TemplateID),
+
+		Status:            domain.Status(m.Status),
+		SignedAt:          m.SignedAt,
+		RootID:            uuid.MustParse(m.RootID),
+		Version:           m.Version,
+		PreviousVersionID: stringPtrToUUID(m.PreviousVersionID),
+		CorrectionReason:  m.CorrectionReason,
+		IsLatest:          m.IsLatest,
+
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+
+	soap := domain.SOAPNote{Subjective: m.Subjective, Objective: m.Objective, Assessment: m.Assessment, Plan: m.Plan}
+	if !soap.IsEmpty() {
+		e.SOAP = &soap
+	}
+	if m.TemplateValues != nil {
+		// El JSON lo escribimos nosotros; si no parsea se devuelve la evolución sin valores.
+		_ = json.Unmarshal([]byte(*m.TemplateValues), &e.TemplateValues)
+	}
+	return e
+}
+
+func uuidPtrToString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func stringPtrToUUID(s *string) *uuid.UUID {
+	if s == nil || *s == "" {
+		return nil
 	}
+	id := uuid.MustParse(*s)
+	return &id
 }
@@ -0,0 +1,106 @@
+package gorm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/javiacuna/kinesio-backend/internal/evolutions/domain"
+)
+
+func (r *Repository) CreateTemplate(ctx context.Context, t domain.EvolutionTemplate) (domain.EvolutionTemplate, error) {
+	m, err := toTemplateModel(t)
+	if err != nil {
+		return domain.EvolutionTemplate{}, err
+	}
+	if err := r.db.WithContext(ctx).Create(&m).Error; err != nil {
+		return domain.EvolutionTemplate{}, err
+	}
+	return toTemplateDomain(m)
+}
+
+func (r *Repository) GetTemplate(ctx context.Context, id uuid.UUID) (domain.EvolutionTemplate, bool, error) {
+	var m EvolutionTemplateModel
+	err := r.db.WithContext(ctx).First(&m, "id = ?", id.String()).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.EvolutionTemplate{}, false, nil
+		}
+		return domain.EvolutionTemplate{}, false, err
+	}
+	t, err := toTemplateDomain(m)
+	if err != nil {
+		return domain.EvolutionTemplate{}, false, err
+	}
+	return t, true, nil
+}
+
+func (r *Repository) ListTemplates(ctx context.Context, treatmentType string, includeInactive bool) ([]domain.EvolutionTemplate, error) {
+	tx := r.db.WithContext(ctx).Model(&EvolutionTemplateModel{})
+	if treatmentType != "" {
+		tx = tx.Where("treatment_type = ?", treatmentType)
+	}
+	if !includeInactive {
+		tx = tx.Where("active = ?", true)
+	}
+
+	var ms []EvolutionTemplateModel
+	if err := tx.Order("name asc").Find(&ms).Error; err != nil {
+		return nil, err
+	}
+
+	out := make([]domain.EvolutionTemplate, 0, len(ms))
+	for _, m := range ms {
+		t, err := toTemplateDomain(m)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+func (r *Repository) ExistsTemplateCode(ctx context.Context, code string) (bool, error) {
+	var n int64
+	err := r.db.WithContext(ctx).Model(&EvolutionTemplateModel{}).Where("code = ?", code).Count(&n).Error
+	return n > 0, err
+}
+
+func toTemplateModel(t domain.EvolutionTemplate) (EvolutionTemplateModel, error) {
+	fields, err := json.Marshal(t.Fields)
+	if err != nil {
+		return EvolutionTemplateModel{}, err
+	}
+	return EvolutionTemplateModel{
+		ID:            t.ID.String(),
+		Code:          t.Code,
+		Name:          t.Name,
+		TreatmentType: t.TreatmentType,
+		Description:   t.Description,
+		Fields:        string(fields),
+		Active:        t.Active,
+		CreatedAt:     t.CreatedAt,
+		UpdatedAt:     t.UpdatedAt,
+	}, nil
+}
+
+func toTemplateDomain(m EvolutionTemplateModel) (domain.EvolutionTemplate, error) {
+	var fields []domain.TemplateField
+	if err := json.Unmarshal([]byte(m.Fields), &fields); err != nil {
+		return domain.EvolutionTemplate{}, err
+	}
+	return domain.EvolutionTemplate{
+		ID:            uuid.MustParse(m.ID),
+		Code:          m.Code,
+		Name:          m.Name,
+		TreatmentType: m.TreatmentType,
+		Description:   m.Description,
+		Fields:        fields,
+		Active:        m.Active,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,192 @@
+package gorm
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/javiacuna/kinesio-backend/internal/evolutions/domain"
+)
+
+func (r *Repository) UpdateDraft(ctx context.Context, e domain.PatientEvolution) (domain.PatientEvolution, error) {
+	m, err := toModel(e)
+	if err != nil {
+		return domain.PatientEvolution{}, err
+	}
+
+	// El WHERE status = draft evita pisar una evolución que se firmó entre la lectura y la escritura.
+	res := r.db.WithContext(ctx).Model(&PatientEvolutionModel{}).
+		Where("id = ? AND status = ?", m.ID, string(domain.StatusDraft)).
+		Select("diagnosis_id", "injury_id", "pain_level", "notes",
+			"subjective", "objective", "assessment", "plan",
+			"template_id", "template_values", "updated_at").
+		Updates(&m)
+	if res.Error != nil {
+		return domain.PatientEvolution{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domain.PatientEvolution{}, domain.ErrSigned
+	}
+
+	out, _, err := r.GetByID(ctx, e.ID)
+	return out, err
+}
+
+func (r *Repository) Sign(ctx context.Context, id uuid.UUID, at time.Time) (domain.PatientEvolution, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var m PatientEvolutionModel
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&m, "id = ?", id.String()).Error; err != nil {
+			return err
+		}
+		if m.Status != string(domain.StatusDraft) {
+			return domain.ErrSigned
+		}
+
+		updates := map[string]any{
+			"status":     string(domain.StatusSigned),
+			"signed_at":  at,
+			"updated_at": at,
+		}
+		// Una corrección en borrador reemplaza a la versión anterior recién al firmarse.
+		if !m.IsLatest && m.PreviousVersionID != nil {
+			res := tx.Model(&PatientEvolutionModel{}).
+				Where("id = ? AND is_latest = ?", *m.PreviousVersionID, true).
+				Update("is_latest", false)
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return domain.ErrNotLatest
+			}
+			updates["is_latest"] = true
+		}
+		return tx.Model(&PatientEvolutionModel{}).Where("id = ?", m.ID).Updates(updates).Error
+	})
+	if err != nil {
+		return domain.PatientEvolution{}, err
+	}
+
+	out, _, err := r.GetByID(ctx, id)
+	return out, err
+}
+
+func (r *Repository) CreateVersion(ctx context.Context, e domain.PatientEvolution) (domain.PatientEvolution, error) {
+	m, err := toModel(e)
+	if err != nil {
+		return domain.PatientEvolution{}, err
+	}
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		prev := tx.Model(&PatientEvolutionModel{}).
+			Where("id = ? AND is_latest = ?", e.PreviousVersionID.String(), true)
+		if e.IsLatest {
+			// Solo is_latest cambia en la versión anterior (el trigger de inmutabilidad lo permite).
+			res := prev.Update("is_latest", false)
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return domain.ErrNotLatest
+			}
+		} else {
+			// Borrador: la anterior sigue vigente; se bloquea para que no la reemplacen mientras tanto.
+			var ms []PatientEvolutionModel
+			if err := prev.Clauses(clause.Locking{Strength: "UPDATE"}).Find(&ms).Error; err != nil {
+				return err
+			}
+			if len(ms) == 0 {
+				return domain.ErrNotLatest
+			}
+		}
+		if err := tx.Create(&m).Error; err != nil {
+			if isDuplicateVersion(err) {
+				return domain.ErrPendingVersion
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.PatientEvolution{}, err
+	}
+
+	out, _, err := r.GetByID(ctx, e.ID)
+	return out, err
+}
+
+func (r *Repository) ListVersions(ctx context.Context, rootID uuid.UUID) ([]domain.PatientEvolution, error) {
+	var ms []PatientEvolutionModel
+	if err := r.db.WithContext(ctx).
+		Where("root_id = ?", rootID.String()).
+		Order("version asc").
+		Find(&ms).Error; err != nil {
+		return nil, err
+	}
+
+	out := make([]domain.PatientEvolution, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, toDomain(m))
+	}
+	return out, nil
+}
+
+func (r *Repository) HasPrimaryForAppointment(ctx context.Context, appointmentID uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&PatientEvolutionModel{}).
+		Where("appointment_id = ? AND is_primary = ? AND is_latest = ?", appointmentID.String(), true, true).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *Repository) CreateAddendum(ctx context.Context, a domain.Addendum) (domain.Addendum, error) {
+	m := EvolutionAddendumModel{
+		ID:              a.ID.String(),
+		EvolutionID:     a.EvolutionID.String(),
+		RootID:          a.RootID.String(),
+		KinesiologistID: a.KinesiologistID.String(),
+		Text:            a.Text,
+		CreatedAt:       a.CreatedAt,
+	}
+	if err := r.db.WithContext(ctx).Create(&m).Error; err != nil {
+		return domain.Addendum{}, err
+	}
+	return a, nil
+}
+
+func (r *Repository) ListAddenda(ctx context.Context, rootID uuid.UUID) ([]domain.Addendum, error) {
+	var ms []EvolutionAddendumModel
+	if err := r.db.WithContext(ctx).
+		Where("root_id = ?", rootID.String()).
+		Order("created_at asc").
+		Find(&ms).Error; err != nil {
+		return nil, err
+	}
+
+	out := make([]domain.Addendum, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, domain.Addendum{
+			ID:              uuid.MustParse(m.ID),
+			EvolutionID:     uuid.MustParse(m.EvolutionID),
+			RootID:          uuid.MustParse(m.RootID),
+			KinesiologistID: uuid.MustParse(m.KinesiologistID),
+			Text:            m.Text,
+			CreatedAt:       m.CreatedAt,
+		})
+	}
+	return out, nil
+}
+
+func isDuplicateVersion(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "ux_patient_evolutions_root_version")
+}
+
+// isDuplicatePrimary: otra evolución principal del mismo turno se guardó entre el chequeo y el insert.
+func isDuplicatePrimary(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "ux_patient_evolutions_appointment_primary")
+}
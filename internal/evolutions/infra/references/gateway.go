@@ -0,0 +1,70 @@
+package references
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	apptDomain "github.com/javiacuna/kinesio-backend/internal/appointments/domain"
+	apptPorts "github.com/javiacuna/kinesio-backend/internal/appointments/ports"
+	apptUC "github.com/javiacuna/kinesio-backend/internal/appointments/usecase"
+	"github.com/javiacuna/kinesio-backend/internal/evolutions/domain"
+	kinePorts "github.com/javiacuna/kinesio-backend/internal/kinesiologists/ports"
+	patientPorts "github.com/javiacuna/kinesio-backend/internal/patients/ports"
+)
+
+var _ domain.References = (*Gateway)(nil)
+
+// Gateway consulta pacientes, kinesiólogos y turnos en sus módulos. Para marcar el turno
+// como atendido pasa por el caso de uso de turnos, así se descuenta la sesión de la orden médica.
+type Gateway struct {
+	patients       patientPorts.Repository
+	kinesiologists kinePorts.Repository
+	appointments   apptPorts.Repository
+	updateAppt     *apptUC.UpdateAppointmentUseCase
+}
+
+func NewGateway(patients patientPorts.Repository, kinesiologists kinePorts.Repository,
+	appointments apptPorts.Repository, updateAppt *apptUC.UpdateAppointmentUseCase) *Gateway {
+	return &Gateway{patients: patients, kinesiologists: kinesiologists, appointments: appointments, updateAppt: updateAppt}
+}
+
+func (g *Gateway) PatientExists(ctx context.Context, patientID uuid.UUID) (bool, error) {
+	_, found, err := g.patients.GetByID(ctx, patientID.String())
+	return found, err
+}
+
+func (g *Gateway) Kinesiologist(ctx context.Context, kinesiologistID uuid.UUID) (bool, bool, error) {
+	k, found, err := g.kinesiologists.GetByID(ctx, kinesiologistID)
+	if err != nil || !found {
+		return false, false, err
+	}
+	return k.Active, true, nil
+}
+
+func (g *Gateway) Appointment(ctx context.Context, appointmentID uuid.UUID) (domain.AppointmentInfo, bool, error) {
+	a, found, err := g.appointments.GetByID(ctx, appointmentID)
+	if err != nil || !found {
+		return domain.AppointmentInfo{}, false, err
+	}
+	return domain.AppointmentInfo{
+		PatientID:       a.PatientID,
+		KinesiologistID: a.KinesiologistID,
+		Cancelled:       a.Status == apptDomain.StatusCancelled,
+		Attended:        a.Status == apptDomain.StatusAttended,
+	}, true, nil
+}
+
+func (g *Gateway) MarkAppointmentAttended(ctx context.Context, appointmentID uuid.UUID) error {
+	return g.setAppointmentStatus(ctx, appointmentID, apptDomain.StatusAttended)
+}
+
+func (g *Gateway) UnmarkAppointmentAttended(ctx context.Context, appointmentID uuid.UUID) error {
+	return g.setAppointmentStatus(ctx, appointmentID, apptDomain.StatusScheduled)
+}
+
+func (g *Gateway) setAppointmentStatus(ctx context.Context, appointmentID uuid.UUID, status apptDomain.Status) error {
+	s := string(status)
+	_, _, err := g.updateAppt.Execute(ctx, appointmentID.String(), apptUC.UpdateAppointmentInput{Status: &s})
+	return err
+}
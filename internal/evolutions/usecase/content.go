@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/clinicalrefs"
+	"github.com/javiacuna/kinesio-backend/internal/evolutions/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+// EvolutionContentInput es el contenido clínico editable de una evolución
+// (se comparte entre alta, edición de borradores y nuevas versiones).
+type EvolutionContentInput struct {
+	DiagnosisID *string `json:"diagnosis_id,omitempty"`
+	InjuryID    *string `json:"injury_id,omitempty"`
+	PainLevel   *int    `json:"pain_level,omitempty"`
+	Notes       string  `json:"notes"`
+
+	// SOAP (opcional)
+	Subjective *string `json:"subjective,omitempty"`
+	Objective  *string `json:"objective,omitempty"`
+	Assessment *string `json:"assessment,omitempty"`
+	Plan       *string `json:"plan,omitempty"`
+
+	TemplateID     *string        `json:"template_id,omitempty"`
+	TemplateValues map[string]any `json:"template_values,omitempty"`
+}
+
+// applyContent valida el contenido y lo vuelca en e. Los errores de validación quedan en validation.
+func applyContent(ctx context.Context, repo domain.Repository, clinical domain.ClinicalRecord, in EvolutionContentInput, e *domain.PatientEvolution, validation *validation.Errors) error {
+	diagnosisID := validation.OptionalUUID("diagnosis_id", in.DiagnosisID)
+	injuryID := validation.OptionalUUID("injury_id", in.InjuryID)
+
+	var soap *domain.SOAPNote
+	note := domain.SOAPNote{
+		Subjective: trimPtr(in.Subjective),
+		Objective:  trimPtr(in.Objective),
+		Assessment: trimPtr(in.Assessment),
+		Plan:       trimPtr(in.Plan),
+	}
+	if !note.IsEmpty() {
+		soap = &note
+	}
+
+	// Texto libre o SOAP: al menos uno de los dos.
+	notes := strings.TrimSpace(in.Notes)
+	if notes == "" {
+		if soap == nil {
+			validation.Add("notes", "required")
+		} else {
+			notes = soap.Summary()
+		}
+	}
+
+	templateID := validation.OptionalUUID("template_id", in.TemplateID)
+	if templateID == nil && len(in.TemplateValues) > 0 {
+		validation.Add("template_id", "required_with_template_values")
+	}
+	if templateID != nil {
+		t, found, err := repo.GetTemplate(ctx, *templateID)
+		if err != nil {
+			return err
+		}
+		switch {
+		case !found:
+			validation.Add("template_id", "not_found")
+		case !t.Active:
+			validation.Add("template_id", "inactive")
+		default:
+			t.ValidateValues(in.TemplateValues, validation)
+		}
+	}
+
+	if in.PainLevel != nil {
+		if *in.PainLevel < 0 || *in.PainLevel > 10 {
+			validation.Add("pain_level", "must_be_between_0_and_10")
+		}
+	}
+
+	if validation.Empty() {
+		if err := clinicalrefs.Check(ctx, clinical, e.PatientID, diagnosisID, injuryID, validation); err != nil {
+			return err
+		}
+	}
+
+	e.DiagnosisID = diagnosisID
+	e.InjuryID = injuryID
+	e.PainLevel = in.PainLevel
+	e.Notes = notes
+	e.SOAP = soap
+	e.TemplateID = templateID
+	e.TemplateValues = templateValues(templateID, in.TemplateValues)
+	return nil
+}
+
+func templateValues(templateID *uuid.UUID, values map[string]any) map[string]any {
+	if templateID == nil || len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+func trimPtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := strings.TrimSpace(*s)
+	if v == "" {
+		return nil
+	}
+	return &v
+}
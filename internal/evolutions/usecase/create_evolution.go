@@ -2,84 +2,191 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/javiacuna/kinesio-backend/internal/evolutions/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
 )
 
 type CreateEvolutionInput struct {
 	PatientID       string  `json:"patient_id"`
 	KinesiologistID string  `json:"kinesiologist_id"`
 	AppointmentID   *string `json:"appointment_id,omitempty"`
-	PainLevel       *int    `json:"pain_level,omitempty"`
-	Notes           string  `json:"notes"`
+	// IsPrimary: por defecto la primera evolución de un turno es la principal.
+	IsPrimary *bool `json:"is_primary,omitempty"`
+	// MarkAttended: además de registrar la evolución, marca el turno como atendido.
+	MarkAttended bool `json:"mark_attended,omitempty"`
+
+	EvolutionContentInput
+
+	// Sign: crea la evolución ya firmada (por defecto queda en borrador).
+	Sign bool `json:"sign,omitempty"`
 }
 
 type CreateEvolutionUseCase struct {
-	repo domain.Repository
+	repo     domain.Repository
+	clinical domain.ClinicalRecord
+	refs     domain.References
 }
 
-func NewCreateEvolutionUseCase(repo domain.Repository) *CreateEvolutionUseCase {
-	return &CreateEvolutionUseCase{repo: repo}
+func NewCreateEvolutionUseCase(repo domain.Repository, clinical domain.ClinicalRecord, refs domain.References) *CreateEvolutionUseCase {
+	return &CreateEvolutionUseCase{repo: repo, clinical: clinical, refs: refs}
 }
 
-func (uc *CreateEvolutionUseCase) Execute(ctx context.Context, in CreateEvolutionInput) (domain.PatientEvolution, map[string]string, error) {
-	validation := map[string]string{}
+func (uc *CreateEvolutionUseCase) Execute(ctx context.Context, in CreateEvolutionInput) (domain.PatientEvolution, *validation.Errors, error) {
+	validation := validation.New()
 
 	patientID, err := uuid.Parse(strings.TrimSpace(in.PatientID))
 	if err != nil {
-		validation["patient_id"] = "invalid_uuid"
+		validation.Add("patient_id", "invalid_uuid")
 	}
 
 	kID, err := uuid.Parse(strings.TrimSpace(in.KinesiologistID))
 	if err != nil {
-		validation["kinesiologist_id"] = "invalid_uuid"
+		validation.Add("kinesiologist_id", "invalid_uuid")
 	}
 
 	var apptID *uuid.UUID
 	if in.AppointmentID != nil && strings.TrimSpace(*in.AppointmentID) != "" {
 		id, err := uuid.Parse(strings.TrimSpace(*in.AppointmentID))
 		if err != nil {
-			validation["appointment_id"] = "invalid_uuid"
+			validation.Add("appointment_id", "invalid_uuid")
 		} else {
 			apptID = &id
 		}
 	}
 
-	notes := strings.TrimSpace(in.Notes)
-	if notes == "" {
-		validation["notes"] = "required"
+	if in.MarkAttended && apptID == nil {
+		validation.Add("mark_attended", "requires_appointment_id")
+	}
+	if in.IsPrimary != nil && *in.IsPrimary && apptID == nil {
+		validation.Add("is_primary", "requires_appointment_id")
+	}
+	if !validation.Empty() {
+		return domain.PatientEvolution{}, validation, domain.ErrValidation
 	}
 
-	if in.PainLevel != nil {
-		if *in.PainLevel < 0 || *in.PainLevel > 10 {
-			validation["pain_level"] = "must_be_between_0_and_10"
-		}
+	appt, err := uc.checkReferences(ctx, patientID, kID, apptID, validation)
+	if err != nil {
+		return domain.PatientEvolution{}, nil, err
 	}
 
-	if len(validation) > 0 {
-		return domain.PatientEvolution{}, validation, domain.ErrValidation
+	isPrimary := false
+	if apptID != nil && validation.Empty() {
+		exists, err := uc.repo.HasPrimaryForAppointment(ctx, *apptID)
+		if err != nil {
+			return domain.PatientEvolution{}, nil, err
+		}
+		switch {
+		case in.IsPrimary == nil:
+			isPrimary = !exists
+		case *in.IsPrimary && exists:
+			return domain.PatientEvolution{}, nil, domain.ErrPrimaryExists
+		default:
+			isPrimary = *in.IsPrimary
+		}
 	}
 
 	now := time.Now().UTC()
+	id := uuid.New()
 
 	e := domain.PatientEvolution{
-		ID:              uuid.New(),
+		ID:              id,
 		PatientID:       patientID,
 		KinesiologistID: kID,
 		AppointmentID:   apptID,
-		PainLevel:       in.PainLevel,
-		Notes:           notes,
+		IsPrimary:       isPrimary,
+		Status:          domain.StatusDraft,
+		RootID:          id,
+		Version:         1,
+		IsLatest:        true,
 		CreatedAt:       now,
 		UpdatedAt:       now,
 	}
+	if err := applyContent(ctx, uc.repo, uc.clinical, in.EvolutionContentInput, &e, validation); err != nil {
+		return domain.PatientEvolution{}, nil, err
+	}
+
+	if !validation.Empty() {
+		return domain.PatientEvolution{}, validation, domain.ErrValidation
+	}
+
+	if in.Sign {
+		e.Sign(now)
+	}
+
+	// El turno se marca antes de guardar la evolución, así un error al marcarlo no deja una
+	// evolución guardada. Si después falla el guardado, el turno vuelve a programado (con su
+	// sesión devuelta) para que el reintento arranque de cero.
+	marked := in.MarkAttended && !appt.Attended
+	if marked {
+		if err := uc.refs.MarkAppointmentAttended(ctx, *apptID); err != nil {
+			return domain.PatientEvolution{}, nil, err
+		}
+	}
 
 	out, err := uc.repo.Create(ctx, e)
 	if err != nil {
+		if marked {
+			if uerr := uc.refs.UnmarkAppointmentAttended(ctx, *apptID); uerr != nil {
+				return domain.PatientEvolution{}, nil, errors.Join(err, uerr)
+			}
+		}
 		return domain.PatientEvolution{}, nil, err
 	}
 	return out, nil, nil
 }
+
+// checkReferences verifica que paciente y kinesiólogo existan y que el turno (si viene)
+// sea de ese paciente con ese kinesiólogo y no esté cancelado.
+func (uc *CreateEvolutionUseCase) checkReferences(ctx context.Context, patientID, kID uuid.UUID, apptID *uuid.UUID, validation *validation.Errors) (domain.AppointmentInfo, error) {
+	exists, err := uc.refs.PatientExists(ctx, patientID)
+	if err != nil {
+		return domain.AppointmentInfo{}, err
+	}
+	if !exists {
+		return domain.AppointmentInfo{}, domain.ErrNotFound
+	}
+
+	if err := checkKinesiologist(ctx, uc.refs, kID, validation); err != nil {
+		return domain.AppointmentInfo{}, err
+	}
+
+	if apptID == nil {
+		return domain.AppointmentInfo{}, nil
+	}
+	appt, found, err := uc.refs.Appointment(ctx, *apptID)
+	if err != nil {
+		return domain.AppointmentInfo{}, err
+	}
+	switch {
+	case !found:
+		validation.Add("appointment_id", "not_found")
+	case appt.PatientID != patientID:
+		validation.Add("appointment_id", "belongs_to_other_patient")
+	case appt.KinesiologistID != kID:
+		validation.Add("appointment_id", "belongs_to_other_kinesiologist")
+	case appt.Cancelled:
+		validation.Add("appointment_id", "appointment_cancelled")
+	}
+	return appt, nil
+}
+
+// checkKinesiologist verifica que el kinesiólogo exista y esté activo.
+func checkKinesiologist(ctx context.Context, refs domain.References, kID uuid.UUID, validation *validation.Errors) error {
+	active, found, err := refs.Kinesiologist(ctx, kID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case !found:
+		validation.Add("kinesiologist_id", "not_found")
+	case !active:
+		validation.Add("kinesiologist_id", "inactive")
+	}
+	return nil
+}
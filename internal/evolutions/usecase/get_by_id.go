@@ -15,6 +15,20 @@ func NewGetEvolutionByIDUseCase(repo domain.Repository) *GetEvolutionByIDUseCase
 	return &GetEvolutionByIDUseCase{repo: repo}
 }
 
-func (uc *GetEvolutionByIDUseCase) Execute(ctx context.Context, id uuid.UUID) (domain.PatientEvolution, bool, error) {
-	return uc.repo.GetByID(ctx, id)
+// Execute devuelve la evolución pedida con toda su cadena de versiones y las addendas.
+func (uc *GetEvolutionByIDUseCase) Execute(ctx context.Context, id uuid.UUID) (domain.EvolutionHistory, bool, error) {
+	e, found, err := uc.repo.GetByID(ctx, id)
+	if err != nil || !found {
+		return domain.EvolutionHistory{}, found, err
+	}
+
+	versions, err := uc.repo.ListVersions(ctx, e.RootID)
+	if err != nil {
+		return domain.EvolutionHistory{}, false, err
+	}
+	addenda, err := uc.repo.ListAddenda(ctx, e.RootID)
+	if err != nil {
+		return domain.EvolutionHistory{}, false, err
+	}
+	return domain.EvolutionHistory{Evolution: e, Versions: versions, Addenda: addenda}, true, nil
 }
@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/evolutions/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+// ---------- Edición de borradores
+
+type UpdateEvolutionUseCase struct {
+	repo     domain.Repository
+	clinical domain.ClinicalRecord
+}
+
+func NewUpdateEvolutionUseCase(repo domain.Repository, clinical domain.ClinicalRecord) *UpdateEvolutionUseCase {
+	return &UpdateEvolutionUseCase{repo: repo, clinical: clinical}
+}
+
+// Execute reemplaza el contenido de un borrador. Las evoluciones firmadas no se tocan.
+func (uc *UpdateEvolutionUseCase) Execute(ctx context.Context, id uuid.UUID, in EvolutionContentInput) (domain.PatientEvolution, *validation.Errors, error) {
+	e, found, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.PatientEvolution{}, nil, err
+	}
+	if !found {
+		return domain.PatientEvolution{}, nil, domain.ErrNotFound
+	}
+	if e.Status == domain.StatusSigned {
+		return domain.PatientEvolution{}, nil, domain.ErrSigned
+	}
+
+	validation := validation.New()
+	if err := applyContent(ctx, uc.repo, uc.clinical, in, &e, validation); err != nil {
+		return domain.PatientEvolution{}, nil, err
+	}
+	if !validation.Empty() {
+		return domain.PatientEvolution{}, validation, domain.ErrValidation
+	}
+
+	e.UpdatedAt = time.Now().UTC()
+	out, err := uc.repo.UpdateDraft(ctx, e)
+	if err != nil {
+		return domain.PatientEvolution{}, nil, err
+	}
+	return out, nil, nil
+}
+
+// ---------- Firma
+
+type SignEvolutionUseCase struct {
+	repo domain.Repository
+}
+
+func NewSignEvolutionUseCase(repo domain.Repository) *SignEvolutionUseCase {
+	return &SignEvolutionUseCase{repo: repo}
+}
+
+// Execute firma el borrador. Solo puede firmar el kinesiólogo autor.
+func (uc *SignEvolutionUseCase) Execute(ctx context.Context, id uuid.UUID, kinesiologistID string) (domain.PatientEvolution, *validation.Errors, error) {
+	validation := validation.New()
+
+	kID, err := uuid.Parse(strings.TrimSpace(kinesiologistID))
+	if err != nil {
+		validation.Add("kinesiologist_id", "invalid_uuid")
+		return domain.PatientEvolution{}, validation, domain.ErrValidation
+	}
+
+	e, found, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.PatientEvolution{}, nil, err
+	}
+	if !found {
+		return domain.PatientEvolution{}, nil, domain.ErrNotFound
+	}
+	if e.Status == domain.StatusSigned {
+		return domain.PatientEvolution{}, nil, domain.ErrSigned
+	}
+	if e.KinesiologistID != kID {
+		validation.Add("kinesiologist_id", "must_be_author")
+		return domain.PatientEvolution{}, validation, domain.ErrValidation
+	}
+
+	out, err := uc.repo.Sign(ctx, id, time.Now().UTC())
+	if err != nil {
+		return domain.PatientEvolution{}, nil, err
+	}
+	return out, nil, nil
+}
+
+// ---------- Nueva versión (corrección de una evolución firmada)
+
+type AmendEvolutionInput struct {
+	KinesiologistID  string `json:"kinesiologist_id"`
+	CorrectionReason string `json:"correction_reason"`
+
+	EvolutionContentInput
+
+	Sign bool `json:"sign,omitempty"`
+}
+
+type AmendEvolutionUseCase struct {
+	repo     domain.Repository
+	clinical domain.ClinicalRecord
+	refs     domain.References
+}
+
+func NewAmendEvolutionUseCase(repo domain.Repository, clinical domain.ClinicalRecord, refs domain.References) *AmendEvolutionUseCase {
+	return &AmendEvolutionUseCase{repo: repo, clinical: clinical, refs: refs}
+}
+
+// Execute crea la versión N+1 a partir de la última versión firmada. La anterior queda intacta
+// y sigue siendo la vigente hasta que se firme la corrección. La fecha clínica es la de la versión 1.
+func (uc *AmendEvolutionUseCase) Execute(ctx context.Context, id uuid.UUID, in AmendEvolutionInput) (domain.PatientEvolution, *validation.Errors, error) {
+	validation := validation.New()
+
+	kID, err := uuid.Parse(strings.TrimSpace(in.KinesiologistID))
+	if err != nil {
+		validation.Add("kinesiologist_id", "invalid_uuid")
+	}
+	reason := strings.TrimSpace(in.CorrectionReason)
+	if reason == "" {
+		validation.Add("correction_reason", "required")
+	}
+
+	prev, found, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.PatientEvolution{}, nil, err
+	}
+	if !found {
+		return domain.PatientEvolution{}, nil, domain.ErrNotFound
+	}
+	switch {
+	case !prev.IsLatest:
+		return domain.PatientEvolution{}, nil, domain.ErrNotLatest
+	case prev.Status != domain.StatusSigned:
+		// Un borrador se corrige editándolo, no versionándolo.
+		return domain.PatientEvolution{}, nil, domain.ErrNotSigned
+	}
+
+	if validation.Empty() {
+		if err := uc.checkKinesiologist(ctx, kID, prev.AppointmentID, validation); err != nil {
+			return domain.PatientEvolution{}, nil, err
+		}
+	}
+
+	root := prev
+	if prev.RootID != prev.ID {
+		root, found, err = uc.repo.GetByID(ctx, prev.RootID)
+		if err != nil {
+			return domain.PatientEvolution{}, nil, err
+		}
+		if !found {
+			return domain.PatientEvolution{}, nil, domain.ErrNotFound
+		}
+	}
+
+	now := time.Now().UTC()
+	prevID := prev.ID
+	e := domain.PatientEvolution{
+		ID:                uuid.New(),
+		PatientID:         prev.PatientID,
+		KinesiologistID:   kID,
+		AppointmentID:     prev.AppointmentID,
+		IsPrimary:         prev.IsPrimary,
+		Status:            domain.StatusDraft,
+		RootID:            prev.RootID,
+		Version:           prev.Version + 1,
+		PreviousVersionID: &prevID,
+		CorrectionReason:  &reason,
+		IsLatest:          in.Sign,
+		CreatedAt:         root.CreatedAt,
+		UpdatedAt:         now,
+	}
+	if err := applyContent(ctx, uc.repo, uc.clinical, in.EvolutionContentInput, &e, validation); err != nil {
+		return domain.PatientEvolution{}, nil, err
+	}
+	if !validation.Empty() {
+		return domain.PatientEvolution{}, validation, domain.ErrValidation
+	}
+
+	if in.Sign {
+		e.Sign(now)
+	}
+
+	out, err := uc.repo.CreateVersion(ctx, e)
+	if err != nil {
+		return domain.PatientEvolution{}, nil, err
+	}
+	return out, nil, nil
+}
+
+// checkKinesiologist aplica a la corrección los mismos controles que al crear: el kinesiólogo
+// existe, está activo y, si la evolución es de un turno, es el del turno.
+func (uc *AmendEvolutionUseCase) checkKinesiologist(ctx context.Context, kID uuid.UUID, apptID *uuid.UUID, validation *validation.Errors) error {
+	if err := checkKinesiologist(ctx, uc.refs, kID, validation); err != nil {
+		return err
+	}
+	if apptID == nil {
+		return nil
+	}
+	appt, found, err := uc.refs.Appointment(ctx, *apptID)
+	if err != nil {
+		return err
+	}
+	if found && appt.KinesiologistID != kID {
+		validation.AddMessage("kinesiologist_id", "belongs_to_other_kinesiologist", "El turno de la evolución es de otro kinesiólogo")
+	}
+	return nil
+}
+
+// ---------- Addendas
+
+type AddAddendumInput struct {
+	KinesiologistID string `json:"kinesiologist_id"`
+	Text            string `json:"text"`
+}
+
+type AddAddendumUseCase struct {
+	repo domain.Repository
+}
+
+func NewAddAddendumUseCase(repo domain.Repository) *AddAddendumUseCase {
+	return &AddAddendumUseCase{repo: repo}
+}
+
+func (uc *AddAddendumUseCase) Execute(ctx context.Context, evolutionID uuid.UUID, in AddAddendumInput) (domain.Addendum, *validation.Errors, error) {
+	validation := validation.New()
+
+	kID, err := uuid.Parse(strings.TrimSpace(in.KinesiologistID))
+	if err != nil {
+		validation.Add("kinesiologist_id", "invalid_uuid")
+	}
+	text := strings.TrimSpace(in.Text)
+	if text == "" {
+		validation.Add("text", "required")
+	}
+	if !validation.Empty() {
+		return domain.Addendum{}, validation, domain.ErrValidation
+	}
+
+	e, found, err := uc.repo.GetByID(ctx, evolutionID)
+	if err != nil {
+		return domain.Addendum{}, nil, err
+	}
+	if !found {
+		return domain.Addendum{}, nil, domain.ErrNotFound
+	}
+	if e.Status != domain.StatusSigned {
+		return domain.Addendum{}, nil, domain.ErrNotSigned
+	}
+
+	a := domain.Addendum{
+		ID:              uuid.New(),
+		EvolutionID:     e.ID,
+		RootID:          e.RootID,
+		KinesiologistID: kID,
+		Text:            text,
+		CreatedAt:       time.Now().UTC(),
+	}
+	out, err := uc.repo.CreateAddendum(ctx, a)
+	if err != nil {
+		return domain.Addendum{}, nil, err
+	}
+	return out, nil, nil
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/evolutions/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+type CreateTemplateInput struct {
+	Code          string                 `json:"code"`
+	Name          string                 `json:"name"`
+	TreatmentType string                 `json:"treatment_type"`
+	Description   *string                `json:"description"`
+	Fields        []domain.TemplateField `json:"fields"`
+}
+
+type CreateTemplateUseCase struct {
+	repo domain.Repository
+}
+
+func NewCreateTemplateUseCase(repo domain.Repository) *CreateTemplateUseCase {
+	return &CreateTemplateUseCase{repo: repo}
+}
+
+func (uc *CreateTemplateUseCase) Execute(ctx context.Context, in CreateTemplateInput) (domain.EvolutionTemplate, *validation.Errors, error) {
+	validation := validation.New()
+
+	now := time.Now().UTC()
+	t := domain.EvolutionTemplate{
+		ID:            uuid.New(),
+		Code:          strings.ToLower(strings.TrimSpace(in.Code)),
+		Name:          strings.TrimSpace(in.Name),
+		TreatmentType: strings.ToLower(strings.TrimSpace(in.TreatmentType)),
+		Description:   trimPtr(in.Description),
+		Fields:        in.Fields,
+		Active:        true,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	for i := range t.Fields {
+		t.Fields[i].Key = strings.TrimSpace(t.Fields[i].Key)
+		t.Fields[i].Label = strings.TrimSpace(t.Fields[i].Label)
+	}
+	t.ValidateDefinition(validation)
+
+	if !validation.Has("code") {
+		exists, err := uc.repo.ExistsTemplateCode(ctx, t.Code)
+		if err != nil {
+			return domain.EvolutionTemplate{}, nil, err
+		}
+		if exists {
+			validation.Add("code", "already_exists")
+		}
+	}
+	if !validation.Empty() {
+		return domain.EvolutionTemplate{}, validation, domain.ErrValidation
+	}
+
+	out, err := uc.repo.CreateTemplate(ctx, t)
+	if err != nil {
+		return domain.EvolutionTemplate{}, nil, err
+	}
+	return out, nil, nil
+}
+
+type ListTemplatesUseCase struct {
+	repo domain.Repository
+}
+
+func NewListTemplatesUseCase(repo domain.Repository) *ListTemplatesUseCase {
+	return &ListTemplatesUseCase{repo: repo}
+}
+
+func (uc *ListTemplatesUseCase) Execute(ctx context.Context, treatmentType string, includeInactive bool) ([]domain.EvolutionTemplate, error) {
+	return uc.repo.ListTemplates(ctx, strings.ToLower(strings.TrimSpace(treatmentType)), includeInactive)
+}
+
+type GetTemplateUseCase struct {
+	repo domain.Repository
+}
+
+func NewGetTemplateUseCase(repo domain.Repository) *GetTemplateUseCase {
+	return &GetTemplateUseCase{repo: repo}
+}
+
+func (uc *GetTemplateUseCase) Execute(ctx context.Context, id uuid.UUID) (domain.EvolutionTemplate, bool, error) {
+	return uc.repo.GetTemplate(ctx, id)
+}
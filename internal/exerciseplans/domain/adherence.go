@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/clinictime"
+)
+
+// Quién cargó el registro: el paciente desde su casa o recepción en su nombre.
+const (
+	ReportedByPatient      = "patient"
+	ReportedByReceptionist = "receptionist"
+)
+
+// AdherenceLog: una sesión de un ejercicio del plan hecha en casa.
+type AdherenceLog struct {
+	ID            uuid.UUID
+	PlanID        uuid.UUID
+	ItemID        uuid.UUID
+	PatientID     uuid.UUID
+	Date          time.Time // solo fecha (UTC)
+	SetsCompleted *int
+	RepsCompleted *int
+	Difficulty    *int // percibida, 1..10
+	PainLevel     *int // durante el ejercicio, 0..10
+	Comment       *string
+	ReportedBy    string
+	CreatedAt     time.Time
+}
+
+type ItemAdherence struct {
+	ItemID     uuid.UUID
+	Name       string
+	Expected   int
+	Completed  int
+	Percentage float64
+}
+
+type Adherence struct {
+	PlanID    uuid.UUID
+	PatientID uuid.UUID
+	// Expected: sesiones (sumando todos los ejercicios) que deberían estar hechas a la fecha.
+	Expected    int
+	Completed   int
+	Percentage  float64
+	LastLogDate *time.Time
+	Items       []ItemAdherence
+}
+
+// dateOnly trunca una fecha que ya es del consultorio (las de los registros o las que
+// llegan como YYYY-MM-DD). Los timestamps pasan por clinictime.Day con la zona del plan.
+func dateOnly(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// LogWindow: primer y último día en que se pueden registrar sesiones del plan
+// (hasta el fin de la duración o el cierre, lo que ocurra antes).
+func (p ExercisePlan) LogWindow() (time.Time, time.Time) {
+	from, to := p.Period()
+	if p.ClosedAt != nil {
+		if closed := clinictime.Day(*p.ClosedAt, p.loc); closed.Before(to) {
+			to = closed
+		}
+	}
+	return from, to
+}
+
+// ComputeAdherence cruza los registros con lo esperado a la fecha según la programación de cada
+// ejercicio (días elegidos, fase y frecuencia del plan). La semana en curso cuenta hasta el
+// día del consultorio en que cae asOf.
+// Por semana, cada ejercicio suma como máximo las sesiones que le tocaban: repetir un registro
+// el mismo día o hacer sesiones de más no infla el porcentaje, pero se puede recuperar un día
+// salteado dentro de la misma semana.
+func ComputeAdherence(p ExercisePlan, logs []AdherenceLog, asOf time.Time) Adherence {
+	out := Adherence{
+		PlanID:    p.ID,
+		PatientID: p.PatientID,
+		Items:     make([]ItemAdherence, 0, len(p.Items)),
+	}
+
+	from, to := p.LogWindow()
+	if limit := clinictime.Day(asOf, p.loc); limit.Before(to) {
+		to = limit
+	}
+
+	// días distintos con registro, por ítem y por semana
+	done := map[uuid.UUID]map[int]map[time.Time]bool{}
+	for _, l := range logs {
+		day := dateOnly(l.Date)
+		if day.Before(from) || day.After(to) {
+			continue
+		}
+		if out.LastLogDate == nil || day.After(*out.LastLogDate) {
+			d := day
+			out.LastLogDate = &d
+		}
+		week := p.WeekOf(day)
+		if done[l.ItemID] == nil {
+			done[l.ItemID] = map[int]map[time.Time]bool{}
+		}
+		if done[l.ItemID][week] == nil {
+			done[l.ItemID][week] = map[time.Time]bool{}
+		}
+		done[l.ItemID][week][day] = true
+	}
+
+	lastWeek := 0
+	if !to.Before(from) {
+		lastWeek = p.WeekOf(to)
+	}
+	for _, it := range p.Items {
+		ia := ItemAdherence{ItemID: it.ID, Name: it.Name}
+		for w := 1; w <= lastWeek; w++ {
+			weekEnd := from.AddDate(0, 0, 7*w-1)
+			if weekEnd.After(to) {
+				weekEnd = to
+			}
+			expected := p.sessionsUntil(it, weekEnd)
+			ia.Expected += expected
+			if n := len(done[it.ID][w]); n < expected {
+				ia.Completed += n
+			} else {
+				ia.Completed += expected
+			}
+		}
+		ia.Percentage = percentage(ia.Completed, ia.Expected)
+		out.Expected += ia.Expected
+		out.Completed += ia.Completed
+		out.Items = append(out.Items, ia)
+	}
+	out.Percentage = percentage(out.Completed, out.Expected)
+	return out
+}
+
+func percentage(done, expected int) float64 {
+	if expected <= 0 {
+		return 0
+	}
+	return math.Round(float64(done)/float64(expected)*1000) / 10
+}
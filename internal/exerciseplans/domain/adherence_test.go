@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// planStart es lunes: la semana 1 del plan va de lunes a domingo.
+var planStart = time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+
+func day(offset int) time.Time {
+	return dateOnly(planStart).AddDate(0, 0, offset)
+}
+
+func testItem(name string) ExercisePlanItem {
+	return ExercisePlanItem{ID: uuid.New(), Name: name}
+}
+
+func logsOn(it ExercisePlanItem, offsets ...int) []AdherenceLog {
+	out := make([]AdherenceLog, 0, len(offsets))
+	for _, o := range offsets {
+		out = append(out, AdherenceLog{ID: uuid.New(), ItemID: it.ID, Date: day(o)})
+	}
+	return out
+}
+
+func TestComputeAdherence(t *testing.T) {
+	daily := testItem("puente")
+	weekly := testItem("plancha")
+	phaseA := testItem("isométricos")
+	phaseA.Phase = ptr("a")
+	phaseA.Weekdays = []time.Weekday{time.Monday, time.Wednesday}
+	phaseB := testItem("sentadillas")
+	phaseB.Phase = ptr("b")
+	phaseB.Weekdays = []time.Weekday{time.Monday, time.Wednesday}
+
+	closedAt := day(2).Add(15 * time.Hour)
+
+	tests := []struct {
+		name          string
+		plan          ExercisePlan
+		logs          []AdherenceLog
+		asOf          time.Time
+		wantExpected  int
+		wantCompleted int
+		wantPct       float64
+		wantLast      *time.Time
+	}{
+		{
+			name: "plan sin ejercicios",
+			plan: ExercisePlan{Frequency: FrequencyDaily, DurationWeeks: 2, CreatedAt: planStart},
+			asOf: day(10),
+		},
+		{
+			name:         "sin registros",
+			plan:         ExercisePlan{Frequency: FrequencyDaily, DurationWeeks: 2, CreatedAt: planStart, Items: []ExercisePlanItem{daily}},
+			asOf:         day(3),
+			wantExpected: 4,
+		},
+		{
+			name: "semana parcial con registro repetido el mismo día",
+			plan: ExercisePlan{Frequency: FrequencyDaily, DurationWeeks: 2, CreatedAt: planStart, Items: []ExercisePlanItem{daily}},
+			logs: logsOn(daily, 0, 0, 2),
+			// miércoles: tocaban lunes, martes y miércoles
+			asOf:          day(2).Add(20 * time.Hour),
+			wantExpected:  3,
+			wantCompleted: 2,
+			wantPct:       66.7,
+			wantLast:      ptr(day(2)),
+		},
+		{
+			name: "semanal: las sesiones de más no compensan otra semana",
+			plan: ExercisePlan{Frequency: FrequencyWeekly, DurationWeeks: 4, CreatedAt: planStart, Items: []ExercisePlanItem{weekly}},
+			logs: logsOn(weekly, 1, 3),
+			asOf: day(8),
+			// una por semana en las semanas 1 y 2
+			wantExpected:  2,
+			wantCompleted: 1,
+			wantPct:       50,
+			wantLast:      ptr(day(3)),
+		},
+		{
+			name: "registros fuera de la ventana no cuentan",
+			plan: ExercisePlan{Frequency: FrequencyDaily, DurationWeeks: 1, CreatedAt: planStart, Items: []ExercisePlanItem{daily}},
+			logs: logsOn(daily, -1, 0, 9),
+			asOf: day(20),
+			// el plan terminó: solo cuenta la semana 1
+			wantExpected:  7,
+			wantCompleted: 1,
+			wantPct:       14.3,
+			wantLast:      ptr(day(0)),
+		},
+		{
+			name: "plan cerrado antes de terminar",
+			plan: ExercisePlan{Frequency: FrequencyDaily, DurationWeeks: 2, CreatedAt: planStart, ClosedAt: &closedAt,
+				Items: []ExercisePlanItem{daily}},
+			logs:          logsOn(daily, 0, 1, 2, 3),
+			asOf:          day(10),
+			wantExpected:  3,
+			wantCompleted: 3,
+			wantPct:       100,
+			wantLast:      ptr(day(2)),
+		},
+		{
+			name: "fases: cada ejercicio cuenta solo en sus semanas",
+			plan: ExercisePlan{Frequency: FrequencyDaily, DurationWeeks: 4, CreatedAt: planStart,
+				Phases: []PlanPhase{{Name: "a", StartWeek: 1, EndWeek: 2}, {Name: "b", StartWeek: 3, EndWeek: 4}},
+				Items:  []ExercisePlanItem{phaseA, phaseB}},
+			logs: append(logsOn(phaseA, 0, 2, 7), logsOn(phaseB, 14)...),
+			// lunes de la semana 3: fase a = 2+2, fase b = 1 (el lunes)
+			asOf:          day(14),
+			wantExpected:  5,
+			wantCompleted: 4,
+			wantPct:       80,
+			wantLast:      ptr(day(14)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeAdherence(tt.plan, tt.logs, tt.asOf)
+			if got.Expected != tt.wantExpected || got.Completed != tt.wantCompleted || got.Percentage != tt.wantPct {
+				t.Fatalf("got expected=%d completed=%d pct=%v, want %d %d %v",
+					got.Expected, got.Completed, got.Percentage, tt.wantExpected, tt.wantCompleted, tt.wantPct)
+			}
+			if len(got.Items) != len(tt.plan.Items) {
+				t.Fatalf("items = %d, want %d", len(got.Items), len(tt.plan.Items))
+			}
+			switch {
+			case tt.wantLast == nil && got.LastLogDate != nil:
+				t.Fatalf("last log = %v, want nil", *got.LastLogDate)
+			case tt.wantLast != nil && (got.LastLogDate == nil || !got.LastLogDate.Equal(*tt.wantLast)):
+				t.Fatalf("last log = %v, want %v", got.LastLogDate, *tt.wantLast)
+			}
+		})
+	}
+}
+
+func TestComputeAdherence_PerItem(t *testing.T) {
+	a, b := testItem("a"), testItem("b")
+	p := ExercisePlan{Frequency: FrequencyDaily, DurationWeeks: 1, CreatedAt: planStart, Items: []ExercisePlanItem{a, b}}
+	logs := append(logsOn(a, 0, 1), logsOn(b, 1)...)
+
+	got := ComputeAdherence(p, logs, day(1))
+	if got.Items[0].Percentage != 100 || got.Items[1].Percentage != 50 {
+		t.Fatalf("items = %+v", got.Items)
+	}
+	if got.Percentage != 75 {
+		t.Fatalf("percentage = %v, want 75", got.Percentage)
+	}
+}
+
+func TestComputeAdherence_ClinicDay(t *testing.T) {
+	ba := time.FixedZone("ART", -3*60*60)
+	daily := testItem("puente")
+	// Lunes 22:30 en el consultorio, ya martes en UTC.
+	created := time.Date(2026, 3, 2, 22, 30, 0, 0, ba).UTC()
+	p := ExercisePlan{Frequency: FrequencyDaily, DurationWeeks: 1, CreatedAt: created, Items: []ExercisePlanItem{daily}}.In(ba)
+
+	if got, want := p.StartDay(), time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("start day = %v, want %v", got, want)
+	}
+	// Martes 21:00 en el consultorio: tocaban lunes y martes, no el miércoles UTC.
+	asOf := time.Date(2026, 3, 3, 21, 0, 0, 0, ba)
+	got := ComputeAdherence(p, logsOn(daily, 0, 1), asOf)
+	if got.Expected != 2 || got.Completed != 2 {
+		t.Fatalf("got expected=%d completed=%d, want 2 2", got.Expected, got.Completed)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClinicInfo: datos del consultorio para el encabezado de los documentos impresos.
+type ClinicInfo struct {
+	Name    string
+	Address string
+	Phone   string
+}
+
+// PlanDocument: lo que necesita el renderer para imprimir un plan.
+type PlanDocument struct {
+	Clinic            ClinicInfo
+	Plan              ExercisePlan
+	PatientName       string
+	KinesiologistName string
+	KinesiologistMN   *string // matrícula
+	GeneratedAt       time.Time
+}
+
+// PlanRenderer arma el archivo imprimible (PDF) de un plan.
+type PlanRenderer interface {
+	Render(doc PlanDocument) ([]byte, error)
+}
+
+// Person: nombre a mostrar y matrícula (solo kinesiólogos).
+type Person struct {
+	FullName      string
+	LicenseNumber *string
+}
+
+// Directory resuelve los nombres de paciente y kinesiólogo (lo implementan sus módulos).
+type Directory interface {
+	Patient(ctx context.Context, id uuid.UUID) (Person, bool, error)
+	Kinesiologist(ctx context.Context, id uuid.UUID) (Person, bool, error)
+}
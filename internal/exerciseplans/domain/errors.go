@@ -5,4 +5,7 @@ import "errors"
 var (
 	ErrValidation = errors.New("validation_error")
 	ErrNotFound   = errors.New("not_found")
+	ErrPlanClosed = errors.New("plan_closed")
+
+	ErrTemplateOutdated = errors.New("template_outdated")
 )
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/clinictime"
 )
 
 type Frequency string
@@ -20,24 +22,50 @@ const (
 	PlanClosed PlanStatus = "closed"
 )
 
+// Motivos de cierre que pone el sistema (el kinesiólogo puede cargar cualquier otro).
+const (
+	CloseReasonCompleted = "duration_completed"
+	CloseReasonReplaced  = "replaced_by_new_version"
+)
+
 type ExercisePlan struct {
 	ID              uuid.UUID
 	PatientID       uuid.UUID
 	KinesiologistID uuid.UUID
 
+	// Vínculo opcional con la historia clínica estructurada
+	DiagnosisID *uuid.UUID
+	InjuryID    *uuid.UUID
+
 	Frequency     Frequency
 	DurationWeeks int
 	Observations  *string
 	Status        PlanStatus
+	ClosedAt      *time.Time
+	CloseReason   *string
+
+	// Versionado: un plan clonado apunta al anterior y suma 1 a Version.
+	PreviousPlanID *uuid.UUID
+	Version        int
+
+	// Versión de protocolo desde la que se armó el plan (si se aplicó una plantilla).
+	TemplateID *uuid.UUID
+
+	// Etapas del plan (opcionales); los ítems con Phase solo rigen en esas semanas.
+	Phases []PlanPhase
 
 	Items     []ExercisePlanItem
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// loc: zona del consultorio con la que CreatedAt / ClosedAt pasan a días del plan (ver In).
+	loc *time.Location
 }
 
 type ExercisePlanItem struct {
 	ID               uuid.UUID
 	PlanID           uuid.UUID
+	ExerciseID       *uuid.UUID // ejercicio del catálogo (los valores se copian y se pueden pisar)
 	Name             string
 	Description      *string
 	VideoURL         *string
@@ -45,6 +73,51 @@ type ExercisePlanItem struct {
 	EstimatedMinutes int
 	Sets             *int
 	Reps             *int
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
+	Load             *float64 // carga inicial (p. ej. kg de la pesa)
+	LoadUnit         *string
+	// Programación propia del ítem: días de la semana (vacío = según Frequency del plan),
+	// fase en la que rige y progresión semanal de la dosis.
+	Weekdays    []time.Weekday
+	Phase       *string
+	Progression *Progression
+	Position    int // orden dentro del plan (0..n-1)
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// EndsAt: el plan dura DurationWeeks semanas desde su creación.
+func (p ExercisePlan) EndsAt() time.Time {
+	return p.CreatedAt.AddDate(0, 0, 7*p.DurationWeeks)
+}
+
+// In devuelve el plan con sus días en la zona del consultorio: la semana 1 empieza el día
+// local en que se creó. Sin zona los días son UTC.
+func (p ExercisePlan) In(loc *time.Location) ExercisePlan {
+	p.loc = loc
+	return p
+}
+
+// StartDay: día del consultorio en que se creó el plan (fecha a las 00:00 UTC).
+func (p ExercisePlan) StartDay() time.Time {
+	return clinictime.Day(p.CreatedAt, p.loc)
+}
+
+// Period: primer y último día del plan según su duración (ambos inclusive).
+func (p ExercisePlan) Period() (time.Time, time.Time) {
+	first := p.StartDay()
+	return first, first.AddDate(0, 0, 7*p.DurationWeeks-1)
+}
+
+func (p *ExercisePlan) Close(reason string, at time.Time) {
+	p.Status = PlanClosed
+	p.ClosedAt = &at
+	p.CloseReason = &reason
+	p.UpdatedAt = at
+}
+
+// Renumber deja las posiciones consecutivas según el orden actual de Items.
+func (p *ExercisePlan) Renumber() {
+	for i := range p.Items {
+		p.Items[i].Position = i
+	}
 }
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -10,4 +11,51 @@ type Repository interface {
 	Create(ctx context.Context, p ExercisePlan) (ExercisePlan, error)
 	GetByID(ctx context.Context, id uuid.UUID) (ExercisePlan, bool, error)
 	ListByPatient(ctx context.Context, patientID uuid.UUID) ([]ExercisePlan, error)
+
+	// Update guarda la cabecera del plan y deja sus ítems exactamente como vienen en p.Items
+	// (agrega, actualiza posiciones y borra los que ya no están).
+	Update(ctx context.Context, p ExercisePlan) (ExercisePlan, error)
+	// CreateVersion guarda el plan anterior (ya cerrado) y crea el nuevo en una sola transacción.
+	CreateVersion(ctx context.Context, previous, next ExercisePlan) (ExercisePlan, error)
+	// CloseExpired cierra los planes activos cuyo created_at + duration_weeks ya pasó.
+	CloseExpired(ctx context.Context, now time.Time, reason string) (int64, error)
+
+	CreateTemplate(ctx context.Context, t PlanTemplate) (PlanTemplate, error)
+	// CreateTemplateVersion inserta la versión nueva y marca la anterior como no vigente.
+	// Devuelve ErrTemplateOutdated si la anterior ya había sido reemplazada.
+	CreateTemplateVersion(ctx context.Context, previousID uuid.UUID, t PlanTemplate) (PlanTemplate, error)
+	GetTemplate(ctx context.Context, id uuid.UUID) (PlanTemplate, bool, error)
+	// ListTemplates devuelve la versión vigente de cada protocolo; con code, todas sus versiones.
+	ListTemplates(ctx context.Context, code string) ([]PlanTemplate, error)
+	ExistsTemplateCode(ctx context.Context, code string) (bool, error)
+
+	// ListActive devuelve los planes activos con sus ítems.
+	ListActive(ctx context.Context) ([]ExercisePlan, error)
+	CreateAdherenceLog(ctx context.Context, l AdherenceLog) (AdherenceLog, error)
+	// ListAdherenceLogs devuelve los registros de los planes indicados (from/to opcionales, inclusive),
+	// ordenados por fecha.
+	ListAdherenceLogs(ctx context.Context, planIDs []uuid.UUID, from, to *time.Time) ([]AdherenceLog, error)
+}
+
+// CatalogExercise: valores por defecto de un ejercicio del catálogo.
+type CatalogExercise struct {
+	Name             string
+	Description      *string
+	VideoURL         *string
+	GuideURL         *string
+	Sets             *int
+	Reps             *int
+	EstimatedMinutes int
+	Active           bool
+}
+
+// Catalog resuelve ejercicios del catálogo (lo implementa el módulo de ejercicios).
+type Catalog interface {
+	Exercise(ctx context.Context, id uuid.UUID) (CatalogExercise, bool, error)
+}
+
+// ClinicalRecord valida referencias a la historia clínica del paciente.
+type ClinicalRecord interface {
+	DiagnosisBelongsTo(ctx context.Context, diagnosisID, patientID uuid.UUID) (bool, error)
+	InjuryBelongsTo(ctx context.Context, injuryID, patientID uuid.UUID) (bool, error)
 }
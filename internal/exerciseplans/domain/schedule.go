@@ -0,0 +1,221 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PlanPhase: etapa del plan en semanas (1 = la semana en que se creó el plan, ambas inclusive).
+type PlanPhase struct {
+	Name      string `json:"name"`
+	StartWeek int    `json:"start_week"`
+	EndWeek   int    `json:"end_week"`
+}
+
+// Progression: cuánto aumenta la dosis de un ejercicio cada EveryWeeks semanas,
+// contando desde la primera semana del ítem (la de su fase o la 1).
+type Progression struct {
+	EveryWeeks int      `json:"every_weeks"`
+	SetsStep   int      `json:"sets_step,omitempty"`
+	RepsStep   int      `json:"reps_step,omitempty"`
+	LoadStep   float64  `json:"load_step,omitempty"`
+	MaxSets    *int     `json:"max_sets,omitempty"`
+	MaxReps    *int     `json:"max_reps,omitempty"`
+	MaxLoad    *float64 `json:"max_load,omitempty"`
+}
+
+var weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+func ParseWeekday(s string) (time.Weekday, bool) {
+	for i, n := range weekdayNames {
+		if n == s {
+			return time.Weekday(i), true
+		}
+	}
+	return 0, false
+}
+
+func WeekdayName(d time.Weekday) string {
+	return weekdayNames[d]
+}
+
+// Phase busca una fase del plan por nombre.
+func (p ExercisePlan) Phase(name string) (PlanPhase, bool) {
+	for _, ph := range p.Phases {
+		if ph.Name == name {
+			return ph, true
+		}
+	}
+	return PlanPhase{}, false
+}
+
+// WeekOf: número de semana del plan (1..) en que cae una fecha del consultorio; 0 si es
+// anterior al inicio.
+func (p ExercisePlan) WeekOf(day time.Time) int {
+	days := int(dateOnly(day).Sub(p.StartDay()).Hours() / 24)
+	if days < 0 {
+		return 0
+	}
+	return days/7 + 1
+}
+
+// ItemWeeks: semanas en que el ítem está vigente (las de su fase o todo el plan).
+func (p ExercisePlan) ItemWeeks(it ExercisePlanItem) (int, int) {
+	if it.Phase != nil {
+		if ph, ok := p.Phase(*it.Phase); ok {
+			return ph.StartWeek, ph.EndWeek
+		}
+	}
+	return 1, p.DurationWeeks
+}
+
+// SessionsPerWeek: los días elegidos del ítem o, si no tiene, la frecuencia del plan.
+func (p ExercisePlan) SessionsPerWeek(it ExercisePlanItem) int {
+	switch {
+	case len(it.Weekdays) > 0:
+		return len(it.Weekdays)
+	case p.Frequency == FrequencyDaily:
+		return 7
+	default:
+		return 1
+	}
+}
+
+// ScheduledOn indica si el ítem toca ese día. Un ítem semanal sin días elegidos se puede hacer
+// cualquier día de la semana (flexible = true).
+func (p ExercisePlan) ScheduledOn(it ExercisePlanItem, day time.Time) (scheduled bool, flexible bool) {
+	week := p.WeekOf(day)
+	from, to := p.ItemWeeks(it)
+	if week < from || week > to {
+		return false, false
+	}
+	if len(it.Weekdays) == 0 {
+		return true, p.Frequency == FrequencyWeekly
+	}
+	wd := dateOnly(day).Weekday()
+	for _, d := range it.Weekdays {
+		if d == wd {
+			return true, false
+		}
+	}
+	return false, false
+}
+
+// sessionsUntil: sesiones del ítem esperadas en la semana del plan que contiene a day,
+// desde el inicio de esa semana hasta day inclusive.
+func (p ExercisePlan) sessionsUntil(it ExercisePlanItem, day time.Time) int {
+	week := p.WeekOf(day)
+	from, to := p.ItemWeeks(it)
+	if week < from || week > to {
+		return 0
+	}
+	if len(it.Weekdays) == 0 && p.Frequency == FrequencyWeekly {
+		return 1
+	}
+	start := p.StartDay().AddDate(0, 0, 7*(week-1))
+	n := 0
+	for d := start; !d.After(dateOnly(day)); d = d.AddDate(0, 0, 1) {
+		if ok, _ := p.ScheduledOn(it, d); ok {
+			n++
+		}
+	}
+	return n
+}
+
+// Dose: series, repeticiones y carga del ítem en una semana del plan, con la progresión aplicada.
+type Dose struct {
+	Sets *int
+	Reps *int
+	Load *float64
+}
+
+func (p ExercisePlan) DoseFor(it ExercisePlanItem, week int) Dose {
+	d := Dose{Sets: it.Sets, Reps: it.Reps, Load: it.Load}
+	pr := it.Progression
+	if pr == nil {
+		return d
+	}
+	from, _ := p.ItemWeeks(it)
+	every := pr.EveryWeeks
+	if every <= 0 {
+		every = 1
+	}
+	steps := 0
+	if week > from {
+		steps = (week - from) / every
+	}
+	if steps == 0 {
+		return d
+	}
+
+	if d.Sets != nil && pr.SetsStep != 0 {
+		v := capInt(*d.Sets+steps*pr.SetsStep, pr.MaxSets)
+		d.Sets = &v
+	}
+	if d.Reps != nil && pr.RepsStep != 0 {
+		v := capInt(*d.Reps+steps*pr.RepsStep, pr.MaxReps)
+		d.Reps = &v
+	}
+	if d.Load != nil && pr.LoadStep != 0 {
+		v := math.Round((*d.Load+float64(steps)*pr.LoadStep)*100) / 100
+		if pr.MaxLoad != nil && v > *pr.MaxLoad {
+			v = *pr.MaxLoad
+		}
+		d.Load = &v
+	}
+	return d
+}
+
+func capInt(v int, max *int) int {
+	if max != nil && v > *max {
+		return *max
+	}
+	return v
+}
+
+type PrescribedItem struct {
+	Item            ExercisePlanItem
+	Dose            Dose
+	SessionsPerWeek int
+	Flexible        bool // semanal sin días fijos: cualquier día de la semana
+}
+
+// Prescription: lo que el paciente tiene que hacer un día determinado.
+type Prescription struct {
+	PlanID uuid.UUID
+	Date   time.Time
+	Week   int
+	Phases []string
+	Items  []PrescribedItem
+}
+
+func (p ExercisePlan) PrescriptionFor(day time.Time) Prescription {
+	day = dateOnly(day)
+	out := Prescription{
+		PlanID: p.ID,
+		Date:   day,
+		Week:   p.WeekOf(day),
+		Phases: make([]string, 0, 1),
+		Items:  make([]PrescribedItem, 0, len(p.Items)),
+	}
+	for _, ph := range p.Phases {
+		if out.Week >= ph.StartWeek && out.Week <= ph.EndWeek {
+			out.Phases = append(out.Phases, ph.Name)
+		}
+	}
+	for _, it := range p.Items {
+		ok, flexible := p.ScheduledOn(it, day)
+		if !ok {
+			continue
+		}
+		out.Items = append(out.Items, PrescribedItem{
+			Item:            it,
+			Dose:            p.DoseFor(it, out.Week),
+			SessionsPerWeek: p.SessionsPerWeek(it),
+			Flexible:        flexible,
+		})
+	}
+	return out
+}
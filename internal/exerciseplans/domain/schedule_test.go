@@ -0,0 +1,207 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSessionsUntil(t *testing.T) {
+	daily := testItem("puente")
+	weekly := testItem("plancha")
+	monWedFri := testItem("remo")
+	monWedFri.Weekdays = []time.Weekday{time.Monday, time.Wednesday, time.Friday}
+	late := testItem("saltos")
+	late.Phase = ptr("b")
+
+	phases := []PlanPhase{{Name: "a", StartWeek: 1, EndWeek: 1}, {Name: "b", StartWeek: 2, EndWeek: 3}}
+
+	tests := []struct {
+		name string
+		freq Frequency
+		item ExercisePlanItem
+		day  time.Time
+		want int
+	}{
+		{name: "diario: primer día", freq: FrequencyDaily, item: daily, day: day(0), want: 1},
+		{name: "diario: último día de la semana", freq: FrequencyDaily, item: daily, day: day(6), want: 7},
+		{name: "diario: la semana 2 arranca de cero", freq: FrequencyDaily, item: daily, day: day(7), want: 1},
+		{name: "diario: hora del día no suma", freq: FrequencyDaily, item: daily, day: day(1).Add(23 * time.Hour), want: 2},
+		{name: "semanal sin días: una sola sesión", freq: FrequencyWeekly, item: weekly, day: day(0), want: 1},
+		{name: "semanal sin días: fin de semana", freq: FrequencyWeekly, item: weekly, day: day(13), want: 1},
+		{name: "días fijos: martes", freq: FrequencyWeekly, item: monWedFri, day: day(1), want: 1},
+		{name: "días fijos: viernes", freq: FrequencyWeekly, item: monWedFri, day: day(4), want: 3},
+		{name: "días fijos: domingo", freq: FrequencyDaily, item: monWedFri, day: day(6), want: 3},
+		{name: "antes del inicio", freq: FrequencyDaily, item: daily, day: day(-1), want: 0},
+		{name: "después del fin del plan", freq: FrequencyDaily, item: daily, day: day(21), want: 0},
+		{name: "fase todavía no empezó", freq: FrequencyDaily, item: late, day: day(6), want: 0},
+		{name: "primer día de la fase", freq: FrequencyDaily, item: late, day: day(7), want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := ExercisePlan{Frequency: tt.freq, DurationWeeks: 3, CreatedAt: planStart, Phases: phases}
+			if got := p.sessionsUntil(tt.item, tt.day); got != tt.want {
+				t.Fatalf("sessionsUntil = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoseFor(t *testing.T) {
+	base := func() ExercisePlanItem {
+		it := testItem("sentadillas")
+		it.Sets, it.Reps, it.Load = ptr(3), ptr(10), ptr(5.0)
+		return it
+	}
+	every2 := &Progression{EveryWeeks: 2, SetsStep: 1, RepsStep: 2, LoadStep: 1.25, MaxSets: ptr(5), MaxReps: ptr(14), MaxLoad: ptr(8.0)}
+
+	plain := base()
+	progressive := base()
+	progressive.Progression = every2
+	weekly := base()
+	weekly.Progression = &Progression{LoadStep: 0.333}
+	phased := base()
+	phased.Phase = ptr("b")
+	phased.Progression = every2
+	noBase := testItem("plancha")
+	noBase.Progression = every2
+
+	phases := []PlanPhase{{Name: "a", StartWeek: 1, EndWeek: 2}, {Name: "b", StartWeek: 3, EndWeek: 8}}
+
+	tests := []struct {
+		name     string
+		item     ExercisePlanItem
+		week     int
+		wantSets *int
+		wantReps *int
+		wantLoad *float64
+	}{
+		{name: "sin progresión", item: plain, week: 6, wantSets: ptr(3), wantReps: ptr(10), wantLoad: ptr(5.0)},
+		{name: "primera semana", item: progressive, week: 1, wantSets: ptr(3), wantReps: ptr(10), wantLoad: ptr(5.0)},
+		{name: "antes del primer escalón", item: progressive, week: 2, wantSets: ptr(3), wantReps: ptr(10), wantLoad: ptr(5.0)},
+		{name: "primer escalón", item: progressive, week: 3, wantSets: ptr(4), wantReps: ptr(12), wantLoad: ptr(6.25)},
+		{name: "tope de repeticiones", item: progressive, week: 5, wantSets: ptr(5), wantReps: ptr(14), wantLoad: ptr(7.5)},
+		{name: "todos los topes", item: progressive, week: 9, wantSets: ptr(5), wantReps: ptr(14), wantLoad: ptr(8.0)},
+		{name: "every_weeks 0 cuenta como 1 y redondea la carga", item: weekly, week: 4, wantSets: ptr(3), wantReps: ptr(10), wantLoad: ptr(6.0)},
+		{name: "la fase corre el inicio", item: phased, week: 4, wantSets: ptr(3), wantReps: ptr(10), wantLoad: ptr(5.0)},
+		{name: "escalón dentro de la fase", item: phased, week: 5, wantSets: ptr(4), wantReps: ptr(12), wantLoad: ptr(6.25)},
+		{name: "sin dosis base no inventa valores", item: noBase, week: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := ExercisePlan{Frequency: FrequencyDaily, DurationWeeks: 8, CreatedAt: planStart, Phases: phases}
+			got := p.DoseFor(tt.item, tt.week)
+			if !eqPtr(got.Sets, tt.wantSets) || !eqPtr(got.Reps, tt.wantReps) || !eqPtr(got.Load, tt.wantLoad) {
+				t.Fatalf("dose = %s/%s/%s, want %s/%s/%s",
+					show(got.Sets), show(got.Reps), show(got.Load), show(tt.wantSets), show(tt.wantReps), show(tt.wantLoad))
+			}
+		})
+	}
+
+	// La progresión no modifica el ítem original.
+	if *progressive.Sets != 3 || *progressive.Load != 5.0 {
+		t.Fatalf("item modified: sets=%d load=%v", *progressive.Sets, *progressive.Load)
+	}
+}
+
+func TestPrescriptionFor(t *testing.T) {
+	daily := testItem("puente")
+	monday := testItem("remo")
+	monday.Weekdays = []time.Weekday{time.Monday}
+	late := testItem("saltos")
+	late.Phase = ptr("b")
+
+	tests := []struct {
+		name         string
+		freq         Frequency
+		day          time.Time
+		wantWeek     int
+		wantPhases   []string
+		wantItems    []string
+		wantFlexible bool
+	}{
+		{name: "día anterior al inicio", freq: FrequencyDaily, day: day(-1), wantWeek: 0},
+		{name: "día de creación", freq: FrequencyDaily, day: planStart, wantWeek: 1, wantPhases: []string{"a"}, wantItems: []string{"puente", "remo"}},
+		{name: "día sin ejercicios de días fijos", freq: FrequencyDaily, day: day(1), wantWeek: 1, wantPhases: []string{"a"}, wantItems: []string{"puente"}},
+		{name: "cambio de fase", freq: FrequencyDaily, day: day(7), wantWeek: 2, wantPhases: []string{"b"}, wantItems: []string{"puente", "remo", "saltos"}},
+		{name: "último día del plan", freq: FrequencyDaily, day: day(13), wantWeek: 2, wantPhases: []string{"b"}, wantItems: []string{"puente", "saltos"}},
+		{name: "día en que vence el plan", freq: FrequencyDaily, day: day(14), wantWeek: 3},
+		{name: "semanal: sin días fijos es flexible", freq: FrequencyWeekly, day: day(2), wantWeek: 1, wantPhases: []string{"a"}, wantItems: []string{"puente"}, wantFlexible: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := ExercisePlan{
+				Frequency: tt.freq, DurationWeeks: 2, CreatedAt: planStart,
+				Phases: []PlanPhase{{Name: "a", StartWeek: 1, EndWeek: 1}, {Name: "b", StartWeek: 2, EndWeek: 2}},
+				Items:  []ExercisePlanItem{daily, monday, late},
+			}
+			got := p.PrescriptionFor(tt.day)
+			if got.Week != tt.wantWeek {
+				t.Fatalf("week = %d, want %d", got.Week, tt.wantWeek)
+			}
+			if !got.Date.Equal(dateOnly(tt.day)) {
+				t.Fatalf("date = %v, want %v", got.Date, dateOnly(tt.day))
+			}
+			if !sameStrings(got.Phases, tt.wantPhases) {
+				t.Fatalf("phases = %v, want %v", got.Phases, tt.wantPhases)
+			}
+			names := make([]string, 0, len(got.Items))
+			for _, it := range got.Items {
+				names = append(names, it.Item.Name)
+				if it.Item.Name == "puente" && it.Flexible != tt.wantFlexible {
+					t.Fatalf("flexible = %v, want %v", it.Flexible, tt.wantFlexible)
+				}
+			}
+			if !sameStrings(names, tt.wantItems) {
+				t.Fatalf("items = %v, want %v", names, tt.wantItems)
+			}
+		})
+	}
+}
+
+func TestPrescriptionFor_ClinicDay(t *testing.T) {
+	ba := time.FixedZone("ART", -3*60*60)
+	// Domingo 22:00 en el consultorio, ya lunes en UTC: la semana 1 arranca el domingo.
+	created := time.Date(2026, 3, 1, 22, 0, 0, 0, ba).UTC()
+	p := ExercisePlan{Frequency: FrequencyDaily, DurationWeeks: 1, CreatedAt: created, Items: []ExercisePlanItem{testItem("puente")}}.In(ba)
+
+	sunday := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	if got := p.PrescriptionFor(sunday); got.Week != 1 || len(got.Items) != 1 {
+		t.Fatalf("domingo: week=%d items=%d, want 1 1", got.Week, len(got.Items))
+	}
+	if got := p.PrescriptionFor(sunday.AddDate(0, 0, 7)); got.Week != 2 {
+		t.Fatalf("domingo siguiente: week=%d, want 2", got.Week)
+	}
+	if _, to := p.LogWindow(); !to.Equal(sunday.AddDate(0, 0, 6)) {
+		t.Fatalf("último día = %v, want %v", to, sunday.AddDate(0, 0, 6))
+	}
+}
+
+func eqPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func show[T any](v *T) string {
+	if v == nil {
+		return "nil"
+	}
+	return fmt.Sprint(*v)
+}
+
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PlanTemplate es un protocolo estándar (p. ej. "LCA semanas 0-2") dividido en fases.
+// Cada edición genera una versión nueva con el mismo Code: los planes ya asignados
+// apuntan a la versión con la que se crearon y no cambian.
+type PlanTemplate struct {
+	ID          uuid.UUID
+	Code        string
+	Version     int
+	IsLatest    bool
+	Name        string
+	Description *string
+	Frequency   Frequency
+	Phases      []TemplatePhase
+	CreatedAt   time.Time
+}
+
+type TemplatePhase struct {
+	Name          string         `json:"name"`
+	DurationWeeks int            `json:"duration_weeks"`
+	Items         []TemplateItem `json:"items"`
+}
+
+// TemplateItem guarda los valores ya resueltos (si viene del catálogo, se copian al crear la versión).
+type TemplateItem struct {
+	ExerciseID       *uuid.UUID   `json:"exercise_id,omitempty"`
+	Name             string       `json:"name"`
+	Description      *string      `json:"description,omitempty"`
+	VideoURL         *string      `json:"video_url,omitempty"`
+	GuideURL         *string      `json:"guide_url,omitempty"`
+	EstimatedMinutes int          `json:"estimated_minutes"`
+	Sets             *int         `json:"sets,omitempty"`
+	Reps             *int         `json:"reps,omitempty"`
+	Load             *float64     `json:"load,omitempty"`
+	LoadUnit         *string      `json:"load_unit,omitempty"`
+	Weekdays         []string     `json:"weekdays,omitempty"`
+	Progression      *Progression `json:"progression,omitempty"`
+}
@@ -0,0 +1,198 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/domain"
+	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/usecase"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+type AdherenceHandler struct {
+	logUC      *usecase.LogAdherenceUseCase
+	listLogsUC *usecase.ListAdherenceLogsUseCase
+	getUC      *usecase.GetPlanAdherenceUseCase
+	lowUC      *usecase.ListLowAdherenceUseCase
+}
+
+func NewAdherenceHandler(logUC *usecase.LogAdherenceUseCase, listLogsUC *usecase.ListAdherenceLogsUseCase,
+	getUC *usecase.GetPlanAdherenceUseCase, lowUC *usecase.ListLowAdherenceUseCase) *AdherenceHandler {
+	return &AdherenceHandler{logUC: logUC, listLogsUC: listLogsUC, getUC: getUC, lowUC: lowUC}
+}
+
+type adherenceLogResponse struct {
+	ID            string  `json:"id"`
+	PlanID        string  `json:"plan_id"`
+	ItemID        string  `json:"item_id"`
+	PatientID     string  `json:"patient_id"`
+	Date          string  `json:"date"`
+	SetsCompleted *int    `json:"sets_completed,omitempty"`
+	RepsCompleted *int    `json:"reps_completed,omitempty"`
+	Difficulty    *int    `json:"difficulty,omitempty"`
+	PainLevel     *int    `json:"pain_level,omitempty"`
+	Comment       *string `json:"comment,omitempty"`
+	ReportedBy    string  `json:"reported_by"`
+	CreatedAt     string  `json:"created_at"`
+}
+
+func toAdherenceLogResponse(l domain.AdherenceLog) adherenceLogResponse {
+	return adherenceLogResponse{
+		ID:            l.ID.String(),
+		PlanID:        l.PlanID.String(),
+		ItemID:        l.ItemID.String(),
+		PatientID:     l.PatientID.String(),
+		Date:          l.Date.Format("2006-01-02"),
+		SetsCompleted: l.SetsCompleted,
+		RepsCompleted: l.RepsCompleted,
+		Difficulty:    l.Difficulty,
+		PainLevel:     l.PainLevel,
+		Comment:       l.Comment,
+		ReportedBy:    l.ReportedBy,
+		CreatedAt:     l.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+type itemAdherenceResponse struct {
+	ItemID     string  `json:"item_id"`
+	Name       string  `json:"name"`
+	Expected   int     `json:"expected"`
+	Completed  int     `json:"completed"`
+	Percentage float64 `json:"percentage"`
+}
+
+type adherenceResponse struct {
+	PlanID      string                  `json:"plan_id"`
+	PatientID   string                  `json:"patient_id"`
+	Expected    int                     `json:"expected"`
+	Completed   int                     `json:"completed"`
+	Percentage  float64                 `json:"percentage"`
+	LastLogDate *string                 `json:"last_log_date,omitempty"`
+	Items       []itemAdherenceResponse `json:"items"`
+}
+
+func toAdherenceResponse(a domain.Adherence) adherenceResponse {
+	out := adherenceResponse{
+		PlanID:     a.PlanID.String(),
+		PatientID:  a.PatientID.String(),
+		Expected:   a.Expected,
+		Completed:  a.Completed,
+		Percentage: a.Percentage,
+		Items:      make([]itemAdherenceResponse, 0, len(a.Items)),
+	}
+	if a.LastLogDate != nil {
+		s := a.LastLogDate.Format("2006-01-02")
+		out.LastLogDate = &s
+	}
+	for _, it := range a.Items {
+		out.Items = append(out.Items, itemAdherenceResponse{
+			ItemID:     it.ItemID.String(),
+			Name:       it.Name,
+			Expected:   it.Expected,
+			Completed:  it.Completed,
+			Percentage: it.Percentage,
+		})
+	}
+	return out
+}
+
+// CreateLog: POST /exercise-plans/:plan_id/adherence-logs
+// Lo usa el paciente o recepción en su nombre (reported_by).
+func (h *AdherenceHandler) CreateLog(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "plan_id")
+	if !ok {
+		return
+	}
+	var req usecase.LogAdherenceInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	out, validation, err := h.logUC.Execute(c.Request.Context(), id, req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusCreated, toAdherenceLogResponse(out))
+}
+
+// ListLogs: GET /exercise-plans/:plan_id/adherence-logs?from=&to=
+func (h *AdherenceHandler) ListLogs(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "plan_id")
+	if !ok {
+		return
+	}
+
+	out, validation, err := h.listLogsUC.Execute(c.Request.Context(), id, queryPtr(c, "from"), queryPtr(c, "to"))
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	items := make([]adherenceLogResponse, 0, len(out))
+	for _, l := range out {
+		items = append(items, toAdherenceLogResponse(l))
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// Get: GET /exercise-plans/:plan_id/adherence
+func (h *AdherenceHandler) Get(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "plan_id")
+	if !ok {
+		return
+	}
+
+	out, err := h.getUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		writeError(c, err, nil)
+		return
+	}
+	c.JSON(http.StatusOK, toAdherenceResponse(out))
+}
+
+// ListLow: GET /exercise-plans/low-adherence?threshold=50&min_days=7
+func (h *AdherenceHandler) ListLow(c *gin.Context) {
+	var in usecase.LowAdherenceInput
+	validation := validation.New()
+	if s := queryPtr(c, "threshold"); s != nil {
+		v, err := strconv.ParseFloat(*s, 64)
+		if err != nil {
+			validation.Add("threshold", "must_be_number")
+		}
+		in.Threshold = &v
+	}
+	if s := queryPtr(c, "min_days"); s != nil {
+		v, err := strconv.Atoi(*s)
+		if err != nil {
+			validation.Add("min_days", "must_be_integer")
+		}
+		in.MinDays = &v
+	}
+	if !validation.Empty() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+		return
+	}
+
+	out, validation, err := h.lowUC.Execute(c.Request.Context(), in)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	items := make([]adherenceResponse, 0, len(out))
+	for _, a := range out {
+		items = append(items, toAdherenceResponse(a))
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+func queryPtr(c *gin.Context, key string) *string {
+	v, ok := c.GetQuery(key)
+	if !ok {
+		return nil
+	}
+	return &v
+}
@@ -15,17 +15,32 @@ type Handler struct {
 	createUC *usecase.CreatePlanUseCase
 	listUC   *usecase.ListPlansByPatientUseCase
 	getUC    *usecase.GetPlanByIDUseCase
+
+	updateUC  *usecase.UpdatePlanUseCase
+	closeUC   *usecase.ClosePlanUseCase
+	renewUC   *usecase.RenewPlanUseCase
+	cloneUC   *usecase.ClonePlanUseCase
+	addItemUC *usecase.AddPlanItemUseCase
+	removeUC  *usecase.RemovePlanItemUseCase
+	reorderUC *usecase.ReorderPlanItemsUseCase
 }
 
-func NewHandler(createUC *usecase.CreatePlanUseCase, listUC *usecase.ListPlansByPatientUseCase, getUC *usecase.GetPlanByIDUseCase) *Handler {
-	return &Handler{createUC: createUC, listUC: listUC, getUC: getUC}
+func NewHandler(createUC *usecase.CreatePlanUseCase, listUC *usecase.ListPlansByPatientUseCase, getUC *usecase.GetPlanByIDUseCase,
+	updateUC *usecase.UpdatePlanUseCase, closeUC *usecase.ClosePlanUseCase, renewUC *usecase.RenewPlanUseCase, cloneUC *usecase.ClonePlanUseCase,
+	addItemUC *usecase.AddPlanItemUseCase, removeUC *usecase.RemovePlanItemUseCase, reorderUC *usecase.ReorderPlanItemsUseCase) *Handler {
+	return &Handler{createUC: createUC, listUC: listUC, getUC: getUC,
+		updateUC: updateUC, closeUC: closeUC, renewUC: renewUC, cloneUC: cloneUC,
+		addItemUC: addItemUC, removeUC: removeUC, reorderUC: reorderUC}
 }
 
 type createPlanRequest struct {
 	KinesiologistID string                        `json:"kinesiologist_id"`
+	DiagnosisID     *string                       `json:"diagnosis_id"`
+	InjuryID        *string                       `json:"injury_id"`
 	Frequency       string                        `json:"frequency"`
 	DurationWeeks   int                           `json:"duration_weeks"`
 	Observations    *string                       `json:"observations"`
+	Phases          []domain.PlanPhase            `json:"phases"`
 	Items           []usecase.CreatePlanItemInput `json:"items"`
 }
 
@@ -41,9 +56,12 @@ func (h *Handler) CreateForPatient(c *gin.Context) {
 	out, validation, err := h.createUC.Execute(c.Request.Context(), usecase.CreatePlanInput{
 		PatientID:       patientID,
 		KinesiologistID: req.KinesiologistID,
+		DiagnosisID:     req.DiagnosisID,
+		InjuryID:        req.InjuryID,
 		Frequency:       req.Frequency,
 		DurationWeeks:   req.DurationWeeks,
 		Observations:    req.Observations,
+		Phases:          req.Phases,
 		Items:           req.Items,
 	})
 	if err != nil {
@@ -0,0 +1,188 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/domain"
+	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/usecase"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+type closePlanRequest struct {
+	Reason string `json:"reason"`
+}
+
+type renewPlanRequest struct {
+	AdditionalWeeks int `json:"additional_weeks"`
+}
+
+type reorderItemsRequest struct {
+	ItemIDs []string `json:"item_ids"`
+}
+
+// Update: PATCH /exercise-plans/:plan_id
+func (h *Handler) Update(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "plan_id")
+	if !ok {
+		return
+	}
+	var req usecase.UpdatePlanInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	out, validation, err := h.updateUC.Execute(c.Request.Context(), id, req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusOK, toResponse(out))
+}
+
+// Close: POST /exercise-plans/:plan_id/close
+func (h *Handler) Close(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "plan_id")
+	if !ok {
+		return
+	}
+	var req closePlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	out, validation, err := h.closeUC.Execute(c.Request.Context(), id, req.Reason)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusOK, toResponse(out))
+}
+
+// Renew: POST /exercise-plans/:plan_id/renew
+func (h *Handler) Renew(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "plan_id")
+	if !ok {
+		return
+	}
+	var req renewPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	out, validation, err := h.renewUC.Execute(c.Request.Context(), id, req.AdditionalWeeks)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusOK, toResponse(out))
+}
+
+// Clone: POST /exercise-plans/:plan_id/clone (crea la versión siguiente).
+func (h *Handler) Clone(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "plan_id")
+	if !ok {
+		return
+	}
+	var req usecase.ClonePlanInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+			return
+		}
+	}
+
+	out, validation, err := h.cloneUC.Execute(c.Request.Context(), id, req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusCreated, toResponse(out))
+}
+
+// AddItem: POST /exercise-plans/:plan_id/items
+func (h *Handler) AddItem(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "plan_id")
+	if !ok {
+		return
+	}
+	var req usecase.AddPlanItemInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	out, validation, err := h.addItemUC.Execute(c.Request.Context(), id, req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusOK, toResponse(out))
+}
+
+// RemoveItem: DELETE /exercise-plans/:plan_id/items/:item_id
+func (h *Handler) RemoveItem(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "plan_id")
+	if !ok {
+		return
+	}
+	itemID, ok := parseUUIDParam(c, "item_id")
+	if !ok {
+		return
+	}
+
+	out, validation, err := h.removeUC.Execute(c.Request.Context(), id, itemID)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusOK, toResponse(out))
+}
+
+// ReorderItems: PUT /exercise-plans/:plan_id/items/order
+func (h *Handler) ReorderItems(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "plan_id")
+	if !ok {
+		return
+	}
+	var req reorderItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	out, validation, err := h.reorderUC.Execute(c.Request.Context(), id, req.ItemIDs)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusOK, toResponse(out))
+}
+
+func parseUUIDParam(c *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_" + name})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func writeError(c *gin.Context, err error, validation *validation.Errors) {
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+	case errors.Is(err, domain.ErrPlanClosed):
+		c.JSON(http.StatusConflict, gin.H{"error": "plan_closed"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+	}
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/usecase"
+)
+
+type PDFHandler struct {
+	exportUC *usecase.ExportPlanPDFUseCase
+}
+
+func NewPDFHandler(exportUC *usecase.ExportPlanPDFUseCase) *PDFHandler {
+	return &PDFHandler{exportUC: exportUC}
+}
+
+// Export: GET /exercise-plans/:plan_id/pdf
+// ?download=true lo baja como adjunto; por defecto se abre en el navegador.
+func (h *PDFHandler) Export(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "plan_id")
+	if !ok {
+		return
+	}
+
+	out, p, err := h.exportUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		writeError(c, err, nil)
+		return
+	}
+
+	disposition := "inline"
+	if c.Query("download") == "true" {
+		disposition = "attachment"
+	}
+	name := fmt.Sprintf("plan-ejercicios-%s.pdf", p.CreatedAt.Format("20060102"))
+	c.Header("Content-Disposition", disposition+`; filename="`+name+`"`)
+	c.Data(http.StatusOK, "application/pdf", out)
+}
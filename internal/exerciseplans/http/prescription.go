@@ -0,0 +1,72 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/domain"
+	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/usecase"
+)
+
+type PrescriptionHandler struct {
+	getUC *usecase.GetPrescriptionUseCase
+}
+
+func NewPrescriptionHandler(getUC *usecase.GetPrescriptionUseCase) *PrescriptionHandler {
+	return &PrescriptionHandler{getUC: getUC}
+}
+
+type prescribedItemResponse struct {
+	planItemResponse
+	// Dosis de la semana (con la progresión aplicada)
+	Sets            *int     `json:"sets,omitempty"`
+	Reps            *int     `json:"reps,omitempty"`
+	Load            *float64 `json:"load,omitempty"`
+	SessionsPerWeek int      `json:"sessions_per_week"`
+	Flexible        bool     `json:"flexible"`
+}
+
+type prescriptionResponse struct {
+	PlanID string                   `json:"plan_id"`
+	Date   string                   `json:"date"`
+	Week   int                      `json:"week"`
+	Phases []string                 `json:"phases"`
+	Items  []prescribedItemResponse `json:"items"`
+}
+
+func toPrescriptionResponse(p domain.Prescription) prescriptionResponse {
+	out := prescriptionResponse{
+		PlanID: p.PlanID.String(),
+		Date:   p.Date.Format("2006-01-02"),
+		Week:   p.Week,
+		Phases: p.Phases,
+		Items:  make([]prescribedItemResponse, 0, len(p.Items)),
+	}
+	for _, it := range p.Items {
+		out.Items = append(out.Items, prescribedItemResponse{
+			planItemResponse: toItemResponse(it.Item),
+			Sets:             it.Dose.Sets,
+			Reps:             it.Dose.Reps,
+			Load:             it.Dose.Load,
+			SessionsPerWeek:  it.SessionsPerWeek,
+			Flexible:         it.Flexible,
+		})
+	}
+	return out
+}
+
+// Get: GET /exercise-plans/:plan_id/prescription?date=YYYY-MM-DD (hoy por defecto)
+func (h *PrescriptionHandler) Get(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "plan_id")
+	if !ok {
+		return
+	}
+
+	out, validation, err := h.getUC.Execute(c.Request.Context(), id, queryPtr(c, "date"))
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusOK, toPrescriptionResponse(out))
+}
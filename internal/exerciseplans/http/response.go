@@ -3,28 +3,46 @@ package http
 import (
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/domain"
 )
 
 type planItemResponse struct {
-	ID               string  `json:"id"`
-	Name             string  `json:"name"`
-	Description      *string `json:"description,omitempty"`
-	VideoURL         *string `json:"video_url,omitempty"`
-	GuideURL         *string `json:"guide_url,omitempty"`
-	EstimatedMinutes int     `json:"estimated_minutes"`
-	Sets             *int    `json:"sets,omitempty"`
-	Reps             *int    `json:"reps,omitempty"`
+	ID               string              `json:"id"`
+	ExerciseID       *string             `json:"exercise_id,omitempty"`
+	Name             string              `json:"name"`
+	Description      *string             `json:"description,omitempty"`
+	VideoURL         *string             `json:"video_url,omitempty"`
+	GuideURL         *string             `json:"guide_url,omitempty"`
+	EstimatedMinutes int                 `json:"estimated_minutes"`
+	Sets             *int                `json:"sets,omitempty"`
+	Reps             *int                `json:"reps,omitempty"`
+	Load             *float64            `json:"load,omitempty"`
+	LoadUnit         *string             `json:"load_unit,omitempty"`
+	Weekdays         []string            `json:"weekdays,omitempty"`
+	Phase            *string             `json:"phase,omitempty"`
+	Progression      *domain.Progression `json:"progression,omitempty"`
+	Position         int                 `json:"position"`
 }
 
 type planResponse struct {
 	ID              string             `json:"id"`
 	PatientID       string             `json:"patient_id"`
 	KinesiologistID string             `json:"kinesiologist_id"`
+	DiagnosisID     *string            `json:"diagnosis_id,omitempty"`
+	InjuryID        *string            `json:"injury_id,omitempty"`
 	Frequency       string             `json:"frequency"`
 	DurationWeeks   int                `json:"duration_weeks"`
 	Observations    *string            `json:"observations,omitempty"`
 	Status          string             `json:"status"`
+	EndsAt          string             `json:"ends_at"`
+	ClosedAt        *string            `json:"closed_at,omitempty"`
+	CloseReason     *string            `json:"close_reason,omitempty"`
+	PreviousPlanID  *string            `json:"previous_plan_id,omitempty"`
+	Version         int                `json:"version"`
+	TemplateID      *string            `json:"template_id,omitempty"`
+	Phases          []domain.PlanPhase `json:"phases"`
 	Items           []planItemResponse `json:"items"`
 	CreatedAt       string             `json:"created_at"`
 	UpdatedAt       string             `json:"updated_at"`
@@ -33,27 +51,67 @@ type planResponse struct {
 func toResponse(p domain.ExercisePlan) planResponse {
 	items := make([]planItemResponse, 0, len(p.Items))
 	for _, it := range p.Items {
-		items = append(items, planItemResponse{
-			ID:               it.ID.String(),
-			Name:             it.Name,
-			Description:      it.Description,
-			VideoURL:         it.VideoURL,
-			GuideURL:         it.GuideURL,
-			EstimatedMinutes: it.EstimatedMinutes,
-			Sets:             it.Sets,
-			Reps:             it.Reps,
-		})
+		items = append(items, toItemResponse(it))
+	}
+	var closedAt *string
+	if p.ClosedAt != nil {
+		s := p.ClosedAt.UTC().Format(time.RFC3339)
+		closedAt = &s
+	}
+	phases := p.Phases
+	if phases == nil {
+		phases = []domain.PlanPhase{}
 	}
 	return planResponse{
 		ID:              p.ID.String(),
 		PatientID:       p.PatientID.String(),
 		KinesiologistID: p.KinesiologistID.String(),
+		DiagnosisID:     uuidPtrToString(p.DiagnosisID),
+		InjuryID:        uuidPtrToString(p.InjuryID),
 		Frequency:       string(p.Frequency),
 		DurationWeeks:   p.DurationWeeks,
 		Observations:    p.Observations,
 		Status:          string(p.Status),
+		EndsAt:          p.EndsAt().UTC().Format(time.RFC3339),
+		ClosedAt:        closedAt,
+		CloseReason:     p.CloseReason,
+		PreviousPlanID:  uuidPtrToString(p.PreviousPlanID),
+		Version:         p.Version,
+		TemplateID:      uuidPtrToString(p.TemplateID),
+		Phases:          phases,
 		Items:           items,
 		CreatedAt:       p.CreatedAt.UTC().Format(time.RFC3339),
 		UpdatedAt:       p.UpdatedAt.UTC().Format(time.RFC3339),
 	}
 }
+
+func toItemResponse(it domain.ExercisePlanItem) planItemResponse {
+	out := planItemResponse{
+		ID:               it.ID.String(),
+		ExerciseID:       uuidPtrToString(it.ExerciseID),
+		Name:             it.Name,
+		Description:      it.Description,
+		VideoURL:         it.VideoURL,
+		GuideURL:         it.GuideURL,
+		EstimatedMinutes: it.EstimatedMinutes,
+		Sets:             it.Sets,
+		Reps:             it.Reps,
+		Load:             it.Load,
+		LoadUnit:         it.LoadUnit,
+		Phase:            it.Phase,
+		Progression:      it.Progression,
+		Position:         it.Position,
+	}
+	for _, d := range it.Weekdays {
+		out.Weekdays = append(out.Weekdays, domain.WeekdayName(d))
+	}
+	return out
+}
+
+func uuidPtrToString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
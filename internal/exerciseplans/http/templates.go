@@ -0,0 +1,157 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/domain"
+	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/usecase"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+type TemplateHandler struct {
+	createUC *usecase.CreatePlanTemplateUseCase
+	updateUC *usecase.UpdatePlanTemplateUseCase
+	listUC   *usecase.ListPlanTemplatesUseCase
+	getUC    *usecase.GetPlanTemplateUseCase
+	applyUC  *usecase.ApplyPlanTemplateUseCase
+}
+
+func NewTemplateHandler(createUC *usecase.CreatePlanTemplateUseCase, updateUC *usecase.UpdatePlanTemplateUseCase,
+	listUC *usecase.ListPlanTemplatesUseCase, getUC *usecase.GetPlanTemplateUseCase, applyUC *usecase.ApplyPlanTemplateUseCase) *TemplateHandler {
+	return &TemplateHandler{createUC: createUC, updateUC: updateUC, listUC: listUC, getUC: getUC, applyUC: applyUC}
+}
+
+type templateResponse struct {
+	ID          string                 `json:"id"`
+	Code        string                 `json:"code"`
+	Version     int                    `json:"version"`
+	IsLatest    bool                   `json:"is_latest"`
+	Name        string                 `json:"name"`
+	Description *string                `json:"description,omitempty"`
+	Frequency   string                 `json:"frequency"`
+	Phases      []domain.TemplatePhase `json:"phases"`
+	CreatedAt   string                 `json:"created_at"`
+}
+
+func toTemplateResponse(t domain.PlanTemplate) templateResponse {
+	return templateResponse{
+		ID:          t.ID.String(),
+		Code:        t.Code,
+		Version:     t.Version,
+		IsLatest:    t.IsLatest,
+		Name:        t.Name,
+		Description: t.Description,
+		Frequency:   string(t.Frequency),
+		Phases:      t.Phases,
+		CreatedAt:   t.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// Create: POST /exercise-plan-templates
+func (h *TemplateHandler) Create(c *gin.Context) {
+	var req usecase.PlanTemplateInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	out, validation, err := h.createUC.Execute(c.Request.Context(), req)
+	if err != nil {
+		writeTemplateError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusCreated, toTemplateResponse(out))
+}
+
+// Update: PUT /exercise-plan-templates/:template_id
+// Crea una nueva versión; los planes ya asignados siguen apuntando a la anterior.
+func (h *TemplateHandler) Update(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "template_id")
+	if !ok {
+		return
+	}
+	var req usecase.PlanTemplateInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	out, validation, err := h.updateUC.Execute(c.Request.Context(), id, req)
+	if err != nil {
+		writeTemplateError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusCreated, toTemplateResponse(out))
+}
+
+// List: GET /exercise-plan-templates?code=
+func (h *TemplateHandler) List(c *gin.Context) {
+	out, err := h.listUC.Execute(c.Request.Context(), c.Query("code"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+	items := make([]templateResponse, 0, len(out))
+	for _, t := range out {
+		items = append(items, toTemplateResponse(t))
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// GetByID: GET /exercise-plan-templates/:template_id
+func (h *TemplateHandler) GetByID(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "template_id")
+	if !ok {
+		return
+	}
+	out, found, err := h.getUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+		return
+	}
+	c.JSON(http.StatusOK, toTemplateResponse(out))
+}
+
+type applyTemplateRequest struct {
+	TemplateID string `json:"template_id"`
+	usecase.ApplyPlanTemplateInput
+}
+
+// ApplyToPatient: POST /patients/:patient_id/exercise-plans/from-template
+func (h *TemplateHandler) ApplyToPatient(c *gin.Context) {
+	var req applyTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+	templateID, err := uuid.Parse(req.TemplateID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation.Field("template_id", "invalid_uuid")})
+		return
+	}
+	req.ApplyPlanTemplateInput.PatientID = c.Param("patient_id")
+
+	out, validation, err := h.applyUC.Execute(c.Request.Context(), templateID, req.ApplyPlanTemplateInput)
+	if err != nil {
+		writeTemplateError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusCreated, toResponse(out))
+}
+
+func writeTemplateError(c *gin.Context, err error, validation *validation.Errors) {
+	if errors.Is(err, domain.ErrTemplateOutdated) {
+		c.JSON(http.StatusConflict, gin.H{"error": "template_outdated"})
+		return
+	}
+	writeError(c, err, validation)
+}
@@ -0,0 +1,44 @@
+package directory
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/domain"
+	kinesioPorts "github.com/javiacuna/kinesio-backend/internal/kinesiologists/ports"
+	patientPorts "github.com/javiacuna/kinesio-backend/internal/patients/ports"
+)
+
+var _ domain.Directory = (*Gateway)(nil)
+
+// Gateway lee pacientes y kinesiólogos desde sus repos.
+type Gateway struct {
+	patients       patientPorts.Repository
+	kinesiologists kinesioPorts.Repository
+}
+
+func NewGateway(patients patientPorts.Repository, kinesiologists kinesioPorts.Repository) *Gateway {
+	return &Gateway{patients: patients, kinesiologists: kinesiologists}
+}
+
+func (g *Gateway) Patient(ctx context.Context, id uuid.UUID) (domain.Person, bool, error) {
+	p, found, err := g.patients.GetByID(ctx, id.String())
+	if err != nil || !found {
+		return domain.Person{}, false, err
+	}
+	return domain.Person{FullName: fullName(p.FirstName, p.LastName)}, true, nil
+}
+
+func (g *Gateway) Kinesiologist(ctx context.Context, id uuid.UUID) (domain.Person, bool, error) {
+	k, found, err := g.kinesiologists.GetByID(ctx, id)
+	if err != nil || !found {
+		return domain.Person{}, false, err
+	}
+	return domain.Person{FullName: fullName(k.FirstName, k.LastName), LicenseNumber: k.LicenseNumber}, true, nil
+}
+
+func fullName(first, last string) string {
+	return strings.TrimSpace(strings.TrimSpace(first) + " " + strings.TrimSpace(last))
+}
@@ -0,0 +1,38 @@
+package exercises
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/domain"
+	exDomain "github.com/javiacuna/kinesio-backend/internal/exercises/domain"
+)
+
+var _ domain.Catalog = (*Gateway)(nil)
+
+// Gateway lee los ejercicios desde el repo del catálogo.
+type Gateway struct {
+	repo exDomain.Repository
+}
+
+func NewGateway(repo exDomain.Repository) *Gateway {
+	return &Gateway{repo: repo}
+}
+
+func (g *Gateway) Exercise(ctx context.Context, id uuid.UUID) (domain.CatalogExercise, bool, error) {
+	e, found, err := g.repo.GetByID(ctx, id)
+	if err != nil || !found {
+		return domain.CatalogExercise{}, false, err
+	}
+	return domain.CatalogExercise{
+		Name:             e.Name,
+		Description:      e.Description,
+		VideoURL:         e.VideoURL,
+		GuideURL:         e.GuideURL,
+		Sets:             e.DefaultSets,
+		Reps:             e.DefaultReps,
+		EstimatedMinutes: e.DefaultMinutes,
+		Active:           e.Active,
+	}, true, nil
+}
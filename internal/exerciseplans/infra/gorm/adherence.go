@@ -0,0 +1,105 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/domain"
+)
+
+type AdherenceLogModel struct {
+	ID            string    `gorm:"type:uuid;primaryKey"`
+	PlanID        string    `gorm:"type:uuid;not null"`
+	ItemID        string    `gorm:"type:uuid;not null"`
+	PatientID     string    `gorm:"type:uuid;not null"`
+	Date          time.Time `gorm:"type:date;not null"`
+	SetsCompleted *int
+	RepsCompleted *int
+	Difficulty    *int
+	PainLevel     *int
+	Comment       *string
+	ReportedBy    string `gorm:"not null"`
+	CreatedAt     time.Time
+}
+
+func (AdherenceLogModel) TableName() string { return "exercise_adherence_logs" }
+
+func (r *Repository) ListActive(ctx context.Context) ([]domain.ExercisePlan, error) {
+	var ms []ExercisePlanModel
+	if err := r.db.WithContext(ctx).
+		Preload("Items", orderItems).
+		Order("created_at asc").
+		Find(&ms, "status = ?", string(domain.PlanActive)).
+		Error; err != nil {
+		return nil, err
+	}
+	out := make([]domain.ExercisePlan, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, toDomain(m))
+	}
+	return out, nil
+}
+
+func (r *Repository) CreateAdherenceLog(ctx context.Context, l domain.AdherenceLog) (domain.AdherenceLog, error) {
+	m := AdherenceLogModel{
+		ID:            l.ID.String(),
+		PlanID:        l.PlanID.String(),
+		ItemID:        l.ItemID.String(),
+		PatientID:     l.PatientID.String(),
+		Date:          l.Date,
+		SetsCompleted: l.SetsCompleted,
+		RepsCompleted: l.RepsCompleted,
+		Difficulty:    l.Difficulty,
+		PainLevel:     l.PainLevel,
+		Comment:       l.Comment,
+		ReportedBy:    l.ReportedBy,
+		CreatedAt:     l.CreatedAt,
+	}
+	if err := r.db.WithContext(ctx).Create(&m).Error; err != nil {
+		return domain.AdherenceLog{}, err
+	}
+	return l, nil
+}
+
+func (r *Repository) ListAdherenceLogs(ctx context.Context, planIDs []uuid.UUID, from, to *time.Time) ([]domain.AdherenceLog, error) {
+	if len(planIDs) == 0 {
+		return []domain.AdherenceLog{}, nil
+	}
+	ids := make([]string, 0, len(planIDs))
+	for _, id := range planIDs {
+		ids = append(ids, id.String())
+	}
+
+	q := r.db.WithContext(ctx).Where("plan_id IN ?", ids)
+	if from != nil {
+		q = q.Where("date >= ?", from.Format("2006-01-02"))
+	}
+	if to != nil {
+		q = q.Where("date <= ?", to.Format("2006-01-02"))
+	}
+
+	var ms []AdherenceLogModel
+	if err := q.Order("date asc, created_at asc").Find(&ms).Error; err != nil {
+		return nil, err
+	}
+	out := make([]domain.AdherenceLog, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, domain.AdherenceLog{
+			ID:            uuid.MustParse(m.ID),
+			PlanID:        uuid.MustParse(m.PlanID),
+			ItemID:        uuid.MustParse(m.ItemID),
+			PatientID:     uuid.MustParse(m.PatientID),
+			Date:          m.Date.UTC(),
+			SetsCompleted: m.SetsCompleted,
+			RepsCompleted: m.RepsCompleted,
+			Difficulty:    m.Difficulty,
+			PainLevel:     m.PainLevel,
+			Comment:       m.Comment,
+			ReportedBy:    m.ReportedBy,
+			CreatedAt:     m.CreatedAt,
+		})
+	}
+	return out, nil
+}
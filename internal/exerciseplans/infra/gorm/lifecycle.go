@@ -0,0 +1,79 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/domain"
+)
+
+func (r *Repository) Update(ctx context.Context, p domain.ExercisePlan) (domain.ExercisePlan, error) {
+	m := toModel(p)
+	if err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return saveWithItems(tx, m)
+	}); err != nil {
+		return domain.ExercisePlan{}, err
+	}
+
+	out, _, err := r.GetByID(ctx, p.ID)
+	return out, err
+}
+
+func (r *Repository) CreateVersion(ctx context.Context, previous, next domain.ExercisePlan) (domain.ExercisePlan, error) {
+	prev := toModel(previous)
+	m := toModel(next)
+	if err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := saveWithItems(tx, prev); err != nil {
+			return err
+		}
+		return tx.Create(&m).Error
+	}); err != nil {
+		return domain.ExercisePlan{}, err
+	}
+
+	out, _, err := r.GetByID(ctx, next.ID)
+	return out, err
+}
+
+func (r *Repository) CloseExpired(ctx context.Context, now time.Time, reason string) (int64, error) {
+	res := r.db.WithContext(ctx).Model(&ExercisePlanModel{}).
+		Where("status = ? AND created_at + make_interval(weeks => duration_weeks) <= ?", string(domain.PlanActive), now).
+		Updates(map[string]any{
+			"status":       string(domain.PlanClosed),
+			"closed_at":    now,
+			"close_reason": reason,
+			"updated_at":   now,
+		})
+	return res.RowsAffected, res.Error
+}
+
+// saveWithItems guarda la cabecera y sincroniza los ítems: upsert de los que vienen y
+// baja de los que ya no están en el plan.
+func saveWithItems(tx *gorm.DB, m ExercisePlanModel) error {
+	items := m.Items
+	m.Items = nil
+	if err := tx.Omit("Items").Save(&m).Error; err != nil {
+		return err
+	}
+
+	keep := make([]string, 0, len(items))
+	for _, it := range items {
+		keep = append(keep, it.ID)
+	}
+	del := tx.Where("plan_id = ?", m.ID)
+	if len(keep) > 0 {
+		del = del.Where("id NOT IN ?", keep)
+	}
+	if err := del.Delete(&ExercisePlanItemModel{}).Error; err != nil {
+		return err
+	}
+
+	for i := range items {
+		if err := tx.Save(&items[i]).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -3,13 +3,21 @@ package gorm
 import "time"
 
 type ExercisePlanModel struct {
-	ID              string `gorm:"type:uuid;primaryKey"`
-	PatientID       string `gorm:"type:uuid;not null"`
-	KinesiologistID string `gorm:"type:uuid;not null"`
-	Frequency       string `gorm:"not null"`
-	DurationWeeks   int    `gorm:"not null"`
+	ID              string  `gorm:"type:uuid;primaryKey"`
+	PatientID       string  `gorm:"type:uuid;not null"`
+	KinesiologistID string  `gorm:"type:uuid;not null"`
+	DiagnosisID     *string `gorm:"type:uuid"`
+	InjuryID        *string `gorm:"type:uuid"`
+	Frequency       string  `gorm:"not null"`
+	DurationWeeks   int     `gorm:"not null"`
 	Observations    *string
 	Status          string `gorm:"not null"`
+	ClosedAt        *time.Time
+	CloseReason     *string
+	PreviousPlanID  *string `gorm:"type:uuid"`
+	Version         int     `gorm:"not null"`
+	TemplateID      *string `gorm:"type:uuid"`
+	Phases          string  `gorm:"type:jsonb;not null"`
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 
@@ -19,15 +27,22 @@ type ExercisePlanModel struct {
 func (ExercisePlanModel) TableName() string { return "exercise_plans" }
 
 type ExercisePlanItemModel struct {
-	ID               string `gorm:"type:uuid;primaryKey"`
-	PlanID           string `gorm:"type:uuid;not null"`
-	Name             string `gorm:"not null"`
+	ID               string  `gorm:"type:uuid;primaryKey"`
+	PlanID           string  `gorm:"type:uuid;not null"`
+	ExerciseID       *string `gorm:"type:uuid"`
+	Name             string  `gorm:"not null"`
 	Description      *string
 	VideoURL         *string
 	GuideURL         *string
 	EstimatedMinutes int `gorm:"not null"`
 	Sets             *int
 	Reps             *int
+	Load             *float64
+	LoadUnit         *string
+	Weekdays         string `gorm:"type:jsonb;not null"`
+	Phase            *string
+	Progression      *string `gorm:"type:jsonb"`
+	Position         int     `gorm:"not null"`
 	CreatedAt        time.Time
 	UpdatedAt        time.Time
 }
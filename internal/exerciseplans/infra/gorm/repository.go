@@ -35,7 +35,7 @@ func (r *Repository) Create(ctx context.Context, p domain.ExercisePlan) (domain.
 func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (domain.ExercisePlan, bool, error) {
 	var m ExercisePlanModel
 	err := r.db.WithContext(ctx).
-		Preload("Items").
+		Preload("Items", orderItems).
 		First(&m, "id = ?", id.String()).
 		Error
 	if err != nil {
@@ -50,7 +50,7 @@ func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (domain.Exercise
 func (r *Repository) ListByPatient(ctx context.Context, patientID uuid.UUID) ([]domain.ExercisePlan, error) {
 	var ms []ExercisePlanModel
 	if err := r.db.WithContext(ctx).
-		Preload("Items").
+		Preload("Items", orderItems).
 		Order("created_at desc").
 		Find(&ms, "patient_id = ?", patientID.String()).
 		Error; err != nil {
@@ -63,15 +63,27 @@ func (r *Repository) ListByPatient(ctx context.Context, patientID uuid.UUID) ([]
 	return out, nil
 }
 
+func orderItems(db *gorm.DB) *gorm.DB {
+	return db.Order("position asc, created_at asc")
+}
+
 func toModel(p domain.ExercisePlan) ExercisePlanModel {
 	m := ExercisePlanModel{
 		ID:              p.ID.String(),
 		PatientID:       p.PatientID.String(),
 		KinesiologistID: p.KinesiologistID.String(),
+		DiagnosisID:     uuidPtrToString(p.DiagnosisID),
+		InjuryID:        uuidPtrToString(p.InjuryID),
 		Frequency:       string(p.Frequency),
 		DurationWeeks:   p.DurationWeeks,
 		Observations:    p.Observations,
 		Status:          string(p.Status),
+		ClosedAt:        p.ClosedAt,
+		CloseReason:     p.CloseReason,
+		PreviousPlanID:  uuidPtrToString(p.PreviousPlanID),
+		Version:         p.Version,
+		TemplateID:      uuidPtrToString(p.TemplateID),
+		Phases:          encodePhases(p.Phases),
 		CreatedAt:       p.CreatedAt,
 		UpdatedAt:       p.UpdatedAt,
 		Items:           make([]ExercisePlanItemModel, 0, len(p.Items)),
@@ -80,6 +92,7 @@ func toModel(p domain.ExercisePlan) ExercisePlanModel {
 		m.Items = append(m.Items, ExercisePlanItemModel{
 			ID:               it.ID.String(),
 			PlanID:           it.PlanID.String(),
+			ExerciseID:       uuidPtrToString(it.ExerciseID),
 			Name:             it.Name,
 			Description:      it.Description,
 			VideoURL:         it.VideoURL,
@@ -87,6 +100,12 @@ func toModel(p domain.ExercisePlan) ExercisePlanModel {
 			EstimatedMinutes: it.EstimatedMinutes,
 			Sets:             it.Sets,
 			Reps:             it.Reps,
+			Load:             it.Load,
+			LoadUnit:         it.LoadUnit,
+			Weekdays:         encodeWeekdays(it.Weekdays),
+			Phase:            it.Phase,
+			Progression:      encodeProgression(it.Progression),
+			Position:         it.Position,
 			CreatedAt:        it.CreatedAt,
 			UpdatedAt:        it.UpdatedAt,
 		})
@@ -99,10 +118,18 @@ func toDomain(m ExercisePlanModel) domain.ExercisePlan {
 		ID:              uuid.MustParse(m.ID),
 		PatientID:       uuid.MustParse(m.PatientID),
 		KinesiologistID: uuid.MustParse(m.KinesiologistID),
+		DiagnosisID:     stringPtrToUUID(m.DiagnosisID),
+		InjuryID:        stringPtrToUUID(m.InjuryID),
 		Frequency:       domain.Frequency(m.Frequency),
 		DurationWeeks:   m.DurationWeeks,
 		Observations:    m.Observations,
 		Status:          domain.PlanStatus(m.Status),
+		ClosedAt:        m.ClosedAt,
+		CloseReason:     m.CloseReason,
+		PreviousPlanID:  stringPtrToUUID(m.PreviousPlanID),
+		Version:         m.Version,
+		TemplateID:      stringPtrToUUID(m.TemplateID),
+		Phases:          decodePhases(m.Phases),
 		CreatedAt:       m.CreatedAt,
 		UpdatedAt:       m.UpdatedAt,
 		Items:           make([]domain.ExercisePlanItem, 0, len(m.Items)),
@@ -111,6 +138,7 @@ func toDomain(m ExercisePlanModel) domain.ExercisePlan {
 		p.Items = append(p.Items, domain.ExercisePlanItem{
 			ID:               uuid.MustParse(it.ID),
 			PlanID:           uuid.MustParse(it.PlanID),
+			ExerciseID:       stringPtrToUUID(it.ExerciseID),
 			Name:             it.Name,
 			Description:      it.Description,
 			VideoURL:         it.VideoURL,
@@ -118,9 +146,31 @@ func toDomain(m ExercisePlanModel) domain.ExercisePlan {
 			EstimatedMinutes: it.EstimatedMinutes,
 			Sets:             it.Sets,
 			Reps:             it.Reps,
+			Load:             it.Load,
+			LoadUnit:         it.LoadUnit,
+			Weekdays:         decodeWeekdays(it.Weekdays),
+			Phase:            it.Phase,
+			Progression:      decodeProgression(it.Progression),
+			Position:         it.Position,
 			CreatedAt:        it.CreatedAt,
 			UpdatedAt:        it.UpdatedAt,
 		})
 	}
 	return p
 }
+
+func uuidPtrToString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func stringPtrToUUID(s *string) *uuid.UUID {
+	if s == nil || *s == "" {
+		return nil
+	}
+	id := uuid.MustParse(*s)
+	return &id
+}
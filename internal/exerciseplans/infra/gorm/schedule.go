@@ -0,0 +1,66 @@
+package gorm
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/domain"
+)
+
+// Fases, días y progresión se guardan como jsonb. Los escribe siempre este repo, así que
+// un valor ilegible se trata como vacío en lugar de romper la lectura del plan.
+
+func encodePhases(phases []domain.PlanPhase) string {
+	if len(phases) == 0 {
+		return "[]"
+	}
+	b, _ := json.Marshal(phases)
+	return string(b)
+}
+
+func decodePhases(s string) []domain.PlanPhase {
+	var out []domain.PlanPhase
+	_ = json.Unmarshal([]byte(s), &out)
+	return out
+}
+
+func encodeWeekdays(days []time.Weekday) string {
+	names := make([]string, 0, len(days))
+	for _, d := range days {
+		names = append(names, domain.WeekdayName(d))
+	}
+	b, _ := json.Marshal(names)
+	return string(b)
+}
+
+func decodeWeekdays(s string) []time.Weekday {
+	var names []string
+	_ = json.Unmarshal([]byte(s), &names)
+	var out []time.Weekday
+	for _, n := range names {
+		if d, ok := domain.ParseWeekday(n); ok {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+func encodeProgression(p *domain.Progression) *string {
+	if p == nil {
+		return nil
+	}
+	b, _ := json.Marshal(p)
+	s := string(b)
+	return &s
+}
+
+func decodeProgression(s *string) *domain.Progression {
+	if s == nil || *s == "" {
+		return nil
+	}
+	var p domain.Progression
+	if err := json.Unmarshal([]byte(*s), &p); err != nil {
+		return nil
+	}
+	return &p
+}
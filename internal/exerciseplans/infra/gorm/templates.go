@@ -0,0 +1,148 @@
+package gorm
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/domain"
+)
+
+type PlanTemplateModel struct {
+	ID          string    `gorm:"type:uuid;primaryKey"`
+	Code        string    `gorm:"not null"`
+	Version     int       `gorm:"not null"`
+	IsLatest    bool      `gorm:"not null"`
+	Name        string    `gorm:"not null"`
+	Description *string   `gorm:"null"`
+	Frequency   string    `gorm:"not null"`
+	Phases      string    `gorm:"type:jsonb;not null"`
+	CreatedAt   time.Time `gorm:"not null"`
+}
+
+func (PlanTemplateModel) TableName() string { return "exercise_plan_templates" }
+
+func (r *Repository) CreateTemplate(ctx context.Context, t domain.PlanTemplate) (domain.PlanTemplate, error) {
+	m, err := toTemplateModel(t)
+	if err != nil {
+		return domain.PlanTemplate{}, err
+	}
+	if err := r.db.WithContext(ctx).Create(&m).Error; err != nil {
+		return domain.PlanTemplate{}, err
+	}
+	return t, nil
+}
+
+// CreateTemplateVersion marca la versión anterior como no vigente e inserta la nueva.
+// Si la anterior ya no era la vigente (otra edición ganó), devuelve ErrTemplateOutdated.
+func (r *Repository) CreateTemplateVersion(ctx context.Context, previousID uuid.UUID, t domain.PlanTemplate) (domain.PlanTemplate, error) {
+	m, err := toTemplateModel(t)
+	if err != nil {
+		return domain.PlanTemplate{}, err
+	}
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&PlanTemplateModel{}).
+			Where("id = ? AND is_latest", previousID.String()).
+			Update("is_latest", false)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return domain.ErrTemplateOutdated
+		}
+		return tx.Create(&m).Error
+	})
+	if err != nil {
+		return domain.PlanTemplate{}, err
+	}
+	return t, nil
+}
+
+func (r *Repository) GetTemplate(ctx context.Context, id uuid.UUID) (domain.PlanTemplate, bool, error) {
+	var m PlanTemplateModel
+	err := r.db.WithContext(ctx).First(&m, "id = ?", id.String()).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return domain.PlanTemplate{}, false, nil
+		}
+		return domain.PlanTemplate{}, false, err
+	}
+	t, err := toTemplateDomain(m)
+	if err != nil {
+		return domain.PlanTemplate{}, false, err
+	}
+	return t, true, nil
+}
+
+// ListTemplates devuelve las versiones vigentes; con code, todas las versiones de esa plantilla.
+func (r *Repository) ListTemplates(ctx context.Context, code string) ([]domain.PlanTemplate, error) {
+	q := r.db.WithContext(ctx).Model(&PlanTemplateModel{})
+	if code != "" {
+		q = q.Where("code = ?", code).Order("version desc")
+	} else {
+		q = q.Where("is_latest").Order("name asc")
+	}
+
+	var ms []PlanTemplateModel
+	if err := q.Find(&ms).Error; err != nil {
+		return nil, err
+	}
+	out := make([]domain.PlanTemplate, 0, len(ms))
+	for _, m := range ms {
+		t, err := toTemplateDomain(m)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+func (r *Repository) ExistsTemplateCode(ctx context.Context, code string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&PlanTemplateModel{}).
+		Where("code = ?", code).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func toTemplateModel(t domain.PlanTemplate) (PlanTemplateModel, error) {
+	phases, err := json.Marshal(t.Phases)
+	if err != nil {
+		return PlanTemplateModel{}, err
+	}
+	return PlanTemplateModel{
+		ID:          t.ID.String(),
+		Code:        t.Code,
+		Version:     t.Version,
+		IsLatest:    t.IsLatest,
+		Name:        t.Name,
+		Description: t.Description,
+		Frequency:   string(t.Frequency),
+		Phases:      string(phases),
+		CreatedAt:   t.CreatedAt,
+	}, nil
+}
+
+func toTemplateDomain(m PlanTemplateModel) (domain.PlanTemplate, error) {
+	var phases []domain.TemplatePhase
+	if err := json.Unmarshal([]byte(m.Phases), &phases); err != nil {
+		return domain.PlanTemplate{}, err
+	}
+	return domain.PlanTemplate{
+		ID:          uuid.MustParse(m.ID),
+		Code:        m.Code,
+		Version:     m.Version,
+		IsLatest:    m.IsLatest,
+		Name:        m.Name,
+		Description: m.Description,
+		Frequency:   domain.Frequency(m.Frequency),
+		Phases:      phases,
+		CreatedAt:   m.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,305 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	qrcode "github.com/skip2/go-qrcode"
+
+	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/domain"
+)
+
+var _ domain.PlanRenderer = (*Renderer)(nil)
+
+const (
+	margin   = 15.0
+	qrSize   = 26.0
+	qrGap    = 4.0
+	lineH    = 5.0
+	fontName = "Helvetica"
+)
+
+// Color de marca del consultorio (encabezado y títulos).
+var brand = [3]int{0, 102, 153}
+
+// Renderer arma el PDF del plan con fpdf y go-qrcode (Go puro, no necesita red ni binarios).
+// Las fechas se imprimen en la zona horaria del consultorio; sin zona, en UTC (igual que
+// los días del plan, nunca la zona del servidor).
+type Renderer struct {
+	loc *time.Location
+}
+
+func NewRenderer(loc *time.Location) *Renderer {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &Renderer{loc: loc}
+}
+
+func (r *Renderer) Render(doc domain.PlanDocument) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(margin, margin, margin)
+	pdf.SetAutoPageBreak(true, margin+5)
+	pdf.SetCreationDate(doc.GeneratedAt)
+	pdf.SetTitle("Plan de ejercicios", true)
+	pdf.AliasNbPages("")
+
+	// Las fuentes estándar son cp1252: hay que traducir acentos y eñes.
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetHeaderFunc(func() { header(pdf, tr, doc.Clinic) })
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-margin)
+		pdf.SetFont(fontName, "I", 8)
+		pdf.SetTextColor(120, 120, 120)
+		pdf.CellFormat(0, lineH, tr(fmt.Sprintf("Generado el %s", doc.GeneratedAt.In(r.loc).Format("02/01/2006 15:04"))), "", 0, "L", false, 0, "")
+		pdf.SetX(margin)
+		pdf.CellFormat(0, lineH, tr(fmt.Sprintf("Página %d/{nb}", pdf.PageNo())), "", 0, "R", false, 0, "")
+	})
+
+	pdf.AddPage()
+	summary(pdf, tr, doc, r.loc)
+
+	for i, it := range doc.Plan.Items {
+		if err := item(pdf, tr, doc.Plan, i, it); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := pdf.Error(); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func header(pdf *fpdf.Fpdf, tr func(string) string, c domain.ClinicInfo) {
+	pdf.SetFont(fontName, "B", 16)
+	pdf.SetTextColor(brand[0], brand[1], brand[2])
+	pdf.CellFormat(0, 8, tr(c.Name), "", 1, "L", false, 0, "")
+
+	contact := make([]string, 0, 2)
+	if c.Address != "" {
+		contact = append(contact, c.Address)
+	}
+	if c.Phone != "" {
+		contact = append(contact, "Tel. "+c.Phone)
+	}
+	if len(contact) > 0 {
+		pdf.SetFont(fontName, "", 9)
+		pdf.SetTextColor(90, 90, 90)
+		pdf.CellFormat(0, lineH, tr(strings.Join(contact, " - ")), "", 1, "L", false, 0, "")
+	}
+
+	w, _ := pdf.GetPageSize()
+	pdf.SetDrawColor(brand[0], brand[1], brand[2])
+	pdf.SetLineWidth(0.6)
+	pdf.Line(margin, pdf.GetY()+2, w-margin, pdf.GetY()+2)
+	pdf.Ln(6)
+	pdf.SetTextColor(0, 0, 0)
+}
+
+func summary(pdf *fpdf.Fpdf, tr func(string) string, doc domain.PlanDocument, loc *time.Location) {
+	p := doc.Plan
+	// Mismos días que la programación y los registros del plan.
+	first, last := p.In(loc).Period()
+
+	pdf.SetFont(fontName, "B", 14)
+	pdf.CellFormat(0, 8, tr("Plan de ejercicios en casa"), "", 1, "L", false, 0, "")
+	pdf.Ln(1)
+
+	kinesiologist := doc.KinesiologistName
+	if doc.KinesiologistMN != nil && *doc.KinesiologistMN != "" {
+		kinesiologist += " (MN " + *doc.KinesiologistMN + ")"
+	}
+	rows := [][2]string{
+		{"Paciente", doc.PatientName},
+		{"Kinesiólogo/a", kinesiologist},
+		{"Frecuencia", frequencyLabel(p.Frequency)},
+		{"Duración", fmt.Sprintf("%d %s (del %s al %s)", p.DurationWeeks, plural(p.DurationWeeks, "semana", "semanas"),
+			first.Format("02/01/2006"), last.Format("02/01/2006"))},
+	}
+	if p.Status == domain.PlanClosed {
+		rows = append(rows, [2]string{"Estado", "Cerrado"})
+	}
+	for _, row := range rows {
+		pdf.SetFont(fontName, "B", 10)
+		pdf.CellFormat(35, 6, tr(row[0]+":"), "", 0, "L", false, 0, "")
+		pdf.SetFont(fontName, "", 10)
+		pdf.CellFormat(0, 6, tr(row[1]), "", 1, "L", false, 0, "")
+	}
+
+	if p.Observations != nil && strings.TrimSpace(*p.Observations) != "" {
+		pdf.Ln(2)
+		pdf.SetFont(fontName, "B", 10)
+		pdf.CellFormat(0, 6, tr("Indicaciones"), "", 1, "L", false, 0, "")
+		pdf.SetFont(fontName, "", 10)
+		pdf.MultiCell(0, lineH, tr(*p.Observations), "", "L", false)
+	}
+	pdf.Ln(4)
+}
+
+type qr struct {
+	label string
+	url   string
+}
+
+// item imprime un ejercicio con sus QR a la derecha; si no entra en la página, pasa a la siguiente.
+func item(pdf *fpdf.Fpdf, tr func(string) string, p domain.ExercisePlan, i int, it domain.ExercisePlanItem) error {
+	codes := make([]qr, 0, 2)
+	if it.VideoURL != nil && *it.VideoURL != "" {
+		codes = append(codes, qr{label: "Video", url: *it.VideoURL})
+	}
+	if it.GuideURL != nil && *it.GuideURL != "" {
+		codes = append(codes, qr{label: "Guía", url: *it.GuideURL})
+	}
+
+	pageW, pageH := pdf.GetPageSize()
+	textW := pageW - 2*margin - float64(len(codes))*(qrSize+qrGap)
+
+	title := fmt.Sprintf("%d. %s", i+1, it.Name)
+	detail := dosage(it)
+	if when := schedule(p, it); when != "" {
+		detail += "\n" + when
+	}
+	var desc string
+	if it.Description != nil {
+		desc = strings.TrimSpace(*it.Description)
+	}
+
+	pdf.SetFont(fontName, "", 10)
+	textH := 7 + float64(strings.Count(detail, "\n")+1)*lineH
+	if desc != "" {
+		textH += float64(len(pdf.SplitLines([]byte(tr(desc)), textW))) * lineH
+	}
+	blockH := textH
+	if len(codes) > 0 && qrSize+lineH > blockH {
+		blockH = qrSize + lineH
+	}
+	if pdf.GetY()+blockH > pageH-margin-5 {
+		pdf.AddPage()
+	}
+
+	top := pdf.GetY()
+	left := margin
+
+	pdf.SetFont(fontName, "B", 12)
+	pdf.SetTextColor(brand[0], brand[1], brand[2])
+	pdf.MultiCell(textW, 7, tr(title), "", "L", false)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont(fontName, "I", 10)
+	pdf.MultiCell(textW, lineH, tr(detail), "", "L", false)
+	if desc != "" {
+		pdf.SetFont(fontName, "", 10)
+		pdf.MultiCell(textW, lineH, tr(desc), "", "L", false)
+	}
+	bottom := pdf.GetY()
+
+	x := left + textW + qrGap
+	for j, c := range codes {
+		png, err := qrcode.Encode(c.url, qrcode.Medium, 256)
+		if err != nil {
+			return err
+		}
+		name := fmt.Sprintf("qr-%d-%d", i, j)
+		pdf.RegisterImageOptionsReader(name, fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(png))
+		pdf.ImageOptions(name, x, top, qrSize, qrSize, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, c.url)
+		pdf.SetXY(x, top+qrSize)
+		pdf.SetFont(fontName, "", 8)
+		pdf.CellFormat(qrSize, 4, tr(c.label), "", 0, "C", false, 0, c.url)
+		if y := top + qrSize + lineH; y > bottom {
+			bottom = y
+		}
+		x += qrSize + qrGap
+	}
+
+	pdf.SetXY(left, bottom+2)
+	pdf.SetDrawColor(210, 210, 210)
+	pdf.SetLineWidth(0.2)
+	pdf.Line(left, pdf.GetY(), pageW-margin, pdf.GetY())
+	pdf.Ln(3)
+	return nil
+}
+
+// dosage: "3 series x 10 repeticiones - 15 min".
+func dosage(it domain.ExercisePlanItem) string {
+	parts := make([]string, 0, 3)
+	switch {
+	case it.Sets != nil && it.Reps != nil:
+		parts = append(parts, fmt.Sprintf("%d %s x %d %s", *it.Sets, plural(*it.Sets, "serie", "series"), *it.Reps, plural(*it.Reps, "repetición", "repeticiones")))
+	case it.Sets != nil:
+		parts = append(parts, fmt.Sprintf("%d %s", *it.Sets, plural(*it.Sets, "serie", "series")))
+	case it.Reps != nil:
+		parts = append(parts, fmt.Sprintf("%d %s", *it.Reps, plural(*it.Reps, "repetición", "repeticiones")))
+	}
+	if it.Load != nil {
+		load := strconv.FormatFloat(*it.Load, 'f', -1, 64)
+		if it.LoadUnit != nil {
+			load += " " + *it.LoadUnit
+		}
+		parts = append(parts, load)
+	}
+	parts = append(parts, fmt.Sprintf("%d min", it.EstimatedMinutes))
+	return strings.Join(parts, " - ")
+}
+
+var weekdayLabels = []string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"}
+
+// schedule: días, fase y progresión del ítem ("lun, mié, vie - Fase 2 (semanas 3 a 6)").
+func schedule(p domain.ExercisePlan, it domain.ExercisePlanItem) string {
+	parts := make([]string, 0, 3)
+	if len(it.Weekdays) > 0 {
+		days := make([]string, 0, len(it.Weekdays))
+		for _, d := range it.Weekdays {
+			days = append(days, weekdayLabels[d])
+		}
+		parts = append(parts, strings.Join(days, ", "))
+	}
+	if it.Phase != nil {
+		from, to := p.ItemWeeks(it)
+		parts = append(parts, fmt.Sprintf("%s (semanas %d a %d)", *it.Phase, from, to))
+	}
+	if pr := it.Progression; pr != nil {
+		steps := make([]string, 0, 3)
+		if pr.SetsStep != 0 {
+			steps = append(steps, fmt.Sprintf("%+d series", pr.SetsStep))
+		}
+		if pr.RepsStep != 0 {
+			steps = append(steps, fmt.Sprintf("%+d rep.", pr.RepsStep))
+		}
+		if pr.LoadStep != 0 {
+			steps = append(steps, fmt.Sprintf("%+g de carga", pr.LoadStep))
+		}
+		every := "por semana"
+		if pr.EveryWeeks > 1 {
+			every = fmt.Sprintf("cada %d semanas", pr.EveryWeeks)
+		}
+		parts = append(parts, "Progresión: "+strings.Join(steps, ", ")+" "+every)
+	}
+	return strings.Join(parts, " - ")
+}
+
+func frequencyLabel(f domain.Frequency) string {
+	switch f {
+	case domain.FrequencyDaily:
+		return "Todos los días"
+	case domain.FrequencyWeekly:
+		return "Una vez por semana"
+	default:
+		return string(f)
+	}
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
@@ -0,0 +1,290 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/clinictime"
+	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+// ---------- Registro de una sesión
+
+type LogAdherenceInput struct {
+	ItemID        string  `json:"item_id"`
+	Date          string  `json:"date"` // YYYY-MM-DD
+	SetsCompleted *int    `json:"sets_completed"`
+	RepsCompleted *int    `json:"reps_completed"`
+	Difficulty    *int    `json:"difficulty"` // 1..10
+	PainLevel     *int    `json:"pain_level"` // 0..10
+	Comment       *string `json:"comment"`
+	ReportedBy    string  `json:"reported_by"` // patient (default) | receptionist
+}
+
+// Las fechas de los registros y los días del plan son los del consultorio (loc).
+type LogAdherenceUseCase struct {
+	repo domain.Repository
+	loc  *time.Location
+}
+
+func NewLogAdherenceUseCase(repo domain.Repository, loc *time.Location) *LogAdherenceUseCase {
+	return &LogAdherenceUseCase{repo: repo, loc: loc}
+}
+
+func (uc *LogAdherenceUseCase) Execute(ctx context.Context, planID uuid.UUID, in LogAdherenceInput) (domain.AdherenceLog, *validation.Errors, error) {
+	p, found, err := uc.repo.GetByID(ctx, planID)
+	if err != nil {
+		return domain.AdherenceLog{}, nil, err
+	}
+	if !found {
+		return domain.AdherenceLog{}, nil, domain.ErrNotFound
+	}
+	p = p.In(uc.loc)
+
+	validation := validation.New()
+	now := time.Now().UTC()
+	today := clinictime.Day(now, uc.loc)
+
+	l := domain.AdherenceLog{
+		ID:            uuid.New(),
+		PlanID:        p.ID,
+		PatientID:     p.PatientID,
+		SetsCompleted: in.SetsCompleted,
+		RepsCompleted: in.RepsCompleted,
+		Difficulty:    in.Difficulty,
+		PainLevel:     in.PainLevel,
+		Comment:       trimPtr(in.Comment),
+		ReportedBy:    strings.TrimSpace(in.ReportedBy),
+		CreatedAt:     now,
+	}
+
+	itemID, err := uuid.Parse(strings.TrimSpace(in.ItemID))
+	if err != nil {
+		validation.Add("item_id", "invalid_uuid")
+	} else {
+		l.ItemID = itemID
+	}
+	item, belongs := findItem(p, l.ItemID)
+	if !validation.Has("item_id") && !belongs {
+		validation.Add("item_id", "not_found")
+	}
+
+	if strings.TrimSpace(in.Date) == "" {
+		l.Date = today
+	} else if d, err := time.Parse("2006-01-02", strings.TrimSpace(in.Date)); err != nil {
+		validation.Add("date", "invalid_date_(YYYY-MM-DD)")
+	} else {
+		l.Date = d.UTC()
+	}
+	if !validation.Has("date") {
+		from, to := p.LogWindow()
+		switch {
+		case l.Date.After(today):
+			validation.Add("date", "must_not_be_in_the_future")
+		case l.Date.Before(from) || l.Date.After(to):
+			validation.Add("date", "outside_plan_period")
+		case belongs:
+			if first, last := p.ItemWeeks(item); p.WeekOf(l.Date) < first || p.WeekOf(l.Date) > last {
+				validation.Add("date", "outside_item_phase")
+			}
+		}
+	}
+
+	if l.SetsCompleted != nil && *l.SetsCompleted < 0 {
+		validation.Add("sets_completed", "must_be_>=_0")
+	}
+	if l.RepsCompleted != nil && *l.RepsCompleted < 0 {
+		validation.Add("reps_completed", "must_be_>=_0")
+	}
+	if l.Difficulty != nil && (*l.Difficulty < 1 || *l.Difficulty > 10) {
+		validation.Add("difficulty", "must_be_between_1_and_10")
+	}
+	if l.PainLevel != nil && (*l.PainLevel < 0 || *l.PainLevel > 10) {
+		validation.Add("pain_level", "must_be_between_0_and_10")
+	}
+
+	switch l.ReportedBy {
+	case "":
+		l.ReportedBy = domain.ReportedByPatient
+	case domain.ReportedByPatient, domain.ReportedByReceptionist:
+	default:
+		validation.Add("reported_by", "must_be_patient_or_receptionist")
+	}
+
+	if !validation.Empty() {
+		return domain.AdherenceLog{}, validation, domain.ErrValidation
+	}
+
+	out, err := uc.repo.CreateAdherenceLog(ctx, l)
+	if err != nil {
+		return domain.AdherenceLog{}, nil, err
+	}
+	return out, nil, nil
+}
+
+func findItem(p domain.ExercisePlan, id uuid.UUID) (domain.ExercisePlanItem, bool) {
+	for _, it := range p.Items {
+		if it.ID == id {
+			return it, true
+		}
+	}
+	return domain.ExercisePlanItem{}, false
+}
+
+// ---------- Listado de registros de un plan
+
+type ListAdherenceLogsUseCase struct {
+	repo domain.Repository
+}
+
+func NewListAdherenceLogsUseCase(repo domain.Repository) *ListAdherenceLogsUseCase {
+	return &ListAdherenceLogsUseCase{repo: repo}
+}
+
+func (uc *ListAdherenceLogsUseCase) Execute(ctx context.Context, planID uuid.UUID, from, to *string) ([]domain.AdherenceLog, *validation.Errors, error) {
+	validation := validation.New()
+	fromDate := parseOptionalDate("from", from, validation)
+	toDate := parseOptionalDate("to", to, validation)
+	if !validation.Empty() {
+		return nil, validation, domain.ErrValidation
+	}
+
+	_, found, err := uc.repo.GetByID(ctx, planID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !found {
+		return nil, nil, domain.ErrNotFound
+	}
+
+	out, err := uc.repo.ListAdherenceLogs(ctx, []uuid.UUID{planID}, fromDate, toDate)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, nil, nil
+}
+
+func parseOptionalDate(field string, s *string, validation *validation.Errors) *time.Time {
+	v := trimPtr(s)
+	if v == nil {
+		return nil
+	}
+	d, err := time.Parse("2006-01-02", *v)
+	if err != nil {
+		validation.Add(field, "invalid_date_(YYYY-MM-DD)")
+		return nil
+	}
+	d = d.UTC()
+	return &d
+}
+
+// ---------- Adherencia de un plan
+
+type GetPlanAdherenceUseCase struct {
+	repo domain.Repository
+	loc  *time.Location
+}
+
+func NewGetPlanAdherenceUseCase(repo domain.Repository, loc *time.Location) *GetPlanAdherenceUseCase {
+	return &GetPlanAdherenceUseCase{repo: repo, loc: loc}
+}
+
+func (uc *GetPlanAdherenceUseCase) Execute(ctx context.Context, planID uuid.UUID) (domain.Adherence, error) {
+	p, found, err := uc.repo.GetByID(ctx, planID)
+	if err != nil {
+		return domain.Adherence{}, err
+	}
+	if !found {
+		return domain.Adherence{}, domain.ErrNotFound
+	}
+
+	logs, err := uc.repo.ListAdherenceLogs(ctx, []uuid.UUID{p.ID}, nil, nil)
+	if err != nil {
+		return domain.Adherence{}, err
+	}
+	return domain.ComputeAdherence(p.In(uc.loc), logs, time.Now()), nil
+}
+
+// ---------- Pacientes con baja adherencia
+
+type LowAdherenceInput struct {
+	Threshold *float64 // porcentaje, 50 por defecto
+	MinDays   *int     // días mínimos de plan para evaluarlo, 7 por defecto
+}
+
+type ListLowAdherenceUseCase struct {
+	repo domain.Repository
+	loc  *time.Location
+}
+
+func NewListLowAdherenceUseCase(repo domain.Repository, loc *time.Location) *ListLowAdherenceUseCase {
+	return &ListLowAdherenceUseCase{repo: repo, loc: loc}
+}
+
+// Execute evalúa los planes activos y devuelve los que están por debajo del umbral,
+// de menor a mayor adherencia. Los planes recién asignados (menos de MinDays) no se evalúan.
+func (uc *ListLowAdherenceUseCase) Execute(ctx context.Context, in LowAdherenceInput) ([]domain.Adherence, *validation.Errors, error) {
+	validation := validation.New()
+	threshold := 50.0
+	if in.Threshold != nil {
+		threshold = *in.Threshold
+		if threshold <= 0 || threshold > 100 {
+			validation.Add("threshold", "must_be_between_0_and_100")
+		}
+	}
+	minDays := 7
+	if in.MinDays != nil {
+		minDays = *in.MinDays
+		if minDays < 0 {
+			validation.Add("min_days", "must_be_>=_0")
+		}
+	}
+	if !validation.Empty() {
+		return nil, validation, domain.ErrValidation
+	}
+
+	plans, err := uc.repo.ListActive(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now().UTC()
+	today := clinictime.Day(now, uc.loc)
+	candidates := make([]domain.ExercisePlan, 0, len(plans))
+	ids := make([]uuid.UUID, 0, len(plans))
+	for _, p := range plans {
+		p = p.In(uc.loc)
+		if len(p.Items) == 0 || p.StartDay().AddDate(0, 0, minDays).After(today) {
+			continue
+		}
+		candidates = append(candidates, p)
+		ids = append(ids, p.ID)
+	}
+	if len(candidates) == 0 {
+		return []domain.Adherence{}, nil, nil
+	}
+
+	logs, err := uc.repo.ListAdherenceLogs(ctx, ids, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	byPlan := map[uuid.UUID][]domain.AdherenceLog{}
+	for _, l := range logs {
+		byPlan[l.PlanID] = append(byPlan[l.PlanID], l)
+	}
+
+	out := make([]domain.Adherence, 0)
+	for _, p := range candidates {
+		a := domain.ComputeAdherence(p, byPlan[p.ID], now)
+		if a.Percentage < threshold {
+			out = append(out, a)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Percentage < out[j].Percentage })
+	return out, nil, nil
+}
@@ -2,15 +2,20 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/javiacuna/kinesio-backend/internal/clinicalrefs"
 	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
 )
 
 type CreatePlanItemInput struct {
+	// ExerciseID: ejercicio del catálogo. Los campos que vengan pisan sus valores por defecto.
+	ExerciseID       *string `json:"exercise_id"`
 	Name             string  `json:"name"`
 	Description      *string `json:"description"`
 	VideoURL         *string `json:"video_url"`
@@ -18,58 +23,85 @@ type CreatePlanItemInput struct {
 	EstimatedMinutes int     `json:"estimated_minutes"`
 	Sets             *int    `json:"sets"`
 	Reps             *int    `json:"reps"`
+
+	// Programación propia (opcional)
+	Load        *float64            `json:"load"`
+	LoadUnit    *string             `json:"load_unit"`
+	Weekdays    []string            `json:"weekdays"` // mon..sun; vacío = según frequency del plan
+	Phase       *string             `json:"phase"`    // nombre de una de las phases del plan
+	Progression *domain.Progression `json:"progression"`
 }
 
 type CreatePlanInput struct {
 	PatientID       string                `json:"patient_id"`
 	KinesiologistID string                `json:"kinesiologist_id"`
+	DiagnosisID     *string               `json:"diagnosis_id"`
+	InjuryID        *string               `json:"injury_id"`
 	Frequency       string                `json:"frequency"`      // daily|weekly
 	DurationWeeks   int                   `json:"duration_weeks"` // >=1
 	Observations    *string               `json:"observations"`
+	Phases          []domain.PlanPhase    `json:"phases"`
 	Items           []CreatePlanItemInput `json:"items"`
+
+	// TemplateID lo completa ApplyPlanTemplateUseCase (no viene del request).
+	TemplateID *uuid.UUID `json:"-"`
 }
 
 type CreatePlanUseCase struct {
-	repo domain.Repository
+	repo     domain.Repository
+	clinical domain.ClinicalRecord
+	catalog  domain.Catalog
 }
 
-func NewCreatePlanUseCase(repo domain.Repository) *CreatePlanUseCase {
-	return &CreatePlanUseCase{repo: repo}
+func NewCreatePlanUseCase(repo domain.Repository, clinical domain.ClinicalRecord, catalog domain.Catalog) *CreatePlanUseCase {
+	return &CreatePlanUseCase{repo: repo, clinical: clinical, catalog: catalog}
 }
 
-func (uc *CreatePlanUseCase) Execute(ctx context.Context, in CreatePlanInput) (domain.ExercisePlan, map[string]string, error) {
-	validation := map[string]string{}
+func (uc *CreatePlanUseCase) Execute(ctx context.Context, in CreatePlanInput) (domain.ExercisePlan, *validation.Errors, error) {
+	validation := validation.New()
 
 	patientID, err := uuid.Parse(strings.TrimSpace(in.PatientID))
 	if err != nil {
-		validation["patient_id"] = "invalid_uuid"
+		validation.Add("patient_id", "invalid_uuid")
 	}
 	kID, err := uuid.Parse(strings.TrimSpace(in.KinesiologistID))
 	if err != nil {
-		validation["kinesiologist_id"] = "invalid_uuid"
+		validation.Add("kinesiologist_id", "invalid_uuid")
 	}
 
+	diagnosisID := validation.OptionalUUID("diagnosis_id", in.DiagnosisID)
+	injuryID := validation.OptionalUUID("injury_id", in.InjuryID)
+
 	freq := domain.Frequency(strings.TrimSpace(in.Frequency))
 	if freq != domain.FrequencyDaily && freq != domain.FrequencyWeekly {
-		validation["frequency"] = "must_be_daily_or_weekly"
+		validation.Add("frequency", "must_be_daily_or_weekly")
 	}
 	if in.DurationWeeks <= 0 {
-		validation["duration_weeks"] = "must_be_>=_1"
+		validation.Add("duration_weeks", "must_be_>=_1")
 	}
 	if len(in.Items) == 0 {
-		validation["items"] = "must_have_at_least_one_item"
+		validation.Add("items", "must_have_at_least_one_item")
 	}
+	phases := validatePhases(in.Phases, in.DurationWeeks, validation)
 
+	items := make([]resolvedItem, 0, len(in.Items))
 	for i, it := range in.Items {
-		if strings.TrimSpace(it.Name) == "" {
-			validation[("items[" + string(rune(i)) + "].name")] = "required"
+		prefix := fmt.Sprintf("items[%d].", i)
+		r, err := resolveItem(ctx, uc.catalog, prefix, it, validation)
+		if err != nil {
+			return domain.ExercisePlan{}, nil, err
 		}
-		if it.EstimatedMinutes <= 0 {
-			validation[("items[" + string(rune(i)) + "].estimated_minutes")] = "must_be_>_0"
+		checkPhase(prefix, r.Phase, phases, validation)
+		items = append(items, r)
+	}
+
+	if validation.Empty() {
+		if err := clinicalrefs.Check(ctx, uc.clinical, patientID, diagnosisID, injuryID, validation); err != nil {
+			return domain.ExercisePlan{}, nil, err
 		}
 	}
 
-	if len(validation) > 0 {
+	if !validation.Empty() {
 		return domain.ExercisePlan{}, validation, domain.ErrValidation
 	}
 
@@ -79,31 +111,22 @@ func (uc *CreatePlanUseCase) Execute(ctx context.Context, in CreatePlanInput) (d
 		ID:              uuid.New(),
 		PatientID:       patientID,
 		KinesiologistID: kID,
+		DiagnosisID:     diagnosisID,
+		InjuryID:        injuryID,
 		Frequency:       freq,
 		DurationWeeks:   in.DurationWeeks,
 		Observations:    in.Observations,
 		Status:          domain.PlanActive,
+		Version:         1,
+		TemplateID:      in.TemplateID,
+		Phases:          phases,
 		Items:           make([]domain.ExercisePlanItem, 0, len(in.Items)),
 		CreatedAt:       now,
 		UpdatedAt:       now,
 	}
 
-	for _, it := range in.Items {
-		name := strings.TrimSpace(it.Name)
-		item := domain.ExercisePlanItem{
-			ID:               uuid.New(),
-			PlanID:           plan.ID,
-			Name:             name,
-			Description:      it.Description,
-			VideoURL:         it.VideoURL,
-			GuideURL:         it.GuideURL,
-			EstimatedMinutes: it.EstimatedMinutes,
-			Sets:             it.Sets,
-			Reps:             it.Reps,
-			CreatedAt:        now,
-			UpdatedAt:        now,
-		}
-		plan.Items = append(plan.Items, item)
+	for i, it := range items {
+		plan.Items = append(plan.Items, newItem(plan.ID, it, i, now))
 	}
 
 	out, err := uc.repo.Create(ctx, plan)
@@ -112,3 +135,26 @@ func (uc *CreatePlanUseCase) Execute(ctx context.Context, in CreatePlanInput) (d
 	}
 	return out, nil, nil
 }
+
+func newItem(planID uuid.UUID, it resolvedItem, position int, now time.Time) domain.ExercisePlanItem {
+	return domain.ExercisePlanItem{
+		ID:               uuid.New(),
+		PlanID:           planID,
+		ExerciseID:       it.exerciseID,
+		Name:             strings.TrimSpace(it.Name),
+		Description:      it.Description,
+		VideoURL:         it.VideoURL,
+		GuideURL:         it.GuideURL,
+		EstimatedMinutes: it.EstimatedMinutes,
+		Sets:             it.Sets,
+		Reps:             it.Reps,
+		Load:             it.Load,
+		LoadUnit:         trimPtr(it.LoadUnit),
+		Weekdays:         it.weekdays,
+		Phase:            trimPtr(it.Phase),
+		Progression:      it.Progression,
+		Position:         position,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+}
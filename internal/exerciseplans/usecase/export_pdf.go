@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/domain"
+)
+
+type ExportPlanPDFUseCase struct {
+	getUC     *GetPlanByIDUseCase
+	directory domain.Directory
+	renderer  domain.PlanRenderer
+	clinic    domain.ClinicInfo
+}
+
+func NewExportPlanPDFUseCase(getUC *GetPlanByIDUseCase, directory domain.Directory, renderer domain.PlanRenderer, clinic domain.ClinicInfo) *ExportPlanPDFUseCase {
+	return &ExportPlanPDFUseCase{getUC: getUC, directory: directory, renderer: renderer, clinic: clinic}
+}
+
+// Execute devuelve el PDF del plan listo para imprimir o compartir con el paciente.
+func (uc *ExportPlanPDFUseCase) Execute(ctx context.Context, id uuid.UUID) ([]byte, domain.ExercisePlan, error) {
+	p, found, err := uc.getUC.Execute(ctx, id)
+	if err != nil {
+		return nil, domain.ExercisePlan{}, err
+	}
+	if !found {
+		return nil, domain.ExercisePlan{}, domain.ErrNotFound
+	}
+
+	doc := domain.PlanDocument{
+		Clinic:      uc.clinic,
+		Plan:        p,
+		GeneratedAt: time.Now().UTC(),
+	}
+
+	// Si no se encuentra el nombre se imprime igual (queda el dato en blanco).
+	patient, _, err := uc.directory.Patient(ctx, p.PatientID)
+	if err != nil {
+		return nil, domain.ExercisePlan{}, err
+	}
+	doc.PatientName = patient.FullName
+
+	k, _, err := uc.directory.Kinesiologist(ctx, p.KinesiologistID)
+	if err != nil {
+		return nil, domain.ExercisePlan{}, err
+	}
+	doc.KinesiologistName = k.FullName
+	doc.KinesiologistMN = k.LicenseNumber
+
+	out, err := uc.renderer.Render(doc)
+	if err != nil {
+		return nil, domain.ExercisePlan{}, err
+	}
+	return out, p, nil
+}
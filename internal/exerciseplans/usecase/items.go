@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+// resolvedItem: el ítem tal como se va a guardar, con los valores del catálogo ya aplicados.
+type resolvedItem struct {
+	CreatePlanItemInput
+	exerciseID *uuid.UUID
+	weekdays   []time.Weekday
+}
+
+// resolveItem completa con los valores del catálogo los campos que no vinieron y valida el resultado.
+func resolveItem(ctx context.Context, catalog domain.Catalog, prefix string, it CreatePlanItemInput, validation *validation.Errors) (resolvedItem, error) {
+	out := resolvedItem{CreatePlanItemInput: it}
+
+	if exID := validation.OptionalUUID(prefix+"exercise_id", it.ExerciseID); exID != nil {
+		ex, found, err := catalog.Exercise(ctx, *exID)
+		if err != nil {
+			return resolvedItem{}, err
+		}
+		switch {
+		case !found:
+			validation.Add(prefix+"exercise_id", "not_found")
+		case !ex.Active:
+			validation.Add(prefix+"exercise_id", "inactive")
+		default:
+			out.exerciseID = exID
+			if strings.TrimSpace(out.Name) == "" {
+				out.Name = ex.Name
+			}
+			if out.Description == nil {
+				out.Description = ex.Description
+			}
+			if out.VideoURL == nil {
+				out.VideoURL = ex.VideoURL
+			}
+			if out.GuideURL == nil {
+				out.GuideURL = ex.GuideURL
+			}
+			if out.Sets == nil {
+				out.Sets = ex.Sets
+			}
+			if out.Reps == nil {
+				out.Reps = ex.Reps
+			}
+			if out.EstimatedMinutes == 0 {
+				out.EstimatedMinutes = ex.EstimatedMinutes
+			}
+		}
+	}
+
+	if strings.TrimSpace(out.Name) == "" {
+		validation.Add(prefix+"name", "required")
+	}
+	if out.EstimatedMinutes <= 0 {
+		validation.Add(prefix+"estimated_minutes", "must_be_>_0")
+	}
+	out.weekdays = validateSchedule(prefix, &out.CreatePlanItemInput, validation)
+	return out, nil
+}
+
+// ---------- Alta de ítem
+
+type AddPlanItemInput struct {
+	CreatePlanItemInput
+	// Position: dónde insertarlo (0 = primero). Si no viene, va al final.
+	Position *int `json:"position"`
+}
+
+type AddPlanItemUseCase struct {
+	repo    domain.Repository
+	catalog domain.Catalog
+}
+
+func NewAddPlanItemUseCase(repo domain.Repository, catalog domain.Catalog) *AddPlanItemUseCase {
+	return &AddPlanItemUseCase{repo: repo, catalog: catalog}
+}
+
+func (uc *AddPlanItemUseCase) Execute(ctx context.Context, planID uuid.UUID, in AddPlanItemInput) (domain.ExercisePlan, *validation.Errors, error) {
+	validation := validation.New()
+
+	p, err := loadActive(ctx, uc.repo, planID)
+	if err != nil {
+		return domain.ExercisePlan{}, nil, err
+	}
+
+	resolved, err := resolveItem(ctx, uc.catalog, "", in.CreatePlanItemInput, validation)
+	if err != nil {
+		return domain.ExercisePlan{}, nil, err
+	}
+
+	checkPhase("", resolved.Phase, p.Phases, validation)
+
+	pos := len(p.Items)
+	if in.Position != nil {
+		if *in.Position < 0 || *in.Position > len(p.Items) {
+			validation.Add("position", fmt.Sprintf("must_be_between_0_and_%d", len(p.Items)))
+		} else {
+			pos = *in.Position
+		}
+	}
+	if !validation.Empty() {
+		return domain.ExercisePlan{}, validation, domain.ErrValidation
+	}
+
+	now := time.Now().UTC()
+	item := newItem(p.ID, resolved, pos, now)
+	p.Items = append(p.Items[:pos], append([]domain.ExercisePlanItem{item}, p.Items[pos:]...)...)
+	p.Renumber()
+	p.UpdatedAt = now
+
+	out, err := uc.repo.Update(ctx, p)
+	if err != nil {
+		return domain.ExercisePlan{}, nil, err
+	}
+	return out, nil, nil
+}
+
+// ---------- Baja de ítem
+
+type RemovePlanItemUseCase struct {
+	repo domain.Repository
+}
+
+func NewRemovePlanItemUseCase(repo domain.Repository) *RemovePlanItemUseCase {
+	return &RemovePlanItemUseCase{repo: repo}
+}
+
+func (uc *RemovePlanItemUseCase) Execute(ctx context.Context, planID, itemID uuid.UUID) (domain.ExercisePlan, *validation.Errors, error) {
+	p, err := loadActive(ctx, uc.repo, planID)
+	if err != nil {
+		return domain.ExercisePlan{}, nil, err
+	}
+
+	idx := -1
+	for i, it := range p.Items {
+		if it.ID == itemID {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return domain.ExercisePlan{}, nil, domain.ErrNotFound
+	}
+	if len(p.Items) == 1 {
+		return domain.ExercisePlan{}, validation.Field("items", "must_have_at_least_one_item"), domain.ErrValidation
+	}
+
+	p.Items = append(p.Items[:idx], p.Items[idx+1:]...)
+	p.Renumber()
+	p.UpdatedAt = time.Now().UTC()
+
+	out, err := uc.repo.Update(ctx, p)
+	if err != nil {
+		return domain.ExercisePlan{}, nil, err
+	}
+	return out, nil, nil
+}
+
+// ---------- Reordenamiento
+
+type ReorderPlanItemsUseCase struct {
+	repo domain.Repository
+}
+
+func NewReorderPlanItemsUseCase(repo domain.Repository) *ReorderPlanItemsUseCase {
+	return &ReorderPlanItemsUseCase{repo: repo}
+}
+
+// Execute recibe todos los ids de ítems del plan en el orden nuevo.
+func (uc *ReorderPlanItemsUseCase) Execute(ctx context.Context, planID uuid.UUID, itemIDs []string) (domain.ExercisePlan, *validation.Errors, error) {
+	p, err := loadActive(ctx, uc.repo, planID)
+	if err != nil {
+		return domain.ExercisePlan{}, nil, err
+	}
+
+	if len(itemIDs) != len(p.Items) {
+		return domain.ExercisePlan{}, validation.Field("item_ids", "must_include_every_item_once"), domain.ErrValidation
+	}
+
+	byID := make(map[uuid.UUID]domain.ExercisePlanItem, len(p.Items))
+	for _, it := range p.Items {
+		byID[it.ID] = it
+	}
+
+	validation := validation.New()
+	ordered := make([]domain.ExercisePlanItem, 0, len(itemIDs))
+	for i, s := range itemIDs {
+		field := fmt.Sprintf("item_ids[%d]", i)
+		id, err := uuid.Parse(strings.TrimSpace(s))
+		if err != nil {
+			validation.Add(field, "invalid_uuid")
+			continue
+		}
+		it, ok := byID[id]
+		if !ok {
+			validation.Add(field, "not_in_plan_or_repeated")
+			continue
+		}
+		delete(byID, id)
+		ordered = append(ordered, it)
+	}
+	if !validation.Empty() {
+		return domain.ExercisePlan{}, validation, domain.ErrValidation
+	}
+
+	p.Items = ordered
+	p.Renumber()
+	p.UpdatedAt = time.Now().UTC()
+
+	out, err := uc.repo.Update(ctx, p)
+	if err != nil {
+		return domain.ExercisePlan{}, nil, err
+	}
+	return out, nil, nil
+}
@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+// loadActive trae el plan y verifica que se pueda modificar.
+func loadActive(ctx context.Context, repo domain.Repository, id uuid.UUID) (domain.ExercisePlan, error) {
+	p, found, err := repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.ExercisePlan{}, err
+	}
+	if !found {
+		return domain.ExercisePlan{}, domain.ErrNotFound
+	}
+	if p.Status == domain.PlanClosed {
+		return domain.ExercisePlan{}, domain.ErrPlanClosed
+	}
+	return p, nil
+}
+
+// ---------- Edición
+
+type UpdatePlanInput struct {
+	Frequency     *string `json:"frequency"`
+	DurationWeeks *int    `json:"duration_weeks"`
+	Observations  *string `json:"observations"`
+	// Phases reemplaza las fases del plan; los ítems tienen que seguir apuntando a fases existentes.
+	Phases *[]domain.PlanPhase `json:"phases"`
+}
+
+type UpdatePlanUseCase struct {
+	repo domain.Repository
+}
+
+func NewUpdatePlanUseCase(repo domain.Repository) *UpdatePlanUseCase {
+	return &UpdatePlanUseCase{repo: repo}
+}
+
+func (uc *UpdatePlanUseCase) Execute(ctx context.Context, id uuid.UUID, in UpdatePlanInput) (domain.ExercisePlan, *validation.Errors, error) {
+	validation := validation.New()
+
+	p, err := loadActive(ctx, uc.repo, id)
+	if err != nil {
+		return domain.ExercisePlan{}, nil, err
+	}
+
+	if in.Frequency != nil {
+		freq := domain.Frequency(strings.TrimSpace(*in.Frequency))
+		if freq != domain.FrequencyDaily && freq != domain.FrequencyWeekly {
+			validation.Add("frequency", "must_be_daily_or_weekly")
+		}
+		p.Frequency = freq
+	}
+	if in.DurationWeeks != nil {
+		if *in.DurationWeeks <= 0 {
+			validation.Add("duration_weeks", "must_be_>=_1")
+		}
+		p.DurationWeeks = *in.DurationWeeks
+	}
+	if in.Observations != nil {
+		p.Observations = trimPtr(in.Observations)
+	}
+	if in.Phases != nil || in.DurationWeeks != nil {
+		phases := p.Phases
+		if in.Phases != nil {
+			phases = *in.Phases
+		}
+		p.Phases = validatePhases(phases, p.DurationWeeks, validation)
+		for i, it := range p.Items {
+			checkPhase(fmt.Sprintf("items[%d].", i), it.Phase, p.Phases, validation)
+		}
+	}
+
+	if !validation.Empty() {
+		return domain.ExercisePlan{}, validation, domain.ErrValidation
+	}
+
+	p.UpdatedAt = time.Now().UTC()
+	out, err := uc.repo.Update(ctx, p)
+	if err != nil {
+		return domain.ExercisePlan{}, nil, err
+	}
+	return out, nil, nil
+}
+
+// ---------- Cierre
+
+type ClosePlanUseCase struct {
+	repo domain.Repository
+}
+
+func NewClosePlanUseCase(repo domain.Repository) *ClosePlanUseCase {
+	return &ClosePlanUseCase{repo: repo}
+}
+
+func (uc *ClosePlanUseCase) Execute(ctx context.Context, id uuid.UUID, reason string) (domain.ExercisePlan, *validation.Errors, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return domain.ExercisePlan{}, validation.Field("reason", "required"), domain.ErrValidation
+	}
+
+	p, err := loadActive(ctx, uc.repo, id)
+	if err != nil {
+		return domain.ExercisePlan{}, nil, err
+	}
+
+	p.Close(reason, time.Now().UTC())
+	out, err := uc.repo.Update(ctx, p)
+	if err != nil {
+		return domain.ExercisePlan{}, nil, err
+	}
+	return out, nil, nil
+}
+
+// ---------- Renovación (extender la duración)
+
+type RenewPlanUseCase struct {
+	repo domain.Repository
+}
+
+func NewRenewPlanUseCase(repo domain.Repository) *RenewPlanUseCase {
+	return &RenewPlanUseCase{repo: repo}
+}
+
+// Execute suma semanas al plan. Un plan cerrado automáticamente por vencimiento se reabre;
+// uno cerrado a mano no (para eso está el clonado).
+func (uc *RenewPlanUseCase) Execute(ctx context.Context, id uuid.UUID, additionalWeeks int) (domain.ExercisePlan, *validation.Errors, error) {
+	if additionalWeeks <= 0 {
+		return domain.ExercisePlan{}, validation.Field("additional_weeks", "must_be_>=_1"), domain.ErrValidation
+	}
+
+	p, found, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.ExercisePlan{}, nil, err
+	}
+	if !found {
+		return domain.ExercisePlan{}, nil, domain.ErrNotFound
+	}
+	if p.Status == domain.PlanClosed {
+		if p.CloseReason == nil || *p.CloseReason != domain.CloseReasonCompleted {
+			return domain.ExercisePlan{}, nil, domain.ErrPlanClosed
+		}
+		p.Status = domain.PlanActive
+		p.ClosedAt = nil
+		p.CloseReason = nil
+	}
+
+	p.DurationWeeks += additionalWeeks
+	p.UpdatedAt = time.Now().UTC()
+	out, err := uc.repo.Update(ctx, p)
+	if err != nil {
+		return domain.ExercisePlan{}, nil, err
+	}
+	return out, nil, nil
+}
+
+// ---------- Clonado (nueva versión)
+
+type ClonePlanInput struct {
+	KinesiologistID *string `json:"kinesiologist_id"` // por defecto, el del plan original
+	DurationWeeks   *int    `json:"duration_weeks"`   // por defecto, la del plan original
+}
+
+type ClonePlanUseCase struct {
+	repo domain.Repository
+}
+
+func NewClonePlanUseCase(repo domain.Repository) *ClonePlanUseCase {
+	return &ClonePlanUseCase{repo: repo}
+}
+
+// Execute crea la versión siguiente del plan (mismo paciente, mismos ítems) y cierra la anterior si seguía activa.
+func (uc *ClonePlanUseCase) Execute(ctx context.Context, id uuid.UUID, in ClonePlanInput) (domain.ExercisePlan, *validation.Errors, error) {
+	validation := validation.New()
+
+	prev, found, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.ExercisePlan{}, nil, err
+	}
+	if !found {
+		return domain.ExercisePlan{}, nil, domain.ErrNotFound
+	}
+
+	kID := prev.KinesiologistID
+	if v := validation.OptionalUUID("kinesiologist_id", in.KinesiologistID); v != nil {
+		kID = *v
+	}
+	weeks := prev.DurationWeeks
+	if in.DurationWeeks != nil {
+		if *in.DurationWeeks <= 0 {
+			validation.Add("duration_weeks", "must_be_>=_1")
+		}
+		weeks = *in.DurationWeeks
+		for _, ph := range prev.Phases {
+			if ph.EndWeek > weeks {
+				validation.Add("duration_weeks", "must_cover_plan_phases")
+			}
+		}
+	}
+	if !validation.Empty() {
+		return domain.ExercisePlan{}, validation, domain.ErrValidation
+	}
+
+	now := time.Now().UTC()
+	prevID := prev.ID
+	next := domain.ExercisePlan{
+		ID:              uuid.New(),
+		PatientID:       prev.PatientID,
+		KinesiologistID: kID,
+		DiagnosisID:     prev.DiagnosisID,
+		InjuryID:        prev.InjuryID,
+		Frequency:       prev.Frequency,
+		DurationWeeks:   weeks,
+		Observations:    prev.Observations,
+		Status:          domain.PlanActive,
+		PreviousPlanID:  &prevID,
+		Version:         prev.Version + 1,
+		TemplateID:      prev.TemplateID,
+		Phases:          prev.Phases,
+		Items:           make([]domain.ExercisePlanItem, 0, len(prev.Items)),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	for _, it := range prev.Items {
+		it.ID = uuid.New()
+		it.PlanID = next.ID
+		it.CreatedAt = now
+		it.UpdatedAt = now
+		next.Items = append(next.Items, it)
+	}
+	next.Renumber()
+
+	if prev.Status == domain.PlanActive {
+		prev.Close(domain.CloseReasonReplaced, now)
+	}
+
+	out, err := uc.repo.CreateVersion(ctx, prev, next)
+	if err != nil {
+		return domain.ExercisePlan{}, nil, err
+	}
+	return out, nil, nil
+}
+
+// ---------- Cierre automático (lo corre el job periódico)
+
+type CloseExpiredPlansUseCase struct {
+	repo domain.Repository
+}
+
+func NewCloseExpiredPlansUseCase(repo domain.Repository) *CloseExpiredPlansUseCase {
+	return &CloseExpiredPlansUseCase{repo: repo}
+}
+
+// Execute cierra los planes vencidos y devuelve cuántos cerró.
+func (uc *CloseExpiredPlansUseCase) Execute(ctx context.Context, now time.Time) (int64, error) {
+	return uc.repo.CloseExpired(ctx, now.UTC(), domain.CloseReasonCompleted)
+}
+
+func trimPtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := strings.TrimSpace(*s)
+	if v == "" {
+		return nil
+	}
+	return &v
+}
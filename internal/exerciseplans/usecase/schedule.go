@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/clinictime"
+	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+// validatePhases normaliza las fases: nombre único, semanas dentro de la duración y sin superponerse.
+func validatePhases(in []domain.PlanPhase, durationWeeks int, validation *validation.Errors) []domain.PlanPhase {
+	out := make([]domain.PlanPhase, 0, len(in))
+	seen := map[string]bool{}
+	for i, ph := range in {
+		prefix := fmt.Sprintf("phases[%d].", i)
+		ph.Name = strings.TrimSpace(ph.Name)
+		switch {
+		case ph.Name == "":
+			validation.Add(prefix+"name", "required")
+		case seen[ph.Name]:
+			validation.Add(prefix+"name", "duplicated")
+		}
+		seen[ph.Name] = true
+		if ph.StartWeek < 1 {
+			validation.Add(prefix+"start_week", "must_be_>=_1")
+		}
+		if ph.EndWeek < ph.StartWeek {
+			validation.Add(prefix+"end_week", "must_be_>=_start_week")
+		} else if durationWeeks > 0 && ph.EndWeek > durationWeeks {
+			validation.Add(prefix+"end_week", "must_be_<=_duration_weeks")
+		}
+		out = append(out, ph)
+	}
+
+	sorted := append([]domain.PlanPhase(nil), out...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].StartWeek < sorted[j].StartWeek })
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].StartWeek <= sorted[i-1].EndWeek {
+			validation.Add("phases", "must_not_overlap")
+			break
+		}
+	}
+	return sorted
+}
+
+// validateSchedule revisa días, carga y progresión de un ítem y devuelve los días parseados.
+func validateSchedule(prefix string, it *CreatePlanItemInput, validation *validation.Errors) []time.Weekday {
+	var days []time.Weekday
+	seen := map[time.Weekday]bool{}
+	for i, s := range it.Weekdays {
+		d, ok := domain.ParseWeekday(strings.ToLower(strings.TrimSpace(s)))
+		if !ok {
+			validation.Add(fmt.Sprintf("%sweekdays[%d]", prefix, i), "must_be_mon_tue_wed_thu_fri_sat_or_sun")
+			continue
+		}
+		if !seen[d] {
+			seen[d] = true
+			days = append(days, d)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i] < days[j] })
+
+	if it.Load != nil && *it.Load < 0 {
+		validation.Add(prefix+"load", "must_be_>=_0")
+	}
+
+	if pr := it.Progression; pr != nil {
+		if pr.EveryWeeks == 0 {
+			pr.EveryWeeks = 1
+		}
+		if pr.EveryWeeks < 0 {
+			validation.Add(prefix+"progression.every_weeks", "must_be_>=_1")
+		}
+		if pr.SetsStep == 0 && pr.RepsStep == 0 && pr.LoadStep == 0 {
+			validation.Add(prefix+"progression", "must_have_a_step")
+		}
+		if pr.SetsStep != 0 && it.Sets == nil {
+			validation.Add(prefix+"progression.sets_step", "requires_sets")
+		}
+		if pr.RepsStep != 0 && it.Reps == nil {
+			validation.Add(prefix+"progression.reps_step", "requires_reps")
+		}
+		if pr.LoadStep != 0 && it.Load == nil {
+			validation.Add(prefix+"progression.load_step", "requires_load")
+		}
+	}
+	return days
+}
+
+func weekdayNames(days []time.Weekday) []string {
+	if len(days) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(days))
+	for _, d := range days {
+		out = append(out, domain.WeekdayName(d))
+	}
+	return out
+}
+
+// checkPhase verifica que la fase de un ítem (si tiene) sea una de las del plan.
+func checkPhase(prefix string, phase *string, phases []domain.PlanPhase, validation *validation.Errors) {
+	name := trimPtr(phase)
+	if name == nil {
+		return
+	}
+	for _, ph := range phases {
+		if ph.Name == *name {
+			return
+		}
+	}
+	validation.Add(prefix+"phase", "not_found")
+}
+
+// ---------- Prescripción para una fecha
+
+type GetPrescriptionUseCase struct {
+	repo domain.Repository
+	loc  *time.Location
+}
+
+func NewGetPrescriptionUseCase(repo domain.Repository, loc *time.Location) *GetPrescriptionUseCase {
+	return &GetPrescriptionUseCase{repo: repo, loc: loc}
+}
+
+// Execute calcula qué ejercicios tocan ese día (hoy en el consultorio si date no viene) y con
+// qué dosis.
+func (uc *GetPrescriptionUseCase) Execute(ctx context.Context, planID uuid.UUID, date *string) (domain.Prescription, *validation.Errors, error) {
+	validation := validation.New()
+	day := clinictime.Today(uc.loc)
+	if d := parseOptionalDate("date", date, validation); d != nil {
+		day = *d
+	}
+	if !validation.Empty() {
+		return domain.Prescription{}, validation, domain.ErrValidation
+	}
+
+	p, found, err := uc.repo.GetByID(ctx, planID)
+	if err != nil {
+		return domain.Prescription{}, nil, err
+	}
+	if !found {
+		return domain.Prescription{}, nil, domain.ErrNotFound
+	}
+	p = p.In(uc.loc)
+
+	from, to := p.LogWindow()
+	if day.Before(from) || day.After(to) {
+		validation.Add("date", "outside_plan_period")
+		return domain.Prescription{}, validation, domain.ErrValidation
+	}
+	return p.PrescriptionFor(day), nil, nil
+}
@@ -0,0 +1,352 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/exerciseplans/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+var templateCodeRe = regexp.MustCompile(`^[a-z0-9_]{3,50}$`)
+
+type TemplatePhaseInput struct {
+	Name          string                `json:"name"`
+	DurationWeeks int                   `json:"duration_weeks"`
+	Items         []CreatePlanItemInput `json:"items"`
+}
+
+type PlanTemplateInput struct {
+	Code        string               `json:"code"` // solo en el alta
+	Name        string               `json:"name"`
+	Description *string              `json:"description"`
+	Frequency   string               `json:"frequency"`
+	Phases      []TemplatePhaseInput `json:"phases"`
+}
+
+// buildTemplate valida nombre, frecuencia y fases, y resuelve los ítems contra el catálogo.
+func buildTemplate(ctx context.Context, catalog domain.Catalog, in PlanTemplateInput, t *domain.PlanTemplate, validation *validation.Errors) error {
+	t.Name = strings.TrimSpace(in.Name)
+	if t.Name == "" {
+		validation.Add("name", "required")
+	}
+	t.Description = trimPtr(in.Description)
+
+	t.Frequency = domain.Frequency(strings.TrimSpace(in.Frequency))
+	if t.Frequency != domain.FrequencyDaily && t.Frequency != domain.FrequencyWeekly {
+		validation.Add("frequency", "must_be_daily_or_weekly")
+	}
+
+	if len(in.Phases) == 0 {
+		validation.Add("phases", "must_have_at_least_one_phase")
+	}
+	t.Phases = make([]domain.TemplatePhase, 0, len(in.Phases))
+	for i, ph := range in.Phases {
+		prefix := fmt.Sprintf("phases[%d].", i)
+		phase := domain.TemplatePhase{
+			Name:          strings.TrimSpace(ph.Name),
+			DurationWeeks: ph.DurationWeeks,
+			Items:         make([]domain.TemplateItem, 0, len(ph.Items)),
+		}
+		if phase.Name == "" {
+			validation.Add(prefix+"name", "required")
+		}
+		if phase.DurationWeeks <= 0 {
+			validation.Add(prefix+"duration_weeks", "must_be_>=_1")
+		}
+		if len(ph.Items) == 0 {
+			validation.Add(prefix+"items", "must_have_at_least_one_item")
+		}
+		for j, it := range ph.Items {
+			r, err := resolveItem(ctx, catalog, fmt.Sprintf("%sitems[%d].", prefix, j), it, validation)
+			if err != nil {
+				return err
+			}
+			phase.Items = append(phase.Items, domain.TemplateItem{
+				ExerciseID:       r.exerciseID,
+				Name:             strings.TrimSpace(r.Name),
+				Description:      r.Description,
+				VideoURL:         r.VideoURL,
+				GuideURL:         r.GuideURL,
+				EstimatedMinutes: r.EstimatedMinutes,
+				Sets:             r.Sets,
+				Reps:             r.Reps,
+				Load:             r.Load,
+				LoadUnit:         trimPtr(r.LoadUnit),
+				Weekdays:         weekdayNames(r.weekdays),
+				Progression:      r.Progression,
+			})
+		}
+		t.Phases = append(t.Phases, phase)
+	}
+	return nil
+}
+
+// ---------- Alta
+
+type CreatePlanTemplateUseCase struct {
+	repo    domain.Repository
+	catalog domain.Catalog
+}
+
+func NewCreatePlanTemplateUseCase(repo domain.Repository, catalog domain.Catalog) *CreatePlanTemplateUseCase {
+	return &CreatePlanTemplateUseCase{repo: repo, catalog: catalog}
+}
+
+func (uc *CreatePlanTemplateUseCase) Execute(ctx context.Context, in PlanTemplateInput) (domain.PlanTemplate, *validation.Errors, error) {
+	validation := validation.New()
+
+	t := domain.PlanTemplate{
+		ID:        uuid.New(),
+		Code:      strings.ToLower(strings.TrimSpace(in.Code)),
+		Version:   1,
+		IsLatest:  true,
+		CreatedAt: time.Now().UTC(),
+	}
+	if !templateCodeRe.MatchString(t.Code) {
+		validation.Add("code", "invalid_code_(a-z0-9_)")
+	} else {
+		exists, err := uc.repo.ExistsTemplateCode(ctx, t.Code)
+		if err != nil {
+			return domain.PlanTemplate{}, nil, err
+		}
+		if exists {
+			validation.Add("code", "already_exists")
+		}
+	}
+	if err := buildTemplate(ctx, uc.catalog, in, &t, validation); err != nil {
+		return domain.PlanTemplate{}, nil, err
+	}
+	if !validation.Empty() {
+		return domain.PlanTemplate{}, validation, domain.ErrValidation
+	}
+
+	out, err := uc.repo.CreateTemplate(ctx, t)
+	if err != nil {
+		return domain.PlanTemplate{}, nil, err
+	}
+	return out, nil, nil
+}
+
+// ---------- Nueva versión
+
+type UpdatePlanTemplateUseCase struct {
+	repo    domain.Repository
+	catalog domain.Catalog
+}
+
+func NewUpdatePlanTemplateUseCase(repo domain.Repository, catalog domain.Catalog) *UpdatePlanTemplateUseCase {
+	return &UpdatePlanTemplateUseCase{repo: repo, catalog: catalog}
+}
+
+// Execute no modifica la plantilla: crea la versión siguiente (mismo code) a partir de la vigente.
+func (uc *UpdatePlanTemplateUseCase) Execute(ctx context.Context, id uuid.UUID, in PlanTemplateInput) (domain.PlanTemplate, *validation.Errors, error) {
+	validation := validation.New()
+
+	prev, found, err := uc.repo.GetTemplate(ctx, id)
+	if err != nil {
+		return domain.PlanTemplate{}, nil, err
+	}
+	if !found {
+		return domain.PlanTemplate{}, nil, domain.ErrNotFound
+	}
+	if !prev.IsLatest {
+		return domain.PlanTemplate{}, nil, domain.ErrTemplateOutdated
+	}
+
+	t := domain.PlanTemplate{
+		ID:        uuid.New(),
+		Code:      prev.Code,
+		Version:   prev.Version + 1,
+		IsLatest:  true,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := buildTemplate(ctx, uc.catalog, in, &t, validation); err != nil {
+		return domain.PlanTemplate{}, nil, err
+	}
+	if !validation.Empty() {
+		return domain.PlanTemplate{}, validation, domain.ErrValidation
+	}
+
+	out, err := uc.repo.CreateTemplateVersion(ctx, prev.ID, t)
+	if err != nil {
+		return domain.PlanTemplate{}, nil, err
+	}
+	return out, nil, nil
+}
+
+// ---------- Consulta
+
+type ListPlanTemplatesUseCase struct {
+	repo domain.Repository
+}
+
+func NewListPlanTemplatesUseCase(repo domain.Repository) *ListPlanTemplatesUseCase {
+	return &ListPlanTemplatesUseCase{repo: repo}
+}
+
+func (uc *ListPlanTemplatesUseCase) Execute(ctx context.Context, code string) ([]domain.PlanTemplate, error) {
+	return uc.repo.ListTemplates(ctx, strings.ToLower(strings.TrimSpace(code)))
+}
+
+type GetPlanTemplateUseCase struct {
+	repo domain.Repository
+}
+
+func NewGetPlanTemplateUseCase(repo domain.Repository) *GetPlanTemplateUseCase {
+	return &GetPlanTemplateUseCase{repo: repo}
+}
+
+func (uc *GetPlanTemplateUseCase) Execute(ctx context.Context, id uuid.UUID) (domain.PlanTemplate, bool, error) {
+	return uc.repo.GetTemplate(ctx, id)
+}
+
+// ---------- Aplicar a un paciente
+
+// TemplateItemOverride pisa valores de un ítem de la fase para este paciente.
+type TemplateItemOverride struct {
+	Index            int     `json:"index"`
+	Description      *string `json:"description"`
+	EstimatedMinutes *int    `json:"estimated_minutes"`
+	Sets             *int    `json:"sets"`
+	Reps             *int    `json:"reps"`
+}
+
+type ApplyPlanTemplateInput struct {
+	PatientID       string  `json:"-"`
+	KinesiologistID string  `json:"kinesiologist_id"`
+	DiagnosisID     *string `json:"diagnosis_id"`
+	InjuryID        *string `json:"injury_id"`
+	Phase           int     `json:"phase"` // índice de la fase (0 = primera)
+
+	// Ajustes por paciente (opcionales)
+	Frequency     *string                `json:"frequency"`
+	DurationWeeks *int                   `json:"duration_weeks"`
+	Observations  *string                `json:"observations"`
+	ExcludeItems  []int                  `json:"exclude_items"`
+	Overrides     []TemplateItemOverride `json:"overrides"`
+	ExtraItems    []CreatePlanItemInput  `json:"extra_items"`
+}
+
+type ApplyPlanTemplateUseCase struct {
+	repo     domain.Repository
+	createUC *CreatePlanUseCase
+}
+
+func NewApplyPlanTemplateUseCase(repo domain.Repository, createUC *CreatePlanUseCase) *ApplyPlanTemplateUseCase {
+	return &ApplyPlanTemplateUseCase{repo: repo, createUC: createUC}
+}
+
+// Execute arma un CreatePlanInput con la fase elegida + los ajustes y lo crea con CreatePlanUseCase,
+// así el plan pasa por las mismas validaciones que uno cargado a mano.
+func (uc *ApplyPlanTemplateUseCase) Execute(ctx context.Context, templateID uuid.UUID, in ApplyPlanTemplateInput) (domain.ExercisePlan, *validation.Errors, error) {
+	t, found, err := uc.repo.GetTemplate(ctx, templateID)
+	if err != nil {
+		return domain.ExercisePlan{}, nil, err
+	}
+	if !found {
+		return domain.ExercisePlan{}, nil, domain.ErrNotFound
+	}
+
+	validation := validation.New()
+	if in.Phase < 0 || in.Phase >= len(t.Phases) {
+		validation.Add("phase", fmt.Sprintf("must_be_between_0_and_%d", len(t.Phases)-1))
+		return domain.ExercisePlan{}, validation, domain.ErrValidation
+	}
+	phase := t.Phases[in.Phase]
+
+	excluded := map[int]bool{}
+	for i, idx := range in.ExcludeItems {
+		if idx < 0 || idx >= len(phase.Items) {
+			validation.Add(fmt.Sprintf("exclude_items[%d]", i), "out_of_range")
+		}
+		excluded[idx] = true
+	}
+	overrides := map[int]TemplateItemOverride{}
+	for i, o := range in.Overrides {
+		if o.Index < 0 || o.Index >= len(phase.Items) {
+			validation.Add(fmt.Sprintf("overrides[%d].index", i), "out_of_range")
+		}
+		overrides[o.Index] = o
+	}
+	if !validation.Empty() {
+		return domain.ExercisePlan{}, validation, domain.ErrValidation
+	}
+
+	items := make([]CreatePlanItemInput, 0, len(phase.Items)+len(in.ExtraItems))
+	for i, it := range phase.Items {
+		if excluded[i] {
+			continue
+		}
+		item := CreatePlanItemInput{
+			ExerciseID:       uuidPtrToString(it.ExerciseID),
+			Name:             it.Name,
+			Description:      it.Description,
+			VideoURL:         it.VideoURL,
+			GuideURL:         it.GuideURL,
+			EstimatedMinutes: it.EstimatedMinutes,
+			Sets:             it.Sets,
+			Reps:             it.Reps,
+			Load:             it.Load,
+			LoadUnit:         it.LoadUnit,
+			Weekdays:         it.Weekdays,
+			Progression:      it.Progression,
+		}
+		if o, ok := overrides[i]; ok {
+			if o.Description != nil {
+				item.Description = o.Description
+			}
+			if o.EstimatedMinutes != nil {
+				item.EstimatedMinutes = *o.EstimatedMinutes
+			}
+			if o.Sets != nil {
+				item.Sets = o.Sets
+			}
+			if o.Reps != nil {
+				item.Reps = o.Reps
+			}
+		}
+		items = append(items, item)
+	}
+	items = append(items, in.ExtraItems...)
+
+	frequency := string(t.Frequency)
+	if in.Frequency != nil {
+		frequency = *in.Frequency
+	}
+	weeks := phase.DurationWeeks
+	if in.DurationWeeks != nil {
+		weeks = *in.DurationWeeks
+	}
+	observations := in.Observations
+	if observations == nil {
+		s := fmt.Sprintf("%s (v%d) - %s", t.Name, t.Version, phase.Name)
+		observations = &s
+	}
+
+	id := t.ID
+	return uc.createUC.Execute(ctx, CreatePlanInput{
+		PatientID:       in.PatientID,
+		KinesiologistID: in.KinesiologistID,
+		DiagnosisID:     in.DiagnosisID,
+		InjuryID:        in.InjuryID,
+		Frequency:       frequency,
+		DurationWeeks:   weeks,
+		Observations:    observations,
+		Items:           items,
+		TemplateID:      &id,
+	})
+}
+
+func uuidPtrToString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
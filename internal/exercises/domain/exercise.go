@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Categorías fijas del catálogo. Las etiquetas (Tags) son libres.
+var (
+	BodyRegions = []string{"cervical", "dorsal", "lumbar", "shoulder", "elbow", "wrist_hand", "hip", "knee", "ankle_foot", "core", "full_body"}
+	Goals       = []string{"mobility", "strength", "stability", "proprioception", "endurance", "stretching", "balance", "relaxation"}
+	Equipment   = []string{"none", "band", "dumbbell", "kettlebell", "ball", "foam_roller", "step", "bosu", "mat", "pulley"}
+)
+
+func IsOneOf(v string, options []string) bool {
+	for _, o := range options {
+		if v == o {
+			return true
+		}
+	}
+	return false
+}
+
+// Exercise es un ejercicio del catálogo del consultorio con sus valores por defecto.
+// Los ítems de un plan lo referencian y pueden pisar cualquiera de esos valores.
+type Exercise struct {
+	ID          uuid.UUID
+	Name        string
+	Description *string
+	BodyRegion  string
+	Goal        string
+	Equipment   []string
+	Tags        []string
+
+	VideoURL *string
+	GuideURL *string
+
+	DefaultSets    *int
+	DefaultReps    *int
+	DefaultMinutes int
+
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NormalizeTag: minúsculas, sin espacios al borde y con guiones bajos en lugar de espacios.
+func NormalizeTag(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), "_")
+}
+
+type SearchQuery struct {
+	Text            string // nombre o descripción
+	BodyRegion      string
+	Goal            string
+	Equipment       string
+	Tags            []string // debe tener todas
+	IncludeInactive bool
+	Limit           int
+}
@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, e Exercise) (Exercise, error)
+	Update(ctx context.Context, e Exercise) (Exercise, error)
+	GetByID(ctx context.Context, id uuid.UUID) (Exercise, bool, error)
+	Search(ctx context.Context, q SearchQuery) ([]Exercise, error)
+	ExistsName(ctx context.Context, name string, excludeID *uuid.UUID) (bool, error)
+}
@@ -0,0 +1,168 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/exercises/domain"
+	"github.com/javiacuna/kinesio-backend/internal/exercises/usecase"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+type Handler struct {
+	createUC *usecase.CreateExerciseUseCase
+	updateUC *usecase.UpdateExerciseUseCase
+	getUC    *usecase.GetExerciseUseCase
+	searchUC *usecase.SearchExercisesUseCase
+}
+
+func NewHandler(createUC *usecase.CreateExerciseUseCase, updateUC *usecase.UpdateExerciseUseCase,
+	getUC *usecase.GetExerciseUseCase, searchUC *usecase.SearchExercisesUseCase) *Handler {
+	return &Handler{createUC: createUC, updateUC: updateUC, getUC: getUC, searchUC: searchUC}
+}
+
+type exerciseResponse struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	Description    *string  `json:"description,omitempty"`
+	BodyRegion     string   `json:"body_region"`
+	Goal           string   `json:"goal"`
+	Equipment      []string `json:"equipment"`
+	Tags           []string `json:"tags"`
+	VideoURL       *string  `json:"video_url,omitempty"`
+	GuideURL       *string  `json:"guide_url,omitempty"`
+	DefaultSets    *int     `json:"default_sets,omitempty"`
+	DefaultReps    *int     `json:"default_reps,omitempty"`
+	DefaultMinutes int      `json:"default_minutes"`
+	Active         bool     `json:"active"`
+	CreatedAt      string   `json:"created_at"`
+	UpdatedAt      string   `json:"updated_at"`
+}
+
+func (h *Handler) Create(c *gin.Context) {
+	var req usecase.ExerciseInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	out, validation, err := h.createUC.Execute(c.Request.Context(), req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusCreated, toResponse(out))
+}
+
+func (h *Handler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("exercise_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_exercise_id"})
+		return
+	}
+	var req usecase.ExerciseInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	out, validation, err := h.updateUC.Execute(c.Request.Context(), id, req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusOK, toResponse(out))
+}
+
+func (h *Handler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("exercise_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_exercise_id"})
+		return
+	}
+	e, found, err := h.getUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+		return
+	}
+	c.JSON(http.StatusOK, toResponse(e))
+}
+
+// Search: ?q=sentadilla&body_region=knee&goal=strength&equipment=band&tags=post_lca,fase_1&include_inactive=true&limit=50
+func (h *Handler) Search(c *gin.Context) {
+	limit := 0
+	if s := c.Query("limit"); s != "" {
+		if n, err := strconv.Atoi(s); err == nil {
+			limit = n
+		}
+	}
+
+	items, validation, err := h.searchUC.Execute(c.Request.Context(), usecase.SearchExercisesInput{
+		Query:           c.Query("q"),
+		BodyRegion:      c.Query("body_region"),
+		Goal:            c.Query("goal"),
+		Equipment:       c.Query("equipment"),
+		Tags:            c.Query("tags"),
+		IncludeInactive: c.Query("include_inactive") == "true",
+		Limit:           limit,
+	})
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+
+	out := make([]exerciseResponse, 0, len(items))
+	for _, e := range items {
+		out = append(out, toResponse(e))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// Categories devuelve las opciones válidas para los filtros del front.
+func (h *Handler) Categories(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"body_regions": domain.BodyRegions,
+		"goals":        domain.Goals,
+		"equipment":    domain.Equipment,
+	})
+}
+
+func writeError(c *gin.Context, err error, validation *validation.Errors) {
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+	}
+}
+
+func toResponse(e domain.Exercise) exerciseResponse {
+	return exerciseResponse{
+		ID:             e.ID.String(),
+		Name:           e.Name,
+		Description:    e.Description,
+		BodyRegion:     e.BodyRegion,
+		Goal:           e.Goal,
+		Equipment:      e.Equipment,
+		Tags:           e.Tags,
+		VideoURL:       e.VideoURL,
+		GuideURL:       e.GuideURL,
+		DefaultSets:    e.DefaultSets,
+		DefaultReps:    e.DefaultReps,
+		DefaultMinutes: e.DefaultMinutes,
+		Active:         e.Active,
+		CreatedAt:      e.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:      e.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,23 @@
+package gorm
+
+import "time"
+
+type ExerciseModel struct {
+	ID             string `gorm:"type:uuid;primaryKey"`
+	Name           string `gorm:"not null"`
+	Description    *string
+	BodyRegion     string `gorm:"not null"`
+	Goal           string `gorm:"not null"`
+	Equipment      string `gorm:"type:jsonb;not null"`
+	Tags           string `gorm:"type:jsonb;not null"`
+	VideoURL       *string
+	GuideURL       *string
+	DefaultSets    *int
+	DefaultReps    *int
+	DefaultMinutes int  `gorm:"not null"`
+	Active         bool `gorm:"not null"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (ExerciseModel) TableName() string { return "exercises" }
@@ -0,0 +1,164 @@
+package gorm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/javiacuna/kinesio-backend/internal/exercises/domain"
+)
+
+var _ domain.Repository = (*Repository)(nil)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(ctx context.Context, e domain.Exercise) (domain.Exercise, error) {
+	m, err := toModel(e)
+	if err != nil {
+		return domain.Exercise{}, err
+	}
+	if err := r.db.WithContext(ctx).Create(&m).Error; err != nil {
+		return domain.Exercise{}, err
+	}
+	return e, nil
+}
+
+func (r *Repository) Update(ctx context.Context, e domain.Exercise) (domain.Exercise, error) {
+	m, err := toModel(e)
+	if err != nil {
+		return domain.Exercise{}, err
+	}
+	if err := r.db.WithContext(ctx).Save(&m).Error; err != nil {
+		return domain.Exercise{}, err
+	}
+	return e, nil
+}
+
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (domain.Exercise, bool, error) {
+	var m ExerciseModel
+	err := r.db.WithContext(ctx).First(&m, "id = ?", id.String()).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.Exercise{}, false, nil
+		}
+		return domain.Exercise{}, false, err
+	}
+	e, err := toDomain(m)
+	if err != nil {
+		return domain.Exercise{}, false, err
+	}
+	return e, true, nil
+}
+
+func (r *Repository) Search(ctx context.Context, q domain.SearchQuery) ([]domain.Exercise, error) {
+	tx := r.db.WithContext(ctx).Model(&ExerciseModel{})
+	if q.Text != "" {
+		like := "%" + strings.ToLower(q.Text) + "%"
+		tx = tx.Where("lower(name) LIKE ? OR lower(coalesce(description, '')) LIKE ?", like, like)
+	}
+	if q.BodyRegion != "" {
+		tx = tx.Where("body_region = ?", q.BodyRegion)
+	}
+	if q.Goal != "" {
+		tx = tx.Where("goal = ?", q.Goal)
+	}
+	if q.Equipment != "" {
+		eq, _ := json.Marshal([]string{q.Equipment})
+		tx = tx.Where("equipment @> ?::jsonb", string(eq))
+	}
+	if len(q.Tags) > 0 {
+		tags, _ := json.Marshal(q.Tags)
+		tx = tx.Where("tags @> ?::jsonb", string(tags))
+	}
+	if !q.IncludeInactive {
+		tx = tx.Where("active = ?", true)
+	}
+
+	var ms []ExerciseModel
+	if err := tx.Order("name asc").Limit(q.Limit).Find(&ms).Error; err != nil {
+		return nil, err
+	}
+
+	out := make([]domain.Exercise, 0, len(ms))
+	for _, m := range ms {
+		e, err := toDomain(m)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+func (r *Repository) ExistsName(ctx context.Context, name string, excludeID *uuid.UUID) (bool, error) {
+	tx := r.db.WithContext(ctx).Model(&ExerciseModel{}).Where("lower(name) = lower(?)", name)
+	if excludeID != nil {
+		tx = tx.Where("id <> ?", excludeID.String())
+	}
+	var n int64
+	err := tx.Count(&n).Error
+	return n > 0, err
+}
+
+func toModel(e domain.Exercise) (ExerciseModel, error) {
+	equipment, err := json.Marshal(e.Equipment)
+	if err != nil {
+		return ExerciseModel{}, err
+	}
+	tags, err := json.Marshal(e.Tags)
+	if err != nil {
+		return ExerciseModel{}, err
+	}
+	return ExerciseModel{
+		ID:             e.ID.String(),
+		Name:           e.Name,
+		Description:    e.Description,
+		BodyRegion:     e.BodyRegion,
+		Goal:           e.Goal,
+		Equipment:      string(equipment),
+		Tags:           string(tags),
+		VideoURL:       e.VideoURL,
+		GuideURL:       e.GuideURL,
+		DefaultSets:    e.DefaultSets,
+		DefaultReps:    e.DefaultReps,
+		DefaultMinutes: e.DefaultMinutes,
+		Active:         e.Active,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}, nil
+}
+
+func toDomain(m ExerciseModel) (domain.Exercise, error) {
+	e := domain.Exercise{
+		ID:             uuid.MustParse(m.ID),
+		Name:           m.Name,
+		Description:    m.Description,
+		BodyRegion:     m.BodyRegion,
+		Goal:           m.Goal,
+		VideoURL:       m.VideoURL,
+		GuideURL:       m.GuideURL,
+		DefaultSets:    m.DefaultSets,
+		DefaultReps:    m.DefaultReps,
+		DefaultMinutes: m.DefaultMinutes,
+		Active:         m.Active,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+	if err := json.Unmarshal([]byte(m.Equipment), &e.Equipment); err != nil {
+		return domain.Exercise{}, err
+	}
+	if err := json.Unmarshal([]byte(m.Tags), &e.Tags); err != nil {
+		return domain.Exercise{}, err
+	}
+	return e, nil
+}
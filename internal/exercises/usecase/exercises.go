@@ -0,0 +1,290 @@
+package usecase
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/exercises/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+// ExerciseInput se usa en alta y en edición (en edición, los nil no se tocan).
+type ExerciseInput struct {
+	Name           *string  `json:"name"`
+	Description    *string  `json:"description"`
+	BodyRegion     *string  `json:"body_region"`
+	Goal           *string  `json:"goal"`
+	Equipment      []string `json:"equipment"`
+	Tags           []string `json:"tags"`
+	VideoURL       *string  `json:"video_url"`
+	GuideURL       *string  `json:"guide_url"`
+	DefaultSets    *int     `json:"default_sets"`
+	DefaultReps    *int     `json:"default_reps"`
+	DefaultMinutes *int     `json:"default_minutes"`
+	Active         *bool    `json:"active"`
+}
+
+// apply vuelca los campos presentes de in sobre e y valida el resultado.
+func apply(in ExerciseInput, e *domain.Exercise, validation *validation.Errors) {
+	if in.Name != nil {
+		e.Name = strings.TrimSpace(*in.Name)
+	}
+	if e.Name == "" {
+		validation.Add("name", "required")
+	}
+	if in.Description != nil {
+		e.Description = trimPtr(in.Description)
+	}
+	if in.BodyRegion != nil {
+		e.BodyRegion = strings.ToLower(strings.TrimSpace(*in.BodyRegion))
+	}
+	if !domain.IsOneOf(e.BodyRegion, domain.BodyRegions) {
+		validation.Add("body_region", "invalid_body_region")
+	}
+	if in.Goal != nil {
+		e.Goal = strings.ToLower(strings.TrimSpace(*in.Goal))
+	}
+	if !domain.IsOneOf(e.Goal, domain.Goals) {
+		validation.Add("goal", "invalid_goal")
+	}
+	if in.Equipment != nil {
+		e.Equipment = uniqueNormalized(in.Equipment)
+	}
+	for _, eq := range e.Equipment {
+		if !domain.IsOneOf(eq, domain.Equipment) {
+			validation.Add("equipment", "invalid_equipment")
+			break
+		}
+	}
+	if in.Tags != nil {
+		e.Tags = uniqueNormalized(in.Tags)
+	}
+
+	if in.VideoURL != nil {
+		e.VideoURL = trimPtr(in.VideoURL)
+	}
+	if in.GuideURL != nil {
+		e.GuideURL = trimPtr(in.GuideURL)
+	}
+	checkURL("video_url", e.VideoURL, validation)
+	checkURL("guide_url", e.GuideURL, validation)
+
+	if in.DefaultSets != nil {
+		e.DefaultSets = in.DefaultSets
+	}
+	if in.DefaultReps != nil {
+		e.DefaultReps = in.DefaultReps
+	}
+	if e.DefaultSets != nil && *e.DefaultSets <= 0 {
+		validation.Add("default_sets", "must_be_>_0")
+	}
+	if e.DefaultReps != nil && *e.DefaultReps <= 0 {
+		validation.Add("default_reps", "must_be_>_0")
+	}
+	if in.DefaultMinutes != nil {
+		e.DefaultMinutes = *in.DefaultMinutes
+	}
+	if e.DefaultMinutes <= 0 {
+		validation.Add("default_minutes", "must_be_>_0")
+	}
+	if in.Active != nil {
+		e.Active = *in.Active
+	}
+}
+
+// ---------- Alta
+
+type CreateExerciseUseCase struct {
+	repo domain.Repository
+}
+
+func NewCreateExerciseUseCase(repo domain.Repository) *CreateExerciseUseCase {
+	return &CreateExerciseUseCase{repo: repo}
+}
+
+func (uc *CreateExerciseUseCase) Execute(ctx context.Context, in ExerciseInput) (domain.Exercise, *validation.Errors, error) {
+	validation := validation.New()
+
+	now := time.Now().UTC()
+	e := domain.Exercise{
+		ID:             uuid.New(),
+		Equipment:      []string{},
+		Tags:           []string{},
+		DefaultMinutes: 10,
+		Active:         true,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	apply(in, &e, validation)
+
+	if !validation.Has("name") {
+		exists, err := uc.repo.ExistsName(ctx, e.Name, nil)
+		if err != nil {
+			return domain.Exercise{}, nil, err
+		}
+		if exists {
+			validation.Add("name", "already_exists")
+		}
+	}
+	if !validation.Empty() {
+		return domain.Exercise{}, validation, domain.ErrValidation
+	}
+
+	out, err := uc.repo.Create(ctx, e)
+	if err != nil {
+		return domain.Exercise{}, nil, err
+	}
+	return out, nil, nil
+}
+
+// ---------- Edición
+
+type UpdateExerciseUseCase struct {
+	repo domain.Repository
+}
+
+func NewUpdateExerciseUseCase(repo domain.Repository) *UpdateExerciseUseCase {
+	return &UpdateExerciseUseCase{repo: repo}
+}
+
+// Execute edita el ejercicio del catálogo. Los planes ya armados no cambian: copiaron los valores al crearse.
+func (uc *UpdateExerciseUseCase) Execute(ctx context.Context, id uuid.UUID, in ExerciseInput) (domain.Exercise, *validation.Errors, error) {
+	validation := validation.New()
+
+	e, found, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.Exercise{}, nil, err
+	}
+	if !found {
+		return domain.Exercise{}, nil, domain.ErrNotFound
+	}
+
+	apply(in, &e, validation)
+	if !validation.Has("name") && in.Name != nil {
+		exists, err := uc.repo.ExistsName(ctx, e.Name, &e.ID)
+		if err != nil {
+			return domain.Exercise{}, nil, err
+		}
+		if exists {
+			validation.Add("name", "already_exists")
+		}
+	}
+	if !validation.Empty() {
+		return domain.Exercise{}, validation, domain.ErrValidation
+	}
+
+	e.UpdatedAt = time.Now().UTC()
+	out, err := uc.repo.Update(ctx, e)
+	if err != nil {
+		return domain.Exercise{}, nil, err
+	}
+	return out, nil, nil
+}
+
+// ---------- Consulta
+
+type GetExerciseUseCase struct {
+	repo domain.Repository
+}
+
+func NewGetExerciseUseCase(repo domain.Repository) *GetExerciseUseCase {
+	return &GetExerciseUseCase{repo: repo}
+}
+
+func (uc *GetExerciseUseCase) Execute(ctx context.Context, id uuid.UUID) (domain.Exercise, bool, error) {
+	return uc.repo.GetByID(ctx, id)
+}
+
+type SearchExercisesInput struct {
+	Query           string
+	BodyRegion      string
+	Goal            string
+	Equipment       string
+	Tags            string // separados por coma
+	IncludeInactive bool
+	Limit           int
+}
+
+type SearchExercisesUseCase struct {
+	repo domain.Repository
+}
+
+func NewSearchExercisesUseCase(repo domain.Repository) *SearchExercisesUseCase {
+	return &SearchExercisesUseCase{repo: repo}
+}
+
+func (uc *SearchExercisesUseCase) Execute(ctx context.Context, in SearchExercisesInput) ([]domain.Exercise, *validation.Errors, error) {
+	validation := validation.New()
+
+	q := domain.SearchQuery{
+		Text:            strings.TrimSpace(in.Query),
+		BodyRegion:      strings.ToLower(strings.TrimSpace(in.BodyRegion)),
+		Goal:            strings.ToLower(strings.TrimSpace(in.Goal)),
+		Equipment:       strings.ToLower(strings.TrimSpace(in.Equipment)),
+		IncludeInactive: in.IncludeInactive,
+		Limit:           in.Limit,
+	}
+	if q.BodyRegion != "" && !domain.IsOneOf(q.BodyRegion, domain.BodyRegions) {
+		validation.Add("body_region", "invalid_body_region")
+	}
+	if q.Goal != "" && !domain.IsOneOf(q.Goal, domain.Goals) {
+		validation.Add("goal", "invalid_goal")
+	}
+	if q.Equipment != "" && !domain.IsOneOf(q.Equipment, domain.Equipment) {
+		validation.Add("equipment", "invalid_equipment")
+	}
+	if !validation.Empty() {
+		return nil, validation, domain.ErrValidation
+	}
+	if in.Tags != "" {
+		q.Tags = uniqueNormalized(strings.Split(in.Tags, ","))
+	}
+	if q.Limit <= 0 || q.Limit > 200 {
+		q.Limit = 50
+	}
+
+	items, err := uc.repo.Search(ctx, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return items, nil, nil
+}
+
+func uniqueNormalized(values []string) []string {
+	out := make([]string, 0, len(values))
+	seen := map[string]bool{}
+	for _, v := range values {
+		v = domain.NormalizeTag(v)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		out = append(out, v)
+	}
+	return out
+}
+
+func checkURL(field string, s *string, validation *validation.Errors) {
+	if s == nil {
+		return
+	}
+	u, err := url.Parse(*s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		validation.Add(field, "invalid_url")
+	}
+}
+
+func trimPtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := strings.TrimSpace(*s)
+	if v == "" {
+		return nil
+	}
+	return &v
+}
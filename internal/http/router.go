@@ -21,14 +21,50 @@ import (
 	kineRepo "github.com/javiacuna/kinesio-backend/internal/kinesiologists/infra/gorm"
 	kineUC "github.com/javiacuna/kinesio-backend/internal/kinesiologists/usecase"
 
+	exercisePlanDomain "github.com/javiacuna/kinesio-backend/internal/exerciseplans/domain"
 	exercisePlanHTTP "github.com/javiacuna/kinesio-backend/internal/exerciseplans/http"
+	exercisePlanDirectory "github.com/javiacuna/kinesio-backend/internal/exerciseplans/infra/directory"
+	exercisePlanExercises "github.com/javiacuna/kinesio-backend/internal/exerciseplans/infra/exercises"
 	exercisePlanGorm "github.com/javiacuna/kinesio-backend/internal/exerciseplans/infra/gorm"
+	exercisePlanPDF "github.com/javiacuna/kinesio-backend/internal/exerciseplans/infra/pdf"
 	exercisePlanUC "github.com/javiacuna/kinesio-backend/internal/exerciseplans/usecase"
 
+	exercisesHTTP "github.com/javiacuna/kinesio-backend/internal/exercises/http"
+	exercisesGorm "github.com/javiacuna/kinesio-backend/internal/exercises/infra/gorm"
+	exercisesUC "github.com/javiacuna/kinesio-backend/internal/exercises/usecase"
+
+	chHTTP "github.com/javiacuna/kinesio-backend/internal/clinicalhistory/http"
+	chGorm "github.com/javiacuna/kinesio-backend/internal/clinicalhistory/infra/gorm"
+	chUC "github.com/javiacuna/kinesio-backend/internal/clinicalhistory/usecase"
+
 	evoHTTP "github.com/javiacuna/kinesio-backend/internal/evolutions/http"
 	evoGorm "github.com/javiacuna/kinesio-backend/internal/evolutions/infra/gorm"
+	evoRefs "github.com/javiacuna/kinesio-backend/internal/evolutions/infra/references"
 	evoUC "github.com/javiacuna/kinesio-backend/internal/evolutions/usecase"
 
+	attHTTP "github.com/javiacuna/kinesio-backend/internal/attachments/http"
+	attGorm "github.com/javiacuna/kinesio-backend/internal/attachments/infra/gorm"
+	attLinks "github.com/javiacuna/kinesio-backend/internal/attachments/infra/links"
+	attStorage "github.com/javiacuna/kinesio-backend/internal/attachments/infra/localfs"
+	attUC "github.com/javiacuna/kinesio-backend/internal/attachments/usecase"
+
+	msHTTP "github.com/javiacuna/kinesio-backend/internal/measurements/http"
+	msEvolutions "github.com/javiacuna/kinesio-backend/internal/measurements/infra/evolutions"
+	msGorm "github.com/javiacuna/kinesio-backend/internal/measurements/infra/gorm"
+	msUC "github.com/javiacuna/kinesio-backend/internal/measurements/usecase"
+
+	tlHTTP "github.com/javiacuna/kinesio-backend/internal/timeline/http"
+	tlGorm "github.com/javiacuna/kinesio-backend/internal/timeline/infra/gorm"
+	tlUC "github.com/javiacuna/kinesio-backend/internal/timeline/usecase"
+
+	anHTTP "github.com/javiacuna/kinesio-backend/internal/analytics/http"
+	anGorm "github.com/javiacuna/kinesio-backend/internal/analytics/infra/gorm"
+	anUC "github.com/javiacuna/kinesio-backend/internal/analytics/usecase"
+
+	rxHTTP "github.com/javiacuna/kinesio-backend/internal/prescriptions/http"
+	rxGorm "github.com/javiacuna/kinesio-backend/internal/prescriptions/infra/gorm"
+	rxUC "github.com/javiacuna/kinesio-backend/internal/prescriptions/usecase"
+
 	matHTTP "github.com/javiacuna/kinesio-backend/internal/materials/http"
 	matGorm "github.com/javiacuna/kinesio-backend/internal/materials/infra/gorm"
 	matUC "github.com/javiacuna/kinesio-backend/internal/materials/usecase"
@@ -63,12 +99,20 @@ func NewRouter(cfg config.Config, db *gorm.DB) http.Handler {
 	registerPatientUC := patientsUC.NewRegisterPatientUseCase(patientRepo)
 	getPatientByIDUC := patientsUC.NewGetPatientByIDUseCase(patientRepo)
 	searchPatients := patientsUC.NewSearchPatientsUseCase(patientRepo)
-	patientHandler := patientsHTTP.NewHandler(registerPatientUC, getPatientByIDUC, searchPatients)
+	importPatientsUC := patientsUC.NewImportPatientsUseCase(patientRepo)
+	updatePatientUC := patientsUC.NewUpdatePatientUseCase(patientRepo)
+	listInsurersUC := patientsUC.NewListInsurersUseCase()
+	patientHandler := patientsHTTP.NewHandler(registerPatientUC, getPatientByIDUC, searchPatients, importPatientsUC,
+		updatePatientUC, listInsurersUC)
+
+	// Órdenes médicas: los turnos las consultan al reservarse y consumen sesiones al atenderse
+	rxRepo := rxGorm.NewRepository(db)
+	rxCoverage := rxUC.NewCoverage(rxRepo, cfg.ClinicLocation)
 
 	apptRepo := appointmentsRepo.New(db)
-	createApptUC := appointmentsUC.NewCreateAppointmentUseCase(apptRepo)
+	createApptUC := appointmentsUC.NewCreateAppointmentUseCase(apptRepo, rxCoverage)
 	listDayUC := appointmentsUC.NewListAppointmentsDayUseCase(apptRepo)
-	updateApptUC := appointmentsUC.NewUpdateAppointmentUseCase(apptRepo)
+	updateApptUC := appointmentsUC.NewUpdateAppointmentUseCase(apptRepo, rxCoverage)
 
 	getApptByIDUC := appointmentsUC.NewGetAppointmentByIDUseCase(apptRepo)
 	listByPatientUC := appointmentsUC.NewListAppointmentsByPatientUseCase(apptRepo)
@@ -85,17 +129,116 @@ func NewRouter(cfg config.Config, db *gorm.DB) http.Handler {
 	listKUC := kineUC.NewListKinesiologistsUseCase(kRepo)
 	kHandler := kineHTTP.NewHandler(listKUC)
 
+	chRepo := chGorm.NewRepository(db)
+	clinicalRefs := chUC.NewReferenceChecker(chRepo)
+	chHandler := chHTTP.NewHandler(chHTTP.UseCases{
+		CreateDiagnosis:          chUC.NewCreateDiagnosisUseCase(chRepo),
+		UpdateDiagnosis:          chUC.NewUpdateDiagnosisUseCase(chRepo),
+		ListDiagnoses:            chUC.NewListDiagnosesUseCase(chRepo),
+		CreateInjury:             chUC.NewCreateInjuryUseCase(chRepo),
+		UpdateInjury:             chUC.NewUpdateInjuryUseCase(chRepo),
+		ListInjuries:             chUC.NewListInjuriesUseCase(chRepo),
+		CreateSurgery:            chUC.NewCreateSurgeryUseCase(chRepo),
+		UpdateSurgery:            chUC.NewUpdateSurgeryUseCase(chRepo),
+		ListSurgeries:            chUC.NewListSurgeriesUseCase(chRepo),
+		CreateMedication:         chUC.NewCreateMedicationUseCase(chRepo),
+		UpdateMedication:         chUC.NewUpdateMedicationUseCase(chRepo),
+		ListMedications:          chUC.NewListMedicationsUseCase(chRepo),
+		CreateAllergy:            chUC.NewCreateAllergyUseCase(chRepo),
+		UpdateAllergy:            chUC.NewUpdateAllergyUseCase(chRepo),
+		ListAllergies:            chUC.NewListAllergiesUseCase(chRepo),
+		CreateContraindication:   chUC.NewCreateContraindicationUseCase(chRepo),
+		UpdateContraindication:   chUC.NewUpdateContraindicationUseCase(chRepo),
+		ListContraindications:    chUC.NewListContraindicationsUseCase(chRepo),
+		CreateReferringPhysician: chUC.NewCreateReferringPhysicianUseCase(chRepo),
+		UpdateReferringPhysician: chUC.NewUpdateReferringPhysicianUseCase(chRepo),
+		ListReferringPhysicians:  chUC.NewListReferringPhysiciansUseCase(chRepo),
+		GetRecord:                chUC.NewGetClinicalRecordUseCase(chRepo),
+	})
+
+	exRepo := exercisesGorm.NewRepository(db)
+	exHandler := exercisesHTTP.NewHandler(
+		exercisesUC.NewCreateExerciseUseCase(exRepo),
+		exercisesUC.NewUpdateExerciseUseCase(exRepo),
+		exercisesUC.NewGetExerciseUseCase(exRepo),
+		exercisesUC.NewSearchExercisesUseCase(exRepo),
+	)
+	exCatalog := exercisePlanExercises.NewGateway(exRepo)
+
 	planRepo := exercisePlanGorm.NewRepository(db)
-	planCreateUC := exercisePlanUC.NewCreatePlanUseCase(planRepo)
+	planCreateUC := exercisePlanUC.NewCreatePlanUseCase(planRepo, clinicalRefs, exCatalog)
 	planListUC := exercisePlanUC.NewListPlansByPatientUseCase(planRepo)
 	planGetUC := exercisePlanUC.NewGetPlanByIDUseCase(planRepo)
-	planHandler := exercisePlanHTTP.NewHandler(planCreateUC, planListUC, planGetUC)
+	planHandler := exercisePlanHTTP.NewHandler(planCreateUC, planListUC, planGetUC,
+		exercisePlanUC.NewUpdatePlanUseCase(planRepo),
+		exercisePlanUC.NewClosePlanUseCase(planRepo),
+		exercisePlanUC.NewRenewPlanUseCase(planRepo),
+		exercisePlanUC.NewClonePlanUseCase(planRepo),
+		exercisePlanUC.NewAddPlanItemUseCase(planRepo, exCatalog),
+		exercisePlanUC.NewRemovePlanItemUseCase(planRepo),
+		exercisePlanUC.NewReorderPlanItemsUseCase(planRepo),
+	)
+	planTemplateHandler := exercisePlanHTTP.NewTemplateHandler(
+		exercisePlanUC.NewCreatePlanTemplateUseCase(planRepo, exCatalog),
+		exercisePlanUC.NewUpdatePlanTemplateUseCase(planRepo, exCatalog),
+		exercisePlanUC.NewListPlanTemplatesUseCase(planRepo),
+		exercisePlanUC.NewGetPlanTemplateUseCase(planRepo),
+		exercisePlanUC.NewApplyPlanTemplateUseCase(planRepo, planCreateUC),
+	)
+	planPDFHandler := exercisePlanHTTP.NewPDFHandler(exercisePlanUC.NewExportPlanPDFUseCase(planGetUC,
+		exercisePlanDirectory.NewGateway(patientRepo, kRepo),
+		exercisePlanPDF.NewRenderer(cfg.ClinicLocation),
+		exercisePlanDomain.ClinicInfo{Name: cfg.ClinicName, Address: cfg.ClinicAddress, Phone: cfg.ClinicPhone},
+	))
+	planPrescriptionHandler := exercisePlanHTTP.NewPrescriptionHandler(exercisePlanUC.NewGetPrescriptionUseCase(planRepo, cfg.ClinicLocation))
+	planAdherenceHandler := exercisePlanHTTP.NewAdherenceHandler(
+		exercisePlanUC.NewLogAdherenceUseCase(planRepo, cfg.ClinicLocation),
+		exercisePlanUC.NewListAdherenceLogsUseCase(planRepo),
+		exercisePlanUC.NewGetPlanAdherenceUseCase(planRepo, cfg.ClinicLocation),
+		exercisePlanUC.NewListLowAdherenceUseCase(planRepo, cfg.ClinicLocation),
+	)
 
 	evoRepo := evoGorm.NewRepository(db)
-	evoCreateUC := evoUC.NewCreateEvolutionUseCase(evoRepo)
+	evoReferences := evoRefs.NewGateway(patientRepo, kRepo, apptRepo, updateApptUC)
+	evoCreateUC := evoUC.NewCreateEvolutionUseCase(evoRepo, clinicalRefs, evoReferences)
 	evoListUC := evoUC.NewListEvolutionsByPatientUseCase(evoRepo)
 	evoGetUC := evoUC.NewGetEvolutionByIDUseCase(evoRepo)
-	evoHandler := evoHTTP.NewHandler(evoCreateUC, evoListUC, evoGetUC)
+	evoHandler := evoHTTP.NewHandler(evoCreateUC, evoListUC, evoGetUC,
+		evoUC.NewUpdateEvolutionUseCase(evoRepo, clinicalRefs),
+		evoUC.NewSignEvolutionUseCase(evoRepo),
+		evoUC.NewAmendEvolutionUseCase(evoRepo, clinicalRefs, evoReferences),
+		evoUC.NewAddAddendumUseCase(evoRepo),
+		evoUC.NewCreateTemplateUseCase(evoRepo),
+		evoUC.NewListTemplatesUseCase(evoRepo),
+		evoUC.NewGetTemplateUseCase(evoRepo),
+	)
+
+	msRepo := msGorm.NewRepository(db)
+	msHandler := msHTTP.NewHandler(
+		msUC.NewAddMeasurementsUseCase(msRepo, msEvolutions.NewGateway(evoRepo)),
+		msUC.NewListMeasurementsByEvolutionUseCase(msRepo),
+		msUC.NewGetSeriesUseCase(msRepo),
+	)
+
+	tlHandler := tlHTTP.NewHandler(tlUC.NewGetTimelineUseCase(tlGorm.NewRepository(db)))
+
+	anHandler := anHTTP.NewHandler(anUC.NewGetPatientAnalyticsUseCase(anGorm.NewRepository(db)))
+
+	rxHandler := rxHTTP.NewHandler(
+		rxUC.NewCreatePrescriptionUseCase(rxRepo, clinicalRefs),
+		rxUC.NewListPrescriptionsByPatientUseCase(rxRepo),
+		rxUC.NewGetPrescriptionUseCase(rxRepo),
+	)
+
+	attRepo := attGorm.NewRepository(db)
+	attStore := attStorage.New(cfg.AttachmentsDir)
+	attHandler := attHTTP.NewHandler(
+		attUC.NewUploadAttachmentUseCase(attRepo, attStore, attLinks.NewGateway(patientRepo, evoRepo, apptRepo), cfg.AttachmentsMaxBytes),
+		attUC.NewListAttachmentsByPatientUseCase(attRepo),
+		attUC.NewDownloadAttachmentUseCase(attRepo, attStore),
+		attUC.NewDeleteAttachmentUseCase(attRepo, attStore),
+		cfg.AttachmentsMaxBytes,
+	)
 
 	matRepo := matGorm.NewRepository(db)
 	matCreateUC := matUC.NewCreateMaterialUseCase(matRepo)
@@ -103,7 +246,45 @@ func NewRouter(cfg config.Config, db *gorm.DB) http.Handler {
 	matLoanUC := matUC.NewLoanMaterialUseCase(matRepo)
 	matReturnUC := matUC.NewReturnMaterialUseCase(matRepo)
 	matListLoansUC := matUC.NewListLoansByPatientUseCase(matRepo)
-	matHandler := matHTTP.NewHandler(matCreateUC, matListUC, matLoanUC, matReturnUC, matListLoansUC)
+	matHandler := matHTTP.NewHandler(
+		matCreateUC,
+		matListUC,
+		matUC.NewGetMaterialUseCase(matRepo),
+		matUC.NewUpdateMaterialUseCase(matRepo),
+		matLoanUC,
+		matReturnUC,
+		matListLoansUC,
+	)
+	matLoanHandler := matHTTP.NewLoanHandler(
+		matUC.NewExtendLoanUseCase(matRepo),
+		matUC.NewListOverdueLoansUseCase(matRepo),
+		matUC.NewListLoanReturnsUseCase(matRepo),
+	)
+	matStockHandler := matHTTP.NewStockHandler(
+		matUC.NewRestockMaterialUseCase(matRepo),
+		matUC.NewWriteOffMaterialUseCase(matRepo),
+		matUC.NewListStockMovementsUseCase(matRepo),
+		matUC.NewCheckStockUseCase(matRepo),
+		matUC.NewReconcileStockUseCase(matRepo),
+	)
+	matCatalogHandler := matHTTP.NewCatalogHandler(
+		matUC.NewListCategoriesUseCase(matRepo),
+		matUC.NewListLowStockUseCase(matRepo),
+	)
+	matHistoryHandler := matHTTP.NewHistoryHandler(
+		matUC.NewListLoansUseCase(matRepo),
+		matUC.NewLoanStatsUseCase(matRepo),
+	)
+	matUnitHandler := matHTTP.NewUnitHandler(
+		matUC.NewAddUnitUseCase(matRepo),
+		matUC.NewListUnitsUseCase(matRepo),
+		matUC.NewGetUnitUseCase(matRepo),
+		matUC.NewRetireUnitUseCase(matRepo),
+		matUC.NewRegisterMaintenanceUseCase(matRepo),
+		matUC.NewCompleteMaintenanceUseCase(matRepo),
+		matUC.NewListMaintenanceUseCase(matRepo),
+		matUC.NewListLoanUnitsUseCase(matRepo),
+	)
 
 	// API v1
 	v1 := r.Group("/api/v1")
@@ -114,8 +295,11 @@ func NewRouter(cfg config.Config, db *gorm.DB) http.Handler {
 
 	// CU01 - Registrar paciente
 	v1.POST("/patients", patientHandler.RegisterPatient)
-	v1.GET("/patients/:id", patientHandler.GetPatientByID)
+	v1.POST("/patients/import", patientHandler.Import)
+	v1.GET("/patients/:patient_id", patientHandler.GetPatientByID)
 	v1.GET("/patients", patientHandler.Search)
+	v1.PATCH("/patients/:patient_id", patientHandler.UpdatePatient)
+	v1.GET("/health-insurers", patientHandler.ListInsurers)
 
 	v1.POST("/appointments", apptHandler.Create)
 	v1.GET("/appointments", apptHandler.ListDay)
@@ -125,21 +309,125 @@ func NewRouter(cfg config.Config, db *gorm.DB) http.Handler {
 
 	v1.GET("/kinesiologists", kHandler.List)
 
+	// Historia clínica estructurada
+	v1.GET("/patients/:patient_id/clinical-record", chHandler.GetRecord)
+	v1.POST("/patients/:patient_id/diagnoses", chHandler.CreateDiagnosis)
+	v1.GET("/patients/:patient_id/diagnoses", chHandler.ListDiagnoses)
+	v1.PUT("/diagnoses/:id", chHandler.UpdateDiagnosis)
+	v1.POST("/patients/:patient_id/injuries", chHandler.CreateInjury)
+	v1.GET("/patients/:patient_id/injuries", chHandler.ListInjuries)
+	v1.PUT("/injuries/:id", chHandler.UpdateInjury)
+	v1.POST("/patients/:patient_id/surgeries", chHandler.CreateSurgery)
+	v1.GET("/patients/:patient_id/surgeries", chHandler.ListSurgeries)
+	v1.PUT("/surgeries/:id", chHandler.UpdateSurgery)
+	v1.POST("/patients/:patient_id/medications", chHandler.CreateMedication)
+	v1.GET("/patients/:patient_id/medications", chHandler.ListMedications)
+	v1.PUT("/medications/:id", chHandler.UpdateMedication)
+	v1.POST("/patients/:patient_id/allergies", chHandler.CreateAllergy)
+	v1.GET("/patients/:patient_id/allergies", chHandler.ListAllergies)
+	v1.PUT("/allergies/:id", chHandler.UpdateAllergy)
+	v1.POST("/patients/:patient_id/contraindications", chHandler.CreateContraindication)
+	v1.GET("/patients/:patient_id/contraindications", chHandler.ListContraindications)
+	v1.PUT("/contraindications/:id", chHandler.UpdateContraindication)
+	v1.POST("/patients/:patient_id/referring-physicians", chHandler.CreateReferringPhysician)
+	v1.GET("/patients/:patient_id/referring-physicians", chHandler.ListReferringPhysicians)
+	v1.PUT("/referring-physicians/:id", chHandler.UpdateReferringPhysician)
+
+	// Catálogo de ejercicios
+	v1.POST("/exercises", exHandler.Create)
+	v1.GET("/exercises", exHandler.Search)
+	v1.GET("/exercises/categories", exHandler.Categories)
+	v1.GET("/exercises/:exercise_id", exHandler.GetByID)
+	v1.PATCH("/exercises/:exercise_id", exHandler.Update)
+
 	v1.POST("/patients/:patient_id/exercise-plans", planHandler.CreateForPatient)
 	v1.GET("/patients/:patient_id/exercise-plans", planHandler.ListByPatient)
 	v1.GET("/exercise-plans/:plan_id", planHandler.GetByID)
+	v1.PATCH("/exercise-plans/:plan_id", planHandler.Update)
+	v1.POST("/exercise-plans/:plan_id/close", planHandler.Close)
+	v1.POST("/exercise-plans/:plan_id/renew", planHandler.Renew)
+	v1.POST("/exercise-plans/:plan_id/clone", planHandler.Clone)
+	v1.POST("/exercise-plans/:plan_id/items", planHandler.AddItem)
+	v1.PUT("/exercise-plans/:plan_id/items/order", planHandler.ReorderItems)
+	v1.DELETE("/exercise-plans/:plan_id/items/:item_id", planHandler.RemoveItem)
+	v1.GET("/exercise-plans/:plan_id/pdf", planPDFHandler.Export)
+	v1.GET("/exercise-plans/:plan_id/prescription", planPrescriptionHandler.Get)
+	v1.POST("/exercise-plans/:plan_id/adherence-logs", planAdherenceHandler.CreateLog)
+	v1.GET("/exercise-plans/:plan_id/adherence-logs", planAdherenceHandler.ListLogs)
+	v1.GET("/exercise-plans/:plan_id/adherence", planAdherenceHandler.Get)
+	v1.GET("/exercise-plans/low-adherence", planAdherenceHandler.ListLow)
+
+	v1.POST("/exercise-plan-templates", planTemplateHandler.Create)
+	v1.GET("/exercise-plan-templates", planTemplateHandler.List)
+	v1.GET("/exercise-plan-templates/:template_id", planTemplateHandler.GetByID)
+	v1.PUT("/exercise-plan-templates/:template_id", planTemplateHandler.Update)
+	v1.POST("/patients/:patient_id/exercise-plans/from-template", planTemplateHandler.ApplyToPatient)
 
 	v1.POST("/patients/:patient_id/evolutions", evoHandler.CreateForPatient)
 	v1.GET("/patients/:patient_id/evolutions", evoHandler.ListByPatient)
 	v1.GET("/evolutions/:evolution_id", evoHandler.GetByID)
+	v1.PATCH("/evolutions/:evolution_id", evoHandler.Update)
+	v1.POST("/evolutions/:evolution_id/sign", evoHandler.Sign)
+	v1.POST("/evolutions/:evolution_id/versions", evoHandler.CreateVersion)
+	v1.POST("/evolutions/:evolution_id/addenda", evoHandler.AddAddendum)
+	// Mediciones objetivas (goniometría, fuerza MRC, cuestionarios)
+	v1.POST("/evolutions/:evolution_id/measurements", msHandler.AddToEvolution)
+	v1.GET("/evolutions/:evolution_id/measurements", msHandler.ListByEvolution)
+	v1.GET("/patients/:patient_id/measurements/series", msHandler.Series)
+
+	// Timeline del paciente (turnos, evoluciones, planes y préstamos en un solo feed)
+	v1.GET("/patients/:patient_id/timeline", tlHandler.Get)
+	// Tendencias de dolor y cuestionarios (series para graficar + indicadores)
+	v1.GET("/patients/:patient_id/analytics", anHandler.Get)
+
+	v1.POST("/evolution-templates", evoHandler.CreateTemplate)
+	v1.GET("/evolution-templates", evoHandler.ListTemplates)
+	v1.GET("/evolution-templates/:template_id", evoHandler.GetTemplate)
+
+	// Órdenes médicas
+	v1.POST("/patients/:patient_id/prescriptions", rxHandler.CreateForPatient)
+	v1.GET("/patients/:patient_id/prescriptions", rxHandler.ListByPatient)
+	v1.GET("/prescriptions/:id", rxHandler.GetByID)
+
+	// Adjuntos (estudios, órdenes médicas, fotos)
+	v1.POST("/patients/:patient_id/attachments", attHandler.Upload)
+	v1.GET("/patients/:patient_id/attachments", attHandler.ListByPatient)
+	v1.GET("/attachments/:id/download", attHandler.Download)
+	v1.DELETE("/attachments/:id", attHandler.Delete)
 
 	v1.POST("/materials", matHandler.CreateMaterial)
 	v1.GET("/materials", matHandler.ListMaterials)
+	v1.GET("/materials/stock-check", matStockHandler.CheckAll)
+	v1.GET("/materials/categories", matCatalogHandler.ListCategories)
+	v1.GET("/materials/low-stock", matCatalogHandler.ListLowStock)
+	v1.GET("/materials/:material_id", matHandler.GetMaterial)
+	v1.PATCH("/materials/:material_id", matHandler.UpdateMaterial)
+	v1.POST("/materials/:material_id/restock", matStockHandler.Restock)
+	v1.POST("/materials/:material_id/write-off", matStockHandler.WriteOff)
+	v1.GET("/materials/:material_id/stock-movements", matStockHandler.ListMovements)
+	v1.GET("/materials/:material_id/stock-check", matStockHandler.Check)
+	v1.POST("/materials/:material_id/reconcile", matStockHandler.Reconcile)
+	v1.POST("/materials/:material_id/units", matUnitHandler.Add)
+	v1.GET("/materials/:material_id/units", matUnitHandler.List)
+	v1.GET("/materials/:material_id/loans", matHistoryHandler.ListByMaterial)
+
+	v1.GET("/material-units/:unit_id", matUnitHandler.Get)
+	v1.POST("/material-units/:unit_id/retire", matUnitHandler.Retire)
+	v1.GET("/material-units/:unit_id/maintenance", matUnitHandler.ListMaintenance)
+	v1.POST("/material-units/:unit_id/maintenance", matUnitHandler.RegisterMaintenance)
+	v1.POST("/material-units/:unit_id/maintenance/:maintenance_id/complete", matUnitHandler.CompleteMaintenance)
 
 	v1.POST("/material-loans", matHandler.LoanMaterial)
+	v1.GET("/material-loans", matHistoryHandler.List)
+	v1.GET("/material-loans/stats", matHistoryHandler.Stats)
+	v1.GET("/material-loans/overdue", matLoanHandler.ListOverdue)
 	v1.POST("/material-loans/:loan_id/return", matHandler.ReturnLoan)
+	v1.POST("/material-loans/:loan_id/extend", matLoanHandler.Extend)
+	v1.GET("/material-loans/:loan_id/returns", matLoanHandler.ListReturns)
+	v1.GET("/material-loans/:loan_id/units", matUnitHandler.ListLoanUnits)
 
 	v1.GET("/patients/:patient_id/material-loans", matHandler.ListLoansByPatient)
+	v1.GET("/kinesiologists/:kinesiologist_id/material-loans", matHistoryHandler.ListByKinesiologist)
 
 	_ = db
 
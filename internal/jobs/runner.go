@@ -0,0 +1,40 @@
+// Package jobs corre tareas periódicas en segundo plano dentro del mismo proceso de la API.
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Start lanza cada job en su propia goroutine: corre una vez al arrancar y después cada Interval,
+// hasta que se cancele ctx. Un error se loguea y el job sigue en la próxima vuelta.
+func Start(ctx context.Context, jobs ...Job) {
+	for _, j := range jobs {
+		go run(ctx, j)
+	}
+}
+
+func run(ctx context.Context, j Job) {
+	ticker := time.NewTicker(j.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.Run(ctx); err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Str("job", j.Name).Msg("job failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -2,6 +2,9 @@ package gorm
 
 import (
 	"context"
+	"errors"
+
+	"github.com/google/uuid"
 
 	"github.com/javiacuna/kinesio-backend/internal/kinesiologists/domain"
 	"github.com/javiacuna/kinesio-backend/internal/kinesiologists/ports"
@@ -31,16 +34,32 @@ func (r *Repository) List(ctx context.Context, onlyActive bool) ([]domain.Kinesi
 
 	out := make([]domain.Kinesiologist, 0, len(ms))
 	for _, m := range ms {
-		out = append(out, domain.Kinesiologist{
-			ID:            m.ID,
-			FirstName:     m.FirstName,
-			LastName:      m.LastName,
-			Email:         m.Email,
-			LicenseNumber: m.LicenseNumber,
-			Active:        m.Active,
-			CreatedAt:     m.CreatedAt,
-			UpdatedAt:     m.UpdatedAt,
-		})
+		out = append(out, toDomain(m))
 	}
 	return out, nil
 }
+
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (domain.Kinesiologist, bool, error) {
+	var m KinesiologistModel
+	err := r.db.WithContext(ctx).First(&m, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.Kinesiologist{}, false, nil
+		}
+		return domain.Kinesiologist{}, false, err
+	}
+	return toDomain(m), true, nil
+}
+
+func toDomain(m KinesiologistModel) domain.Kinesiologist {
+	return domain.Kinesiologist{
+		ID:            m.ID,
+		FirstName:     m.FirstName,
+		LastName:      m.LastName,
+		Email:         m.Email,
+		LicenseNumber: m.LicenseNumber,
+		Active:        m.Active,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
@@ -3,9 +3,12 @@ package ports
 import (
 	"context"
 
+	"github.com/google/uuid"
+
 	"github.com/javiacuna/kinesio-backend/internal/kinesiologists/domain"
 )
 
 type Repository interface {
 	List(ctx context.Context, onlyActive bool) ([]domain.Kinesiologist, error)
+	GetByID(ctx context.Context, id uuid.UUID) (domain.Kinesiologist, bool, error)
 }
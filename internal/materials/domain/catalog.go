@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// MaterialFilter: búsqueda y filtros del catálogo. El listado se ordena por
+// (nombre, id), así que el cursor es estable aunque haya nombres repetidos.
+type MaterialFilter struct {
+	Search    *string // nombre, descripción o categoría (sin distinguir mayúsculas)
+	Category  *string
+	Available *bool // true = con stock disponible, false = sin stock
+	LowStock  bool  // solo los que están por debajo de su umbral
+	After     *MaterialCursor
+	Limit     int
+}
+
+// MaterialCursor marca el último material devuelto.
+type MaterialCursor struct {
+	Name string
+	ID   uuid.UUID
+}
+
+type MaterialPage struct {
+	Items      []Material
+	NextCursor *string
+}
+
+func MaterialCursorFor(m Material) MaterialCursor {
+	return MaterialCursor{Name: m.Name, ID: m.ID}
+}
+
+func (c MaterialCursor) Encode() string {
+	raw := c.ID.String() + "|" + c.Name
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeMaterialCursor(s string) (MaterialCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return MaterialCursor{}, ErrInvalidCursor
+	}
+	// el id va primero: el nombre puede tener "|"
+	idPart, name, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return MaterialCursor{}, ErrInvalidCursor
+	}
+	id, err := uuid.Parse(idPart)
+	if err != nil {
+		return MaterialCursor{}, ErrInvalidCursor
+	}
+	return MaterialCursor{Name: name, ID: id}, nil
+}
+
+// CategoryCount: una categoría del catálogo y cuántos materiales tiene.
+type CategoryCount struct {
+	Category  string
+	Materials int
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Estado en que vuelve el material.
+const (
+	ConditionOK      = "ok"
+	ConditionDamaged = "damaged"
+	ConditionLost    = "lost"
+)
+
+var ReturnConditions = []string{ConditionOK, ConditionDamaged, ConditionLost}
+
+// Período de cobro del alquiler.
+const (
+	RentalPerDay   = "day"
+	RentalPerWeek  = "week"
+	RentalPerMonth = "month"
+)
+
+var RentalPeriods = []string{RentalPerDay, RentalPerWeek, RentalPerMonth}
+
+// RentalPeriodDays devuelve la duración en días de un período de alquiler (el mes se toma de 30).
+func RentalPeriodDays(period string) int {
+	switch period {
+	case RentalPerWeek:
+		return 7
+	case RentalPerMonth:
+		return 30
+	default:
+		return 1
+	}
+}
+
+// LoanReturn es una devolución (total o parcial) de un préstamo con su liquidación.
+// Los importes van en centavos.
+type LoanReturn struct {
+	ID         uuid.UUID
+	LoanID     uuid.UUID
+	Qty        int
+	Condition  string
+	WrittenOff bool
+	Notes      *string
+
+	RentalCents          int64 // alquiler de las unidades devueltas
+	ChargeCents          int64 // cargo por daño o pérdida
+	DepositAppliedCents  int64 // parte del depósito que cubre los cargos
+	DepositRefundedCents int64 // parte del depósito que se devuelve
+	BalanceDueCents      int64 // lo que el paciente todavía debe pagar
+
+	ReturnedAt time.Time
+}
+
+// RentalCharge calcula el alquiler de qty unidades desde el préstamo hasta at.
+// Se cobra por período iniciado, como mínimo uno.
+func (l MaterialLoan) RentalCharge(qty int, at time.Time) int64 {
+	if l.RentalFeeCents == nil || l.RentalPeriod == nil {
+		return 0
+	}
+	days := int(Day(at).Sub(Day(l.LoanedAt)).Hours() / 24)
+	periodDays := RentalPeriodDays(*l.RentalPeriod)
+	periods := (days + periodDays - 1) / periodDays
+	if periods < 1 {
+		periods = 1
+	}
+	return *l.RentalFeeCents * int64(periods) * int64(qty)
+}
+
+// DepositShare es la parte del depósito que corresponde a qty unidades. La
+// última devolución se lleva lo que quede para no perder centavos por redondeo.
+func (l MaterialLoan) DepositShare(qty int) int64 {
+	remaining := l.DepositCents - l.DepositAppliedCents - l.DepositRefundedCents
+	if qty >= l.OutstandingQty() {
+		return remaining
+	}
+	share := l.DepositCents * int64(qty) / int64(l.Qty)
+	if share > remaining {
+		return remaining
+	}
+	return share
+}
+
+// Settle arma la liquidación de devolver qty unidades en la condición indicada:
+// el depósito cubre primero alquiler y cargos y el resto se reintegra.
+func (l MaterialLoan) Settle(qty int, condition string, chargeCents int64, writtenOff bool, at time.Time) LoanReturn {
+	r := LoanReturn{
+		ID:          uuid.New(),
+		LoanID:      l.ID,
+		Qty:         qty,
+		Condition:   condition,
+		WrittenOff:  writtenOff,
+		RentalCents: l.RentalCharge(qty, at),
+		ChargeCents: chargeCents,
+		ReturnedAt:  at,
+	}
+
+	owed := r.RentalCents + r.ChargeCents
+	deposit := l.DepositShare(qty)
+	r.DepositAppliedCents = min(deposit, owed)
+	r.DepositRefundedCents = deposit - r.DepositAppliedCents
+	r.BalanceDueCents = owed - r.DepositAppliedCents
+	return r
+}
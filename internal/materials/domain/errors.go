@@ -3,9 +3,13 @@ package domain
 import "errors"
 
 var (
-	ErrValidation        = errors.New("validation_error")
-	ErrNotFound          = errors.New("not_found")
-	ErrDuplicateName     = errors.New("duplicate_name")
-	ErrInsufficientStock = errors.New("insufficient_stock")
-	ErrAlreadyReturned   = errors.New("already_returned")
+	ErrValidation           = errors.New("validation_error")
+	ErrNotFound             = errors.New("not_found")
+	ErrDuplicateName        = errors.New("duplicate_name")
+	ErrInsufficientStock    = errors.New("insufficient_stock")
+	ErrAlreadyReturned      = errors.New("already_returned")
+	ErrDuplicateSerial      = errors.New("duplicate_serial")
+	ErrUnitNotAvailable     = errors.New("unit_not_available")
+	ErrMaintenanceCompleted = errors.New("maintenance_already_completed")
+	ErrInvalidCursor        = errors.New("invalid_cursor")
 )
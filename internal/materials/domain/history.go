@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Estado de un préstamo para filtrar el historial.
+const (
+	LoanStatusActive   = "active"
+	LoanStatusReturned = "returned"
+)
+
+var LoanStatuses = []string{LoanStatusActive, LoanStatusReturned}
+
+// LoanFilter: historial de préstamos de toda la clínica. Los campos nil no
+// filtran. Se ordena por (loaned_at, id) descendente.
+type LoanFilter struct {
+	MaterialID      *uuid.UUID
+	PatientID       *uuid.UUID
+	KinesiologistID *uuid.UUID
+	Status          string     // "" = todos
+	From            *time.Time // loaned_at inclusive
+	To              *time.Time // loaned_at exclusive
+	OverdueAt       *time.Time // solo activos vencidos a esa fecha
+	After           *LoanCursor
+	Limit           int
+}
+
+// LoanCursor marca el último préstamo devuelto.
+type LoanCursor struct {
+	LoanedAt time.Time
+	ID       uuid.UUID
+}
+
+type LoanPage struct {
+	Items      []MaterialLoan
+	NextCursor *string
+}
+
+func LoanCursorFor(l MaterialLoan) LoanCursor {
+	return LoanCursor{LoanedAt: l.LoanedAt, ID: l.ID}
+}
+
+func (c LoanCursor) Encode() string {
+	raw := strconv.FormatInt(c.LoanedAt.UnixNano(), 10) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeLoanCursor(s string) (LoanCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return LoanCursor{}, ErrInvalidCursor
+	}
+	nanosPart, idPart, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return LoanCursor{}, ErrInvalidCursor
+	}
+	nanos, err := strconv.ParseInt(nanosPart, 10, 64)
+	if err != nil {
+		return LoanCursor{}, ErrInvalidCursor
+	}
+	id, err := uuid.Parse(idPart)
+	if err != nil {
+		return LoanCursor{}, ErrInvalidCursor
+	}
+	return LoanCursor{LoanedAt: time.Unix(0, nanos).UTC(), ID: id}, nil
+}
+
+// MaterialLoanStats: uso de un material en un período [From, To). Préstamos,
+// duración y pérdidas cuentan los préstamos iniciados en el período; las
+// unidades-día cuentan todo el tiempo prestado dentro del período.
+type MaterialLoanStats struct {
+	MaterialID uuid.UUID
+	Name       string
+	TotalQty   int
+
+	Loans          int
+	UnitsLoaned    int
+	ActiveQty      int     // prestado ahora
+	LoanedUnitDays float64 // unidades × días prestadas dentro del período
+	ReturnedLoans  int
+	AvgLoanDays    *float64 // de los préstamos ya cerrados
+
+	LostQty          int // devueltas como perdidas
+	DamagedQty       int // devueltas dañadas y dadas de baja
+	StockWriteOffQty int // bajas de stock fuera de préstamos
+}
+
+// Utilization es la fracción de la capacidad (total actual × días) que estuvo
+// prestada. Usa el total de hoy, así que es aproximada si el stock cambió en el período.
+func (s MaterialLoanStats) Utilization(periodDays int) float64 {
+	capacity := float64(s.TotalQty * periodDays)
+	if capacity <= 0 {
+		return 0
+	}
+	return s.LoanedUnitDays / capacity
+}
+
+// LossRate: unidades perdidas sobre unidades prestadas.
+func (s MaterialLoanStats) LossRate() float64 {
+	if s.UnitsLoaned == 0 {
+		return 0
+	}
+	return float64(s.LostQty) / float64(s.UnitsLoaned)
+}
@@ -12,8 +12,30 @@ type Material struct {
 	Description  *string
 	TotalQty     int
 	AvailableQty int
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	// TrackingQuantity o TrackingUnit; se define al crear el material.
+	TrackingMode string
+	// Días de préstamo por defecto; nil = los préstamos no tienen vencimiento salvo que se indique.
+	DefaultLoanDays *int
+	// Depósito que se cobra por unidad al prestar (centavos).
+	DepositCents *int64
+	// Alquiler por unidad y período; nil = préstamo sin cargo.
+	RentalFeeCents *int64
+	RentalPeriod   *string
+	// Costo de reposición por unidad: cargo por defecto si se pierde o se da de baja por daño.
+	ReplacementCostCents *int64
+	// Categoría libre (p. ej. "electroterapia", "bandas"); nil = sin categoría.
+	Category *string
+	// Umbral de stock bajo: se avisa cuando AvailableQty queda por debajo. nil = sin alerta.
+	LowStockThreshold *int
+	// Último aviso de stock bajo; se limpia cuando el stock vuelve a superar el umbral.
+	LowStockNotifiedAt *time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// LowStock indica si lo disponible quedó por debajo del umbral configurado.
+func (m Material) LowStock() bool {
+	return m.LowStockThreshold != nil && m.AvailableQty < *m.LowStockThreshold
 }
 
 type MaterialLoan struct {
@@ -22,7 +44,44 @@ type MaterialLoan struct {
 	PatientID       uuid.UUID
 	KinesiologistID uuid.UUID
 	Qty             int
-	Notes           *string
-	LoanedAt        time.Time
-	ReturnedAt      *time.Time
+	// Unidades ya devueltas; el préstamo se cierra (ReturnedAt) cuando llega a Qty.
+	ReturnedQty       int
+	Notes             *string
+	LoanedAt          time.Time
+	DueDate           *time.Time // solo fecha (UTC)
+	ReturnedAt        *time.Time
+	OverdueNotifiedAt *time.Time
+
+	// Depósito cobrado al prestar y cómo se fue liquidando en las devoluciones (centavos).
+	DepositCents         int64
+	DepositAppliedCents  int64
+	DepositRefundedCents int64
+	// Alquiler vigente al momento del préstamo.
+	RentalFeeCents *int64
+	RentalPeriod   *string
+	// Total de alquiler y cargos liquidados en las devoluciones.
+	ChargedCents int64
+}
+
+// OutstandingQty son las unidades que el paciente todavía tiene.
+func (l MaterialLoan) OutstandingQty() int { return l.Qty - l.ReturnedQty }
+
+// DaysOverdue devuelve cuántos días pasaron desde el vencimiento (0 si no está vencido).
+func (l MaterialLoan) DaysOverdue(today time.Time) int {
+	if l.DueDate == nil || l.ReturnedAt != nil {
+		return 0
+	}
+	days := int(Day(today).Sub(Day(*l.DueDate)).Hours() / 24)
+	if days < 0 {
+		return 0
+	}
+	return days
+}
+
+func (l MaterialLoan) Overdue(today time.Time) bool { return l.DaysOverdue(today) > 0 }
+
+// Day trunca t al día (UTC).
+func Day(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
 }
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// PatientContact: datos para avisarle algo al paciente.
+type PatientContact struct {
+	ID       uuid.UUID
+	FullName string
+	Email    string
+	Phone    *string
+}
+
+// PatientDirectory resuelve el contacto de un paciente (lo implementa el módulo de pacientes).
+type PatientDirectory interface {
+	PatientContact(ctx context.Context, id uuid.UUID) (PatientContact, bool, error)
+}
+
+// OverdueNotice: aviso de un préstamo vencido.
+type OverdueNotice struct {
+	Loan        MaterialLoan
+	Material    Material
+	Patient     PatientContact
+	DaysOverdue int
+}
+
+// LowStockNotice: aviso interno de un material con stock por debajo del umbral.
+type LowStockNotice struct {
+	Material Material
+}
+
+// Notifier envía los avisos: préstamos vencidos a los pacientes y stock bajo al
+// consultorio. La implementación (log, webhook, email...) se elige al armar el router.
+type Notifier interface {
+	NotifyOverdueLoan(ctx context.Context, n OverdueNotice) error
+	NotifyLowStock(ctx context.Context, n LowStockNotice) error
+}
@@ -8,18 +8,78 @@ import (
 )
 
 type Repository interface {
+	// WithinTx ejecuta fn dentro de una transacción. El Repository que recibe fn
+	// opera sobre esa transacción; si fn devuelve error se deshace todo.
+	WithinTx(ctx context.Context, fn func(tx Repository) error) error
+
 	// Materials
+	// CreateMaterial registra también el movimiento inicial del libro si TotalQty > 0.
 	CreateMaterial(ctx context.Context, m Material) (Material, error)
-	ListMaterials(ctx context.Context, limit int) ([]Material, error)
+	UpdateMaterial(ctx context.Context, m Material) (Material, error)
+	// ListMaterials devuelve hasta f.Limit materiales que cumplen el filtro, ordenados por (nombre, id).
+	ListMaterials(ctx context.Context, f MaterialFilter) ([]Material, error)
+	ListCategories(ctx context.Context) ([]CategoryCount, error)
+	// ListLowStockMaterials devuelve los materiales por debajo de su umbral;
+	// onlyUnnotified deja afuera los ya avisados.
+	ListLowStockMaterials(ctx context.Context, onlyUnnotified bool) ([]Material, error)
+	MarkLowStockNotified(ctx context.Context, materialID uuid.UUID, at time.Time) error
+	// ResetLowStockAlerts limpia el aviso de los materiales que volvieron a superar
+	// su umbral (o ya no tienen), para que se vuelva a avisar si bajan de nuevo.
+	ResetLowStockAlerts(ctx context.Context) (int64, error)
+	ListAllMaterials(ctx context.Context) ([]Material, error)
 	GetMaterialByID(ctx context.Context, id uuid.UUID) (Material, bool, error)
+	// GetMaterialForUpdate bloquea la fila (SELECT ... FOR UPDATE) hasta el fin de la transacción.
+	GetMaterialForUpdate(ctx context.Context, id uuid.UUID) (Material, bool, error)
 
 	// Loans
 	CreateLoan(ctx context.Context, l MaterialLoan) (MaterialLoan, error)
 	GetLoanByID(ctx context.Context, id uuid.UUID) (MaterialLoan, bool, error)
+	GetLoanForUpdate(ctx context.Context, id uuid.UUID) (MaterialLoan, bool, error)
 	ListLoansByPatient(ctx context.Context, patientID uuid.UUID, onlyActive bool, limit int) ([]MaterialLoan, error)
+	// ListLoans devuelve hasta f.Limit préstamos que cumplen el filtro, del más reciente al más viejo.
+	ListLoans(ctx context.Context, f LoanFilter) ([]MaterialLoan, error)
+	// LoanStats calcula el uso de cada material (o solo materialID) en [from, to);
+	// now cierra el tiempo de los préstamos todavía activos.
+	LoanStats(ctx context.Context, from, to, now time.Time, materialID *uuid.UUID) ([]MaterialLoanStats, error)
+	// ListOverdueLoans devuelve los préstamos activos con vencimiento anterior a today,
+	// del más atrasado al más reciente. onlyUnnotified deja afuera los ya avisados.
+	ListOverdueLoans(ctx context.Context, today time.Time, onlyUnnotified bool, limit int) ([]MaterialLoan, error)
+	// ActiveLoanQty suma las unidades todavía no devueltas por material.
+	ActiveLoanQty(ctx context.Context, materialIDs []uuid.UUID) (map[uuid.UUID]int, error)
+	// RegisterReturn guarda la devolución, suma sus unidades e importes al préstamo y
+	// lo cierra si se devolvió todo. Devuelve ErrAlreadyReturned si el préstamo ya
+	// estaba cerrado o se devolvería más de lo prestado.
+	RegisterReturn(ctx context.Context, r LoanReturn) error
+	ListLoanReturns(ctx context.Context, loanID uuid.UUID) ([]LoanReturn, error)
+	// SetLoanDueDate cambia el vencimiento y limpia el aviso de vencido para que se vuelva a avisar.
+	SetLoanDueDate(ctx context.Context, loanID uuid.UUID, dueDate time.Time) error
+	MarkOverdueNotified(ctx context.Context, loanID uuid.UUID, at time.Time) error
+
+	// Units (materiales en modo unit)
+	// CreateUnit devuelve ErrDuplicateSerial si el material ya tiene ese número de serie.
+	CreateUnit(ctx context.Context, u MaterialUnit) (MaterialUnit, error)
+	GetUnitByID(ctx context.Context, id uuid.UUID) (MaterialUnit, bool, error)
+	GetUnitForUpdate(ctx context.Context, id uuid.UUID) (MaterialUnit, bool, error)
+	ListUnits(ctx context.Context, materialID uuid.UUID, status *string) ([]MaterialUnit, error)
+	SetUnitStatus(ctx context.Context, unitIDs []uuid.UUID, status string, at time.Time) error
+	// UnitCounts resume el estado de las unidades por material.
+	UnitCounts(ctx context.Context, materialIDs []uuid.UUID) (map[uuid.UUID]UnitCounts, error)
+	AttachLoanUnits(ctx context.Context, loanID uuid.UUID, unitIDs []uuid.UUID) error
+	ListLoanUnits(ctx context.Context, loanID uuid.UUID) ([]LoanUnit, error)
+	MarkLoanUnitsReturned(ctx context.Context, loanID uuid.UUID, unitIDs []uuid.UUID, returnID uuid.UUID, at time.Time) error
+	CreateMaintenance(ctx context.Context, r MaintenanceRecord) (MaintenanceRecord, error)
+	GetMaintenanceForUpdate(ctx context.Context, id uuid.UUID) (MaintenanceRecord, bool, error)
+	// OpenMaintenance devuelve el mantenimiento fuera de servicio sin completar de la unidad, si hay.
+	OpenMaintenance(ctx context.Context, unitID uuid.UUID) (MaintenanceRecord, bool, error)
+	CompleteMaintenance(ctx context.Context, r MaintenanceRecord) error
+	ListMaintenance(ctx context.Context, unitID uuid.UUID) ([]MaintenanceRecord, error)
 
-	// Stock ops (transactional)
-	DecrementAvailable(ctx context.Context, materialID uuid.UUID, qty int) error
-	IncrementAvailable(ctx context.Context, materialID uuid.UUID, qty int) error
-	MarkReturned(ctx context.Context, loanID uuid.UUID, returnedAt time.Time) error
+	// Stock ledger
+	// ApplyMovement registra el movimiento y actualiza las cantidades del material
+	// de forma atómica. Devuelve ErrInsufficientStock si el stock quedaría negativo.
+	ApplyMovement(ctx context.Context, mv StockMovement) error
+	ListMovements(ctx context.Context, materialID uuid.UUID, limit int) ([]StockMovement, error)
+	LedgerBalances(ctx context.Context, materialIDs []uuid.UUID) (map[uuid.UUID]LedgerBalance, error)
+	// SyncStockFromLedger reescribe total_qty y available_qty con los saldos del libro.
+	SyncStockFromLedger(ctx context.Context, materialID uuid.UUID) (Material, error)
 }
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tipos de movimiento del libro de stock.
+const (
+	MovementInitial    = "initial"
+	MovementRestock    = "restock"
+	MovementWriteOff   = "write_off"
+	MovementLoan       = "loan"
+	MovementReturn     = "return"
+	MovementAdjustment = "adjustment"
+	// Una unidad sale de servicio por mantenimiento y vuelve.
+	MovementMaintenanceOut = "maintenance_out"
+	MovementMaintenanceIn  = "maintenance_in"
+)
+
+// Motivos admitidos para reponer stock.
+var RestockReasons = []string{"purchase", "donation", "found", "other"}
+
+// Motivos admitidos para dar de baja unidades.
+var WriteOffReasons = []string{"lost", "damaged", "expired", "other"}
+
+// StockMovement es un asiento del libro de stock. Cada cambio en las cantidades
+// de un material queda registrado con su efecto sobre el total y lo disponible.
+type StockMovement struct {
+	ID             uuid.UUID
+	MaterialID     uuid.UUID
+	Kind           string
+	TotalDelta     int
+	AvailableDelta int
+	Reason         *string
+	Note           *string
+	LoanID         *uuid.UUID
+	UnitID         *uuid.UUID
+	CreatedAt      time.Time
+}
+
+// NewMovement arma un movimiento con los deltas que corresponden a su tipo.
+// qty siempre es positiva; el signo lo define el tipo.
+func NewMovement(materialID uuid.UUID, kind string, qty int, at time.Time) StockMovement {
+	mv := StockMovement{
+		ID:         uuid.New(),
+		MaterialID: materialID,
+		Kind:       kind,
+		CreatedAt:  at,
+	}
+	switch kind {
+	case MovementInitial, MovementRestock:
+		mv.TotalDelta, mv.AvailableDelta = qty, qty
+	case MovementWriteOff:
+		mv.TotalDelta, mv.AvailableDelta = -qty, -qty
+	case MovementLoan, MovementMaintenanceOut:
+		mv.AvailableDelta = -qty
+	case MovementReturn, MovementMaintenanceIn:
+		mv.AvailableDelta = qty
+	}
+	return mv
+}
+
+// LedgerBalance son las cantidades que resultan de sumar el libro de un material.
+type LedgerBalance struct {
+	MaterialID uuid.UUID
+	Total      int
+	Available  int
+}
+
+// StockCheck compara las cantidades guardadas en el material con las del libro,
+// con los préstamos activos y, en modo unit, con el estado de las unidades.
+type StockCheck struct {
+	Material        Material
+	LedgerTotal     int
+	LedgerAvailable int
+	LoanedQty       int
+	MaintenanceQty  int
+	Units           *UnitCounts
+	Issues          []string
+}
+
+func (c StockCheck) Consistent() bool { return len(c.Issues) == 0 }
+
+// OutOfStockQty son las unidades que cuentan en el total pero no están disponibles.
+func (c StockCheck) OutOfStockQty() int { return c.LoanedQty + c.MaintenanceQty }
+
+// CheckStock detecta diferencias entre el material, su libro, los préstamos
+// activos y (modo unit) las unidades.
+func CheckStock(m Material, balance LedgerBalance, loanedQty int, units UnitCounts) StockCheck {
+	check := StockCheck{
+		Material:        m,
+		LedgerTotal:     balance.Total,
+		LedgerAvailable: balance.Available,
+		LoanedQty:       loanedQty,
+		Issues:          []string{},
+	}
+	if m.TrackingMode == TrackingUnit {
+		check.Units = &units
+		check.MaintenanceQty = units.Maintenance
+	}
+	if m.TotalQty != balance.Total {
+		check.Issues = append(check.Issues, "total_qty_mismatch")
+	}
+	if m.AvailableQty != balance.Available {
+		check.Issues = append(check.Issues, "available_qty_mismatch")
+	}
+	if balance.Total-balance.Available != check.OutOfStockQty() {
+		check.Issues = append(check.Issues, "active_loans_mismatch")
+	}
+	if balance.Available < 0 || balance.Available > balance.Total {
+		check.Issues = append(check.Issues, "available_out_of_range")
+	}
+	if check.Units != nil {
+		if units.Active != balance.Total {
+			check.Issues = append(check.Issues, "unit_count_mismatch")
+		}
+		if units.Available != balance.Available {
+			check.Issues = append(check.Issues, "available_units_mismatch")
+		}
+	}
+	return check
+}
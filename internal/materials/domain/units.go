@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Modo de seguimiento del stock de un material.
+const (
+	// TrackingQuantity: solo se cuentan unidades (bandas, pelotas, consumibles).
+	TrackingQuantity = "quantity"
+	// TrackingUnit: cada unidad tiene número de serie, estado e historial de mantenimiento.
+	TrackingUnit = "unit"
+)
+
+var TrackingModes = []string{TrackingQuantity, TrackingUnit}
+
+// Estado de una unidad.
+const (
+	UnitAvailable   = "available"
+	UnitLoaned      = "loaned"
+	UnitMaintenance = "maintenance"
+	UnitRetired     = "retired"
+)
+
+var UnitStatuses = []string{UnitAvailable, UnitLoaned, UnitMaintenance, UnitRetired}
+
+type MaterialUnit struct {
+	ID         uuid.UUID
+	MaterialID uuid.UUID
+	Serial     string
+	Status     string
+	Notes      *string
+	AcquiredAt *time.Time // solo fecha
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// LoanUnit es una unidad prestada dentro de un préstamo.
+type LoanUnit struct {
+	LoanID     uuid.UUID
+	UnitID     uuid.UUID
+	Serial     string
+	ReturnID   *uuid.UUID
+	ReturnedAt *time.Time
+}
+
+// Tipos de mantenimiento.
+var MaintenanceKinds = []string{"inspection", "repair", "calibration", "cleaning", "other"}
+
+// MaintenanceRecord es una entrada del historial de mantenimiento de una unidad.
+// Si OutOfService, la unidad no se puede prestar hasta que se complete.
+type MaintenanceRecord struct {
+	ID           uuid.UUID
+	UnitID       uuid.UUID
+	Kind         string
+	Description  *string
+	CostCents    *int64
+	OutOfService bool
+	StartedAt    time.Time
+	CompletedAt  *time.Time
+	CreatedAt    time.Time
+}
+
+// UnitCounts resume el estado de las unidades de un material (modo unit).
+type UnitCounts struct {
+	Active      int // todas menos las retiradas
+	Available   int
+	Maintenance int
+}
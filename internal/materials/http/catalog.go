@@ -0,0 +1,53 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/javiacuna/kinesio-backend/internal/materials/usecase"
+)
+
+// CatalogHandler: categorías del catálogo y alertas de stock bajo.
+type CatalogHandler struct {
+	categoriesUC *usecase.ListCategoriesUseCase
+	lowStockUC   *usecase.ListLowStockUseCase
+}
+
+func NewCatalogHandler(categoriesUC *usecase.ListCategoriesUseCase, lowStockUC *usecase.ListLowStockUseCase) *CatalogHandler {
+	return &CatalogHandler{categoriesUC: categoriesUC, lowStockUC: lowStockUC}
+}
+
+type categoryResponse struct {
+	Category  string `json:"category"`
+	Materials int    `json:"materials"`
+}
+
+func (h *CatalogHandler) ListCategories(c *gin.Context) {
+	items, err := h.categoriesUC.Execute(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	out := make([]categoryResponse, 0, len(items))
+	for _, cat := range items {
+		out = append(out, categoryResponse{Category: cat.Category, Materials: cat.Materials})
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// ListLowStock devuelve los materiales con lo disponible por debajo de su umbral.
+func (h *CatalogHandler) ListLowStock(c *gin.Context) {
+	items, err := h.lowStockUC.Execute(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	out := make([]materialResponse, 0, len(items))
+	for _, m := range items {
+		out = append(out, toMaterialResp(m))
+	}
+	c.JSON(http.StatusOK, out)
+}
@@ -17,6 +17,8 @@ import (
 type Handler struct {
 	createMaterialUC *usecase.CreateMaterialUseCase
 	listMaterialsUC  *usecase.ListMaterialsUseCase
+	getMaterialUC    *usecase.GetMaterialUseCase
+	updateMaterialUC *usecase.UpdateMaterialUseCase
 	loanUC           *usecase.LoanMaterialUseCase
 	returnUC         *usecase.ReturnMaterialUseCase
 	listLoansUC      *usecase.ListLoansByPatientUseCase
@@ -25,6 +27,8 @@ type Handler struct {
 func NewHandler(
 	createMaterialUC *usecase.CreateMaterialUseCase,
 	listMaterialsUC *usecase.ListMaterialsUseCase,
+	getMaterialUC *usecase.GetMaterialUseCase,
+	updateMaterialUC *usecase.UpdateMaterialUseCase,
 	loanUC *usecase.LoanMaterialUseCase,
 	returnUC *usecase.ReturnMaterialUseCase,
 	listLoansUC *usecase.ListLoansByPatientUseCase,
@@ -32,6 +36,8 @@ func NewHandler(
 	return &Handler{
 		createMaterialUC: createMaterialUC,
 		listMaterialsUC:  listMaterialsUC,
+		getMaterialUC:    getMaterialUC,
+		updateMaterialUC: updateMaterialUC,
 		loanUC:           loanUC,
 		returnUC:         returnUC,
 		listLoansUC:      listLoansUC,
@@ -41,53 +47,107 @@ func NewHandler(
 // ---------- Responses
 
 type materialResponse struct {
-	ID           string  `json:"id"`
-	Name         string  `json:"name"`
-	Description  *string `json:"description,omitempty"`
-	TotalQty     int     `json:"total_qty"`
-	AvailableQty int     `json:"available_qty"`
-	CreatedAt    string  `json:"created_at"`
-	UpdatedAt    string  `json:"updated_at"`
+	ID                   string  `json:"id"`
+	Name                 string  `json:"name"`
+	Description          *string `json:"description,omitempty"`
+	TotalQty             int     `json:"total_qty"`
+	AvailableQty         int     `json:"available_qty"`
+	DefaultLoanDays      *int    `json:"default_loan_days,omitempty"`
+	DepositCents         *int64  `json:"deposit_cents,omitempty"`
+	RentalFeeCents       *int64  `json:"rental_fee_cents,omitempty"`
+	RentalPeriod         *string `json:"rental_period,omitempty"`
+	ReplacementCostCents *int64  `json:"replacement_cost_cents,omitempty"`
+	TrackingMode         string  `json:"tracking_mode"`
+	Category             *string `json:"category,omitempty"`
+	LowStockThreshold    *int    `json:"low_stock_threshold,omitempty"`
+	LowStock             bool    `json:"low_stock"`
+	CreatedAt            string  `json:"created_at"`
+	UpdatedAt            string  `json:"updated_at"`
+}
+
+type materialPageResponse struct {
+	Items      []materialResponse `json:"items"`
+	NextCursor *string            `json:"next_cursor"`
 }
 
 type loanResponse struct {
-	ID              string  `json:"id"`
-	MaterialID      string  `json:"material_id"`
-	PatientID       string  `json:"patient_id"`
-	KinesiologistID string  `json:"kinesiologist_id"`
-	Qty             int     `json:"qty"`
-	Notes           *string `json:"notes,omitempty"`
-	LoanedAt        string  `json:"loaned_at"`
-	ReturnedAt      *string `json:"returned_at,omitempty"`
+	ID                   string  `json:"id"`
+	MaterialID           string  `json:"material_id"`
+	PatientID            string  `json:"patient_id"`
+	KinesiologistID      string  `json:"kinesiologist_id"`
+	Qty                  int     `json:"qty"`
+	ReturnedQty          int     `json:"returned_qty"`
+	Notes                *string `json:"notes,omitempty"`
+	LoanedAt             string  `json:"loaned_at"`
+	DueDate              *string `json:"due_date,omitempty"`
+	ReturnedAt           *string `json:"returned_at,omitempty"`
+	Overdue              bool    `json:"overdue"`
+	DaysOverdue          int     `json:"days_overdue"`
+	OverdueNotifiedAt    *string `json:"overdue_notified_at,omitempty"`
+	DepositCents         int64   `json:"deposit_cents"`
+	DepositAppliedCents  int64   `json:"deposit_applied_cents"`
+	DepositRefundedCents int64   `json:"deposit_refunded_cents"`
+	RentalFeeCents       *int64  `json:"rental_fee_cents,omitempty"`
+	RentalPeriod         *string `json:"rental_period,omitempty"`
+	ChargedCents         int64   `json:"charged_cents"`
 }
 
 func toMaterialResp(m domain.Material) materialResponse {
 	return materialResponse{
-		ID:           m.ID.String(),
-		Name:         m.Name,
-		Description:  m.Description,
-		TotalQty:     m.TotalQty,
-		AvailableQty: m.AvailableQty,
-		CreatedAt:    m.CreatedAt.UTC().Format(time.RFC3339),
-		UpdatedAt:    m.UpdatedAt.UTC().Format(time.RFC3339),
+		ID:                   m.ID.String(),
+		Name:                 m.Name,
+		Description:          m.Description,
+		TotalQty:             m.TotalQty,
+		AvailableQty:         m.AvailableQty,
+		DefaultLoanDays:      m.DefaultLoanDays,
+		DepositCents:         m.DepositCents,
+		RentalFeeCents:       m.RentalFeeCents,
+		RentalPeriod:         m.RentalPeriod,
+		ReplacementCostCents: m.ReplacementCostCents,
+		TrackingMode:         m.TrackingMode,
+		Category:             m.Category,
+		LowStockThreshold:    m.LowStockThreshold,
+		LowStock:             m.LowStock(),
+		CreatedAt:            m.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:            m.UpdatedAt.UTC().Format(time.RFC3339),
 	}
 }
 
 func toLoanResp(l domain.MaterialLoan) loanResponse {
-	var returned *string
+	var returned, due, notified *string
 	if l.ReturnedAt != nil {
 		s := l.ReturnedAt.UTC().Format(time.RFC3339)
 		returned = &s
 	}
+	if l.DueDate != nil {
+		s := l.DueDate.Format("2006-01-02")
+		due = &s
+	}
+	if l.OverdueNotifiedAt != nil {
+		s := l.OverdueNotifiedAt.UTC().Format(time.RFC3339)
+		notified = &s
+	}
+	today := time.Now()
 	return loanResponse{
-		ID:              l.ID.String(),
-		MaterialID:      l.MaterialID.String(),
-		PatientID:       l.PatientID.String(),
-		KinesiologistID: l.KinesiologistID.String(),
-		Qty:             l.Qty,
-		Notes:           l.Notes,
-		LoanedAt:        l.LoanedAt.UTC().Format(time.RFC3339),
-		ReturnedAt:      returned,
+		ID:                   l.ID.String(),
+		MaterialID:           l.MaterialID.String(),
+		PatientID:            l.PatientID.String(),
+		KinesiologistID:      l.KinesiologistID.String(),
+		Qty:                  l.Qty,
+		ReturnedQty:          l.ReturnedQty,
+		Notes:                l.Notes,
+		LoanedAt:             l.LoanedAt.UTC().Format(time.RFC3339),
+		DueDate:              due,
+		ReturnedAt:           returned,
+		Overdue:              l.Overdue(today),
+		DaysOverdue:          l.DaysOverdue(today),
+		OverdueNotifiedAt:    notified,
+		DepositCents:         l.DepositCents,
+		DepositAppliedCents:  l.DepositAppliedCents,
+		DepositRefundedCents: l.DepositRefundedCents,
+		RentalFeeCents:       l.RentalFeeCents,
+		RentalPeriod:         l.RentalPeriod,
+		ChargedCents:         l.ChargedCents,
 	}
 }
 
@@ -118,6 +178,7 @@ func (h *Handler) CreateMaterial(c *gin.Context) {
 	c.JSON(http.StatusCreated, toMaterialResp(out))
 }
 
+// ListMaterials: ?q=tens&category=electroterapia&available=true&low_stock=true&limit=50&cursor=...
 func (h *Handler) ListMaterials(c *gin.Context) {
 	limit := 50
 	if s := strings.TrimSpace(c.Query("limit")); s != "" {
@@ -126,19 +187,87 @@ func (h *Handler) ListMaterials(c *gin.Context) {
 		}
 	}
 
-	items, err := h.listMaterialsUC.Execute(c.Request.Context(), limit)
+	page, validation, err := h.listMaterialsUC.Execute(c.Request.Context(), usecase.ListMaterialsInput{
+		Search:    queryPtr(c, "q"),
+		Category:  queryPtr(c, "category"),
+		Available: queryPtr(c, "available"),
+		LowStock:  queryPtr(c, "low_stock"),
+		Cursor:    queryPtr(c, "cursor"),
+		Limit:     limit,
+	})
 	if err != nil {
+		if errors.Is(err, domain.ErrValidation) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
 		return
 	}
 
-	out := make([]materialResponse, 0, len(items))
-	for _, m := range items {
-		out = append(out, toMaterialResp(m))
+	out := materialPageResponse{
+		Items:      make([]materialResponse, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+	}
+	for _, m := range page.Items {
+		out.Items = append(out.Items, toMaterialResp(m))
 	}
 	c.JSON(http.StatusOK, out)
 }
 
+func (h *Handler) GetMaterial(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("material_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_material_id"})
+		return
+	}
+
+	m, found, err := h.getMaterialUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toMaterialResp(m))
+}
+
+func (h *Handler) UpdateMaterial(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("material_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_material_id"})
+		return
+	}
+
+	var req usecase.UpdateMaterialInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	out, validation, err := h.updateMaterialUC.Execute(c.Request.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrValidation):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+			return
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+			return
+		case errors.Is(err, domain.ErrDuplicateName):
+			c.JSON(http.StatusConflict, gin.H{"error": "duplicate_name"})
+			return
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, toMaterialResp(out))
+}
+
 func (h *Handler) LoanMaterial(c *gin.Context) {
 	var req usecase.LoanMaterialInput
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -158,6 +287,9 @@ func (h *Handler) LoanMaterial(c *gin.Context) {
 		case errors.Is(err, domain.ErrInsufficientStock):
 			c.JSON(http.StatusConflict, gin.H{"error": "insufficient_stock"})
 			return
+		case errors.Is(err, domain.ErrUnitNotAvailable):
+			c.JSON(http.StatusConflict, gin.H{"error": "unit_not_available", "details": validation})
+			return
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
 			return
@@ -174,9 +306,21 @@ func (h *Handler) ReturnLoan(c *gin.Context) {
 		return
 	}
 
-	out, err := h.returnUC.Execute(c.Request.Context(), id)
+	// el body es opcional: sin qty se devuelve todo lo pendiente
+	var req usecase.ReturnMaterialInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+			return
+		}
+	}
+
+	out, validation, err := h.returnUC.Execute(c.Request.Context(), id, req)
 	if err != nil {
 		switch {
+		case errors.Is(err, domain.ErrValidation):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+			return
 		case errors.Is(err, domain.ErrNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
 			return
@@ -189,7 +333,7 @@ func (h *Handler) ReturnLoan(c *gin.Context) {
 		}
 	}
 
-	c.JSON(http.StatusOK, toLoanResp(out))
+	c.JSON(http.StatusOK, returnResponse{loanResponse: toLoanResp(out.Loan), Settlement: toLoanReturnResp(out.Return)})
 }
 
 func (h *Handler) ListLoansByPatient(c *gin.Context) {
@@ -220,3 +364,11 @@ func (h *Handler) ListLoansByPatient(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, out)
 }
+
+func queryPtr(c *gin.Context, key string) *string {
+	v, ok := c.GetQuery(key)
+	if !ok {
+		return nil
+	}
+	return &v
+}
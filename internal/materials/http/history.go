@@ -0,0 +1,182 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
+	"github.com/javiacuna/kinesio-backend/internal/materials/usecase"
+)
+
+// HistoryHandler: historial de préstamos (por material, por kinesiólogo o de
+// toda la clínica) y estadísticas de uso.
+type HistoryHandler struct {
+	listUC  *usecase.ListLoansUseCase
+	statsUC *usecase.LoanStatsUseCase
+}
+
+func NewHistoryHandler(listUC *usecase.ListLoansUseCase, statsUC *usecase.LoanStatsUseCase) *HistoryHandler {
+	return &HistoryHandler{listUC: listUC, statsUC: statsUC}
+}
+
+type loanPageResponse struct {
+	Items      []loanResponse `json:"items"`
+	NextCursor *string        `json:"next_cursor"`
+}
+
+type loanStatsResponse struct {
+	MaterialID       *string  `json:"material_id,omitempty"`
+	Name             *string  `json:"name,omitempty"`
+	TotalQty         int      `json:"total_qty"`
+	Loans            int      `json:"loans"`
+	UnitsLoaned      int      `json:"units_loaned"`
+	ActiveQty        int      `json:"active_qty"`
+	LoanedUnitDays   float64  `json:"loaned_unit_days"`
+	Utilization      float64  `json:"utilization"`
+	ReturnedLoans    int      `json:"returned_loans"`
+	AvgLoanDays      *float64 `json:"avg_loan_days"`
+	LostQty          int      `json:"lost_qty"`
+	DamagedQty       int      `json:"damaged_qty"`
+	LossRate         float64  `json:"loss_rate"`
+	StockWriteOffQty int      `json:"stock_write_off_qty"`
+}
+
+type loanStatsReportResponse struct {
+	From       string              `json:"from"`
+	To         string              `json:"to"`
+	PeriodDays int                 `json:"period_days"`
+	Materials  []loanStatsResponse `json:"materials"`
+	Totals     loanStatsResponse   `json:"totals"`
+}
+
+func toLoanStatsResp(s domain.MaterialLoanStats, periodDays int) loanStatsResponse {
+	return loanStatsResponse{
+		TotalQty:         s.TotalQty,
+		Loans:            s.Loans,
+		UnitsLoaned:      s.UnitsLoaned,
+		ActiveQty:        s.ActiveQty,
+		LoanedUnitDays:   s.LoanedUnitDays,
+		Utilization:      s.Utilization(periodDays),
+		ReturnedLoans:    s.ReturnedLoans,
+		AvgLoanDays:      s.AvgLoanDays,
+		LostQty:          s.LostQty,
+		DamagedQty:       s.DamagedQty,
+		LossRate:         s.LossRate(),
+		StockWriteOffQty: s.StockWriteOffQty,
+	}
+}
+
+// List: historial de toda la clínica.
+// ?material_id=&patient_id=&kinesiologist_id=&status=active|returned&from=&to=&overdue=true&limit=&cursor=
+func (h *HistoryHandler) List(c *gin.Context) {
+	h.list(c, loansInput(c))
+}
+
+// ListByMaterial: quién lo tiene ahora (status=active) y quién lo tuvo antes.
+func (h *HistoryHandler) ListByMaterial(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("material_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_material_id"})
+		return
+	}
+	in := loansInput(c)
+	materialID := id.String()
+	in.MaterialID = &materialID
+	h.list(c, in)
+}
+
+func (h *HistoryHandler) ListByKinesiologist(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("kinesiologist_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_kinesiologist_id"})
+		return
+	}
+	in := loansInput(c)
+	kinesiologistID := id.String()
+	in.KinesiologistID = &kinesiologistID
+	h.list(c, in)
+}
+
+func (h *HistoryHandler) list(c *gin.Context, in usecase.ListLoansInput) {
+	page, validation, err := h.listUC.Execute(c.Request.Context(), in)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrValidation):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		}
+		return
+	}
+
+	out := loanPageResponse{
+		Items:      make([]loanResponse, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+	}
+	for _, l := range page.Items {
+		out.Items = append(out.Items, toLoanResp(l))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// Stats: ?from=YYYY-MM-DD&to=YYYY-MM-DD&material_id=
+func (h *HistoryHandler) Stats(c *gin.Context) {
+	report, validation, err := h.statsUC.Execute(c.Request.Context(), usecase.LoanStatsInput{
+		From:       queryPtr(c, "from"),
+		To:         queryPtr(c, "to"),
+		MaterialID: queryPtr(c, "material_id"),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrValidation):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		}
+		return
+	}
+
+	out := loanStatsReportResponse{
+		From:       report.From.Format("2006-01-02"),
+		To:         report.To.Format("2006-01-02"),
+		PeriodDays: report.PeriodDays,
+		Materials:  make([]loanStatsResponse, 0, len(report.Materials)),
+		Totals:     toLoanStatsResp(report.Totals, report.PeriodDays),
+	}
+	for _, s := range report.Materials {
+		resp := toLoanStatsResp(s, report.PeriodDays)
+		id, name := s.MaterialID.String(), s.Name
+		resp.MaterialID, resp.Name = &id, &name
+		out.Materials = append(out.Materials, resp)
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+func loansInput(c *gin.Context) usecase.ListLoansInput {
+	limit := 50
+	if s := strings.TrimSpace(c.Query("limit")); s != "" {
+		if n, err := strconv.Atoi(s); err == nil {
+			limit = n
+		}
+	}
+	return usecase.ListLoansInput{
+		MaterialID:      queryPtr(c, "material_id"),
+		PatientID:       queryPtr(c, "patient_id"),
+		KinesiologistID: queryPtr(c, "kinesiologist_id"),
+		Status:          queryPtr(c, "status"),
+		From:            queryPtr(c, "from"),
+		To:              queryPtr(c, "to"),
+		Overdue:         queryPtr(c, "overdue"),
+		Cursor:          queryPtr(c, "cursor"),
+		Limit:           limit,
+	}
+}
@@ -0,0 +1,149 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
+	"github.com/javiacuna/kinesio-backend/internal/materials/usecase"
+)
+
+// LoanHandler: vencimientos de préstamos (prórrogas y vencidos) y devoluciones registradas.
+type LoanHandler struct {
+	extendUC  *usecase.ExtendLoanUseCase
+	overdueUC *usecase.ListOverdueLoansUseCase
+	returnsUC *usecase.ListLoanReturnsUseCase
+}
+
+func NewLoanHandler(
+	extendUC *usecase.ExtendLoanUseCase,
+	overdueUC *usecase.ListOverdueLoansUseCase,
+	returnsUC *usecase.ListLoanReturnsUseCase,
+) *LoanHandler {
+	return &LoanHandler{extendUC: extendUC, overdueUC: overdueUC, returnsUC: returnsUC}
+}
+
+// ---------- Responses
+
+type loanReturnResponse struct {
+	ID                   string  `json:"id"`
+	Qty                  int     `json:"qty"`
+	Condition            string  `json:"condition"`
+	WrittenOff           bool    `json:"written_off"`
+	Notes                *string `json:"notes,omitempty"`
+	RentalCents          int64   `json:"rental_cents"`
+	ChargeCents          int64   `json:"charge_cents"`
+	DepositAppliedCents  int64   `json:"deposit_applied_cents"`
+	DepositRefundedCents int64   `json:"deposit_refunded_cents"`
+	BalanceDueCents      int64   `json:"balance_due_cents"`
+	ReturnedAt           string  `json:"returned_at"`
+}
+
+// returnResponse es el préstamo actualizado más la liquidación de esta devolución.
+type returnResponse struct {
+	loanResponse
+	Settlement loanReturnResponse `json:"settlement"`
+}
+
+func toLoanReturnResp(r domain.LoanReturn) loanReturnResponse {
+	return loanReturnResponse{
+		ID:                   r.ID.String(),
+		Qty:                  r.Qty,
+		Condition:            r.Condition,
+		WrittenOff:           r.WrittenOff,
+		Notes:                r.Notes,
+		RentalCents:          r.RentalCents,
+		ChargeCents:          r.ChargeCents,
+		DepositAppliedCents:  r.DepositAppliedCents,
+		DepositRefundedCents: r.DepositRefundedCents,
+		BalanceDueCents:      r.BalanceDueCents,
+		ReturnedAt:           r.ReturnedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// ---------- Handlers
+
+func (h *LoanHandler) Extend(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("loan_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_loan_id"})
+		return
+	}
+
+	var req usecase.ExtendLoanInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	out, validation, err := h.extendUC.Execute(c.Request.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrValidation):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+			return
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+			return
+		case errors.Is(err, domain.ErrAlreadyReturned):
+			c.JSON(http.StatusConflict, gin.H{"error": "already_returned"})
+			return
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, toLoanResp(out))
+}
+
+func (h *LoanHandler) ListOverdue(c *gin.Context) {
+	limit := 50
+	if s := strings.TrimSpace(c.Query("limit")); s != "" {
+		if n, err := strconv.Atoi(s); err == nil {
+			limit = n
+		}
+	}
+
+	items, err := h.overdueUC.Execute(c.Request.Context(), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	out := make([]loanResponse, 0, len(items))
+	for _, l := range items {
+		out = append(out, toLoanResp(l))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+func (h *LoanHandler) ListReturns(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("loan_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_loan_id"})
+		return
+	}
+
+	items, err := h.returnsUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	out := make([]loanReturnResponse, 0, len(items))
+	for _, r := range items {
+		out = append(out, toLoanReturnResp(r))
+	}
+	c.JSON(http.StatusOK, out)
+}
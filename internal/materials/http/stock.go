@@ -0,0 +1,272 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
+	"github.com/javiacuna/kinesio-backend/internal/materials/usecase"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+type StockHandler struct {
+	restockUC   *usecase.RestockMaterialUseCase
+	writeOffUC  *usecase.WriteOffMaterialUseCase
+	movementsUC *usecase.ListStockMovementsUseCase
+	checkUC     *usecase.CheckStockUseCase
+	reconcileUC *usecase.ReconcileStockUseCase
+}
+
+func NewStockHandler(
+	restockUC *usecase.RestockMaterialUseCase,
+	writeOffUC *usecase.WriteOffMaterialUseCase,
+	movementsUC *usecase.ListStockMovementsUseCase,
+	checkUC *usecase.CheckStockUseCase,
+	reconcileUC *usecase.ReconcileStockUseCase,
+) *StockHandler {
+	return &StockHandler{
+		restockUC:   restockUC,
+		writeOffUC:  writeOffUC,
+		movementsUC: movementsUC,
+		checkUC:     checkUC,
+		reconcileUC: reconcileUC,
+	}
+}
+
+// ---------- Responses
+
+type movementResponse struct {
+	ID             string  `json:"id"`
+	MaterialID     string  `json:"material_id"`
+	Kind           string  `json:"kind"`
+	TotalDelta     int     `json:"total_delta"`
+	AvailableDelta int     `json:"available_delta"`
+	Reason         *string `json:"reason,omitempty"`
+	Note           *string `json:"note,omitempty"`
+	LoanID         *string `json:"loan_id,omitempty"`
+	UnitID         *string `json:"unit_id,omitempty"`
+	CreatedAt      string  `json:"created_at"`
+}
+
+type stockCheckResponse struct {
+	MaterialID         string              `json:"material_id"`
+	Name               string              `json:"name"`
+	TotalQty           int                 `json:"total_qty"`
+	AvailableQty       int                 `json:"available_qty"`
+	LedgerTotalQty     int                 `json:"ledger_total_qty"`
+	LedgerAvailableQty int                 `json:"ledger_available_qty"`
+	LoanedQty          int                 `json:"loaned_qty"`
+	MaintenanceQty     int                 `json:"maintenance_qty"`
+	Units              *unitCountsResponse `json:"units,omitempty"`
+	Consistent         bool                `json:"consistent"`
+	Issues             []string            `json:"issues"`
+}
+
+// unitCountsResponse: estado de las unidades (solo materiales en modo unit).
+type unitCountsResponse struct {
+	Active      int `json:"active"`
+	Available   int `json:"available"`
+	Maintenance int `json:"maintenance"`
+}
+
+func toMovementResp(mv domain.StockMovement) movementResponse {
+	var loanID *string
+	if mv.LoanID != nil {
+		s := mv.LoanID.String()
+		loanID = &s
+	}
+	var unitID *string
+	if mv.UnitID != nil {
+		s := mv.UnitID.String()
+		unitID = &s
+	}
+	return movementResponse{
+		ID:             mv.ID.String(),
+		MaterialID:     mv.MaterialID.String(),
+		Kind:           mv.Kind,
+		TotalDelta:     mv.TotalDelta,
+		AvailableDelta: mv.AvailableDelta,
+		Reason:         mv.Reason,
+		Note:           mv.Note,
+		LoanID:         loanID,
+		UnitID:         unitID,
+		CreatedAt:      mv.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func toStockCheckResp(c domain.StockCheck) stockCheckResponse {
+	out := stockCheckResponse{
+		MaterialID:         c.Material.ID.String(),
+		Name:               c.Material.Name,
+		TotalQty:           c.Material.TotalQty,
+		AvailableQty:       c.Material.AvailableQty,
+		LedgerTotalQty:     c.LedgerTotal,
+		LedgerAvailableQty: c.LedgerAvailable,
+		LoanedQty:          c.LoanedQty,
+		MaintenanceQty:     c.MaintenanceQty,
+		Consistent:         c.Consistent(),
+		Issues:             c.Issues,
+	}
+	if c.Units != nil {
+		out.Units = &unitCountsResponse{Active: c.Units.Active, Available: c.Units.Available, Maintenance: c.Units.Maintenance}
+	}
+	return out
+}
+
+// ---------- Handlers
+
+func (h *StockHandler) Restock(c *gin.Context) {
+	h.change(c, h.restockUC.Execute)
+}
+
+func (h *StockHandler) WriteOff(c *gin.Context) {
+	h.change(c, h.writeOffUC.Execute)
+}
+
+type stockChangeFunc func(ctx context.Context, id uuid.UUID, in usecase.StockChangeInput) (domain.Material, *validation.Errors, error)
+
+func (h *StockHandler) change(c *gin.Context, execute stockChangeFunc) {
+	id, err := uuid.Parse(c.Param("material_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_material_id"})
+		return
+	}
+
+	var req usecase.StockChangeInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	out, validation, err := execute(c.Request.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrValidation):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+			return
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+			return
+		case errors.Is(err, domain.ErrInsufficientStock):
+			c.JSON(http.StatusConflict, gin.H{"error": "insufficient_stock", "details": validation})
+			return
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, toMaterialResp(out))
+}
+
+func (h *StockHandler) ListMovements(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("material_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_material_id"})
+		return
+	}
+
+	limit := 50
+	if s := strings.TrimSpace(c.Query("limit")); s != "" {
+		if n, err := strconv.Atoi(s); err == nil {
+			limit = n
+		}
+	}
+
+	items, err := h.movementsUC.Execute(c.Request.Context(), id, limit)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	out := make([]movementResponse, 0, len(items))
+	for _, mv := range items {
+		out = append(out, toMovementResp(mv))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// CheckAll controla todos los materiales. Con ?only_issues=true devuelve solo
+// los que no cuadran.
+func (h *StockHandler) CheckAll(c *gin.Context) {
+	checks, err := h.checkUC.Execute(c.Request.Context(), nil)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	onlyIssues := strings.EqualFold(strings.TrimSpace(c.Query("only_issues")), "true")
+	out := make([]stockCheckResponse, 0, len(checks))
+	for _, check := range checks {
+		if onlyIssues && check.Consistent() {
+			continue
+		}
+		out = append(out, toStockCheckResp(check))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+func (h *StockHandler) Check(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("material_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_material_id"})
+		return
+	}
+
+	checks, err := h.checkUC.Execute(c.Request.Context(), &id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toStockCheckResp(checks[0]))
+}
+
+func (h *StockHandler) Reconcile(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("material_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_material_id"})
+		return
+	}
+
+	// el body es opcional
+	var req usecase.ReconcileStockInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+			return
+		}
+	}
+
+	out, err := h.reconcileUC.Execute(c.Request.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+			return
+		case errors.Is(err, domain.ErrInsufficientStock):
+			c.JSON(http.StatusConflict, gin.H{"error": "insufficient_stock"})
+			return
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, toStockCheckResp(out))
+}
@@ -0,0 +1,376 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
+	"github.com/javiacuna/kinesio-backend/internal/materials/usecase"
+)
+
+// UnitHandler: unidades de materiales en modo unit, su mantenimiento y las
+// unidades de cada préstamo.
+type UnitHandler struct {
+	addUC             *usecase.AddUnitUseCase
+	listUC            *usecase.ListUnitsUseCase
+	getUC             *usecase.GetUnitUseCase
+	retireUC          *usecase.RetireUnitUseCase
+	maintenanceUC     *usecase.RegisterMaintenanceUseCase
+	completeUC        *usecase.CompleteMaintenanceUseCase
+	listMaintenanceUC *usecase.ListMaintenanceUseCase
+	loanUnitsUC       *usecase.ListLoanUnitsUseCase
+}
+
+func NewUnitHandler(
+	addUC *usecase.AddUnitUseCase,
+	listUC *usecase.ListUnitsUseCase,
+	getUC *usecase.GetUnitUseCase,
+	retireUC *usecase.RetireUnitUseCase,
+	maintenanceUC *usecase.RegisterMaintenanceUseCase,
+	completeUC *usecase.CompleteMaintenanceUseCase,
+	listMaintenanceUC *usecase.ListMaintenanceUseCase,
+	loanUnitsUC *usecase.ListLoanUnitsUseCase,
+) *UnitHandler {
+	return &UnitHandler{
+		addUC:             addUC,
+		listUC:            listUC,
+		getUC:             getUC,
+		retireUC:          retireUC,
+		maintenanceUC:     maintenanceUC,
+		completeUC:        completeUC,
+		listMaintenanceUC: listMaintenanceUC,
+		loanUnitsUC:       loanUnitsUC,
+	}
+}
+
+// ---------- Responses
+
+type unitResponse struct {
+	ID         string  `json:"id"`
+	MaterialID string  `json:"material_id"`
+	Serial     string  `json:"serial"`
+	Status     string  `json:"status"`
+	Notes      *string `json:"notes,omitempty"`
+	AcquiredAt *string `json:"acquired_at,omitempty"`
+	CreatedAt  string  `json:"created_at"`
+	UpdatedAt  string  `json:"updated_at"`
+}
+
+type maintenanceResponse struct {
+	ID           string  `json:"id"`
+	UnitID       string  `json:"unit_id"`
+	Kind         string  `json:"kind"`
+	Description  *string `json:"description,omitempty"`
+	CostCents    *int64  `json:"cost_cents,omitempty"`
+	OutOfService bool    `json:"out_of_service"`
+	StartedAt    string  `json:"started_at"`
+	CompletedAt  *string `json:"completed_at,omitempty"`
+}
+
+type loanUnitResponse struct {
+	UnitID     string  `json:"unit_id"`
+	Serial     string  `json:"serial"`
+	ReturnID   *string `json:"return_id,omitempty"`
+	ReturnedAt *string `json:"returned_at,omitempty"`
+}
+
+func toUnitResp(u domain.MaterialUnit) unitResponse {
+	var acquired *string
+	if u.AcquiredAt != nil {
+		s := u.AcquiredAt.Format("2006-01-02")
+		acquired = &s
+	}
+	return unitResponse{
+		ID:         u.ID.String(),
+		MaterialID: u.MaterialID.String(),
+		Serial:     u.Serial,
+		Status:     u.Status,
+		Notes:      u.Notes,
+		AcquiredAt: acquired,
+		CreatedAt:  u.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:  u.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func toMaintenanceResp(r domain.MaintenanceRecord) maintenanceResponse {
+	var completed *string
+	if r.CompletedAt != nil {
+		s := r.CompletedAt.UTC().Format(time.RFC3339)
+		completed = &s
+	}
+	return maintenanceResponse{
+		ID:           r.ID.String(),
+		UnitID:       r.UnitID.String(),
+		Kind:         r.Kind,
+		Description:  r.Description,
+		CostCents:    r.CostCents,
+		OutOfService: r.OutOfService,
+		StartedAt:    r.StartedAt.UTC().Format(time.RFC3339),
+		CompletedAt:  completed,
+	}
+}
+
+func toLoanUnitResp(lu domain.LoanUnit) loanUnitResponse {
+	var returnID, returned *string
+	if lu.ReturnID != nil {
+		s := lu.ReturnID.String()
+		returnID = &s
+	}
+	if lu.ReturnedAt != nil {
+		s := lu.ReturnedAt.UTC().Format(time.RFC3339)
+		returned = &s
+	}
+	return loanUnitResponse{
+		UnitID:     lu.UnitID.String(),
+		Serial:     lu.Serial,
+		ReturnID:   returnID,
+		ReturnedAt: returned,
+	}
+}
+
+// ---------- Handlers
+
+func (h *UnitHandler) Add(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("material_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_material_id"})
+		return
+	}
+
+	var req usecase.AddUnitInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	out, validation, err := h.addUC.Execute(c.Request.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrValidation):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+			return
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+			return
+		case errors.Is(err, domain.ErrDuplicateSerial):
+			c.JSON(http.StatusConflict, gin.H{"error": "duplicate_serial"})
+			return
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusCreated, toUnitResp(out))
+}
+
+func (h *UnitHandler) List(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("material_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_material_id"})
+		return
+	}
+
+	items, validation, err := h.listUC.Execute(c.Request.Context(), id, c.Query("status"))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrValidation):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+			return
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+			return
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+			return
+		}
+	}
+
+	out := make([]unitResponse, 0, len(items))
+	for _, u := range items {
+		out = append(out, toUnitResp(u))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+func (h *UnitHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("unit_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_unit_id"})
+		return
+	}
+
+	u, found, err := h.getUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toUnitResp(u))
+}
+
+func (h *UnitHandler) Retire(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("unit_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_unit_id"})
+		return
+	}
+
+	var req usecase.RetireUnitInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	out, validation, err := h.retireUC.Execute(c.Request.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrValidation):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+			return
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+			return
+		case errors.Is(err, domain.ErrUnitNotAvailable):
+			c.JSON(http.StatusConflict, gin.H{"error": "unit_not_available"})
+			return
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, toUnitResp(out))
+}
+
+func (h *UnitHandler) RegisterMaintenance(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("unit_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_unit_id"})
+		return
+	}
+
+	var req usecase.MaintenanceInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	out, validation, err := h.maintenanceUC.Execute(c.Request.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrValidation):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+			return
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+			return
+		case errors.Is(err, domain.ErrUnitNotAvailable):
+			c.JSON(http.StatusConflict, gin.H{"error": "unit_not_available"})
+			return
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusCreated, toMaintenanceResp(out))
+}
+
+func (h *UnitHandler) CompleteMaintenance(c *gin.Context) {
+	unitID, err := uuid.Parse(c.Param("unit_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_unit_id"})
+		return
+	}
+	id, err := uuid.Parse(c.Param("maintenance_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_maintenance_id"})
+		return
+	}
+
+	// el body es opcional
+	var req usecase.CompleteMaintenanceInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+			return
+		}
+	}
+
+	out, validation, err := h.completeUC.Execute(c.Request.Context(), unitID, id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrValidation):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+			return
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+			return
+		case errors.Is(err, domain.ErrMaintenanceCompleted):
+			c.JSON(http.StatusConflict, gin.H{"error": "maintenance_already_completed"})
+			return
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, toMaintenanceResp(out))
+}
+
+func (h *UnitHandler) ListMaintenance(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("unit_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_unit_id"})
+		return
+	}
+
+	items, err := h.listMaintenanceUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	out := make([]maintenanceResponse, 0, len(items))
+	for _, r := range items {
+		out = append(out, toMaintenanceResp(r))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+func (h *UnitHandler) ListLoanUnits(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("loan_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_loan_id"})
+		return
+	}
+
+	items, err := h.loanUnitsUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	out := make([]loanUnitResponse, 0, len(items))
+	for _, lu := range items {
+		out = append(out, toLoanUnitResp(lu))
+	}
+	c.JSON(http.StatusOK, out)
+}
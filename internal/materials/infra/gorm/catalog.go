@@ -0,0 +1,111 @@
+package gorm
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
+)
+
+// -------- Catálogo
+
+func (r *Repository) ListMaterials(ctx context.Context, f domain.MaterialFilter) ([]domain.Material, error) {
+	q := r.db.WithContext(ctx).Model(&MaterialModel{}).Order("name asc, id asc").Limit(f.Limit)
+
+	if f.Search != nil {
+		pattern := "%" + escapeLike(*f.Search) + "%"
+		q = q.Where("(name ILIKE ? OR description ILIKE ? OR category ILIKE ?)", pattern, pattern, pattern)
+	}
+	if f.Category != nil {
+		q = q.Where("lower(category) = lower(?)", *f.Category)
+	}
+	if f.Available != nil {
+		if *f.Available {
+			q = q.Where("available_qty > 0")
+		} else {
+			q = q.Where("available_qty = 0")
+		}
+	}
+	if f.LowStock {
+		q = q.Where("low_stock_threshold IS NOT NULL AND available_qty < low_stock_threshold")
+	}
+	if f.After != nil {
+		q = q.Where("(name, id) > (?, ?)", f.After.Name, f.After.ID.String())
+	}
+
+	var ms []MaterialModel
+	if err := q.Find(&ms).Error; err != nil {
+		return nil, err
+	}
+	out := make([]domain.Material, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, toMaterialDomain(m))
+	}
+	return out, nil
+}
+
+func (r *Repository) ListCategories(ctx context.Context) ([]domain.CategoryCount, error) {
+	var rows []struct {
+		Category  string
+		Materials int
+	}
+	err := r.db.WithContext(ctx).
+		Model(&MaterialModel{}).
+		Select("min(category) AS category, COUNT(*) AS materials").
+		Where("category IS NOT NULL").
+		Group("lower(category)").
+		Order("lower(category) asc").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.CategoryCount, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, domain.CategoryCount{Category: row.Category, Materials: row.Materials})
+	}
+	return out, nil
+}
+
+// -------- Alertas de stock bajo
+
+func (r *Repository) ListLowStockMaterials(ctx context.Context, onlyUnnotified bool) ([]domain.Material, error) {
+	q := r.db.WithContext(ctx).
+		Where("low_stock_threshold IS NOT NULL AND available_qty < low_stock_threshold").
+		Order("name asc, id asc")
+	if onlyUnnotified {
+		q = q.Where("low_stock_notified_at IS NULL")
+	}
+
+	var ms []MaterialModel
+	if err := q.Find(&ms).Error; err != nil {
+		return nil, err
+	}
+	out := make([]domain.Material, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, toMaterialDomain(m))
+	}
+	return out, nil
+}
+
+func (r *Repository) MarkLowStockNotified(ctx context.Context, materialID uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&MaterialModel{}).
+		Where("id = ?", materialID.String()).
+		Update("low_stock_notified_at", at).Error
+}
+
+func (r *Repository) ResetLowStockAlerts(ctx context.Context) (int64, error) {
+	res := r.db.WithContext(ctx).
+		Model(&MaterialModel{}).
+		Where("low_stock_notified_at IS NOT NULL AND (low_stock_threshold IS NULL OR available_qty >= low_stock_threshold)").
+		Update("low_stock_notified_at", nil)
+	return res.RowsAffected, res.Error
+}
+
+// escapeLike escapa los comodines de LIKE para buscar el texto tal cual.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
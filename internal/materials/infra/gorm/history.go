@@ -0,0 +1,166 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
+)
+
+// -------- Historial de préstamos
+
+func (r *Repository) ListLoans(ctx context.Context, f domain.LoanFilter) ([]domain.MaterialLoan, error) {
+	q := r.db.WithContext(ctx).Model(&MaterialLoanModel{}).Order("loaned_at desc, id desc").Limit(f.Limit)
+
+	if f.MaterialID != nil {
+		q = q.Where("material_id = ?", f.MaterialID.String())
+	}
+	if f.PatientID != nil {
+		q = q.Where("patient_id = ?", f.PatientID.String())
+	}
+	if f.KinesiologistID != nil {
+		q = q.Where("kinesiologist_id = ?", f.KinesiologistID.String())
+	}
+	switch f.Status {
+	case domain.LoanStatusActive:
+		q = q.Where("returned_at IS NULL")
+	case domain.LoanStatusReturned:
+		q = q.Where("returned_at IS NOT NULL")
+	}
+	if f.From != nil {
+		q = q.Where("loaned_at >= ?", *f.From)
+	}
+	if f.To != nil {
+		q = q.Where("loaned_at < ?", *f.To)
+	}
+	if f.OverdueAt != nil {
+		q = q.Where("returned_at IS NULL AND due_date < ?", domain.Day(*f.OverdueAt).Format("2006-01-02"))
+	}
+	if f.After != nil {
+		q = q.Where("(loaned_at, id) < (?, ?)", f.After.LoanedAt, f.After.ID.String())
+	}
+
+	var ms []MaterialLoanModel
+	if err := q.Find(&ms).Error; err != nil {
+		return nil, err
+	}
+	out := make([]domain.MaterialLoan, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, toLoanDomain(m))
+	}
+	return out, nil
+}
+
+// loanStatsSQL arma las métricas por material:
+//   - period: préstamos iniciados en [from, to) con su duración y pérdidas
+//   - usage: unidades-día prestadas dentro de [from, to); cada devolución cierra
+//     sus unidades y lo pendiente corre hasta now
+//   - write_offs: bajas de stock que no vienen de un préstamo
+const loanStatsSQL = `
+WITH period AS (
+	SELECT material_id,
+	       COUNT(*) AS loans,
+	       SUM(qty) AS units_loaned,
+	       COUNT(*) FILTER (WHERE returned_at IS NOT NULL) AS returned_loans,
+	       (AVG(EXTRACT(EPOCH FROM returned_at - loaned_at) / 86400) FILTER (WHERE returned_at IS NOT NULL))::float8 AS avg_loan_days
+	FROM material_loans
+	WHERE loaned_at >= @from AND loaned_at < @to
+	GROUP BY material_id
+), losses AS (
+	SELECT l.material_id,
+	       SUM(r.qty) FILTER (WHERE r.condition = 'lost') AS lost_qty,
+	       SUM(r.qty) FILTER (WHERE r.condition = 'damaged' AND r.written_off) AS damaged_qty
+	FROM material_loan_returns r
+	JOIN material_loans l ON l.id = r.loan_id
+	WHERE l.loaned_at >= @from AND l.loaned_at < @to
+	GROUP BY l.material_id
+), spans AS (
+	SELECT l.material_id, l.loaned_at, r.qty, r.returned_at AS ended_at
+	FROM material_loan_returns r
+	JOIN material_loans l ON l.id = r.loan_id
+	UNION ALL
+	SELECT material_id, loaned_at, qty - returned_qty, CAST(@now AS timestamptz)
+	FROM material_loans
+	WHERE qty > returned_qty
+), usage AS (
+	SELECT material_id,
+	       SUM(qty * GREATEST(0, EXTRACT(EPOCH FROM LEAST(ended_at, CAST(@to AS timestamptz)) - GREATEST(loaned_at, CAST(@from AS timestamptz))) / 86400))::float8 AS unit_days
+	FROM spans
+	WHERE loaned_at < @to AND ended_at > @from
+	GROUP BY material_id
+), active AS (
+	SELECT material_id, SUM(qty - returned_qty) AS qty
+	FROM material_loans
+	WHERE returned_at IS NULL
+	GROUP BY material_id
+), write_offs AS (
+	SELECT material_id, SUM(-total_delta) AS qty
+	FROM material_stock_movements
+	WHERE kind = 'write_off' AND loan_id IS NULL AND created_at >= @from AND created_at < @to
+	GROUP BY material_id
+)
+SELECT m.id AS material_id, m.name, m.total_qty,
+       COALESCE(p.loans, 0) AS loans,
+       COALESCE(p.units_loaned, 0) AS units_loaned,
+       COALESCE(a.qty, 0) AS active_qty,
+       COALESCE(u.unit_days, 0) AS loaned_unit_days,
+       COALESCE(p.returned_loans, 0) AS returned_loans,
+       p.avg_loan_days,
+       COALESCE(x.lost_qty, 0) AS lost_qty,
+       COALESCE(x.damaged_qty, 0) AS damaged_qty,
+       COALESCE(w.qty, 0) AS stock_write_off_qty
+FROM materials m
+LEFT JOIN period p ON p.material_id = m.id
+LEFT JOIN losses x ON x.material_id = m.id
+LEFT JOIN usage u ON u.material_id = m.id
+LEFT JOIN active a ON a.material_id = m.id
+LEFT JOIN write_offs w ON w.material_id = m.id`
+
+func (r *Repository) LoanStats(ctx context.Context, from, to, now time.Time, materialID *uuid.UUID) ([]domain.MaterialLoanStats, error) {
+	args := map[string]any{"from": from, "to": to, "now": now}
+	sql := loanStatsSQL
+	if materialID != nil {
+		sql += "\nWHERE m.id = CAST(@material AS uuid)"
+		args["material"] = materialID.String()
+	}
+	sql += "\nORDER BY m.name ASC, m.id ASC"
+
+	var rows []struct {
+		MaterialID       string
+		Name             string
+		TotalQty         int
+		Loans            int
+		UnitsLoaned      int
+		ActiveQty        int
+		LoanedUnitDays   float64
+		ReturnedLoans    int
+		AvgLoanDays      *float64
+		LostQty          int
+		DamagedQty       int
+		StockWriteOffQty int
+	}
+	if err := r.db.WithContext(ctx).Raw(sql, args).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	out := make([]domain.MaterialLoanStats, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, domain.MaterialLoanStats{
+			MaterialID:       uuid.MustParse(row.MaterialID),
+			Name:             row.Name,
+			TotalQty:         row.TotalQty,
+			Loans:            row.Loans,
+			UnitsLoaned:      row.UnitsLoaned,
+			ActiveQty:        row.ActiveQty,
+			LoanedUnitDays:   row.LoanedUnitDays,
+			ReturnedLoans:    row.ReturnedLoans,
+			AvgLoanDays:      row.AvgLoanDays,
+			LostQty:          row.LostQty,
+			DamagedQty:       row.DamagedQty,
+			StockWriteOffQty: row.StockWriteOffQty,
+		})
+	}
+	return out, nil
+}
@@ -3,26 +3,112 @@ package gorm
 import "time"
 
 type MaterialModel struct {
-	ID           string `gorm:"type:uuid;primaryKey"`
-	Name         string `gorm:"not null"`
-	Description  *string
-	TotalQty     int `gorm:"not null"`
-	AvailableQty int `gorm:"not null"`
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID                   string `gorm:"type:uuid;primaryKey"`
+	Name                 string `gorm:"not null"`
+	Description          *string
+	TotalQty             int `gorm:"not null"`
+	AvailableQty         int `gorm:"not null"`
+	DefaultLoanDays      *int
+	DepositCents         *int64
+	RentalFeeCents       *int64
+	RentalPeriod         *string
+	ReplacementCostCents *int64
+	TrackingMode         string `gorm:"not null"`
+	Category             *string
+	LowStockThreshold    *int
+	LowStockNotifiedAt   *time.Time
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
 }
 
 func (MaterialModel) TableName() string { return "materials" }
 
 type MaterialLoanModel struct {
-	ID              string `gorm:"type:uuid;primaryKey"`
-	MaterialID      string `gorm:"type:uuid;not null"`
-	PatientID       string `gorm:"type:uuid;not null"`
-	KinesiologistID string `gorm:"type:uuid;not null"`
-	Qty             int    `gorm:"not null"`
-	Notes           *string
-	LoanedAt        time.Time
-	ReturnedAt      *time.Time
+	ID                   string `gorm:"type:uuid;primaryKey"`
+	MaterialID           string `gorm:"type:uuid;not null"`
+	PatientID            string `gorm:"type:uuid;not null"`
+	KinesiologistID      string `gorm:"type:uuid;not null"`
+	Qty                  int    `gorm:"not null"`
+	ReturnedQty          int    `gorm:"not null"`
+	Notes                *string
+	LoanedAt             time.Time
+	DueDate              *time.Time `gorm:"type:date"`
+	ReturnedAt           *time.Time
+	OverdueNotifiedAt    *time.Time
+	DepositCents         int64 `gorm:"not null"`
+	DepositAppliedCents  int64 `gorm:"not null"`
+	DepositRefundedCents int64 `gorm:"not null"`
+	RentalFeeCents       *int64
+	RentalPeriod         *string
+	ChargedCents         int64 `gorm:"not null"`
 }
 
 func (MaterialLoanModel) TableName() string { return "material_loans" }
+
+type LoanReturnModel struct {
+	ID                   string `gorm:"type:uuid;primaryKey"`
+	LoanID               string `gorm:"type:uuid;not null"`
+	Qty                  int    `gorm:"not null"`
+	Condition            string `gorm:"not null"`
+	WrittenOff           bool   `gorm:"not null"`
+	Notes                *string
+	RentalCents          int64 `gorm:"not null"`
+	ChargeCents          int64 `gorm:"not null"`
+	DepositAppliedCents  int64 `gorm:"not null"`
+	DepositRefundedCents int64 `gorm:"not null"`
+	BalanceDueCents      int64 `gorm:"not null"`
+	ReturnedAt           time.Time
+}
+
+func (LoanReturnModel) TableName() string { return "material_loan_returns" }
+
+type StockMovementModel struct {
+	ID             string `gorm:"type:uuid;primaryKey"`
+	MaterialID     string `gorm:"type:uuid;not null"`
+	Kind           string `gorm:"not null"`
+	TotalDelta     int    `gorm:"not null"`
+	AvailableDelta int    `gorm:"not null"`
+	Reason         *string
+	Note           *string
+	LoanID         *string `gorm:"type:uuid"`
+	UnitID         *string `gorm:"type:uuid"`
+	CreatedAt      time.Time
+}
+
+func (StockMovementModel) TableName() string { return "material_stock_movements" }
+
+type MaterialUnitModel struct {
+	ID         string `gorm:"type:uuid;primaryKey"`
+	MaterialID string `gorm:"type:uuid;not null"`
+	Serial     string `gorm:"not null"`
+	Status     string `gorm:"not null"`
+	Notes      *string
+	AcquiredAt *time.Time `gorm:"type:date"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (MaterialUnitModel) TableName() string { return "material_units" }
+
+type LoanUnitModel struct {
+	LoanID     string  `gorm:"type:uuid;primaryKey"`
+	UnitID     string  `gorm:"type:uuid;primaryKey"`
+	ReturnID   *string `gorm:"type:uuid"`
+	ReturnedAt *time.Time
+}
+
+func (LoanUnitModel) TableName() string { return "material_loan_units" }
+
+type MaintenanceModel struct {
+	ID           string `gorm:"type:uuid;primaryKey"`
+	UnitID       string `gorm:"type:uuid;not null"`
+	Kind         string `gorm:"not null"`
+	Description  *string
+	CostCents    *int64
+	OutOfService bool `gorm:"not null"`
+	StartedAt    time.Time
+	CompletedAt  *time.Time
+	CreatedAt    time.Time
+}
+
+func (MaintenanceModel) TableName() string { return "material_unit_maintenance" }
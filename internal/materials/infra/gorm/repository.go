@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
 )
@@ -20,15 +21,30 @@ func NewRepository(db *gorm.DB) *Repository {
 	return &Repository{db: db}
 }
 
+// WithinTx corre fn con un Repository atado a la transacción. Las operaciones
+// que ya abren su propia transacción (ApplyMovement) pasan a usar un savepoint.
+func (r *Repository) WithinTx(ctx context.Context, fn func(tx domain.Repository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&Repository{db: tx})
+	})
+}
+
 // -------- Materials
 
 func (r *Repository) CreateMaterial(ctx context.Context, m domain.Material) (domain.Material, error) {
 	model := toMaterialModel(m)
-	err := r.db.WithContext(ctx).Create(&model).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&model).Error; err != nil {
+			return err
+		}
+		if m.TotalQty <= 0 {
+			return nil
+		}
+		mv := toMovementModel(domain.NewMovement(m.ID, domain.MovementInitial, m.TotalQty, m.CreatedAt))
+		return tx.Create(&mv).Error
+	})
 	if err != nil {
-		// unique name -> duplicate
-		if strings.Contains(strings.ToLower(err.Error()), "ux_materials_name") ||
-			strings.Contains(strings.ToLower(err.Error()), "duplicate") {
+		if isDuplicateName(err) {
 			return domain.Material{}, domain.ErrDuplicateName
 		}
 		return domain.Material{}, err
@@ -40,9 +56,40 @@ func (r *Repository) CreateMaterial(ctx context.Context, m domain.Material) (dom
 	return out, nil
 }
 
-func (r *Repository) ListMaterials(ctx context.Context, limit int) ([]domain.Material, error) {
+// UpdateMaterial no toca las cantidades: cambian únicamente a través de
+// movimientos del libro.
+func (r *Repository) UpdateMaterial(ctx context.Context, m domain.Material) (domain.Material, error) {
+	err := r.db.WithContext(ctx).
+		Model(&MaterialModel{}).
+		Where("id = ?", m.ID.String()).
+		Updates(map[string]any{
+			"name":                   m.Name,
+			"description":            m.Description,
+			"default_loan_days":      m.DefaultLoanDays,
+			"deposit_cents":          m.DepositCents,
+			"rental_fee_cents":       m.RentalFeeCents,
+			"rental_period":          m.RentalPeriod,
+			"replacement_cost_cents": m.ReplacementCostCents,
+			"category":               m.Category,
+			"low_stock_threshold":    m.LowStockThreshold,
+			"updated_at":             m.UpdatedAt,
+		}).Error
+	if err != nil {
+		if isDuplicateName(err) {
+			return domain.Material{}, domain.ErrDuplicateName
+		}
+		return domain.Material{}, err
+	}
+	out, _, err := r.GetMaterialByID(ctx, m.ID)
+	if err != nil {
+		return domain.Material{}, err
+	}
+	return out, nil
+}
+
+func (r *Repository) ListAllMaterials(ctx context.Context) ([]domain.Material, error) {
 	var ms []MaterialModel
-	if err := r.db.WithContext(ctx).Order("name asc").Limit(limit).Find(&ms).Error; err != nil {
+	if err := r.db.WithContext(ctx).Order("name asc").Find(&ms).Error; err != nil {
 		return nil, err
 	}
 	out := make([]domain.Material, 0, len(ms))
@@ -64,28 +111,16 @@ func (r *Repository) GetMaterialByID(ctx context.Context, id uuid.UUID) (domain.
 	return toMaterialDomain(m), true, nil
 }
 
-func (r *Repository) DecrementAvailable(ctx context.Context, materialID uuid.UUID, qty int) error {
-	// UPDATE materials SET available_qty = available_qty - qty WHERE id=? AND available_qty >= qty
-	res := r.db.WithContext(ctx).
-		Model(&MaterialModel{}).
-		Where("id = ? AND available_qty >= ?", materialID.String(), qty).
-		Update("available_qty", gorm.Expr("available_qty - ?", qty))
-
-	if res.Error != nil {
-		return res.Error
-	}
-	if res.RowsAffected == 0 {
-		return domain.ErrInsufficientStock
+func (r *Repository) GetMaterialForUpdate(ctx context.Context, id uuid.UUID) (domain.Material, bool, error) {
+	var m MaterialModel
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&m, "id = ?", id.String()).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.Material{}, false, nil
+		}
+		return domain.Material{}, false, err
 	}
-	return nil
-}
-
-func (r *Repository) IncrementAvailable(ctx context.Context, materialID uuid.UUID, qty int) error {
-	res := r.db.WithContext(ctx).
-		Model(&MaterialModel{}).
-		Where("id = ?", materialID.String()).
-		Update("available_qty", gorm.Expr("available_qty + ?", qty))
-	return res.Error
+	return toMaterialDomain(m), true, nil
 }
 
 // -------- Loans
@@ -114,6 +149,18 @@ func (r *Repository) GetLoanByID(ctx context.Context, id uuid.UUID) (domain.Mate
 	return toLoanDomain(m), true, nil
 }
 
+func (r *Repository) GetLoanForUpdate(ctx context.Context, id uuid.UUID) (domain.MaterialLoan, bool, error) {
+	var m MaterialLoanModel
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&m, "id = ?", id.String()).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.MaterialLoan{}, false, nil
+		}
+		return domain.MaterialLoan{}, false, err
+	}
+	return toLoanDomain(m), true, nil
+}
+
 func (r *Repository) ListLoansByPatient(ctx context.Context, patientID uuid.UUID, onlyActive bool, limit int) ([]domain.MaterialLoan, error) {
 	q := r.db.WithContext(ctx).Order("loaned_at desc").Limit(limit).Where("patient_id = ?", patientID.String())
 	if onlyActive {
@@ -132,45 +179,134 @@ func (r *Repository) ListLoansByPatient(ctx context.Context, patientID uuid.UUID
 	return out, nil
 }
 
-func (r *Repository) MarkReturned(ctx context.Context, loanID uuid.UUID, returnedAt time.Time) error {
-	// solo si no estaba returned
-	res := r.db.WithContext(ctx).
-		Model(&MaterialLoanModel{}).
-		Where("id = ? AND returned_at IS NULL", loanID.String()).
-		Update("returned_at", returnedAt)
+func (r *Repository) RegisterReturn(ctx context.Context, ret domain.LoanReturn) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// solo si no estaba returned y sin devolver más de lo prestado
+		res := tx.Model(&MaterialLoanModel{}).
+			Where("id = ? AND returned_at IS NULL AND returned_qty + ? <= qty", ret.LoanID.String(), ret.Qty).
+			Updates(map[string]any{
+				"returned_qty":           gorm.Expr("returned_qty + ?", ret.Qty),
+				"returned_at":            gorm.Expr("CASE WHEN returned_qty + ? >= qty THEN ?::timestamptz ELSE NULL END", ret.Qty, ret.ReturnedAt),
+				"charged_cents":          gorm.Expr("charged_cents + ?", ret.RentalCents+ret.ChargeCents),
+				"deposit_applied_cents":  gorm.Expr("deposit_applied_cents + ?", ret.DepositAppliedCents),
+				"deposit_refunded_cents": gorm.Expr("deposit_refunded_cents + ?", ret.DepositRefundedCents),
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return domain.ErrAlreadyReturned
+		}
+
+		model := toReturnModel(ret)
+		return tx.Create(&model).Error
+	})
+}
 
-	if res.Error != nil {
-		return res.Error
+func (r *Repository) ListLoanReturns(ctx context.Context, loanID uuid.UUID) ([]domain.LoanReturn, error) {
+	var ms []LoanReturnModel
+	if err := r.db.WithContext(ctx).Where("loan_id = ?", loanID.String()).Order("returned_at asc").Find(&ms).Error; err != nil {
+		return nil, err
+	}
+	out := make([]domain.LoanReturn, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, toReturnDomain(m))
 	}
-	if res.RowsAffected == 0 {
-		return domain.ErrAlreadyReturned
+	return out, nil
+}
+
+func (r *Repository) ListOverdueLoans(ctx context.Context, today time.Time, onlyUnnotified bool, limit int) ([]domain.MaterialLoan, error) {
+	q := r.db.WithContext(ctx).
+		Where("returned_at IS NULL AND due_date < ?", domain.Day(today).Format("2006-01-02")).
+		Order("due_date asc, loaned_at asc").
+		Limit(limit)
+	if onlyUnnotified {
+		q = q.Where("overdue_notified_at IS NULL")
+	}
+
+	var ms []MaterialLoanModel
+	if err := q.Find(&ms).Error; err != nil {
+		return nil, err
+	}
+	out := make([]domain.MaterialLoan, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, toLoanDomain(m))
 	}
-	return nil
+	return out, nil
+}
+
+func (r *Repository) SetLoanDueDate(ctx context.Context, loanID uuid.UUID, dueDate time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&MaterialLoanModel{}).
+		Where("id = ?", loanID.String()).
+		Updates(map[string]any{
+			"due_date":            domain.Day(dueDate).Format("2006-01-02"),
+			"overdue_notified_at": nil,
+		}).Error
+}
+
+func (r *Repository) MarkOverdueNotified(ctx context.Context, loanID uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&MaterialLoanModel{}).
+		Where("id = ?", loanID.String()).
+		Update("overdue_notified_at", at).Error
+}
+
+// isDuplicateName: solo el índice único del nombre; cualquier otra violación sigue como error.
+func isDuplicateName(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "ux_materials_name")
 }
 
 // -------- mappers
 
+// dayPtr normaliza las columnas DATE a medianoche UTC.
+func dayPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	d := domain.Day(*t)
+	return &d
+}
+
 func toMaterialModel(m domain.Material) MaterialModel {
 	return MaterialModel{
-		ID:           m.ID.String(),
-		Name:         m.Name,
-		Description:  m.Description,
-		TotalQty:     m.TotalQty,
-		AvailableQty: m.AvailableQty,
-		CreatedAt:    m.CreatedAt,
-		UpdatedAt:    m.UpdatedAt,
+		ID:                   m.ID.String(),
+		Name:                 m.Name,
+		Description:          m.Description,
+		TotalQty:             m.TotalQty,
+		AvailableQty:         m.AvailableQty,
+		DefaultLoanDays:      m.DefaultLoanDays,
+		DepositCents:         m.DepositCents,
+		RentalFeeCents:       m.RentalFeeCents,
+		RentalPeriod:         m.RentalPeriod,
+		ReplacementCostCents: m.ReplacementCostCents,
+		TrackingMode:         m.TrackingMode,
+		Category:             m.Category,
+		LowStockThreshold:    m.LowStockThreshold,
+		LowStockNotifiedAt:   m.LowStockNotifiedAt,
+		CreatedAt:            m.CreatedAt,
+		UpdatedAt:            m.UpdatedAt,
 	}
 }
 
 func toMaterialDomain(m MaterialModel) domain.Material {
 	return domain.Material{
-		ID:           uuid.MustParse(m.ID),
-		Name:         m.Name,
-		Description:  m.Description,
-		TotalQty:     m.TotalQty,
-		AvailableQty: m.AvailableQty,
-		CreatedAt:    m.CreatedAt,
-		UpdatedAt:    m.UpdatedAt,
+		ID:                   uuid.MustParse(m.ID),
+		Name:                 m.Name,
+		Description:          m.Description,
+		TotalQty:             m.TotalQty,
+		AvailableQty:         m.AvailableQty,
+		DefaultLoanDays:      m.DefaultLoanDays,
+		DepositCents:         m.DepositCents,
+		RentalFeeCents:       m.RentalFeeCents,
+		RentalPeriod:         m.RentalPeriod,
+		ReplacementCostCents: m.ReplacementCostCents,
+		TrackingMode:         m.TrackingMode,
+		Category:             m.Category,
+		LowStockThreshold:    m.LowStockThreshold,
+		LowStockNotifiedAt:   m.LowStockNotifiedAt,
+		CreatedAt:            m.CreatedAt,
+		UpdatedAt:            m.UpdatedAt,
 	}
 }
 
@@ -180,26 +316,78 @@ func toLoanModel(l domain.MaterialLoan) MaterialLoanModel {
 		returnedAt = l.ReturnedAt
 	}
 	return MaterialLoanModel{
-		ID:              l.ID.String(),
-		MaterialID:      l.MaterialID.String(),
-		PatientID:       l.PatientID.String(),
-		KinesiologistID: l.KinesiologistID.String(),
-		Qty:             l.Qty,
-		Notes:           l.Notes,
-		LoanedAt:        l.LoanedAt,
-		ReturnedAt:      returnedAt,
+		ID:                   l.ID.String(),
+		MaterialID:           l.MaterialID.String(),
+		PatientID:            l.PatientID.String(),
+		KinesiologistID:      l.KinesiologistID.String(),
+		Qty:                  l.Qty,
+		ReturnedQty:          l.ReturnedQty,
+		Notes:                l.Notes,
+		LoanedAt:             l.LoanedAt,
+		DueDate:              l.DueDate,
+		ReturnedAt:           returnedAt,
+		OverdueNotifiedAt:    l.OverdueNotifiedAt,
+		DepositCents:         l.DepositCents,
+		DepositAppliedCents:  l.DepositAppliedCents,
+		DepositRefundedCents: l.DepositRefundedCents,
+		RentalFeeCents:       l.RentalFeeCents,
+		RentalPeriod:         l.RentalPeriod,
+		ChargedCents:         l.ChargedCents,
 	}
 }
 
 func toLoanDomain(m MaterialLoanModel) domain.MaterialLoan {
 	return domain.MaterialLoan{
-		ID:              uuid.MustParse(m.ID),
-		MaterialID:      uuid.MustParse(m.MaterialID),
-		PatientID:       uuid.MustParse(m.PatientID),
-		KinesiologistID: uuid.MustParse(m.KinesiologistID),
-		Qty:             m.Qty,
-		Notes:           m.Notes,
-		LoanedAt:        m.LoanedAt,
-		ReturnedAt:      m.ReturnedAt,
+		ID:                   uuid.MustParse(m.ID),
+		MaterialID:           uuid.MustParse(m.MaterialID),
+		PatientID:            uuid.MustParse(m.PatientID),
+		KinesiologistID:      uuid.MustParse(m.KinesiologistID),
+		Qty:                  m.Qty,
+		ReturnedQty:          m.ReturnedQty,
+		Notes:                m.Notes,
+		LoanedAt:             m.LoanedAt,
+		DueDate:              dayPtr(m.DueDate),
+		ReturnedAt:           m.ReturnedAt,
+		OverdueNotifiedAt:    m.OverdueNotifiedAt,
+		DepositCents:         m.DepositCents,
+		DepositAppliedCents:  m.DepositAppliedCents,
+		DepositRefundedCents: m.DepositRefundedCents,
+		RentalFeeCents:       m.RentalFeeCents,
+		RentalPeriod:         m.RentalPeriod,
+		ChargedCents:         m.ChargedCents,
+	}
+}
+
+func toReturnModel(r domain.LoanReturn) LoanReturnModel {
+	return LoanReturnModel{
+		ID:                   r.ID.String(),
+		LoanID:               r.LoanID.String(),
+		Qty:                  r.Qty,
+		Condition:            r.Condition,
+		WrittenOff:           r.WrittenOff,
+		Notes:                r.Notes,
+		RentalCents:          r.RentalCents,
+		ChargeCents:          r.ChargeCents,
+		DepositAppliedCents:  r.DepositAppliedCents,
+		DepositRefundedCents: r.DepositRefundedCents,
+		BalanceDueCents:      r.BalanceDueCents,
+		ReturnedAt:           r.ReturnedAt,
+	}
+}
+
+func toReturnDomain(m LoanReturnModel) domain.LoanReturn {
+	return domain.LoanReturn{
+		ID:                   uuid.MustParse(m.ID),
+		LoanID:               uuid.MustParse(m.LoanID),
+		Qty:                  m.Qty,
+		Condition:            m.Condition,
+		WrittenOff:           m.WrittenOff,
+		Notes:                m.Notes,
+		RentalCents:          m.RentalCents,
+		ChargeCents:          m.ChargeCents,
+		DepositAppliedCents:  m.DepositAppliedCents,
+		DepositRefundedCents: m.DepositRefundedCents,
+		BalanceDueCents:      m.BalanceDueCents,
+		ReturnedAt:           m.ReturnedAt,
 	}
 }
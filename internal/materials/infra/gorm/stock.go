@@ -0,0 +1,189 @@
+package gorm
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
+)
+
+// -------- Stock ledger
+
+func (r *Repository) ApplyMovement(ctx context.Context, mv domain.StockMovement) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// el guard evita que lo disponible quede negativo o supere al total
+		res := tx.Model(&MaterialModel{}).
+			Where("id = ? AND available_qty + ? >= 0 AND total_qty + ? >= available_qty + ?",
+				mv.MaterialID.String(), mv.AvailableDelta, mv.TotalDelta, mv.AvailableDelta).
+			Updates(map[string]any{
+				"total_qty":     gorm.Expr("total_qty + ?", mv.TotalDelta),
+				"available_qty": gorm.Expr("available_qty + ?", mv.AvailableDelta),
+				"updated_at":    mv.CreatedAt,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			var n int64
+			if err := tx.Model(&MaterialModel{}).Where("id = ?", mv.MaterialID.String()).Count(&n).Error; err != nil {
+				return err
+			}
+			if n == 0 {
+				return domain.ErrNotFound
+			}
+			return domain.ErrInsufficientStock
+		}
+
+		model := toMovementModel(mv)
+		return tx.Create(&model).Error
+	})
+}
+
+func (r *Repository) ListMovements(ctx context.Context, materialID uuid.UUID, limit int) ([]domain.StockMovement, error) {
+	var ms []StockMovementModel
+	err := r.db.WithContext(ctx).
+		Where("material_id = ?", materialID.String()).
+		Order("created_at desc").
+		Limit(limit).
+		Find(&ms).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.StockMovement, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, toMovementDomain(m))
+	}
+	return out, nil
+}
+
+func (r *Repository) LedgerBalances(ctx context.Context, materialIDs []uuid.UUID) (map[uuid.UUID]domain.LedgerBalance, error) {
+	out := make(map[uuid.UUID]domain.LedgerBalance, len(materialIDs))
+	if len(materialIDs) == 0 {
+		return out, nil
+	}
+
+	var rows []struct {
+		MaterialID string
+		Total      int
+		Available  int
+	}
+	err := r.db.WithContext(ctx).
+		Model(&StockMovementModel{}).
+		Select("material_id, COALESCE(SUM(total_delta), 0) AS total, COALESCE(SUM(available_delta), 0) AS available").
+		Where("material_id IN ?", uuidStrings(materialIDs)).
+		Group("material_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		id := uuid.MustParse(row.MaterialID)
+		out[id] = domain.LedgerBalance{MaterialID: id, Total: row.Total, Available: row.Available}
+	}
+	return out, nil
+}
+
+func (r *Repository) ActiveLoanQty(ctx context.Context, materialIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	out := make(map[uuid.UUID]int, len(materialIDs))
+	if len(materialIDs) == 0 {
+		return out, nil
+	}
+
+	var rows []struct {
+		MaterialID string
+		Qty        int
+	}
+	err := r.db.WithContext(ctx).
+		Model(&MaterialLoanModel{}).
+		Select("material_id, COALESCE(SUM(qty - returned_qty), 0) AS qty").
+		Where("material_id IN ? AND returned_at IS NULL", uuidStrings(materialIDs)).
+		Group("material_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		out[uuid.MustParse(row.MaterialID)] = row.Qty
+	}
+	return out, nil
+}
+
+func (r *Repository) SyncStockFromLedger(ctx context.Context, materialID uuid.UUID) (domain.Material, error) {
+	err := r.db.WithContext(ctx).Exec(`
+		UPDATE materials m SET
+		  total_qty = COALESCE((SELECT SUM(total_delta) FROM material_stock_movements WHERE material_id = m.id), 0),
+		  available_qty = COALESCE((SELECT SUM(available_delta) FROM material_stock_movements WHERE material_id = m.id), 0),
+		  updated_at = now()
+		WHERE m.id = ?`, materialID.String()).Error
+	if err != nil {
+		return domain.Material{}, err
+	}
+	out, found, err := r.GetMaterialByID(ctx, materialID)
+	if err != nil {
+		return domain.Material{}, err
+	}
+	if !found {
+		return domain.Material{}, domain.ErrNotFound
+	}
+	return out, nil
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, id.String())
+	}
+	return out
+}
+
+func toMovementModel(mv domain.StockMovement) StockMovementModel {
+	var loanID *string
+	if mv.LoanID != nil {
+		s := mv.LoanID.String()
+		loanID = &s
+	}
+	var unitID *string
+	if mv.UnitID != nil {
+		s := mv.UnitID.String()
+		unitID = &s
+	}
+	return StockMovementModel{
+		ID:             mv.ID.String(),
+		MaterialID:     mv.MaterialID.String(),
+		Kind:           mv.Kind,
+		TotalDelta:     mv.TotalDelta,
+		AvailableDelta: mv.AvailableDelta,
+		Reason:         mv.Reason,
+		Note:           mv.Note,
+		LoanID:         loanID,
+		UnitID:         unitID,
+		CreatedAt:      mv.CreatedAt,
+	}
+}
+
+func toMovementDomain(m StockMovementModel) domain.StockMovement {
+	var loanID *uuid.UUID
+	if m.LoanID != nil {
+		id := uuid.MustParse(*m.LoanID)
+		loanID = &id
+	}
+	var unitID *uuid.UUID
+	if m.UnitID != nil {
+		id := uuid.MustParse(*m.UnitID)
+		unitID = &id
+	}
+	return domain.StockMovement{
+		ID:             uuid.MustParse(m.ID),
+		MaterialID:     uuid.MustParse(m.MaterialID),
+		Kind:           m.Kind,
+		TotalDelta:     m.TotalDelta,
+		AvailableDelta: m.AvailableDelta,
+		Reason:         m.Reason,
+		Note:           m.Note,
+		LoanID:         loanID,
+		UnitID:         unitID,
+		CreatedAt:      m.CreatedAt,
+	}
+}
@@ -0,0 +1,295 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
+)
+
+// -------- Units
+
+func (r *Repository) CreateUnit(ctx context.Context, u domain.MaterialUnit) (domain.MaterialUnit, error) {
+	model := toUnitModel(u)
+	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
+		if isDuplicateSerial(err) {
+			return domain.MaterialUnit{}, domain.ErrDuplicateSerial
+		}
+		return domain.MaterialUnit{}, err
+	}
+	out, _, err := r.GetUnitByID(ctx, u.ID)
+	if err != nil {
+		return domain.MaterialUnit{}, err
+	}
+	return out, nil
+}
+
+func (r *Repository) GetUnitByID(ctx context.Context, id uuid.UUID) (domain.MaterialUnit, bool, error) {
+	var m MaterialUnitModel
+	err := r.db.WithContext(ctx).First(&m, "id = ?", id.String()).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.MaterialUnit{}, false, nil
+		}
+		return domain.MaterialUnit{}, false, err
+	}
+	return toUnitDomain(m), true, nil
+}
+
+func (r *Repository) GetUnitForUpdate(ctx context.Context, id uuid.UUID) (domain.MaterialUnit, bool, error) {
+	var m MaterialUnitModel
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&m, "id = ?", id.String()).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.MaterialUnit{}, false, nil
+		}
+		return domain.MaterialUnit{}, false, err
+	}
+	return toUnitDomain(m), true, nil
+}
+
+func (r *Repository) ListUnits(ctx context.Context, materialID uuid.UUID, status *string) ([]domain.MaterialUnit, error) {
+	q := r.db.WithContext(ctx).Where("material_id = ?", materialID.String()).Order("serial asc")
+	if status != nil {
+		q = q.Where("status = ?", *status)
+	}
+
+	var ms []MaterialUnitModel
+	if err := q.Find(&ms).Error; err != nil {
+		return nil, err
+	}
+	out := make([]domain.MaterialUnit, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, toUnitDomain(m))
+	}
+	return out, nil
+}
+
+func (r *Repository) SetUnitStatus(ctx context.Context, unitIDs []uuid.UUID, status string, at time.Time) error {
+	if len(unitIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Model(&MaterialUnitModel{}).
+		Where("id IN ?", uuidStrings(unitIDs)).
+		Updates(map[string]any{"status": status, "updated_at": at}).Error
+}
+
+func (r *Repository) UnitCounts(ctx context.Context, materialIDs []uuid.UUID) (map[uuid.UUID]domain.UnitCounts, error) {
+	out := make(map[uuid.UUID]domain.UnitCounts, len(materialIDs))
+	if len(materialIDs) == 0 {
+		return out, nil
+	}
+
+	var rows []struct {
+		MaterialID  string
+		Active      int
+		Available   int
+		Maintenance int
+	}
+	err := r.db.WithContext(ctx).
+		Model(&MaterialUnitModel{}).
+		Select(`material_id,
+			COUNT(*) FILTER (WHERE status <> ?) AS active,
+			COUNT(*) FILTER (WHERE status = ?) AS available,
+			COUNT(*) FILTER (WHERE status = ?) AS maintenance`,
+			domain.UnitRetired, domain.UnitAvailable, domain.UnitMaintenance).
+		Where("material_id IN ?", uuidStrings(materialIDs)).
+		Group("material_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		out[uuid.MustParse(row.MaterialID)] = domain.UnitCounts{
+			Active:      row.Active,
+			Available:   row.Available,
+			Maintenance: row.Maintenance,
+		}
+	}
+	return out, nil
+}
+
+// -------- Loan units
+
+func (r *Repository) AttachLoanUnits(ctx context.Context, loanID uuid.UUID, unitIDs []uuid.UUID) error {
+	if len(unitIDs) == 0 {
+		return nil
+	}
+	ms := make([]LoanUnitModel, 0, len(unitIDs))
+	for _, id := range unitIDs {
+		ms = append(ms, LoanUnitModel{LoanID: loanID.String(), UnitID: id.String()})
+	}
+	return r.db.WithContext(ctx).Create(&ms).Error
+}
+
+func (r *Repository) ListLoanUnits(ctx context.Context, loanID uuid.UUID) ([]domain.LoanUnit, error) {
+	var rows []struct {
+		LoanUnitModel
+		Serial string
+	}
+	err := r.db.WithContext(ctx).
+		Table("material_loan_units lu").
+		Select("lu.*, u.serial").
+		Joins("JOIN material_units u ON u.id = lu.unit_id").
+		Where("lu.loan_id = ?", loanID.String()).
+		Order("u.serial asc").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.LoanUnit, 0, len(rows))
+	for _, row := range rows {
+		lu := domain.LoanUnit{
+			LoanID:     uuid.MustParse(row.LoanID),
+			UnitID:     uuid.MustParse(row.UnitID),
+			Serial:     row.Serial,
+			ReturnedAt: row.ReturnedAt,
+		}
+		if row.ReturnID != nil {
+			id := uuid.MustParse(*row.ReturnID)
+			lu.ReturnID = &id
+		}
+		out = append(out, lu)
+	}
+	return out, nil
+}
+
+func (r *Repository) MarkLoanUnitsReturned(ctx context.Context, loanID uuid.UUID, unitIDs []uuid.UUID, returnID uuid.UUID, at time.Time) error {
+	if len(unitIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Model(&LoanUnitModel{}).
+		Where("loan_id = ? AND unit_id IN ? AND returned_at IS NULL", loanID.String(), uuidStrings(unitIDs)).
+		Updates(map[string]any{"return_id": returnID.String(), "returned_at": at}).Error
+}
+
+// -------- Maintenance
+
+func (r *Repository) CreateMaintenance(ctx context.Context, rec domain.MaintenanceRecord) (domain.MaintenanceRecord, error) {
+	model := toMaintenanceModel(rec)
+	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
+		return domain.MaintenanceRecord{}, err
+	}
+	return toMaintenanceDomain(model), nil
+}
+
+func (r *Repository) GetMaintenanceForUpdate(ctx context.Context, id uuid.UUID) (domain.MaintenanceRecord, bool, error) {
+	var m MaintenanceModel
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&m, "id = ?", id.String()).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.MaintenanceRecord{}, false, nil
+		}
+		return domain.MaintenanceRecord{}, false, err
+	}
+	return toMaintenanceDomain(m), true, nil
+}
+
+func (r *Repository) OpenMaintenance(ctx context.Context, unitID uuid.UUID) (domain.MaintenanceRecord, bool, error) {
+	var m MaintenanceModel
+	err := r.db.WithContext(ctx).
+		Where("unit_id = ? AND out_of_service AND completed_at IS NULL", unitID.String()).
+		Order("started_at desc").
+		First(&m).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.MaintenanceRecord{}, false, nil
+		}
+		return domain.MaintenanceRecord{}, false, err
+	}
+	return toMaintenanceDomain(m), true, nil
+}
+
+func (r *Repository) CompleteMaintenance(ctx context.Context, rec domain.MaintenanceRecord) error {
+	return r.db.WithContext(ctx).
+		Model(&MaintenanceModel{}).
+		Where("id = ?", rec.ID.String()).
+		Updates(map[string]any{
+			"description":  rec.Description,
+			"cost_cents":   rec.CostCents,
+			"completed_at": rec.CompletedAt,
+		}).Error
+}
+
+func (r *Repository) ListMaintenance(ctx context.Context, unitID uuid.UUID) ([]domain.MaintenanceRecord, error) {
+	var ms []MaintenanceModel
+	err := r.db.WithContext(ctx).
+		Where("unit_id = ?", unitID.String()).
+		Order("started_at desc").
+		Find(&ms).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.MaintenanceRecord, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, toMaintenanceDomain(m))
+	}
+	return out, nil
+}
+
+func isDuplicateSerial(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "ux_material_units_serial")
+}
+
+func toUnitModel(u domain.MaterialUnit) MaterialUnitModel {
+	return MaterialUnitModel{
+		ID:         u.ID.String(),
+		MaterialID: u.MaterialID.String(),
+		Serial:     u.Serial,
+		Status:     u.Status,
+		Notes:      u.Notes,
+		AcquiredAt: u.AcquiredAt,
+		CreatedAt:  u.CreatedAt,
+		UpdatedAt:  u.UpdatedAt,
+	}
+}
+
+func toUnitDomain(m MaterialUnitModel) domain.MaterialUnit {
+	return domain.MaterialUnit{
+		ID:         uuid.MustParse(m.ID),
+		MaterialID: uuid.MustParse(m.MaterialID),
+		Serial:     m.Serial,
+		Status:     m.Status,
+		Notes:      m.Notes,
+		AcquiredAt: dayPtr(m.AcquiredAt),
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	}
+}
+
+func toMaintenanceModel(rec domain.MaintenanceRecord) MaintenanceModel {
+	return MaintenanceModel{
+		ID:           rec.ID.String(),
+		UnitID:       rec.UnitID.String(),
+		Kind:         rec.Kind,
+		Description:  rec.Description,
+		CostCents:    rec.CostCents,
+		OutOfService: rec.OutOfService,
+		StartedAt:    rec.StartedAt,
+		CompletedAt:  rec.CompletedAt,
+		CreatedAt:    rec.CreatedAt,
+	}
+}
+
+func toMaintenanceDomain(m MaintenanceModel) domain.MaintenanceRecord {
+	return domain.MaintenanceRecord{
+		ID:           uuid.MustParse(m.ID),
+		UnitID:       uuid.MustParse(m.UnitID),
+		Kind:         m.Kind,
+		Description:  m.Description,
+		CostCents:    m.CostCents,
+		OutOfService: m.OutOfService,
+		StartedAt:    m.StartedAt,
+		CompletedAt:  m.CompletedAt,
+		CreatedAt:    m.CreatedAt,
+	}
+}
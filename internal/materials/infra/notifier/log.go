@@ -0,0 +1,40 @@
+// Package notifier tiene las implementaciones de domain.Notifier (préstamos vencidos y stock bajo).
+package notifier
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
+)
+
+var _ domain.Notifier = (*LogNotifier)(nil)
+
+// LogNotifier solo deja el aviso en el log. Es el default cuando no hay otro canal configurado.
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier { return &LogNotifier{} }
+
+func (LogNotifier) NotifyOverdueLoan(ctx context.Context, n domain.OverdueNotice) error {
+	log.Info().
+		Str("loan_id", n.Loan.ID.String()).
+		Str("material", n.Material.Name).
+		Str("patient_id", n.Patient.ID.String()).
+		Str("email", n.Patient.Email).
+		Int("days_overdue", n.DaysOverdue).
+		Msg("overdue material loan")
+	return nil
+}
+
+func (LogNotifier) NotifyLowStock(ctx context.Context, n domain.LowStockNotice) error {
+	ev := log.Warn().
+		Str("material_id", n.Material.ID.String()).
+		Str("material", n.Material.Name).
+		Int("available_qty", n.Material.AvailableQty)
+	if n.Material.LowStockThreshold != nil {
+		ev = ev.Int("threshold", *n.Material.LowStockThreshold)
+	}
+	ev.Msg("material low stock")
+	return nil
+}
@@ -0,0 +1,103 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
+)
+
+var _ domain.Notifier = (*WebhookNotifier)(nil)
+
+// WebhookNotifier publica cada aviso como JSON en una URL (p. ej. un servicio de
+// mensajería o email que se encarga del envío).
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+type overdueLoanPayload struct {
+	Event        string  `json:"event"`
+	LoanID       string  `json:"loan_id"`
+	MaterialID   string  `json:"material_id"`
+	MaterialName string  `json:"material_name"`
+	Qty          int     `json:"qty"`
+	DueDate      string  `json:"due_date"`
+	DaysOverdue  int     `json:"days_overdue"`
+	PatientID    string  `json:"patient_id"`
+	PatientName  string  `json:"patient_name"`
+	Email        string  `json:"email"`
+	Phone        *string `json:"phone,omitempty"`
+}
+
+func (w *WebhookNotifier) NotifyOverdueLoan(ctx context.Context, n domain.OverdueNotice) error {
+	payload := overdueLoanPayload{
+		Event:        "material_loan_overdue",
+		LoanID:       n.Loan.ID.String(),
+		MaterialID:   n.Material.ID.String(),
+		MaterialName: n.Material.Name,
+		Qty:          n.Loan.OutstandingQty(),
+		DaysOverdue:  n.DaysOverdue,
+		PatientID:    n.Patient.ID.String(),
+		PatientName:  n.Patient.FullName,
+		Email:        n.Patient.Email,
+		Phone:        n.Patient.Phone,
+	}
+	if n.Loan.DueDate != nil {
+		payload.DueDate = n.Loan.DueDate.Format("2006-01-02")
+	}
+
+	return w.post(ctx, payload)
+}
+
+type lowStockPayload struct {
+	Event        string  `json:"event"`
+	MaterialID   string  `json:"material_id"`
+	MaterialName string  `json:"material_name"`
+	Category     *string `json:"category,omitempty"`
+	AvailableQty int     `json:"available_qty"`
+	TotalQty     int     `json:"total_qty"`
+	Threshold    *int    `json:"threshold"`
+}
+
+func (w *WebhookNotifier) NotifyLowStock(ctx context.Context, n domain.LowStockNotice) error {
+	return w.post(ctx, lowStockPayload{
+		Event:        "material_low_stock",
+		MaterialID:   n.Material.ID.String(),
+		MaterialName: n.Material.Name,
+		Category:     n.Material.Category,
+		AvailableQty: n.Material.AvailableQty,
+		TotalQty:     n.Material.TotalQty,
+		Threshold:    n.Material.LowStockThreshold,
+	})
+}
+
+func (w *WebhookNotifier) post(ctx context.Context, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notifier webhook: status %d", resp.StatusCode)
+	}
+	return nil
+}
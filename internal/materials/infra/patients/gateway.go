@@ -0,0 +1,35 @@
+package patients
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
+	patientPorts "github.com/javiacuna/kinesio-backend/internal/patients/ports"
+)
+
+var _ domain.PatientDirectory = (*Gateway)(nil)
+
+// Gateway lee el contacto del paciente desde el repo de pacientes.
+type Gateway struct {
+	repo patientPorts.Repository
+}
+
+func NewGateway(repo patientPorts.Repository) *Gateway {
+	return &Gateway{repo: repo}
+}
+
+func (g *Gateway) PatientContact(ctx context.Context, id uuid.UUID) (domain.PatientContact, bool, error) {
+	p, found, err := g.repo.GetByID(ctx, id.String())
+	if err != nil || !found {
+		return domain.PatientContact{}, false, err
+	}
+	return domain.PatientContact{
+		ID:       p.ID,
+		FullName: strings.TrimSpace(strings.TrimSpace(p.FirstName) + " " + strings.TrimSpace(p.LastName)),
+		Email:    p.Email,
+		Phone:    p.Phone,
+	}, true, nil
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+// MaterialPricingInput: depósito, alquiler y costo de reposición por unidad, en
+// centavos. Al editar, 0 (o rental_period vacío) quita el valor.
+type MaterialPricingInput struct {
+	DepositCents         *int64  `json:"deposit_cents,omitempty"`
+	RentalFeeCents       *int64  `json:"rental_fee_cents,omitempty"`
+	RentalPeriod         *string `json:"rental_period,omitempty"`
+	ReplacementCostCents *int64  `json:"replacement_cost_cents,omitempty"`
+}
+
+func applyPricing(in MaterialPricingInput, m *domain.Material, validation *validation.Errors) {
+	cents := func(field string, v *int64, dst **int64) {
+		switch {
+		case v == nil:
+		case *v < 0:
+			validation.Add(field, "must_be_>=_0")
+		case *v == 0:
+			*dst = nil
+		default:
+			c := *v
+			*dst = &c
+		}
+	}
+	cents("deposit_cents", in.DepositCents, &m.DepositCents)
+	cents("rental_fee_cents", in.RentalFeeCents, &m.RentalFeeCents)
+	cents("replacement_cost_cents", in.ReplacementCostCents, &m.ReplacementCostCents)
+
+	if in.RentalPeriod != nil {
+		period := strings.ToLower(strings.TrimSpace(*in.RentalPeriod))
+		switch {
+		case period == "":
+			m.RentalPeriod = nil
+		case !slices.Contains(domain.RentalPeriods, period):
+			validation.Add("rental_period", "must_be_one_of_"+strings.Join(domain.RentalPeriods, "|"))
+		default:
+			m.RentalPeriod = &period
+		}
+	}
+	if m.RentalFeeCents != nil && m.RentalPeriod == nil && !validation.Has("rental_period") {
+		validation.Add("rental_period", "required")
+	}
+}
+
+// ---------- Devoluciones
+
+type ListLoanReturnsUseCase struct {
+	repo domain.Repository
+}
+
+func NewListLoanReturnsUseCase(repo domain.Repository) *ListLoanReturnsUseCase {
+	return &ListLoanReturnsUseCase{repo: repo}
+}
+
+func (uc *ListLoanReturnsUseCase) Execute(ctx context.Context, loanID uuid.UUID) ([]domain.LoanReturn, error) {
+	_, found, err := uc.repo.GetLoanByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, domain.ErrNotFound
+	}
+	return uc.repo.ListLoanReturns(ctx, loanID)
+}
@@ -2,18 +2,29 @@ package usecase
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
 )
 
 type CreateMaterialInput struct {
 	Name        string  `json:"name"`
 	Description *string `json:"description,omitempty"`
 	TotalQty    int     `json:"total_qty"`
+	// Días de préstamo por defecto (opcional).
+	DefaultLoanDays *int `json:"default_loan_days,omitempty"`
+	// quantity (por defecto) o unit. En modo unit total_qty tiene que ser 0: las
+	// unidades se cargan una por una con su número de serie.
+	TrackingMode string  `json:"tracking_mode,omitempty"`
+	Category     *string `json:"category,omitempty"`
+	// Se avisa cuando lo disponible queda por debajo (opcional).
+	LowStockThreshold *int `json:"low_stock_threshold,omitempty"`
+	MaterialPricingInput
 }
 
 type CreateMaterialUseCase struct {
@@ -24,30 +35,59 @@ func NewCreateMaterialUseCase(repo domain.Repository) *CreateMaterialUseCase {
 	return &CreateMaterialUseCase{repo: repo}
 }
 
-func (uc *CreateMaterialUseCase) Execute(ctx context.Context, in CreateMaterialInput) (domain.Material, map[string]string, error) {
-	validation := map[string]string{}
+func (uc *CreateMaterialUseCase) Execute(ctx context.Context, in CreateMaterialInput) (domain.Material, *validation.Errors, error) {
+	validation := validation.New()
 
 	name := strings.TrimSpace(in.Name)
 	if name == "" {
-		validation["name"] = "required"
+		validation.Add("name", "required")
 	}
 	if in.TotalQty < 0 {
-		validation["total_qty"] = "must_be_>=_0"
+		validation.Add("total_qty", "must_be_>=_0")
 	}
-	if len(validation) > 0 {
+	if in.DefaultLoanDays != nil && *in.DefaultLoanDays <= 0 {
+		validation.Add("default_loan_days", "must_be_>_0")
+	}
+	tracking := strings.ToLower(strings.TrimSpace(in.TrackingMode))
+	if tracking == "" {
+		tracking = domain.TrackingQuantity
+	}
+	switch {
+	case !slices.Contains(domain.TrackingModes, tracking):
+		validation.Add("tracking_mode", "must_be_one_of_"+strings.Join(domain.TrackingModes, "|"))
+	case tracking == domain.TrackingUnit && in.TotalQty != 0:
+		validation.Add("total_qty", "unit_tracked")
+	}
+	if in.LowStockThreshold != nil && *in.LowStockThreshold <= 0 {
+		validation.Add("low_stock_threshold", "must_be_>_0")
+	}
+	var pricing domain.Material
+	applyPricing(in.MaterialPricingInput, &pricing, validation)
+	if !validation.Empty() {
 		return domain.Material{}, validation, domain.ErrValidation
 	}
 
 	now := time.Now().UTC()
 
 	m := domain.Material{
-		ID:           uuid.New(),
-		Name:         name,
-		Description:  in.Description,
-		TotalQty:     in.TotalQty,
-		AvailableQty: in.TotalQty,
-		CreatedAt:    now,
-		UpdatedAt:    now,
+		ID:                   uuid.New(),
+		Name:                 name,
+		Description:          in.Description,
+		TotalQty:             in.TotalQty,
+		AvailableQty:         in.TotalQty,
+		DefaultLoanDays:      in.DefaultLoanDays,
+		DepositCents:         pricing.DepositCents,
+		RentalFeeCents:       pricing.RentalFeeCents,
+		RentalPeriod:         pricing.RentalPeriod,
+		ReplacementCostCents: pricing.ReplacementCostCents,
+		TrackingMode:         tracking,
+		LowStockThreshold:    in.LowStockThreshold,
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+
+	if in.Category != nil {
+		m.Category = trimmedPtr(*in.Category)
 	}
 
 	out, err := uc.repo.CreateMaterial(ctx, m)
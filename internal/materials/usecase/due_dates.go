@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+// ---------- Prórroga
+
+// ExtendLoanInput: nueva fecha de vencimiento (YYYY-MM-DD) o días a sumar al vencimiento actual.
+type ExtendLoanInput struct {
+	DueDate *string `json:"due_date,omitempty"`
+	Days    *int    `json:"days,omitempty"`
+}
+
+type ExtendLoanUseCase struct {
+	repo domain.Repository
+}
+
+func NewExtendLoanUseCase(repo domain.Repository) *ExtendLoanUseCase {
+	return &ExtendLoanUseCase{repo: repo}
+}
+
+func (uc *ExtendLoanUseCase) Execute(ctx context.Context, loanID uuid.UUID, in ExtendLoanInput) (domain.MaterialLoan, *validation.Errors, error) {
+	validation := validation.New()
+
+	var dueDate *time.Time
+	switch {
+	case in.DueDate != nil && in.Days != nil:
+		validation.AddMessage("days", "conflicts_with_due_date", "No se puede usar junto con due_date")
+	case in.DueDate != nil:
+		d, err := time.Parse("2006-01-02", strings.TrimSpace(*in.DueDate))
+		if err != nil {
+			validation.Add("due_date", "invalid_date_(YYYY-MM-DD)")
+		} else {
+			dueDate = &d
+		}
+	case in.Days != nil:
+		if *in.Days <= 0 {
+			validation.Add("days", "must_be_>_0")
+		}
+	default:
+		validation.Add("due_date", "required")
+	}
+	if !validation.Empty() {
+		return domain.MaterialLoan{}, validation, domain.ErrValidation
+	}
+
+	today := domain.Day(time.Now())
+
+	err := uc.repo.WithinTx(ctx, func(tx domain.Repository) error {
+		loan, found, err := tx.GetLoanForUpdate(ctx, loanID)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return domain.ErrNotFound
+		}
+		if loan.ReturnedAt != nil {
+			return domain.ErrAlreadyReturned
+		}
+
+		next := dueDate
+		if in.Days != nil {
+			// se extiende desde el vencimiento actual, o desde hoy si no tenía o ya pasó
+			base := today
+			if loan.DueDate != nil && loan.DueDate.After(today) {
+				base = *loan.DueDate
+			}
+			d := base.AddDate(0, 0, *in.Days)
+			next = &d
+		}
+
+		field := "due_date"
+		if in.Days != nil {
+			field = "days"
+		}
+		switch {
+		case next.Before(today):
+			validation.Add(field, "must_not_be_in_the_past")
+		case loan.DueDate != nil && !next.After(*loan.DueDate):
+			validation.AddMessage(field, "must_be_after_current_due_date", "Tiene que ser posterior al vencimiento actual")
+		}
+		if !validation.Empty() {
+			return domain.ErrValidation
+		}
+
+		return tx.SetLoanDueDate(ctx, loanID, *next)
+	})
+	if err != nil {
+		if validation.Empty() {
+			return domain.MaterialLoan{}, nil, err
+		}
+		return domain.MaterialLoan{}, validation, err
+	}
+
+	out, _, err := uc.repo.GetLoanByID(ctx, loanID)
+	if err != nil {
+		return domain.MaterialLoan{}, nil, err
+	}
+	return out, nil, nil
+}
+
+// ---------- Vencidos
+
+type ListOverdueLoansUseCase struct {
+	repo domain.Repository
+}
+
+func NewListOverdueLoansUseCase(repo domain.Repository) *ListOverdueLoansUseCase {
+	return &ListOverdueLoansUseCase{repo: repo}
+}
+
+func (uc *ListOverdueLoansUseCase) Execute(ctx context.Context, limit int) ([]domain.MaterialLoan, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	return uc.repo.ListOverdueLoans(ctx, time.Now(), false, limit)
+}
+
+// NotifyOverdueLoansUseCase avisa a cada paciente con un préstamo vencido y deja
+// el préstamo marcado para no repetir el aviso (una prórroga lo vuelve a habilitar).
+// Lo corre un job periódico.
+type NotifyOverdueLoansUseCase struct {
+	repo     domain.Repository
+	patients domain.PatientDirectory
+	notifier domain.Notifier
+}
+
+func NewNotifyOverdueLoansUseCase(repo domain.Repository, patients domain.PatientDirectory, notifier domain.Notifier) *NotifyOverdueLoansUseCase {
+	return &NotifyOverdueLoansUseCase{repo: repo, patients: patients, notifier: notifier}
+}
+
+// Execute devuelve cuántos avisos se enviaron. Si falla un aviso sigue con el
+// resto y devuelve los errores juntos; el préstamo queda para la próxima vuelta.
+func (uc *NotifyOverdueLoansUseCase) Execute(ctx context.Context, now time.Time) (int, error) {
+	loans, err := uc.repo.ListOverdueLoans(ctx, now, true, 200)
+	if err != nil {
+		return 0, err
+	}
+
+	materials := map[uuid.UUID]domain.Material{}
+	sent := 0
+	var errs []error
+	for _, loan := range loans {
+		m, ok := materials[loan.MaterialID]
+		if !ok {
+			m, _, err = uc.repo.GetMaterialByID(ctx, loan.MaterialID)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			materials[loan.MaterialID] = m
+		}
+
+		patient, found, err := uc.patients.PatientContact(ctx, loan.PatientID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !found {
+			continue
+		}
+
+		notice := domain.OverdueNotice{
+			Loan:        loan,
+			Material:    m,
+			Patient:     patient,
+			DaysOverdue: loan.DaysOverdue(now),
+		}
+		if err := uc.notifier.NotifyOverdueLoan(ctx, notice); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := uc.repo.MarkOverdueNotified(ctx, loan.ID, now.UTC()); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sent++
+	}
+	return sent, errors.Join(errs...)
+}
@@ -0,0 +1,593 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
+)
+
+var errInjected = errors.New("injected")
+
+// fakeRepo es un Repository en memoria que imita la semántica de Postgres que
+// usan los casos de uso: GetXForUpdate toma un lock por fila que dura hasta el
+// fin de la transacción, y un error dentro de WithinTx deshace lo escrito.
+type fakeRepo struct {
+	st *fakeState
+	tx *fakeTx // nil fuera de una transacción
+}
+
+type fakeState struct {
+	mu        sync.Mutex
+	rowLocks  map[uuid.UUID]*sync.Mutex
+	materials map[uuid.UUID]domain.Material
+	loans     map[uuid.UUID]domain.MaterialLoan
+	movements []domain.StockMovement
+	returns   []domain.LoanReturn
+	units     map[uuid.UUID]domain.MaterialUnit
+	loanUnits []domain.LoanUnit
+	upkeep    map[uuid.UUID]domain.MaintenanceRecord
+
+	// failOn hace fallar la operación indicada (p. ej. "ApplyMovement").
+	failOn string
+}
+
+type fakeTx struct {
+	held map[uuid.UUID]*sync.Mutex
+	undo []func()
+}
+
+func newFakeRepo() *fakeRepo {
+	return &fakeRepo{st: &fakeState{
+		rowLocks:  map[uuid.UUID]*sync.Mutex{},
+		materials: map[uuid.UUID]domain.Material{},
+		loans:     map[uuid.UUID]domain.MaterialLoan{},
+		units:     map[uuid.UUID]domain.MaterialUnit{},
+		upkeep:    map[uuid.UUID]domain.MaintenanceRecord{},
+	}}
+}
+
+func (r *fakeRepo) WithinTx(ctx context.Context, fn func(tx domain.Repository) error) error {
+	if r.tx != nil {
+		return fn(r)
+	}
+	tx := &fakeTx{held: map[uuid.UUID]*sync.Mutex{}}
+	err := fn(&fakeRepo{st: r.st, tx: tx})
+	if err != nil {
+		r.st.mu.Lock()
+		for i := len(tx.undo) - 1; i >= 0; i-- {
+			tx.undo[i]()
+		}
+		r.st.mu.Unlock()
+	}
+	for _, l := range tx.held {
+		l.Unlock()
+	}
+	return err
+}
+
+// lock bloquea la fila id hasta el fin de la transacción. Fuera de una
+// transacción no hace nada, igual que un SELECT ... FOR UPDATE en autocommit.
+func (r *fakeRepo) lock(id uuid.UUID) {
+	if r.tx == nil {
+		return
+	}
+	if _, ok := r.tx.held[id]; ok {
+		return
+	}
+	r.st.mu.Lock()
+	l, ok := r.st.rowLocks[id]
+	if !ok {
+		l = &sync.Mutex{}
+		r.st.rowLocks[id] = l
+	}
+	r.st.mu.Unlock()
+
+	l.Lock()
+	r.tx.held[id] = l
+}
+
+func (r *fakeRepo) onRollback(fn func()) {
+	if r.tx != nil {
+		r.tx.undo = append(r.tx.undo, fn)
+	}
+}
+
+func (r *fakeRepo) fail(op string) error {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	if r.st.failOn == op {
+		return errInjected
+	}
+	return nil
+}
+
+// -------- Materials
+
+func (r *fakeRepo) CreateMaterial(ctx context.Context, m domain.Material) (domain.Material, error) {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	r.st.materials[m.ID] = m
+	if m.TotalQty > 0 {
+		r.st.movements = append(r.st.movements, domain.NewMovement(m.ID, domain.MovementInitial, m.TotalQty, m.CreatedAt))
+	}
+	return m, nil
+}
+
+func (r *fakeRepo) UpdateMaterial(ctx context.Context, m domain.Material) (domain.Material, error) {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	cur := r.st.materials[m.ID]
+	cur.Name, cur.Description, cur.UpdatedAt = m.Name, m.Description, m.UpdatedAt
+	r.st.materials[m.ID] = cur
+	return cur, nil
+}
+
+func (r *fakeRepo) ListMaterials(ctx context.Context, f domain.MaterialFilter) ([]domain.Material, error) {
+	all, _ := r.ListAllMaterials(ctx)
+	out := []domain.Material{}
+	for _, m := range all {
+		if f.LowStock && !m.LowStock() {
+			continue
+		}
+		out = append(out, m)
+	}
+	if len(out) > f.Limit {
+		out = out[:f.Limit]
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) ListCategories(ctx context.Context) ([]domain.CategoryCount, error) {
+	return []domain.CategoryCount{}, nil
+}
+
+func (r *fakeRepo) ListLowStockMaterials(ctx context.Context, onlyUnnotified bool) ([]domain.Material, error) {
+	all, _ := r.ListAllMaterials(ctx)
+	out := []domain.Material{}
+	for _, m := range all {
+		if m.LowStock() && (!onlyUnnotified || m.LowStockNotifiedAt == nil) {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) MarkLowStockNotified(ctx context.Context, materialID uuid.UUID, at time.Time) error {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	m := r.st.materials[materialID]
+	m.LowStockNotifiedAt = &at
+	r.st.materials[materialID] = m
+	return nil
+}
+
+func (r *fakeRepo) ResetLowStockAlerts(ctx context.Context) (int64, error) {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	var n int64
+	for id, m := range r.st.materials {
+		if m.LowStockNotifiedAt != nil && !m.LowStock() {
+			m.LowStockNotifiedAt = nil
+			r.st.materials[id] = m
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *fakeRepo) ListAllMaterials(ctx context.Context) ([]domain.Material, error) {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	out := make([]domain.Material, 0, len(r.st.materials))
+	for _, m := range r.st.materials {
+		out = append(out, m)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+func (r *fakeRepo) GetMaterialByID(ctx context.Context, id uuid.UUID) (domain.Material, bool, error) {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	m, ok := r.st.materials[id]
+	return m, ok, nil
+}
+
+func (r *fakeRepo) GetMaterialForUpdate(ctx context.Context, id uuid.UUID) (domain.Material, bool, error) {
+	r.lock(id)
+	runtime.Gosched()
+	return r.GetMaterialByID(ctx, id)
+}
+
+// -------- Loans
+
+func (r *fakeRepo) CreateLoan(ctx context.Context, l domain.MaterialLoan) (domain.MaterialLoan, error) {
+	if err := r.fail("CreateLoan"); err != nil {
+		return domain.MaterialLoan{}, err
+	}
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	r.st.loans[l.ID] = l
+	r.onRollback(func() { delete(r.st.loans, l.ID) })
+	return l, nil
+}
+
+func (r *fakeRepo) GetLoanByID(ctx context.Context, id uuid.UUID) (domain.MaterialLoan, bool, error) {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	l, ok := r.st.loans[id]
+	return l, ok, nil
+}
+
+func (r *fakeRepo) GetLoanForUpdate(ctx context.Context, id uuid.UUID) (domain.MaterialLoan, bool, error) {
+	r.lock(id)
+	runtime.Gosched()
+	return r.GetLoanByID(ctx, id)
+}
+
+func (r *fakeRepo) ListLoansByPatient(ctx context.Context, patientID uuid.UUID, onlyActive bool, limit int) ([]domain.MaterialLoan, error) {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	out := []domain.MaterialLoan{}
+	for _, l := range r.st.loans {
+		if l.PatientID == patientID && (!onlyActive || l.ReturnedAt == nil) {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) ListLoans(ctx context.Context, f domain.LoanFilter) ([]domain.MaterialLoan, error) {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	out := []domain.MaterialLoan{}
+	for _, l := range r.st.loans {
+		if f.MaterialID == nil || l.MaterialID == *f.MaterialID {
+			out = append(out, l)
+		}
+	}
+	if len(out) > f.Limit {
+		out = out[:f.Limit]
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) LoanStats(ctx context.Context, from, to, now time.Time, materialID *uuid.UUID) ([]domain.MaterialLoanStats, error) {
+	return []domain.MaterialLoanStats{}, nil
+}
+
+func (r *fakeRepo) ActiveLoanQty(ctx context.Context, materialIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	out := map[uuid.UUID]int{}
+	for _, l := range r.st.loans {
+		if l.ReturnedAt == nil {
+			out[l.MaterialID] += l.OutstandingQty()
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) RegisterReturn(ctx context.Context, ret domain.LoanReturn) error {
+	r.lock(ret.LoanID)
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	l, ok := r.st.loans[ret.LoanID]
+	if !ok || l.ReturnedAt != nil || l.ReturnedQty+ret.Qty > l.Qty {
+		return domain.ErrAlreadyReturned
+	}
+	prev := l
+	l.ReturnedQty += ret.Qty
+	if l.ReturnedQty == l.Qty {
+		at := ret.ReturnedAt
+		l.ReturnedAt = &at
+	}
+	l.ChargedCents += ret.RentalCents + ret.ChargeCents
+	l.DepositAppliedCents += ret.DepositAppliedCents
+	l.DepositRefundedCents += ret.DepositRefundedCents
+	r.st.loans[ret.LoanID] = l
+	r.st.returns = append(r.st.returns, ret)
+	r.onRollback(func() {
+		r.st.loans[ret.LoanID] = prev
+		for i, x := range r.st.returns {
+			if x.ID == ret.ID {
+				r.st.returns = append(r.st.returns[:i], r.st.returns[i+1:]...)
+				break
+			}
+		}
+	})
+	return nil
+}
+
+func (r *fakeRepo) ListLoanReturns(ctx context.Context, loanID uuid.UUID) ([]domain.LoanReturn, error) {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	out := []domain.LoanReturn{}
+	for _, ret := range r.st.returns {
+		if ret.LoanID == loanID {
+			out = append(out, ret)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) ListOverdueLoans(ctx context.Context, today time.Time, onlyUnnotified bool, limit int) ([]domain.MaterialLoan, error) {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	out := []domain.MaterialLoan{}
+	for _, l := range r.st.loans {
+		if l.Overdue(today) && (!onlyUnnotified || l.OverdueNotifiedAt == nil) {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) SetLoanDueDate(ctx context.Context, loanID uuid.UUID, dueDate time.Time) error {
+	r.lock(loanID)
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	l := r.st.loans[loanID]
+	prev := l
+	d := domain.Day(dueDate)
+	l.DueDate, l.OverdueNotifiedAt = &d, nil
+	r.st.loans[loanID] = l
+	r.onRollback(func() { r.st.loans[loanID] = prev })
+	return nil
+}
+
+func (r *fakeRepo) MarkOverdueNotified(ctx context.Context, loanID uuid.UUID, at time.Time) error {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	l := r.st.loans[loanID]
+	l.OverdueNotifiedAt = &at
+	r.st.loans[loanID] = l
+	return nil
+}
+
+// -------- Units
+
+func (r *fakeRepo) CreateUnit(ctx context.Context, u domain.MaterialUnit) (domain.MaterialUnit, error) {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	for _, x := range r.st.units {
+		if x.MaterialID == u.MaterialID && x.Serial == u.Serial {
+			return domain.MaterialUnit{}, domain.ErrDuplicateSerial
+		}
+	}
+	r.st.units[u.ID] = u
+	r.onRollback(func() { delete(r.st.units, u.ID) })
+	return u, nil
+}
+
+func (r *fakeRepo) GetUnitByID(ctx context.Context, id uuid.UUID) (domain.MaterialUnit, bool, error) {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	u, ok := r.st.units[id]
+	return u, ok, nil
+}
+
+func (r *fakeRepo) GetUnitForUpdate(ctx context.Context, id uuid.UUID) (domain.MaterialUnit, bool, error) {
+	r.lock(id)
+	runtime.Gosched()
+	return r.GetUnitByID(ctx, id)
+}
+
+func (r *fakeRepo) ListUnits(ctx context.Context, materialID uuid.UUID, status *string) ([]domain.MaterialUnit, error) {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	out := []domain.MaterialUnit{}
+	for _, u := range r.st.units {
+		if u.MaterialID == materialID && (status == nil || u.Status == *status) {
+			out = append(out, u)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Serial < out[j].Serial })
+	return out, nil
+}
+
+func (r *fakeRepo) SetUnitStatus(ctx context.Context, unitIDs []uuid.UUID, status string, at time.Time) error {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	for _, id := range unitIDs {
+		u, ok := r.st.units[id]
+		if !ok {
+			continue
+		}
+		prev := u
+		u.Status, u.UpdatedAt = status, at
+		r.st.units[id] = u
+		r.onRollback(func() { r.st.units[id] = prev })
+	}
+	return nil
+}
+
+func (r *fakeRepo) UnitCounts(ctx context.Context, materialIDs []uuid.UUID) (map[uuid.UUID]domain.UnitCounts, error) {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	out := map[uuid.UUID]domain.UnitCounts{}
+	for _, u := range r.st.units {
+		c := out[u.MaterialID]
+		switch u.Status {
+		case domain.UnitAvailable:
+			c.Available++
+		case domain.UnitMaintenance:
+			c.Maintenance++
+		}
+		if u.Status != domain.UnitRetired {
+			c.Active++
+		}
+		out[u.MaterialID] = c
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) AttachLoanUnits(ctx context.Context, loanID uuid.UUID, unitIDs []uuid.UUID) error {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	n := len(r.st.loanUnits)
+	for _, id := range unitIDs {
+		r.st.loanUnits = append(r.st.loanUnits, domain.LoanUnit{LoanID: loanID, UnitID: id, Serial: r.st.units[id].Serial})
+	}
+	r.onRollback(func() { r.st.loanUnits = r.st.loanUnits[:n] })
+	return nil
+}
+
+func (r *fakeRepo) ListLoanUnits(ctx context.Context, loanID uuid.UUID) ([]domain.LoanUnit, error) {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	out := []domain.LoanUnit{}
+	for _, lu := range r.st.loanUnits {
+		if lu.LoanID == loanID {
+			out = append(out, lu)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) MarkLoanUnitsReturned(ctx context.Context, loanID uuid.UUID, unitIDs []uuid.UUID, returnID uuid.UUID, at time.Time) error {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	for i, lu := range r.st.loanUnits {
+		if lu.LoanID != loanID || lu.ReturnedAt != nil || !slices.Contains(unitIDs, lu.UnitID) {
+			continue
+		}
+		prev := lu
+		lu.ReturnID, lu.ReturnedAt = &returnID, &at
+		r.st.loanUnits[i] = lu
+		r.onRollback(func() { r.st.loanUnits[i] = prev })
+	}
+	return nil
+}
+
+func (r *fakeRepo) CreateMaintenance(ctx context.Context, rec domain.MaintenanceRecord) (domain.MaintenanceRecord, error) {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	r.st.upkeep[rec.ID] = rec
+	r.onRollback(func() { delete(r.st.upkeep, rec.ID) })
+	return rec, nil
+}
+
+func (r *fakeRepo) GetMaintenanceForUpdate(ctx context.Context, id uuid.UUID) (domain.MaintenanceRecord, bool, error) {
+	r.lock(id)
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	rec, ok := r.st.upkeep[id]
+	return rec, ok, nil
+}
+
+func (r *fakeRepo) OpenMaintenance(ctx context.Context, unitID uuid.UUID) (domain.MaintenanceRecord, bool, error) {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	for _, rec := range r.st.upkeep {
+		if rec.UnitID == unitID && rec.OutOfService && rec.CompletedAt == nil {
+			return rec, true, nil
+		}
+	}
+	return domain.MaintenanceRecord{}, false, nil
+}
+
+func (r *fakeRepo) CompleteMaintenance(ctx context.Context, rec domain.MaintenanceRecord) error {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	prev := r.st.upkeep[rec.ID]
+	r.st.upkeep[rec.ID] = rec
+	r.onRollback(func() { r.st.upkeep[rec.ID] = prev })
+	return nil
+}
+
+func (r *fakeRepo) ListMaintenance(ctx context.Context, unitID uuid.UUID) ([]domain.MaintenanceRecord, error) {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	out := []domain.MaintenanceRecord{}
+	for _, rec := range r.st.upkeep {
+		if rec.UnitID == unitID {
+			out = append(out, rec)
+		}
+	}
+	return out, nil
+}
+
+// -------- Stock ledger
+
+func (r *fakeRepo) ApplyMovement(ctx context.Context, mv domain.StockMovement) error {
+	if err := r.fail("ApplyMovement"); err != nil {
+		return err
+	}
+	// el UPDATE bloquea la fila hasta el commit
+	r.lock(mv.MaterialID)
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	m, ok := r.st.materials[mv.MaterialID]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	total, available := m.TotalQty+mv.TotalDelta, m.AvailableQty+mv.AvailableDelta
+	if available < 0 || total < available {
+		return domain.ErrInsufficientStock
+	}
+	prev := m
+	m.TotalQty, m.AvailableQty = total, available
+	r.st.materials[m.ID] = m
+	r.st.movements = append(r.st.movements, mv)
+	r.onRollback(func() {
+		r.st.materials[prev.ID] = prev
+		for i, x := range r.st.movements {
+			if x.ID == mv.ID {
+				r.st.movements = append(r.st.movements[:i], r.st.movements[i+1:]...)
+				break
+			}
+		}
+	})
+	return nil
+}
+
+func (r *fakeRepo) ListMovements(ctx context.Context, materialID uuid.UUID, limit int) ([]domain.StockMovement, error) {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	out := []domain.StockMovement{}
+	for _, mv := range r.st.movements {
+		if mv.MaterialID == materialID {
+			out = append(out, mv)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) LedgerBalances(ctx context.Context, materialIDs []uuid.UUID) (map[uuid.UUID]domain.LedgerBalance, error) {
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	out := map[uuid.UUID]domain.LedgerBalance{}
+	for _, mv := range r.st.movements {
+		b := out[mv.MaterialID]
+		b.MaterialID = mv.MaterialID
+		b.Total += mv.TotalDelta
+		b.Available += mv.AvailableDelta
+		out[mv.MaterialID] = b
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) SyncStockFromLedger(ctx context.Context, materialID uuid.UUID) (domain.Material, error) {
+	r.lock(materialID)
+	balances, _ := r.LedgerBalances(ctx, []uuid.UUID{materialID})
+	r.st.mu.Lock()
+	defer r.st.mu.Unlock()
+	m, ok := r.st.materials[materialID]
+	if !ok {
+		return domain.Material{}, domain.ErrNotFound
+	}
+	prev := m
+	m.TotalQty, m.AvailableQty = balances[materialID].Total, balances[materialID].Available
+	r.st.materials[materialID] = m
+	r.onRollback(func() { r.st.materials[materialID] = prev })
+	return m, nil
+}
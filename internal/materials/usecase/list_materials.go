@@ -2,10 +2,23 @@ package usecase
 
 import (
 	"context"
+	"strconv"
+	"strings"
 
 	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
 )
 
+// ListMaterialsInput: filtros del catálogo tal como llegan en la query.
+type ListMaterialsInput struct {
+	Search    *string // q
+	Category  *string
+	Available *string // true|false
+	LowStock  *string // true|false
+	Cursor    *string
+	Limit     int
+}
+
 type ListMaterialsUseCase struct {
 	repo domain.Repository
 }
@@ -14,9 +27,72 @@ func NewListMaterialsUseCase(repo domain.Repository) *ListMaterialsUseCase {
 	return &ListMaterialsUseCase{repo: repo}
 }
 
-func (uc *ListMaterialsUseCase) Execute(ctx context.Context, limit int) ([]domain.Material, error) {
-	if limit <= 0 || limit > 200 {
-		limit = 50
+func (uc *ListMaterialsUseCase) Execute(ctx context.Context, in ListMaterialsInput) (domain.MaterialPage, *validation.Errors, error) {
+	validation := validation.New()
+
+	f := domain.MaterialFilter{Limit: in.Limit}
+	if f.Limit <= 0 || f.Limit > 200 {
+		f.Limit = 50
+	}
+	if in.Search != nil {
+		f.Search = trimmedPtr(*in.Search)
+	}
+	if in.Category != nil {
+		f.Category = trimmedPtr(*in.Category)
+	}
+	f.Available = parseBool("available", in.Available, validation)
+	if low := parseBool("low_stock", in.LowStock, validation); low != nil {
+		f.LowStock = *low
+	}
+	if in.Cursor != nil && strings.TrimSpace(*in.Cursor) != "" {
+		c, err := domain.DecodeMaterialCursor(strings.TrimSpace(*in.Cursor))
+		if err != nil {
+			validation.Add("cursor", "invalid_cursor")
+		} else {
+			f.After = &c
+		}
+	}
+	if !validation.Empty() {
+		return domain.MaterialPage{}, validation, domain.ErrValidation
+	}
+
+	// Se pide uno de más para saber si hay otra página.
+	limit := f.Limit
+	f.Limit = limit + 1
+	items, err := uc.repo.ListMaterials(ctx, f)
+	if err != nil {
+		return domain.MaterialPage{}, nil, err
+	}
+
+	page := domain.MaterialPage{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		next := domain.MaterialCursorFor(page.Items[limit-1]).Encode()
+		page.NextCursor = &next
+	}
+	return page, nil, nil
+}
+
+type ListCategoriesUseCase struct {
+	repo domain.Repository
+}
+
+func NewListCategoriesUseCase(repo domain.Repository) *ListCategoriesUseCase {
+	return &ListCategoriesUseCase{repo: repo}
+}
+
+func (uc *ListCategoriesUseCase) Execute(ctx context.Context) ([]domain.CategoryCount, error) {
+	return uc.repo.ListCategories(ctx)
+}
+
+func parseBool(field string, s *string, validation *validation.Errors) *bool {
+	if s == nil || strings.TrimSpace(*s) == "" {
+		return nil
+	}
+	b, err := strconv.ParseBool(strings.TrimSpace(*s))
+	if err != nil {
+		validation.Add(field, "must_be_boolean")
+		return nil
 	}
-	return uc.repo.ListMaterials(ctx, limit)
+	return &b
 }
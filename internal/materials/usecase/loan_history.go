@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+// ---------- Historial
+
+// ListLoansInput: filtros del historial tal como llegan en la query. Las rutas
+// por material y por kinesiólogo completan el id desde el path.
+type ListLoansInput struct {
+	MaterialID      *string
+	PatientID       *string
+	KinesiologistID *string
+	Status          *string // active|returned
+	From            *string // YYYY-MM-DD, fecha del préstamo
+	To              *string // YYYY-MM-DD (inclusive)
+	Overdue         *string // true = solo activos vencidos
+	Cursor          *string
+	Limit           int
+}
+
+type ListLoansUseCase struct {
+	repo domain.Repository
+}
+
+func NewListLoansUseCase(repo domain.Repository) *ListLoansUseCase {
+	return &ListLoansUseCase{repo: repo}
+}
+
+func (uc *ListLoansUseCase) Execute(ctx context.Context, in ListLoansInput) (domain.LoanPage, *validation.Errors, error) {
+	validation := validation.New()
+
+	f := domain.LoanFilter{Limit: in.Limit}
+	if f.Limit <= 0 || f.Limit > 200 {
+		f.Limit = 50
+	}
+	f.MaterialID = validation.OptionalUUID("material_id", in.MaterialID)
+	f.PatientID = validation.OptionalUUID("patient_id", in.PatientID)
+	f.KinesiologistID = validation.OptionalUUID("kinesiologist_id", in.KinesiologistID)
+
+	if in.Status != nil {
+		status := strings.ToLower(strings.TrimSpace(*in.Status))
+		if status != "" && !slices.Contains(domain.LoanStatuses, status) {
+			validation.Add("status", "must_be_one_of_"+strings.Join(domain.LoanStatuses, "|"))
+		}
+		f.Status = status
+	}
+	f.From = parseDay("from", in.From, validation)
+	if to := parseDay("to", in.To, validation); to != nil {
+		end := to.AddDate(0, 0, 1)
+		f.To = &end
+	}
+	if overdue := parseBool("overdue", in.Overdue, validation); overdue != nil && *overdue {
+		now := time.Now()
+		f.OverdueAt = &now
+	}
+	if in.Cursor != nil && strings.TrimSpace(*in.Cursor) != "" {
+		c, err := domain.DecodeLoanCursor(strings.TrimSpace(*in.Cursor))
+		if err != nil {
+			validation.Add("cursor", "invalid_cursor")
+		} else {
+			f.After = &c
+		}
+	}
+	if !validation.Empty() {
+		return domain.LoanPage{}, validation, domain.ErrValidation
+	}
+
+	if f.MaterialID != nil {
+		_, found, err := uc.repo.GetMaterialByID(ctx, *f.MaterialID)
+		if err != nil {
+			return domain.LoanPage{}, nil, err
+		}
+		if !found {
+			return domain.LoanPage{}, nil, domain.ErrNotFound
+		}
+	}
+
+	// Se pide uno de más para saber si hay otra página.
+	limit := f.Limit
+	f.Limit = limit + 1
+	items, err := uc.repo.ListLoans(ctx, f)
+	if err != nil {
+		return domain.LoanPage{}, nil, err
+	}
+
+	page := domain.LoanPage{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		next := domain.LoanCursorFor(page.Items[limit-1]).Encode()
+		page.NextCursor = &next
+	}
+	return page, nil, nil
+}
+
+// ---------- Estadísticas
+
+// LoanStatsInput: período (YYYY-MM-DD, ambos inclusive; por defecto los últimos
+// 30 días) y opcionalmente un solo material.
+type LoanStatsInput struct {
+	From       *string
+	To         *string
+	MaterialID *string
+}
+
+// LoanStatsReport: métricas por material y el total de la clínica.
+type LoanStatsReport struct {
+	From       time.Time
+	To         time.Time // inclusive
+	PeriodDays int
+	Materials  []domain.MaterialLoanStats
+	Totals     domain.MaterialLoanStats
+}
+
+type LoanStatsUseCase struct {
+	repo domain.Repository
+}
+
+func NewLoanStatsUseCase(repo domain.Repository) *LoanStatsUseCase {
+	return &LoanStatsUseCase{repo: repo}
+}
+
+func (uc *LoanStatsUseCase) Execute(ctx context.Context, in LoanStatsInput) (LoanStatsReport, *validation.Errors, error) {
+	validation := validation.New()
+
+	now := time.Now().UTC()
+	to := domain.Day(now)
+	if d := parseDay("to", in.To, validation); d != nil {
+		to = *d
+	}
+	from := to.AddDate(0, 0, -29)
+	if d := parseDay("from", in.From, validation); d != nil {
+		from = *d
+	}
+	if validation.Empty() && from.After(to) {
+		validation.AddMessage("from", "must_not_be_after_to", "No puede ser posterior a to")
+	}
+	materialID := validation.OptionalUUID("material_id", in.MaterialID)
+	if !validation.Empty() {
+		return LoanStatsReport{}, validation, domain.ErrValidation
+	}
+
+	if materialID != nil {
+		_, found, err := uc.repo.GetMaterialByID(ctx, *materialID)
+		if err != nil {
+			return LoanStatsReport{}, nil, err
+		}
+		if !found {
+			return LoanStatsReport{}, nil, domain.ErrNotFound
+		}
+	}
+
+	end := to.AddDate(0, 0, 1)
+	stats, err := uc.repo.LoanStats(ctx, from, end, now, materialID)
+	if err != nil {
+		return LoanStatsReport{}, nil, err
+	}
+
+	report := LoanStatsReport{
+		From:       from,
+		To:         to,
+		PeriodDays: int(end.Sub(from).Hours() / 24),
+		Materials:  stats,
+	}
+	report.Totals = sumLoanStats(stats)
+	return report, nil, nil
+}
+
+// sumLoanStats suma las métricas de todos los materiales. El promedio de
+// duración se pondera por la cantidad de préstamos cerrados de cada uno.
+func sumLoanStats(stats []domain.MaterialLoanStats) domain.MaterialLoanStats {
+	var total domain.MaterialLoanStats
+	var days float64
+	for _, s := range stats {
+		total.TotalQty += s.TotalQty
+		total.Loans += s.Loans
+		total.UnitsLoaned += s.UnitsLoaned
+		total.ActiveQty += s.ActiveQty
+		total.LoanedUnitDays += s.LoanedUnitDays
+		total.ReturnedLoans += s.ReturnedLoans
+		total.LostQty += s.LostQty
+		total.DamagedQty += s.DamagedQty
+		total.StockWriteOffQty += s.StockWriteOffQty
+		if s.AvgLoanDays != nil {
+			days += *s.AvgLoanDays * float64(s.ReturnedLoans)
+		}
+	}
+	if total.ReturnedLoans > 0 {
+		avg := days / float64(total.ReturnedLoans)
+		total.AvgLoanDays = &avg
+	}
+	return total
+}
+
+func parseDay(field string, s *string, validation *validation.Errors) *time.Time {
+	if s == nil || strings.TrimSpace(*s) == "" {
+		return nil
+	}
+	tm, err := time.Parse("2006-01-02", strings.TrimSpace(*s))
+	if err != nil {
+		validation.Add(field, "invalid_date_(YYYY-MM-DD)")
+		return nil
+	}
+	return &tm
+}
@@ -2,12 +2,16 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
 )
 
 type LoanMaterialInput struct {
@@ -16,6 +20,13 @@ type LoanMaterialInput struct {
 	KinesiologistID string  `json:"kinesiologist_id"`
 	Qty             int     `json:"qty"`
 	Notes           *string `json:"notes,omitempty"`
+	// YYYY-MM-DD; si no viene se usa el plazo por defecto del material.
+	DueDate *string `json:"due_date,omitempty"`
+	// Depósito cobrado en centavos; si no viene se usa el del material por unidad (0 = sin depósito).
+	DepositCents *int64 `json:"deposit_cents,omitempty"`
+	// Unidades a prestar, obligatorias para materiales en modo unit. qty puede
+	// omitirse: se toma la cantidad de unidades.
+	UnitIDs []string `json:"unit_ids,omitempty"`
 }
 
 type LoanMaterialUseCase struct {
@@ -26,68 +37,187 @@ func NewLoanMaterialUseCase(repo domain.Repository) *LoanMaterialUseCase {
 	return &LoanMaterialUseCase{repo: repo}
 }
 
-func (uc *LoanMaterialUseCase) Execute(ctx context.Context, in LoanMaterialInput) (domain.MaterialLoan, map[string]string, error) {
-	validation := map[string]string{}
+func (uc *LoanMaterialUseCase) Execute(ctx context.Context, in LoanMaterialInput) (domain.MaterialLoan, *validation.Errors, error) {
+	validation := validation.New()
 
 	mID, err := uuid.Parse(strings.TrimSpace(in.MaterialID))
 	if err != nil {
-		validation["material_id"] = "invalid_uuid"
+		validation.Add("material_id", "invalid_uuid")
 	}
 	pID, err := uuid.Parse(strings.TrimSpace(in.PatientID))
 	if err != nil {
-		validation["patient_id"] = "invalid_uuid"
+		validation.Add("patient_id", "invalid_uuid")
 	}
 	kID, err := uuid.Parse(strings.TrimSpace(in.KinesiologistID))
 	if err != nil {
-		validation["kinesiologist_id"] = "invalid_uuid"
+		validation.Add("kinesiologist_id", "invalid_uuid")
 	}
 
-	if in.Qty <= 0 {
-		validation["qty"] = "must_be_>_0"
+	unitIDs := make([]uuid.UUID, 0, len(in.UnitIDs))
+	for i, raw := range in.UnitIDs {
+		field := fmt.Sprintf("unit_ids[%d]", i)
+		id, err := uuid.Parse(strings.TrimSpace(raw))
+		switch {
+		case err != nil:
+			validation.Add(field, "invalid_uuid")
+		case slices.Contains(unitIDs, id):
+			validation.Add(field, "duplicated")
+		default:
+			unitIDs = append(unitIDs, id)
+		}
 	}
 
-	if len(validation) > 0 {
-		return domain.MaterialLoan{}, validation, domain.ErrValidation
+	qty := in.Qty
+	if qty == 0 && len(in.UnitIDs) > 0 {
+		qty = len(in.UnitIDs)
 	}
-
-	// chequear stock antes de crear el préstamo
-	mat, found, err := uc.repo.GetMaterialByID(ctx, mID)
-	if err != nil {
-		return domain.MaterialLoan{}, nil, err
+	switch {
+	case qty <= 0:
+		validation.Add("qty", "must_be_>_0")
+	case len(in.UnitIDs) > 0 && qty != len(in.UnitIDs):
+		validation.AddMessage("qty", "must_match_unit_ids", "Tiene que coincidir con la cantidad de unidades")
 	}
-	if !found {
-		return domain.MaterialLoan{}, map[string]string{"material_id": "not_found"}, domain.ErrNotFound
+
+	now := time.Now().UTC()
+	today := domain.Day(now)
+
+	var dueDate *time.Time
+	if in.DueDate != nil && strings.TrimSpace(*in.DueDate) != "" {
+		d, err := time.Parse("2006-01-02", strings.TrimSpace(*in.DueDate))
+		switch {
+		case err != nil:
+			validation.Add("due_date", "invalid_date_(YYYY-MM-DD)")
+		case d.Before(today):
+			validation.Add("due_date", "must_not_be_in_the_past")
+		default:
+			dueDate = &d
+		}
 	}
-	if mat.AvailableQty < in.Qty {
-		return domain.MaterialLoan{}, map[string]string{"qty": "insufficient_stock"}, domain.ErrInsufficientStock
+
+	if in.DepositCents != nil && *in.DepositCents < 0 {
+		validation.Add("deposit_cents", "must_be_>=_0")
 	}
 
-	now := time.Now().UTC()
+	if !validation.Empty() {
+		return domain.MaterialLoan{}, validation, domain.ErrValidation
+	}
 
 	loan := domain.MaterialLoan{
 		ID:              uuid.New(),
 		MaterialID:      mID,
 		PatientID:       pID,
 		KinesiologistID: kID,
-		Qty:             in.Qty,
+		Qty:             qty,
 		Notes:           in.Notes,
 		LoanedAt:        now,
+		DueDate:         dueDate,
 		ReturnedAt:      nil,
 	}
 
-	// en repo: transacción stock-- + create loan
-	// para mantenerlo simple, : decrement available y luego create loan.
-	// ideal: una transacción real en repo.
-	if err := uc.repo.DecrementAvailable(ctx, mID, in.Qty); err != nil {
-		return domain.MaterialLoan{}, nil, err
-	}
-
-	out, err := uc.repo.CreateLoan(ctx, loan)
+	// stock y préstamo en una sola transacción: el material queda bloqueado
+	// hasta el commit, así dos préstamos simultáneos no pueden tomar la misma unidad.
+	var out domain.MaterialLoan
+	err = uc.repo.WithinTx(ctx, func(tx domain.Repository) error {
+		mat, found, err := tx.GetMaterialForUpdate(ctx, mID)
+		if err != nil {
+			return err
+		}
+		if !found {
+			validation.Add("material_id", "not_found")
+			return domain.ErrNotFound
+		}
+		if mat.AvailableQty < qty {
+			validation.Add("qty", "insufficient_stock")
+			return domain.ErrInsufficientStock
+		}
+		if err := checkLoanUnits(ctx, tx, mat, unitIDs, validation); err != nil {
+			return err
+		}
+		if loan.DueDate == nil && mat.DefaultLoanDays != nil {
+			d := today.AddDate(0, 0, *mat.DefaultLoanDays)
+			loan.DueDate = &d
+		}
+		switch {
+		case in.DepositCents != nil:
+			loan.DepositCents = *in.DepositCents
+		case mat.DepositCents != nil:
+			loan.DepositCents = *mat.DepositCents * int64(qty)
+		}
+		loan.RentalFeeCents, loan.RentalPeriod = mat.RentalFeeCents, mat.RentalPeriod
+
+		created, err := tx.CreateLoan(ctx, loan)
+		if err != nil {
+			return err
+		}
+
+		if len(unitIDs) > 0 {
+			if err := tx.AttachLoanUnits(ctx, created.ID, unitIDs); err != nil {
+				return err
+			}
+			if err := tx.SetUnitStatus(ctx, unitIDs, domain.UnitLoaned, now); err != nil {
+				return err
+			}
+		}
+
+		mv := domain.NewMovement(mID, domain.MovementLoan, qty, now)
+		mv.LoanID = &created.ID
+		if err := tx.ApplyMovement(ctx, mv); err != nil {
+			if errors.Is(err, domain.ErrInsufficientStock) {
+				validation.Add("qty", "insufficient_stock")
+			}
+			return err
+		}
+
+		out = created
+		return nil
+	})
 	if err != nil {
-		// compensación: si falló crear el loan, devolvemos stock
-		_ = uc.repo.IncrementAvailable(ctx, mID, in.Qty)
-		return domain.MaterialLoan{}, nil, err
+		if validation.Empty() {
+			return domain.MaterialLoan{}, nil, err
+		}
+		return domain.MaterialLoan{}, validation, err
 	}
 
 	return out, nil, nil
 }
+
+// checkLoanUnits controla que las unidades correspondan al modo del material:
+// obligatorias en modo unit (del mismo material y disponibles), no admitidas en
+// modo quantity. Las unidades quedan bloqueadas hasta el fin de la transacción.
+func checkLoanUnits(ctx context.Context, tx domain.Repository, mat domain.Material, unitIDs []uuid.UUID, validation *validation.Errors) error {
+	if mat.TrackingMode != domain.TrackingUnit {
+		if len(unitIDs) > 0 {
+			validation.Add("unit_ids", "unsupported")
+			return domain.ErrValidation
+		}
+		return nil
+	}
+	if len(unitIDs) == 0 {
+		validation.Add("unit_ids", "required")
+		return domain.ErrValidation
+	}
+
+	unavailable := false
+	for i, id := range unitIDs {
+		field := fmt.Sprintf("unit_ids[%d]", i)
+		unit, found, err := tx.GetUnitForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		switch {
+		case !found || unit.MaterialID != mat.ID:
+			validation.Add(field, "not_found")
+		case unit.Status != domain.UnitAvailable:
+			validation.Add(field, "not_available")
+			unavailable = true
+		}
+	}
+	switch {
+	case validation.Empty():
+		return nil
+	case unavailable:
+		return domain.ErrUnitNotAvailable
+	default:
+		return domain.ErrValidation
+	}
+}
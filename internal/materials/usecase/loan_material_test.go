@@ -0,0 +1,401 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
+)
+
+func seedMaterial(t *testing.T, repo *fakeRepo, qty int) domain.Material {
+	t.Helper()
+	now := time.Now().UTC()
+	m, err := repo.CreateMaterial(context.Background(), domain.Material{
+		ID:           uuid.New(),
+		Name:         "Banda elástica",
+		TotalQty:     qty,
+		AvailableQty: qty,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	})
+	if err != nil {
+		t.Fatalf("seed material: %v", err)
+	}
+	return m
+}
+
+func loanInput(materialID uuid.UUID, qty int) LoanMaterialInput {
+	return LoanMaterialInput{
+		MaterialID:      materialID.String(),
+		PatientID:       uuid.NewString(),
+		KinesiologistID: uuid.NewString(),
+		Qty:             qty,
+	}
+}
+
+// assertConsistent controla que material, libro y préstamos activos cuadren.
+func assertConsistent(t *testing.T, repo *fakeRepo, materialID uuid.UUID, wantAvailable int) {
+	t.Helper()
+	m, _, _ := repo.GetMaterialByID(context.Background(), materialID)
+	if m.AvailableQty != wantAvailable {
+		t.Errorf("available_qty = %d, want %d", m.AvailableQty, wantAvailable)
+	}
+	checks, err := checkMaterials(context.Background(), repo, []domain.Material{m})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if !checks[0].Consistent() {
+		t.Errorf("stock inconsistente: %v", checks[0].Issues)
+	}
+}
+
+func TestLoanMaterial_ConcurrentLoans(t *testing.T) {
+	tests := []struct {
+		name     string
+		stock    int
+		workers  int
+		wantLent int
+	}{
+		{"última unidad", 1, 20, 1},
+		{"pocas unidades para muchos pedidos", 3, 30, 3},
+		{"alcanza para todos", 10, 10, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRepo()
+			m := seedMaterial(t, repo, tt.stock)
+			uc := NewLoanMaterialUseCase(repo)
+
+			var (
+				wg           sync.WaitGroup
+				mu           sync.Mutex
+				ok, rejected int
+				unexpected   []error
+			)
+			start := make(chan struct{})
+			for i := 0; i < tt.workers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					<-start
+					_, details, err := uc.Execute(context.Background(), loanInput(m.ID, 1))
+					mu.Lock()
+					defer mu.Unlock()
+					switch {
+					case err == nil:
+						ok++
+					case errors.Is(err, domain.ErrInsufficientStock):
+						rejected++
+						if details == nil || !details.Has("qty") {
+							unexpected = append(unexpected, errors.New("rechazo sin detalle en qty"))
+						}
+					default:
+						unexpected = append(unexpected, err)
+					}
+				}()
+			}
+			close(start)
+			wg.Wait()
+
+			if len(unexpected) > 0 {
+				t.Fatalf("errores inesperados: %v", unexpected)
+			}
+			if ok != tt.wantLent {
+				t.Errorf("préstamos = %d, want %d", ok, tt.wantLent)
+			}
+			if rejected != tt.workers-tt.wantLent {
+				t.Errorf("rechazados = %d, want %d", rejected, tt.workers-tt.wantLent)
+			}
+			assertConsistent(t, repo, m.ID, tt.stock-tt.wantLent)
+		})
+	}
+}
+
+func TestLoanMaterial_RollbackWhenLedgerFails(t *testing.T) {
+	repo := newFakeRepo()
+	m := seedMaterial(t, repo, 2)
+	repo.st.failOn = "ApplyMovement"
+
+	_, _, err := NewLoanMaterialUseCase(repo).Execute(context.Background(), loanInput(m.ID, 1))
+	if !errors.Is(err, errInjected) {
+		t.Fatalf("err = %v, want %v", err, errInjected)
+	}
+	if n := len(repo.st.loans); n != 0 {
+		t.Errorf("quedaron %d préstamos después del rollback", n)
+	}
+
+	repo.st.failOn = ""
+	assertConsistent(t, repo, m.ID, 2)
+}
+
+func TestReturnMaterial_ConcurrentReturnsOfSameLoan(t *testing.T) {
+	repo := newFakeRepo()
+	m := seedMaterial(t, repo, 2)
+	loan, _, err := NewLoanMaterialUseCase(repo).Execute(context.Background(), loanInput(m.ID, 2))
+	if err != nil {
+		t.Fatalf("loan: %v", err)
+	}
+	uc := NewReturnMaterialUseCase(repo)
+
+	const workers = 10
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		ok, dupe int
+		others   []error
+	)
+	start := make(chan struct{})
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, _, err := uc.Execute(context.Background(), loan.ID, ReturnMaterialInput{})
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				ok++
+			case errors.Is(err, domain.ErrAlreadyReturned):
+				dupe++
+			default:
+				others = append(others, err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if len(others) > 0 {
+		t.Fatalf("errores inesperados: %v", others)
+	}
+	if ok != 1 || dupe != workers-1 {
+		t.Errorf("devoluciones = %d, ya devueltos = %d; want 1 y %d", ok, dupe, workers-1)
+	}
+	assertConsistent(t, repo, m.ID, 2)
+}
+
+func TestReturnMaterial_ConcurrentPartialReturns(t *testing.T) {
+	repo := newFakeRepo()
+	m := seedMaterial(t, repo, 5)
+	loan, _, err := NewLoanMaterialUseCase(repo).Execute(context.Background(), loanInput(m.ID, 5))
+	if err != nil {
+		t.Fatalf("loan: %v", err)
+	}
+	uc := NewReturnMaterialUseCase(repo)
+
+	// 8 devoluciones de 1 unidad sobre un préstamo de 5: entran 5 y el resto se rechaza
+	const workers = 8
+	var (
+		wg           sync.WaitGroup
+		mu           sync.Mutex
+		ok, rejected int
+		others       []error
+	)
+	one := 1
+	start := make(chan struct{})
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, _, err := uc.Execute(context.Background(), loan.ID, ReturnMaterialInput{Qty: &one})
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				ok++
+			case errors.Is(err, domain.ErrAlreadyReturned):
+				rejected++
+			default:
+				others = append(others, err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if len(others) > 0 {
+		t.Fatalf("errores inesperados: %v", others)
+	}
+	if ok != 5 || rejected != workers-5 {
+		t.Errorf("devoluciones = %d, rechazadas = %d; want 5 y %d", ok, rejected, workers-5)
+	}
+	got, _, _ := repo.GetLoanByID(context.Background(), loan.ID)
+	if got.ReturnedQty != 5 || got.ReturnedAt == nil {
+		t.Errorf("returned_qty = %d, returned_at = %v; want 5 y cerrado", got.ReturnedQty, got.ReturnedAt)
+	}
+	assertConsistent(t, repo, m.ID, 5)
+}
+
+func TestReturnMaterial_RollbackWhenLedgerFails(t *testing.T) {
+	repo := newFakeRepo()
+	m := seedMaterial(t, repo, 1)
+	loan, _, err := NewLoanMaterialUseCase(repo).Execute(context.Background(), loanInput(m.ID, 1))
+	if err != nil {
+		t.Fatalf("loan: %v", err)
+	}
+
+	repo.st.failOn = "ApplyMovement"
+	if _, _, err := NewReturnMaterialUseCase(repo).Execute(context.Background(), loan.ID, ReturnMaterialInput{}); !errors.Is(err, errInjected) {
+		t.Fatalf("err = %v, want %v", err, errInjected)
+	}
+	repo.st.failOn = ""
+
+	got, _, _ := repo.GetLoanByID(context.Background(), loan.ID)
+	if got.ReturnedAt != nil {
+		t.Errorf("el préstamo quedó marcado como devuelto")
+	}
+	assertConsistent(t, repo, m.ID, 0)
+}
+
+func TestLoanAndWriteOff_Concurrent(t *testing.T) {
+	repo := newFakeRepo()
+	m := seedMaterial(t, repo, 1)
+	loanUC := NewLoanMaterialUseCase(repo)
+	writeOffUC := NewWriteOffMaterialUseCase(repo)
+
+	var wg sync.WaitGroup
+	var loanErr, writeOffErr error
+	start := make(chan struct{})
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		<-start
+		_, _, loanErr = loanUC.Execute(context.Background(), loanInput(m.ID, 1))
+	}()
+	go func() {
+		defer wg.Done()
+		<-start
+		_, _, writeOffErr = writeOffUC.Execute(context.Background(), m.ID, StockChangeInput{Qty: 1, Reason: "lost"})
+	}()
+	close(start)
+	wg.Wait()
+
+	// solo uno de los dos puede quedarse con la unidad
+	if (loanErr == nil) == (writeOffErr == nil) {
+		t.Fatalf("loan err = %v, write-off err = %v; want exactamente uno OK", loanErr, writeOffErr)
+	}
+	for _, err := range []error{loanErr, writeOffErr} {
+		if err != nil && !errors.Is(err, domain.ErrInsufficientStock) {
+			t.Fatalf("err = %v, want %v", err, domain.ErrInsufficientStock)
+		}
+	}
+	assertConsistent(t, repo, m.ID, 0)
+}
+
+func seedUnitMaterial(t *testing.T, repo *fakeRepo, serials ...string) (domain.Material, []domain.MaterialUnit) {
+	t.Helper()
+	m, _, err := NewCreateMaterialUseCase(repo).Execute(context.Background(), CreateMaterialInput{Name: "TENS", TrackingMode: domain.TrackingUnit})
+	if err != nil {
+		t.Fatalf("seed material: %v", err)
+	}
+	units := make([]domain.MaterialUnit, 0, len(serials))
+	for _, serial := range serials {
+		u, _, err := NewAddUnitUseCase(repo).Execute(context.Background(), m.ID, AddUnitInput{Serial: serial})
+		if err != nil {
+			t.Fatalf("seed unit %s: %v", serial, err)
+		}
+		units = append(units, u)
+	}
+	return m, units
+}
+
+func TestLoanMaterial_ConcurrentLoansOfSameUnit(t *testing.T) {
+	repo := newFakeRepo()
+	m, units := seedUnitMaterial(t, repo, "TENS-001", "TENS-002")
+	uc := NewLoanMaterialUseCase(repo)
+
+	const workers = 10
+	var (
+		wg         sync.WaitGroup
+		mu         sync.Mutex
+		ok, taken  int
+		unexpected []error
+	)
+	start := make(chan struct{})
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			in := loanInput(m.ID, 0)
+			in.UnitIDs = []string{units[0].ID.String()}
+			_, _, err := uc.Execute(context.Background(), in)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				ok++
+			case errors.Is(err, domain.ErrUnitNotAvailable):
+				taken++
+			default:
+				unexpected = append(unexpected, err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if len(unexpected) > 0 {
+		t.Fatalf("errores inesperados: %v", unexpected)
+	}
+	if ok != 1 || taken != workers-1 {
+		t.Errorf("préstamos = %d, rechazados = %d; want 1 y %d", ok, taken, workers-1)
+	}
+	u, _, _ := repo.GetUnitByID(context.Background(), units[0].ID)
+	if u.Status != domain.UnitLoaned {
+		t.Errorf("status = %s, want %s", u.Status, domain.UnitLoaned)
+	}
+	assertConsistent(t, repo, m.ID, 1)
+}
+
+func TestUnits_MaintenanceAndLostReturn(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeRepo()
+	m, units := seedUnitMaterial(t, repo, "US-1", "US-2")
+
+	// US-1 sale de servicio: no se puede prestar hasta completar el mantenimiento
+	rec, _, err := NewRegisterMaintenanceUseCase(repo).Execute(ctx, units[0].ID, MaintenanceInput{Kind: "calibration", OutOfService: true})
+	if err != nil {
+		t.Fatalf("maintenance: %v", err)
+	}
+	assertConsistent(t, repo, m.ID, 1)
+
+	in := loanInput(m.ID, 0)
+	in.UnitIDs = []string{units[0].ID.String()}
+	if _, _, err := NewLoanMaterialUseCase(repo).Execute(ctx, in); !errors.Is(err, domain.ErrUnitNotAvailable) {
+		t.Fatalf("loan en mantenimiento: err = %v, want %v", err, domain.ErrUnitNotAvailable)
+	}
+
+	if _, _, err := NewCompleteMaintenanceUseCase(repo).Execute(ctx, units[0].ID, rec.ID, CompleteMaintenanceInput{}); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	assertConsistent(t, repo, m.ID, 2)
+
+	// se prestan las dos y vuelve US-2 perdida: queda retirada y fuera del total
+	in.UnitIDs = []string{units[0].ID.String(), units[1].ID.String()}
+	loan, _, err := NewLoanMaterialUseCase(repo).Execute(ctx, in)
+	if err != nil {
+		t.Fatalf("loan: %v", err)
+	}
+	one := 1
+	_, details, err := NewReturnMaterialUseCase(repo).Execute(ctx, loan.ID, ReturnMaterialInput{Qty: &one, Condition: "lost"})
+	if !errors.Is(err, domain.ErrValidation) || details == nil || !details.Has("unit_ids") {
+		t.Fatalf("devolución parcial sin unit_ids: err = %v, details = %v", err, details)
+	}
+	ret := ReturnMaterialInput{Condition: "lost", UnitIDs: []string{units[1].ID.String()}}
+	if _, _, err := NewReturnMaterialUseCase(repo).Execute(ctx, loan.ID, ret); err != nil {
+		t.Fatalf("return: %v", err)
+	}
+	u, _, _ := repo.GetUnitByID(ctx, units[1].ID)
+	if u.Status != domain.UnitRetired {
+		t.Errorf("status = %s, want %s", u.Status, domain.UnitRetired)
+	}
+	assertConsistent(t, repo, m.ID, 0)
+}
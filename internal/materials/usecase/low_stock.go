@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
+)
+
+// ListLowStockUseCase devuelve los materiales con stock por debajo de su umbral.
+type ListLowStockUseCase struct {
+	repo domain.Repository
+}
+
+func NewListLowStockUseCase(repo domain.Repository) *ListLowStockUseCase {
+	return &ListLowStockUseCase{repo: repo}
+}
+
+func (uc *ListLowStockUseCase) Execute(ctx context.Context) ([]domain.Material, error) {
+	return uc.repo.ListLowStockMaterials(ctx, false)
+}
+
+// NotifyLowStockUseCase avisa una vez por cada material que quedó por debajo de
+// su umbral. Cuando el stock se recupera el aviso se rehabilita. Lo corre un job periódico.
+type NotifyLowStockUseCase struct {
+	repo     domain.Repository
+	notifier domain.Notifier
+}
+
+func NewNotifyLowStockUseCase(repo domain.Repository, notifier domain.Notifier) *NotifyLowStockUseCase {
+	return &NotifyLowStockUseCase{repo: repo, notifier: notifier}
+}
+
+// Execute devuelve cuántos avisos se enviaron. Si falla un aviso sigue con el
+// resto; el material queda para la próxima vuelta.
+func (uc *NotifyLowStockUseCase) Execute(ctx context.Context, now time.Time) (int, error) {
+	if _, err := uc.repo.ResetLowStockAlerts(ctx); err != nil {
+		return 0, err
+	}
+	materials, err := uc.repo.ListLowStockMaterials(ctx, true)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []error
+	for _, m := range materials {
+		if err := uc.notifier.NotifyLowStock(ctx, domain.LowStockNotice{Material: m}); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := uc.repo.MarkLowStockNotified(ctx, m.ID, now.UTC()); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sent++
+	}
+	return sent, errors.Join(errs...)
+}
@@ -2,12 +2,35 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+
 	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
 )
 
+// ReturnMaterialInput: sin qty se devuelve todo lo pendiente. La condición por
+// defecto es ok; lost da de baja las unidades y damaged solo si write_off es true.
+// Sin charge_cents, las unidades dadas de baja se cobran al costo de reposición.
+// En préstamos por unidades, una devolución parcial indica las unidades en unit_ids.
+type ReturnMaterialInput struct {
+	Qty         *int     `json:"qty,omitempty"`
+	UnitIDs     []string `json:"unit_ids,omitempty"`
+	Condition   string   `json:"condition,omitempty"`
+	WriteOff    *bool    `json:"write_off,omitempty"`
+	ChargeCents *int64   `json:"charge_cents,omitempty"`
+	Notes       *string  `json:"notes,omitempty"`
+}
+
+type ReturnMaterialOutput struct {
+	Loan   domain.MaterialLoan
+	Return domain.LoanReturn
+}
+
 type ReturnMaterialUseCase struct {
 	repo domain.Repository
 }
@@ -16,31 +39,186 @@ func NewReturnMaterialUseCase(repo domain.Repository) *ReturnMaterialUseCase {
 	return &ReturnMaterialUseCase{repo: repo}
 }
 
-func (uc *ReturnMaterialUseCase) Execute(ctx context.Context, loanID uuid.UUID) (domain.MaterialLoan, error) {
-	loan, found, err := uc.repo.GetLoanByID(ctx, loanID)
-	if err != nil {
-		return domain.MaterialLoan{}, err
+func (uc *ReturnMaterialUseCase) Execute(ctx context.Context, loanID uuid.UUID, in ReturnMaterialInput) (ReturnMaterialOutput, *validation.Errors, error) {
+	validation := validation.New()
+	if in.Qty != nil && *in.Qty <= 0 {
+		validation.Add("qty", "must_be_>_0")
+	}
+	condition := strings.ToLower(strings.TrimSpace(in.Condition))
+	if condition == "" {
+		condition = domain.ConditionOK
 	}
-	if !found {
-		return domain.MaterialLoan{}, domain.ErrNotFound
+	if !slices.Contains(domain.ReturnConditions, condition) {
+		validation.Add("condition", "must_be_one_of_"+strings.Join(domain.ReturnConditions, "|"))
 	}
-	if loan.ReturnedAt != nil {
-		return domain.MaterialLoan{}, domain.ErrAlreadyReturned
+	writtenOff := condition == domain.ConditionLost
+	if in.WriteOff != nil {
+		switch {
+		case condition == domain.ConditionDamaged:
+			writtenOff = *in.WriteOff
+		case condition == domain.ConditionOK && *in.WriteOff:
+			validation.AddMessage("write_off", "requires_damaged_condition", "Solo se puede dar de baja material dañado o perdido")
+		}
+	}
+	if in.ChargeCents != nil && *in.ChargeCents < 0 {
+		validation.Add("charge_cents", "must_be_>=_0")
+	}
+	unitIDs := make([]uuid.UUID, 0, len(in.UnitIDs))
+	for i, raw := range in.UnitIDs {
+		field := fmt.Sprintf("unit_ids[%d]", i)
+		id, err := uuid.Parse(strings.TrimSpace(raw))
+		switch {
+		case err != nil:
+			validation.Add(field, "invalid_uuid")
+		case slices.Contains(unitIDs, id):
+			validation.Add(field, "duplicated")
+		default:
+			unitIDs = append(unitIDs, id)
+		}
+	}
+	if in.Qty != nil && len(in.UnitIDs) > 0 && *in.Qty != len(in.UnitIDs) {
+		validation.AddMessage("qty", "must_match_unit_ids", "Tiene que coincidir con la cantidad de unidades")
+	}
+	if !validation.Empty() {
+		return ReturnMaterialOutput{}, validation, domain.ErrValidation
 	}
 
 	now := time.Now().UTC()
 
-	// marcar returned y devolver stock
-	if err := uc.repo.MarkReturned(ctx, loanID, now); err != nil {
-		return domain.MaterialLoan{}, err
+	// registrar la devolución y devolver stock en la misma transacción; el préstamo
+	// queda bloqueado para que dos devoluciones simultáneas no sumen stock dos veces.
+	var ret domain.LoanReturn
+	err := uc.repo.WithinTx(ctx, func(tx domain.Repository) error {
+		loan, found, err := tx.GetLoanForUpdate(ctx, loanID)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return domain.ErrNotFound
+		}
+		if loan.ReturnedAt != nil {
+			return domain.ErrAlreadyReturned
+		}
+
+		qty := loan.OutstandingQty()
+		if in.Qty != nil {
+			if *in.Qty > qty {
+				validation.Add("qty", fmt.Sprintf("must_be_<=_%d", qty))
+				return domain.ErrValidation
+			}
+			qty = *in.Qty
+		}
+
+		returned, err := returnedUnits(ctx, tx, loan, qty, unitIDs, validation)
+		if err != nil {
+			return err
+		}
+		if len(returned) > 0 {
+			qty = len(returned)
+		}
+
+		var charge int64
+		switch {
+		case in.ChargeCents != nil:
+			charge = *in.ChargeCents
+		case writtenOff:
+			mat, _, err := tx.GetMaterialByID(ctx, loan.MaterialID)
+			if err != nil {
+				return err
+			}
+			if mat.ReplacementCostCents != nil {
+				charge = *mat.ReplacementCostCents * int64(qty)
+			}
+		}
+
+		ret = loan.Settle(qty, condition, charge, writtenOff, now)
+		if in.Notes != nil {
+			ret.Notes = trimmedPtr(*in.Notes)
+		}
+		if err := tx.RegisterReturn(ctx, ret); err != nil {
+			return err
+		}
+		if len(returned) > 0 {
+			if err := tx.MarkLoanUnitsReturned(ctx, loan.ID, returned, ret.ID, now); err != nil {
+				return err
+			}
+			status := domain.UnitAvailable
+			if writtenOff {
+				status = domain.UnitRetired
+			}
+			if err := tx.SetUnitStatus(ctx, returned, status, now); err != nil {
+				return err
+			}
+		}
+
+		mv := domain.NewMovement(loan.MaterialID, domain.MovementReturn, qty, now)
+		mv.LoanID = &loan.ID
+		if err := tx.ApplyMovement(ctx, mv); err != nil {
+			return err
+		}
+		if !writtenOff {
+			return nil
+		}
+
+		// lo perdido o inservible vuelve al libro y sale como baja
+		off := domain.NewMovement(loan.MaterialID, domain.MovementWriteOff, qty, now)
+		off.LoanID = &loan.ID
+		off.Reason = &condition
+		off.Note = ret.Notes
+		return tx.ApplyMovement(ctx, off)
+	})
+	if err != nil {
+		if validation.Empty() {
+			return ReturnMaterialOutput{}, nil, err
+		}
+		return ReturnMaterialOutput{}, validation, err
 	}
-	if err := uc.repo.IncrementAvailable(ctx, loan.MaterialID, loan.Qty); err != nil {
-		return domain.MaterialLoan{}, err
+
+	loan, _, err := uc.repo.GetLoanByID(ctx, loanID)
+	if err != nil {
+		return ReturnMaterialOutput{}, nil, err
 	}
+	return ReturnMaterialOutput{Loan: loan, Return: ret}, nil, nil
+}
 
-	out, _, err := uc.repo.GetLoanByID(ctx, loanID)
+// returnedUnits resuelve qué unidades vuelven en un préstamo por unidades: las
+// indicadas en unitIDs (tienen que estar pendientes en el préstamo) o, si no se
+// indican, todas las pendientes cuando se devuelve todo. Para préstamos por
+// cantidad devuelve nil.
+func returnedUnits(ctx context.Context, tx domain.Repository, loan domain.MaterialLoan, qty int, unitIDs []uuid.UUID, validation *validation.Errors) ([]uuid.UUID, error) {
+	units, err := tx.ListLoanUnits(ctx, loan.ID)
 	if err != nil {
-		return domain.MaterialLoan{}, err
+		return nil, err
+	}
+	pending := make([]uuid.UUID, 0, len(units))
+	for _, u := range units {
+		if u.ReturnedAt == nil {
+			pending = append(pending, u.UnitID)
+		}
+	}
+
+	if len(units) == 0 {
+		if len(unitIDs) > 0 {
+			validation.Add("unit_ids", "unsupported")
+			return nil, domain.ErrValidation
+		}
+		return nil, nil
+	}
+	if len(unitIDs) == 0 {
+		if qty < len(pending) {
+			validation.Add("unit_ids", "required")
+			return nil, domain.ErrValidation
+		}
+		return pending, nil
+	}
+
+	for i, id := range unitIDs {
+		if !slices.Contains(pending, id) {
+			validation.Add(fmt.Sprintf("unit_ids[%d]", i), "not_found")
+		}
+	}
+	if !validation.Empty() {
+		return nil, domain.ErrValidation
 	}
-	return out, nil
+	return unitIDs, nil
 }
@@ -0,0 +1,362 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+// ---------- Consulta y edición
+
+type GetMaterialUseCase struct {
+	repo domain.Repository
+}
+
+func NewGetMaterialUseCase(repo domain.Repository) *GetMaterialUseCase {
+	return &GetMaterialUseCase{repo: repo}
+}
+
+func (uc *GetMaterialUseCase) Execute(ctx context.Context, id uuid.UUID) (domain.Material, bool, error) {
+	return uc.repo.GetMaterialByID(ctx, id)
+}
+
+// UpdateMaterialInput no incluye cantidades: se modifican con reposiciones y
+// bajas para que queden en el libro.
+type UpdateMaterialInput struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	// 0 quita el vencimiento por defecto.
+	DefaultLoanDays *int `json:"default_loan_days,omitempty"`
+	// "" quita la categoría.
+	Category *string `json:"category,omitempty"`
+	// 0 quita la alerta de stock bajo.
+	LowStockThreshold *int `json:"low_stock_threshold,omitempty"`
+	MaterialPricingInput
+}
+
+type UpdateMaterialUseCase struct {
+	repo domain.Repository
+}
+
+func NewUpdateMaterialUseCase(repo domain.Repository) *UpdateMaterialUseCase {
+	return &UpdateMaterialUseCase{repo: repo}
+}
+
+func (uc *UpdateMaterialUseCase) Execute(ctx context.Context, id uuid.UUID, in UpdateMaterialInput) (domain.Material, *validation.Errors, error) {
+	validation := validation.New()
+
+	m, found, err := uc.repo.GetMaterialByID(ctx, id)
+	if err != nil {
+		return domain.Material{}, nil, err
+	}
+	if !found {
+		return domain.Material{}, nil, domain.ErrNotFound
+	}
+
+	if in.Name != nil {
+		name := strings.TrimSpace(*in.Name)
+		if name == "" {
+			validation.Add("name", "required")
+		}
+		m.Name = name
+	}
+	if in.Description != nil {
+		m.Description = trimmedPtr(*in.Description)
+	}
+	if in.DefaultLoanDays != nil {
+		switch days := *in.DefaultLoanDays; {
+		case days < 0:
+			validation.Add("default_loan_days", "must_be_>=_0")
+		case days == 0:
+			m.DefaultLoanDays = nil
+		default:
+			m.DefaultLoanDays = &days
+		}
+	}
+	if in.Category != nil {
+		m.Category = trimmedPtr(*in.Category)
+	}
+	if in.LowStockThreshold != nil {
+		switch threshold := *in.LowStockThreshold; {
+		case threshold < 0:
+			validation.Add("low_stock_threshold", "must_be_>=_0")
+		case threshold == 0:
+			m.LowStockThreshold = nil
+		default:
+			m.LowStockThreshold = &threshold
+		}
+	}
+	applyPricing(in.MaterialPricingInput, &m, validation)
+	if !validation.Empty() {
+		return domain.Material{}, validation, domain.ErrValidation
+	}
+
+	m.UpdatedAt = time.Now().UTC()
+	out, err := uc.repo.UpdateMaterial(ctx, m)
+	if err != nil {
+		return domain.Material{}, nil, err
+	}
+	return out, nil, nil
+}
+
+// ---------- Reposición y baja
+
+type StockChangeInput struct {
+	Qty    int     `json:"qty"`
+	Reason string  `json:"reason"`
+	Note   *string `json:"note,omitempty"`
+}
+
+type RestockMaterialUseCase struct {
+	repo domain.Repository
+}
+
+func NewRestockMaterialUseCase(repo domain.Repository) *RestockMaterialUseCase {
+	return &RestockMaterialUseCase{repo: repo}
+}
+
+func (uc *RestockMaterialUseCase) Execute(ctx context.Context, id uuid.UUID, in StockChangeInput) (domain.Material, *validation.Errors, error) {
+	return applyStockChange(ctx, uc.repo, id, domain.MovementRestock, domain.RestockReasons, in)
+}
+
+// WriteOffMaterialUseCase da de baja unidades perdidas o dañadas. Solo se
+// pueden dar de baja unidades disponibles (no prestadas).
+type WriteOffMaterialUseCase struct {
+	repo domain.Repository
+}
+
+func NewWriteOffMaterialUseCase(repo domain.Repository) *WriteOffMaterialUseCase {
+	return &WriteOffMaterialUseCase{repo: repo}
+}
+
+func (uc *WriteOffMaterialUseCase) Execute(ctx context.Context, id uuid.UUID, in StockChangeInput) (domain.Material, *validation.Errors, error) {
+	return applyStockChange(ctx, uc.repo, id, domain.MovementWriteOff, domain.WriteOffReasons, in)
+}
+
+func applyStockChange(ctx context.Context, repo domain.Repository, id uuid.UUID, kind string, reasons []string, in StockChangeInput) (domain.Material, *validation.Errors, error) {
+	validation := validation.New()
+
+	if in.Qty <= 0 {
+		validation.Add("qty", "must_be_>_0")
+	}
+	reason := strings.ToLower(strings.TrimSpace(in.Reason))
+	if reason == "" {
+		validation.Add("reason", "required")
+	} else if !slices.Contains(reasons, reason) {
+		validation.Add("reason", "must_be_one_of_"+strings.Join(reasons, "|"))
+	}
+	if !validation.Empty() {
+		return domain.Material{}, validation, domain.ErrValidation
+	}
+
+	// el material queda bloqueado para que una baja no compita con un préstamo
+	err := repo.WithinTx(ctx, func(tx domain.Repository) error {
+		m, found, err := tx.GetMaterialForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return domain.ErrNotFound
+		}
+		if m.TrackingMode == domain.TrackingUnit {
+			validation.Add("qty", "unit_tracked")
+			return domain.ErrValidation
+		}
+		if kind == domain.MovementWriteOff && m.AvailableQty < in.Qty {
+			validation.Add("qty", "insufficient_stock")
+			return domain.ErrInsufficientStock
+		}
+
+		mv := domain.NewMovement(m.ID, kind, in.Qty, time.Now().UTC())
+		mv.Reason = &reason
+		if in.Note != nil {
+			mv.Note = trimmedPtr(*in.Note)
+		}
+		return tx.ApplyMovement(ctx, mv)
+	})
+	if err != nil {
+		if validation.Empty() {
+			return domain.Material{}, nil, err
+		}
+		return domain.Material{}, validation, err
+	}
+
+	out, _, err := repo.GetMaterialByID(ctx, id)
+	if err != nil {
+		return domain.Material{}, nil, err
+	}
+	return out, nil, nil
+}
+
+// ---------- Libro de stock
+
+type ListStockMovementsUseCase struct {
+	repo domain.Repository
+}
+
+func NewListStockMovementsUseCase(repo domain.Repository) *ListStockMovementsUseCase {
+	return &ListStockMovementsUseCase{repo: repo}
+}
+
+func (uc *ListStockMovementsUseCase) Execute(ctx context.Context, materialID uuid.UUID, limit int) ([]domain.StockMovement, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	_, found, err := uc.repo.GetMaterialByID(ctx, materialID)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, domain.ErrNotFound
+	}
+	return uc.repo.ListMovements(ctx, materialID, limit)
+}
+
+// ---------- Control de consistencia
+
+type CheckStockUseCase struct {
+	repo domain.Repository
+}
+
+func NewCheckStockUseCase(repo domain.Repository) *CheckStockUseCase {
+	return &CheckStockUseCase{repo: repo}
+}
+
+// Execute controla todos los materiales, o solo materialID si se indica.
+func (uc *CheckStockUseCase) Execute(ctx context.Context, materialID *uuid.UUID) ([]domain.StockCheck, error) {
+	var materials []domain.Material
+	if materialID != nil {
+		m, found, err := uc.repo.GetMaterialByID(ctx, *materialID)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, domain.ErrNotFound
+		}
+		materials = []domain.Material{m}
+	} else {
+		all, err := uc.repo.ListAllMaterials(ctx)
+		if err != nil {
+			return nil, err
+		}
+		materials = all
+	}
+	return checkMaterials(ctx, uc.repo, materials)
+}
+
+func checkMaterials(ctx context.Context, repo domain.Repository, materials []domain.Material) ([]domain.StockCheck, error) {
+	ids := make([]uuid.UUID, 0, len(materials))
+	for _, m := range materials {
+		ids = append(ids, m.ID)
+	}
+	balances, err := repo.LedgerBalances(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	loaned, err := repo.ActiveLoanQty(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	units, err := repo.UnitCounts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]domain.StockCheck, 0, len(materials))
+	for _, m := range materials {
+		out = append(out, domain.CheckStock(m, balances[m.ID], loaned[m.ID], units[m.ID]))
+	}
+	return out, nil
+}
+
+type ReconcileStockInput struct {
+	Note *string `json:"note,omitempty"`
+}
+
+// ReconcileStockUseCase alinea las cantidades del material con el libro. Si el
+// libro no cuadra con los préstamos activos, registra además un ajuste.
+type ReconcileStockUseCase struct {
+	repo domain.Repository
+}
+
+func NewReconcileStockUseCase(repo domain.Repository) *ReconcileStockUseCase {
+	return &ReconcileStockUseCase{repo: repo}
+}
+
+func (uc *ReconcileStockUseCase) Execute(ctx context.Context, materialID uuid.UUID, in ReconcileStockInput) (domain.StockCheck, error) {
+	var out domain.StockCheck
+	err := uc.repo.WithinTx(ctx, func(tx domain.Repository) error {
+		m, found, err := tx.GetMaterialForUpdate(ctx, materialID)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return domain.ErrNotFound
+		}
+
+		checks, err := checkMaterials(ctx, tx, []domain.Material{m})
+		if err != nil {
+			return err
+		}
+		check := checks[0]
+		if check.Consistent() {
+			out = check
+			return nil
+		}
+
+		// primero las cantidades guardadas pasan a ser las del libro
+		m, err = tx.SyncStockFromLedger(ctx, materialID)
+		if err != nil {
+			return err
+		}
+
+		// después, si hace falta, un ajuste para que lo disponible sea total - prestado
+		// (y, en modo unit, menos lo que está en mantenimiento)
+		if diff := check.LedgerTotal - check.OutOfStockQty() - check.LedgerAvailable; diff != 0 {
+			mv := domain.StockMovement{
+				ID:             uuid.New(),
+				MaterialID:     materialID,
+				Kind:           domain.MovementAdjustment,
+				AvailableDelta: diff,
+				CreatedAt:      time.Now().UTC(),
+			}
+			reason := "reconcile"
+			mv.Reason = &reason
+			if in.Note != nil {
+				mv.Note = trimmedPtr(*in.Note)
+			}
+			if err := tx.ApplyMovement(ctx, mv); err != nil {
+				return err
+			}
+			m, _, err = tx.GetMaterialByID(ctx, materialID)
+			if err != nil {
+				return err
+			}
+		}
+
+		checks, err = checkMaterials(ctx, tx, []domain.Material{m})
+		if err != nil {
+			return err
+		}
+		out = checks[0]
+		return nil
+	})
+	if err != nil {
+		return domain.StockCheck{}, err
+	}
+	return out, nil
+}
+
+func trimmedPtr(s string) *string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,462 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/materials/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+// ---------- Alta de unidades
+
+// AddUnitInput: alta de una unidad de un material en modo unit. Suma una unidad
+// al stock con un movimiento de reposición (motivo purchase por defecto).
+type AddUnitInput struct {
+	Serial     string  `json:"serial"`
+	Notes      *string `json:"notes,omitempty"`
+	AcquiredAt *string `json:"acquired_at,omitempty"` // YYYY-MM-DD
+	Reason     string  `json:"reason,omitempty"`
+}
+
+type AddUnitUseCase struct {
+	repo domain.Repository
+}
+
+func NewAddUnitUseCase(repo domain.Repository) *AddUnitUseCase {
+	return &AddUnitUseCase{repo: repo}
+}
+
+func (uc *AddUnitUseCase) Execute(ctx context.Context, materialID uuid.UUID, in AddUnitInput) (domain.MaterialUnit, *validation.Errors, error) {
+	validation := validation.New()
+
+	serial := strings.TrimSpace(in.Serial)
+	if serial == "" {
+		validation.Add("serial", "required")
+	}
+	var acquiredAt *time.Time
+	if in.AcquiredAt != nil && strings.TrimSpace(*in.AcquiredAt) != "" {
+		d, err := time.Parse("2006-01-02", strings.TrimSpace(*in.AcquiredAt))
+		if err != nil {
+			validation.Add("acquired_at", "invalid_date_(YYYY-MM-DD)")
+		} else {
+			acquiredAt = &d
+		}
+	}
+	reason := strings.ToLower(strings.TrimSpace(in.Reason))
+	if reason == "" {
+		reason = "purchase"
+	}
+	if !slices.Contains(domain.RestockReasons, reason) {
+		validation.Add("reason", "must_be_one_of_"+strings.Join(domain.RestockReasons, "|"))
+	}
+	if !validation.Empty() {
+		return domain.MaterialUnit{}, validation, domain.ErrValidation
+	}
+
+	now := time.Now().UTC()
+	unit := domain.MaterialUnit{
+		ID:         uuid.New(),
+		MaterialID: materialID,
+		Serial:     serial,
+		Status:     domain.UnitAvailable,
+		AcquiredAt: acquiredAt,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if in.Notes != nil {
+		unit.Notes = trimmedPtr(*in.Notes)
+	}
+
+	var out domain.MaterialUnit
+	err := uc.repo.WithinTx(ctx, func(tx domain.Repository) error {
+		m, found, err := tx.GetMaterialForUpdate(ctx, materialID)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return domain.ErrNotFound
+		}
+		if m.TrackingMode != domain.TrackingUnit {
+			validation.AddMessage("material_id", "requires_unit_tracking", "El material no se maneja por unidades")
+			return domain.ErrValidation
+		}
+
+		created, err := tx.CreateUnit(ctx, unit)
+		if err != nil {
+			if errors.Is(err, domain.ErrDuplicateSerial) {
+				validation.Add("serial", "already_exists")
+			}
+			return err
+		}
+
+		mv := domain.NewMovement(materialID, domain.MovementRestock, 1, now)
+		mv.UnitID = &created.ID
+		mv.Reason = &reason
+		mv.Note = &created.Serial
+		if err := tx.ApplyMovement(ctx, mv); err != nil {
+			return err
+		}
+
+		out = created
+		return nil
+	})
+	if err != nil {
+		if validation.Empty() {
+			return domain.MaterialUnit{}, nil, err
+		}
+		return domain.MaterialUnit{}, validation, err
+	}
+	return out, nil, nil
+}
+
+// ---------- Consultas
+
+type ListUnitsUseCase struct {
+	repo domain.Repository
+}
+
+func NewListUnitsUseCase(repo domain.Repository) *ListUnitsUseCase {
+	return &ListUnitsUseCase{repo: repo}
+}
+
+func (uc *ListUnitsUseCase) Execute(ctx context.Context, materialID uuid.UUID, status string) ([]domain.MaterialUnit, *validation.Errors, error) {
+	var filter *string
+	if s := strings.ToLower(strings.TrimSpace(status)); s != "" {
+		if !slices.Contains(domain.UnitStatuses, s) {
+			validation := validation.New()
+			validation.Add("status", "must_be_one_of_"+strings.Join(domain.UnitStatuses, "|"))
+			return nil, validation, domain.ErrValidation
+		}
+		filter = &s
+	}
+
+	_, found, err := uc.repo.GetMaterialByID(ctx, materialID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !found {
+		return nil, nil, domain.ErrNotFound
+	}
+	out, err := uc.repo.ListUnits(ctx, materialID, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, nil, nil
+}
+
+type GetUnitUseCase struct {
+	repo domain.Repository
+}
+
+func NewGetUnitUseCase(repo domain.Repository) *GetUnitUseCase {
+	return &GetUnitUseCase{repo: repo}
+}
+
+func (uc *GetUnitUseCase) Execute(ctx context.Context, id uuid.UUID) (domain.MaterialUnit, bool, error) {
+	return uc.repo.GetUnitByID(ctx, id)
+}
+
+type ListLoanUnitsUseCase struct {
+	repo domain.Repository
+}
+
+func NewListLoanUnitsUseCase(repo domain.Repository) *ListLoanUnitsUseCase {
+	return &ListLoanUnitsUseCase{repo: repo}
+}
+
+func (uc *ListLoanUnitsUseCase) Execute(ctx context.Context, loanID uuid.UUID) ([]domain.LoanUnit, error) {
+	_, found, err := uc.repo.GetLoanByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, domain.ErrNotFound
+	}
+	return uc.repo.ListLoanUnits(ctx, loanID)
+}
+
+// ---------- Baja de una unidad
+
+type RetireUnitInput struct {
+	Reason string  `json:"reason"`
+	Note   *string `json:"note,omitempty"`
+}
+
+// RetireUnitUseCase da de baja una unidad disponible o en mantenimiento. Una
+// unidad prestada se da de baja al devolverla como perdida o dañada.
+type RetireUnitUseCase struct {
+	repo domain.Repository
+}
+
+func NewRetireUnitUseCase(repo domain.Repository) *RetireUnitUseCase {
+	return &RetireUnitUseCase{repo: repo}
+}
+
+func (uc *RetireUnitUseCase) Execute(ctx context.Context, unitID uuid.UUID, in RetireUnitInput) (domain.MaterialUnit, *validation.Errors, error) {
+	validation := validation.New()
+	reason := strings.ToLower(strings.TrimSpace(in.Reason))
+	if reason == "" {
+		validation.Add("reason", "required")
+	} else if !slices.Contains(domain.WriteOffReasons, reason) {
+		validation.Add("reason", "must_be_one_of_"+strings.Join(domain.WriteOffReasons, "|"))
+	}
+	if !validation.Empty() {
+		return domain.MaterialUnit{}, validation, domain.ErrValidation
+	}
+
+	now := time.Now().UTC()
+	err := uc.repo.WithinTx(ctx, func(tx domain.Repository) error {
+		unit, err := lockUnit(ctx, tx, unitID)
+		if err != nil {
+			return err
+		}
+
+		mv := domain.NewMovement(unit.MaterialID, domain.MovementWriteOff, 1, now)
+		switch unit.Status {
+		case domain.UnitAvailable:
+		case domain.UnitMaintenance:
+			// ya no estaba disponible: la baja solo descuenta del total
+			mv.AvailableDelta = 0
+			open, found, err := tx.OpenMaintenance(ctx, unit.ID)
+			if err != nil {
+				return err
+			}
+			if found {
+				open.CompletedAt = &now
+				if err := tx.CompleteMaintenance(ctx, open); err != nil {
+					return err
+				}
+			}
+		default:
+			validation.Add("unit_id", "not_available")
+			return domain.ErrUnitNotAvailable
+		}
+
+		if err := tx.SetUnitStatus(ctx, []uuid.UUID{unit.ID}, domain.UnitRetired, now); err != nil {
+			return err
+		}
+		mv.UnitID = &unit.ID
+		mv.Reason = &reason
+		if in.Note != nil {
+			mv.Note = trimmedPtr(*in.Note)
+		}
+		return tx.ApplyMovement(ctx, mv)
+	})
+	if err != nil {
+		if validation.Empty() {
+			return domain.MaterialUnit{}, nil, err
+		}
+		return domain.MaterialUnit{}, validation, err
+	}
+
+	out, _, err := uc.repo.GetUnitByID(ctx, unitID)
+	if err != nil {
+		return domain.MaterialUnit{}, nil, err
+	}
+	return out, nil, nil
+}
+
+// ---------- Mantenimiento
+
+// MaintenanceInput: con out_of_service la unidad queda en mantenimiento (no se
+// presta) hasta completarlo; sin él el registro queda cerrado en el momento.
+type MaintenanceInput struct {
+	Kind         string  `json:"kind"`
+	Description  *string `json:"description,omitempty"`
+	CostCents    *int64  `json:"cost_cents,omitempty"`
+	OutOfService bool    `json:"out_of_service"`
+}
+
+type RegisterMaintenanceUseCase struct {
+	repo domain.Repository
+}
+
+func NewRegisterMaintenanceUseCase(repo domain.Repository) *RegisterMaintenanceUseCase {
+	return &RegisterMaintenanceUseCase{repo: repo}
+}
+
+func (uc *RegisterMaintenanceUseCase) Execute(ctx context.Context, unitID uuid.UUID, in MaintenanceInput) (domain.MaintenanceRecord, *validation.Errors, error) {
+	validation := validation.New()
+	kind := strings.ToLower(strings.TrimSpace(in.Kind))
+	if kind == "" {
+		validation.Add("kind", "required")
+	} else if !slices.Contains(domain.MaintenanceKinds, kind) {
+		validation.Add("kind", "must_be_one_of_"+strings.Join(domain.MaintenanceKinds, "|"))
+	}
+	if in.CostCents != nil && *in.CostCents < 0 {
+		validation.Add("cost_cents", "must_be_>=_0")
+	}
+	if !validation.Empty() {
+		return domain.MaintenanceRecord{}, validation, domain.ErrValidation
+	}
+
+	now := time.Now().UTC()
+	rec := domain.MaintenanceRecord{
+		ID:           uuid.New(),
+		UnitID:       unitID,
+		Kind:         kind,
+		CostCents:    in.CostCents,
+		OutOfService: in.OutOfService,
+		StartedAt:    now,
+		CreatedAt:    now,
+	}
+	if in.Description != nil {
+		rec.Description = trimmedPtr(*in.Description)
+	}
+	if !in.OutOfService {
+		rec.CompletedAt = &now
+	}
+
+	var out domain.MaintenanceRecord
+	err := uc.repo.WithinTx(ctx, func(tx domain.Repository) error {
+		unit, err := lockUnit(ctx, tx, unitID)
+		if err != nil {
+			return err
+		}
+		// para sacarla de servicio tiene que estar disponible; un registro
+		// suelto (inspección, limpieza) se admite mientras no esté prestada ni retirada
+		if unit.Status != domain.UnitAvailable && (in.OutOfService || unit.Status != domain.UnitMaintenance) {
+			validation.Add("unit_id", "not_available")
+			return domain.ErrUnitNotAvailable
+		}
+
+		created, err := tx.CreateMaintenance(ctx, rec)
+		if err != nil {
+			return err
+		}
+		out = created
+		if !in.OutOfService {
+			return nil
+		}
+
+		if err := tx.SetUnitStatus(ctx, []uuid.UUID{unit.ID}, domain.UnitMaintenance, now); err != nil {
+			return err
+		}
+		mv := domain.NewMovement(unit.MaterialID, domain.MovementMaintenanceOut, 1, now)
+		mv.UnitID = &unit.ID
+		mv.Reason = &kind
+		mv.Note = rec.Description
+		return tx.ApplyMovement(ctx, mv)
+	})
+	if err != nil {
+		if validation.Empty() {
+			return domain.MaintenanceRecord{}, nil, err
+		}
+		return domain.MaintenanceRecord{}, validation, err
+	}
+	return out, nil, nil
+}
+
+// CompleteMaintenanceInput: costo y descripción finales (opcionales; reemplazan los cargados al inicio).
+type CompleteMaintenanceInput struct {
+	Description *string `json:"description,omitempty"`
+	CostCents   *int64  `json:"cost_cents,omitempty"`
+}
+
+// CompleteMaintenanceUseCase cierra un mantenimiento y devuelve la unidad al stock disponible.
+type CompleteMaintenanceUseCase struct {
+	repo domain.Repository
+}
+
+func NewCompleteMaintenanceUseCase(repo domain.Repository) *CompleteMaintenanceUseCase {
+	return &CompleteMaintenanceUseCase{repo: repo}
+}
+
+func (uc *CompleteMaintenanceUseCase) Execute(ctx context.Context, unitID, maintenanceID uuid.UUID, in CompleteMaintenanceInput) (domain.MaintenanceRecord, *validation.Errors, error) {
+	validation := validation.New()
+	if in.CostCents != nil && *in.CostCents < 0 {
+		validation.Add("cost_cents", "must_be_>=_0")
+		return domain.MaintenanceRecord{}, validation, domain.ErrValidation
+	}
+
+	now := time.Now().UTC()
+	var out domain.MaintenanceRecord
+	err := uc.repo.WithinTx(ctx, func(tx domain.Repository) error {
+		unit, err := lockUnit(ctx, tx, unitID)
+		if err != nil {
+			return err
+		}
+		rec, found, err := tx.GetMaintenanceForUpdate(ctx, maintenanceID)
+		if err != nil {
+			return err
+		}
+		if !found || rec.UnitID != unit.ID {
+			return domain.ErrNotFound
+		}
+		if rec.CompletedAt != nil {
+			return domain.ErrMaintenanceCompleted
+		}
+
+		if in.Description != nil {
+			rec.Description = trimmedPtr(*in.Description)
+		}
+		if in.CostCents != nil {
+			rec.CostCents = in.CostCents
+		}
+		rec.CompletedAt = &now
+		if err := tx.CompleteMaintenance(ctx, rec); err != nil {
+			return err
+		}
+		out = rec
+		if !rec.OutOfService || unit.Status != domain.UnitMaintenance {
+			return nil
+		}
+
+		if err := tx.SetUnitStatus(ctx, []uuid.UUID{unit.ID}, domain.UnitAvailable, now); err != nil {
+			return err
+		}
+		mv := domain.NewMovement(unit.MaterialID, domain.MovementMaintenanceIn, 1, now)
+		mv.UnitID = &unit.ID
+		mv.Reason = &rec.Kind
+		return tx.ApplyMovement(ctx, mv)
+	})
+	if err != nil {
+		return domain.MaintenanceRecord{}, nil, err
+	}
+	return out, nil, nil
+}
+
+type ListMaintenanceUseCase struct {
+	repo domain.Repository
+}
+
+func NewListMaintenanceUseCase(repo domain.Repository) *ListMaintenanceUseCase {
+	return &ListMaintenanceUseCase{repo: repo}
+}
+
+func (uc *ListMaintenanceUseCase) Execute(ctx context.Context, unitID uuid.UUID) ([]domain.MaintenanceRecord, error) {
+	_, found, err := uc.repo.GetUnitByID(ctx, unitID)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, domain.ErrNotFound
+	}
+	return uc.repo.ListMaintenance(ctx, unitID)
+}
+
+// lockUnit bloquea el material y después la unidad, en el mismo orden que un
+// préstamo, para que dos operaciones sobre la misma unidad no se crucen.
+func lockUnit(ctx context.Context, tx domain.Repository, unitID uuid.UUID) (domain.MaterialUnit, error) {
+	unit, found, err := tx.GetUnitByID(ctx, unitID)
+	if err != nil {
+		return domain.MaterialUnit{}, err
+	}
+	if !found {
+		return domain.MaterialUnit{}, domain.ErrNotFound
+	}
+	if _, _, err := tx.GetMaterialForUpdate(ctx, unit.MaterialID); err != nil {
+		return domain.MaterialUnit{}, err
+	}
+	unit, _, err = tx.GetUnitForUpdate(ctx, unitID)
+	if err != nil {
+		return domain.MaterialUnit{}, err
+	}
+	return unit, nil
+}
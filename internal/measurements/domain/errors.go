@@ -0,0 +1,12 @@
+package domain
+
+import "errors"
+
+var (
+	ErrValidation = errors.New("validation_error")
+	ErrNotFound   = errors.New("not_found")
+
+	// Mismos códigos que el módulo de evoluciones: solo se mide sobre el borrador vigente.
+	ErrEvolutionSigned    = errors.New("evolution_signed")
+	ErrEvolutionNotLatest = errors.New("not_latest_version")
+)
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Kind string
+
+const (
+	KindGoniometry    Kind = "goniometry"
+	KindStrength      Kind = "strength"
+	KindQuestionnaire Kind = "questionnaire"
+)
+
+type Side string
+
+const (
+	SideLeft  Side = "left"
+	SideRight Side = "right"
+	SideNone  Side = "none" // columna / mediciones sin lateralidad
+)
+
+func (s Side) Valid() bool {
+	return s == SideLeft || s == SideRight || s == SideNone
+}
+
+// Movimientos admitidos por articulación (goniometría).
+var JointMovements = map[string][]string{
+	"shoulder":       {"flexion", "extension", "abduction", "adduction", "internal_rotation", "external_rotation"},
+	"elbow":          {"flexion", "extension", "pronation", "supination"},
+	"wrist":          {"flexion", "extension", "radial_deviation", "ulnar_deviation"},
+	"hip":            {"flexion", "extension", "abduction", "adduction", "internal_rotation", "external_rotation"},
+	"knee":           {"flexion", "extension"},
+	"ankle":          {"dorsiflexion", "plantarflexion", "inversion", "eversion"},
+	"cervical_spine": {"flexion", "extension", "lateral_flexion", "rotation"},
+	"lumbar_spine":   {"flexion", "extension", "lateral_flexion", "rotation"},
+}
+
+func ValidMovement(joint, movement string) bool {
+	for _, m := range JointMovements[joint] {
+		if m == movement {
+			return true
+		}
+	}
+	return false
+}
+
+// Rango aceptado en grados (permite negativos para déficit de extensión).
+const (
+	MinDegrees = -45
+	MaxDegrees = 200
+)
+
+// Measurement es una medición objetiva tomada en una evolución.
+// Metric identifica la serie: rom, mrc o el código del cuestionario.
+type Measurement struct {
+	ID          uuid.UUID
+	EvolutionID uuid.UUID
+	PatientID   uuid.UUID
+	Kind        Kind
+	Metric      string
+
+	// Goniometría
+	Joint    *string
+	Movement *string
+	// Fuerza (escala MRC 0-5)
+	Muscle *string
+
+	Side *Side
+
+	// Cuestionarios
+	Answers   []*int
+	Subscores map[string]float64
+
+	// Valor principal: grados, grado MRC o puntaje del cuestionario (nil en KOOS, que solo tiene subescalas)
+	Value *float64
+	Notes *string
+
+	MeasuredAt time.Time
+	CreatedAt  time.Time
+}
+
+// SeriesQuery filtra la serie temporal de una métrica.
+type SeriesQuery struct {
+	PatientID uuid.UUID
+	Metric    string
+	Joint     *string
+	Movement  *string
+	Muscle    *string
+	Side      *Side
+	Subscale  *string // para KOOS
+	From      *time.Time
+	To        *time.Time
+}
+
+type SeriesPoint struct {
+	MeasuredAt    time.Time
+	Value         float64
+	EvolutionID   uuid.UUID
+	MeasurementID uuid.UUID
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	CreateBatch(ctx context.Context, ms []Measurement) ([]Measurement, error)
+	ListByEvolution(ctx context.Context, evolutionID uuid.UUID) ([]Measurement, error)
+	Series(ctx context.Context, q SeriesQuery) ([]SeriesPoint, error)
+}
+
+// EvolutionInfo: lo que hace falta de la evolución para adjuntarle mediciones.
+type EvolutionInfo struct {
+	PatientID uuid.UUID
+	CreatedAt time.Time
+	Signed    bool
+	IsLatest  bool
+}
+
+// Evolutions resuelve paciente, fecha y estado de la evolución a la que se adjuntan las mediciones.
+type Evolutions interface {
+	EvolutionInfo(ctx context.Context, evolutionID uuid.UUID) (EvolutionInfo, bool, error)
+}
@@ -0,0 +1,169 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/measurements/domain"
+	"github.com/javiacuna/kinesio-backend/internal/measurements/usecase"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+type Handler struct {
+	addUC    *usecase.AddMeasurementsUseCase
+	listUC   *usecase.ListMeasurementsByEvolutionUseCase
+	seriesUC *usecase.GetSeriesUseCase
+}
+
+func NewHandler(addUC *usecase.AddMeasurementsUseCase, listUC *usecase.ListMeasurementsByEvolutionUseCase, seriesUC *usecase.GetSeriesUseCase) *Handler {
+	return &Handler{addUC: addUC, listUC: listUC, seriesUC: seriesUC}
+}
+
+type addMeasurementsRequest struct {
+	Measurements []usecase.MeasurementInput `json:"measurements"`
+}
+
+type measurementResponse struct {
+	ID          string             `json:"id"`
+	EvolutionID string             `json:"evolution_id"`
+	PatientID   string             `json:"patient_id"`
+	Kind        string             `json:"kind"`
+	Metric      string             `json:"metric"`
+	Joint       *string            `json:"joint,omitempty"`
+	Movement    *string            `json:"movement,omitempty"`
+	Muscle      *string            `json:"muscle,omitempty"`
+	Side        *string            `json:"side,omitempty"`
+	Value       *float64           `json:"value,omitempty"`
+	Subscores   map[string]float64 `json:"subscores,omitempty"`
+	Answers     []*int             `json:"answers,omitempty"`
+	Notes       *string            `json:"notes,omitempty"`
+	MeasuredAt  string             `json:"measured_at"`
+}
+
+type seriesPointResponse struct {
+	MeasuredAt    string  `json:"measured_at"`
+	Value         float64 `json:"value"`
+	EvolutionID   string  `json:"evolution_id"`
+	MeasurementID string  `json:"measurement_id"`
+}
+
+func (h *Handler) AddToEvolution(c *gin.Context) {
+	var req addMeasurementsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	items, validation, err := h.addUC.Execute(c.Request.Context(), c.Param("evolution_id"), req.Measurements)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+
+	out := make([]measurementResponse, 0, len(items))
+	for _, m := range items {
+		out = append(out, toResponse(m))
+	}
+	c.JSON(http.StatusCreated, out)
+}
+
+func (h *Handler) ListByEvolution(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("evolution_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_evolution_id"})
+		return
+	}
+
+	items, err := h.listUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	out := make([]measurementResponse, 0, len(items))
+	for _, m := range items {
+		out = append(out, toResponse(m))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// Series: ?metric=rom&joint=knee&movement=flexion&side=right&from=2025-01-01&to=2025-06-30
+// metric: rom|mrc|oswestry|dash|koos|lysholm (koos requiere subscale).
+func (h *Handler) Series(c *gin.Context) {
+	points, validation, err := h.seriesUC.Execute(c.Request.Context(), c.Param("patient_id"), usecase.SeriesInput{
+		Metric:   c.Query("metric"),
+		Joint:    queryPtr(c, "joint"),
+		Movement: queryPtr(c, "movement"),
+		Muscle:   queryPtr(c, "muscle"),
+		Side:     queryPtr(c, "side"),
+		Subscale: queryPtr(c, "subscale"),
+		From:     queryPtr(c, "from"),
+		To:       queryPtr(c, "to"),
+	})
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+
+	out := make([]seriesPointResponse, 0, len(points))
+	for _, p := range points {
+		out = append(out, seriesPointResponse{
+			MeasuredAt:    p.MeasuredAt.UTC().Format(time.RFC3339),
+			Value:         p.Value,
+			EvolutionID:   p.EvolutionID.String(),
+			MeasurementID: p.MeasurementID.String(),
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{"metric": c.Query("metric"), "points": out})
+}
+
+func writeError(c *gin.Context, err error, validation *validation.Errors) {
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+	case errors.Is(err, domain.ErrEvolutionSigned):
+		c.JSON(http.StatusConflict, gin.H{"error": "evolution_signed"})
+	case errors.Is(err, domain.ErrEvolutionNotLatest):
+		c.JSON(http.StatusConflict, gin.H{"error": "not_latest_version"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+	}
+}
+
+func toResponse(m domain.Measurement) measurementResponse {
+	var side *string
+	if m.Side != nil {
+		s := string(*m.Side)
+		side = &s
+	}
+	return measurementResponse{
+		ID:          m.ID.String(),
+		EvolutionID: m.EvolutionID.String(),
+		PatientID:   m.PatientID.String(),
+		Kind:        string(m.Kind),
+		Metric:      m.Metric,
+		Joint:       m.Joint,
+		Movement:    m.Movement,
+		Muscle:      m.Muscle,
+		Side:        side,
+		Value:       m.Value,
+		Subscores:   m.Subscores,
+		Answers:     m.Answers,
+		Notes:       m.Notes,
+		MeasuredAt:  m.MeasuredAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func queryPtr(c *gin.Context, key string) *string {
+	v, ok := c.GetQuery(key)
+	if !ok {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,34 @@
+package evolutions
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	evoDomain "github.com/javiacuna/kinesio-backend/internal/evolutions/domain"
+	"github.com/javiacuna/kinesio-backend/internal/measurements/domain"
+)
+
+var _ domain.Evolutions = (*Gateway)(nil)
+
+// Gateway lee la evolución desde el repo del módulo de evoluciones.
+type Gateway struct {
+	repo evoDomain.Repository
+}
+
+func NewGateway(repo evoDomain.Repository) *Gateway {
+	return &Gateway{repo: repo}
+}
+
+func (g *Gateway) EvolutionInfo(ctx context.Context, evolutionID uuid.UUID) (domain.EvolutionInfo, bool, error) {
+	e, found, err := g.repo.GetByID(ctx, evolutionID)
+	if err != nil || !found {
+		return domain.EvolutionInfo{}, false, err
+	}
+	return domain.EvolutionInfo{
+		PatientID: e.PatientID,
+		CreatedAt: e.CreatedAt,
+		Signed:    e.Status == evoDomain.StatusSigned,
+		IsLatest:  e.IsLatest,
+	}, true, nil
+}
@@ -0,0 +1,23 @@
+package gorm
+
+import "time"
+
+type MeasurementModel struct {
+	ID          string `gorm:"type:uuid;primaryKey"`
+	EvolutionID string `gorm:"type:uuid;not null"`
+	PatientID   string `gorm:"type:uuid;not null"`
+	Kind        string `gorm:"not null"`
+	Metric      string `gorm:"not null"`
+	Joint       *string
+	Movement    *string
+	Muscle      *string
+	Side        *string
+	Answers     *string `gorm:"type:jsonb"`
+	Subscores   *string `gorm:"type:jsonb"`
+	Value       *float64
+	Notes       *string
+	MeasuredAt  time.Time
+	CreatedAt   time.Time
+}
+
+func (MeasurementModel) TableName() string { return "evolution_measurements" }
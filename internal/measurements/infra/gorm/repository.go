@@ -0,0 +1,193 @@
+package gorm
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/javiacuna/kinesio-backend/internal/measurements/domain"
+)
+
+var _ domain.Repository = (*Repository)(nil)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) CreateBatch(ctx context.Context, ms []domain.Measurement) ([]domain.Measurement, error) {
+	models := make([]MeasurementModel, 0, len(ms))
+	for _, m := range ms {
+		model, err := toModel(m)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, model)
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(&models).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ms, nil
+}
+
+func (r *Repository) ListByEvolution(ctx context.Context, evolutionID uuid.UUID) ([]domain.Measurement, error) {
+	var ms []MeasurementModel
+	if err := r.db.WithContext(ctx).
+		Where("evolution_id = ?", evolutionID.String()).
+		Order("kind asc, metric asc, created_at asc").
+		Find(&ms).Error; err != nil {
+		return nil, err
+	}
+
+	out := make([]domain.Measurement, 0, len(ms))
+	for _, m := range ms {
+		d, err := toDomain(m)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+func (r *Repository) Series(ctx context.Context, q domain.SeriesQuery) ([]domain.SeriesPoint, error) {
+	tx := r.db.WithContext(ctx).Model(&MeasurementModel{}).
+		Where("patient_id = ? AND metric = ?", q.PatientID.String(), q.Metric)
+
+	// Las subescalas (KOOS) se guardan en subscores (jsonb).
+	if q.Subscale != nil {
+		tx = tx.Select("id, evolution_id, measured_at, (subscores ->> ?)::float8 AS value", *q.Subscale).
+			Where("subscores ->> ? IS NOT NULL", *q.Subscale)
+	} else {
+		tx = tx.Select("id, evolution_id, measured_at, value").
+			Where("value IS NOT NULL")
+	}
+	if q.Joint != nil {
+		tx = tx.Where("joint = ?", *q.Joint)
+	}
+	if q.Movement != nil {
+		tx = tx.Where("movement = ?", *q.Movement)
+	}
+	if q.Muscle != nil {
+		tx = tx.Where("muscle = ?", *q.Muscle)
+	}
+	if q.Side != nil {
+		tx = tx.Where("side = ?", string(*q.Side))
+	}
+	if q.From != nil {
+		tx = tx.Where("measured_at >= ?", *q.From)
+	}
+	if q.To != nil {
+		tx = tx.Where("measured_at < ?", *q.To)
+	}
+
+	var rows []struct {
+		ID          string
+		EvolutionID string
+		MeasuredAt  time.Time
+		Value       float64
+	}
+	if err := tx.Order("measured_at asc, created_at asc").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	out := make([]domain.SeriesPoint, 0, len(rows))
+	for _, rw := range rows {
+		out = append(out, domain.SeriesPoint{
+			MeasuredAt:    rw.MeasuredAt,
+			Value:         rw.Value,
+			EvolutionID:   uuid.MustParse(rw.EvolutionID),
+			MeasurementID: uuid.MustParse(rw.ID),
+		})
+	}
+	return out, nil
+}
+
+func toModel(m domain.Measurement) (MeasurementModel, error) {
+	answers, err := marshalOptional(len(m.Answers) > 0, m.Answers)
+	if err != nil {
+		return MeasurementModel{}, err
+	}
+	subscores, err := marshalOptional(len(m.Subscores) > 0, m.Subscores)
+	if err != nil {
+		return MeasurementModel{}, err
+	}
+
+	var side *string
+	if m.Side != nil {
+		s := string(*m.Side)
+		side = &s
+	}
+
+	return MeasurementModel{
+		ID:          m.ID.String(),
+		EvolutionID: m.EvolutionID.String(),
+		PatientID:   m.PatientID.String(),
+		Kind:        string(m.Kind),
+		Metric:      m.Metric,
+		Joint:       m.Joint,
+		Movement:    m.Movement,
+		Muscle:      m.Muscle,
+		Side:        side,
+		Answers:     answers,
+		Subscores:   subscores,
+		Value:       m.Value,
+		Notes:       m.Notes,
+		MeasuredAt:  m.MeasuredAt,
+		CreatedAt:   m.CreatedAt,
+	}, nil
+}
+
+func toDomain(m MeasurementModel) (domain.Measurement, error) {
+	out := domain.Measurement{
+		ID:          uuid.MustParse(m.ID),
+		EvolutionID: uuid.MustParse(m.EvolutionID),
+		PatientID:   uuid.MustParse(m.PatientID),
+		Kind:        domain.Kind(m.Kind),
+		Metric:      m.Metric,
+		Joint:       m.Joint,
+		Movement:    m.Movement,
+		Muscle:      m.Muscle,
+		Value:       m.Value,
+		Notes:       m.Notes,
+		MeasuredAt:  m.MeasuredAt,
+		CreatedAt:   m.CreatedAt,
+	}
+	if m.Side != nil {
+		side := domain.Side(*m.Side)
+		out.Side = &side
+	}
+	if m.Answers != nil {
+		if err := json.Unmarshal([]byte(*m.Answers), &out.Answers); err != nil {
+			return domain.Measurement{}, err
+		}
+	}
+	if m.Subscores != nil {
+		if err := json.Unmarshal([]byte(*m.Subscores), &out.Subscores); err != nil {
+			return domain.Measurement{}, err
+		}
+	}
+	return out, nil
+}
+
+func marshalOptional(present bool, v any) (*string, error) {
+	if !present {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	s := string(b)
+	return &s, nil
+}
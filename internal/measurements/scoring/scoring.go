@@ -0,0 +1,190 @@
+// Package scoring calcula el puntaje de cuestionarios funcionales validados.
+// No tiene dependencias: recibe las respuestas tal cual las carga el profesional.
+package scoring
+
+import (
+	"fmt"
+	"math"
+)
+
+type Questionnaire string
+
+const (
+	Oswestry Questionnaire = "oswestry"
+	DASH     Questionnaire = "dash"
+	KOOS     Questionnaire = "koos"
+	Lysholm  Questionnaire = "lysholm"
+)
+
+func (q Questionnaire) Valid() bool {
+	switch q {
+	case Oswestry, DASH, KOOS, Lysholm:
+		return true
+	}
+	return false
+}
+
+// Result: Score es el puntaje principal (KOOS no tiene total, se informa por subescala).
+type Result struct {
+	Score     *float64
+	Subscores map[string]float64
+	// HigherIsBetter indica la dirección de la escala (Oswestry/DASH: más alto = más discapacidad).
+	HigherIsBetter bool
+}
+
+// AnswerError indica qué respuesta es inválida. Index -1 = error sobre el conjunto.
+type AnswerError struct {
+	Index int
+	Code  string
+}
+
+func (e *AnswerError) Error() string {
+	if e.Index < 0 {
+		return e.Code
+	}
+	return fmt.Sprintf("answers[%d]: %s", e.Index, e.Code)
+}
+
+// Score valida y puntúa. answers admite nil para ítems sin responder.
+func Score(q Questionnaire, answers []*int) (Result, error) {
+	switch q {
+	case Oswestry:
+		return scoreOswestry(answers)
+	case DASH:
+		return scoreDASH(answers)
+	case KOOS:
+		return scoreKOOS(answers)
+	case Lysholm:
+		return scoreLysholm(answers)
+	}
+	return Result{}, &AnswerError{Index: -1, Code: "unknown_questionnaire"}
+}
+
+// Oswestry (ODI): 10 secciones de 0 a 5. Puntaje = suma / (5 * respondidas) * 100.
+// Se tolera una sección sin responder (criterio habitual del ODI).
+func scoreOswestry(answers []*int) (Result, error) {
+	if len(answers) != 10 {
+		return Result{}, &AnswerError{Index: -1, Code: "must_have_10_answers"}
+	}
+	sum, n, err := sumAnswered(answers, 0, 5)
+	if err != nil {
+		return Result{}, err
+	}
+	if n < 9 {
+		return Result{}, &AnswerError{Index: -1, Code: "at_most_1_unanswered"}
+	}
+	score := round1(float64(sum) / float64(5*n) * 100)
+	return Result{Score: &score}, nil
+}
+
+// DASH: 30 ítems de 1 a 5. Puntaje = ((suma / respondidas) - 1) * 25.
+// No se puede calcular con más de 3 ítems sin responder.
+func scoreDASH(answers []*int) (Result, error) {
+	if len(answers) != 30 {
+		return Result{}, &AnswerError{Index: -1, Code: "must_have_30_answers"}
+	}
+	sum, n, err := sumAnswered(answers, 1, 5)
+	if err != nil {
+		return Result{}, err
+	}
+	if n < 27 {
+		return Result{}, &AnswerError{Index: -1, Code: "at_most_3_unanswered"}
+	}
+	score := round1((float64(sum)/float64(n) - 1) * 25)
+	return Result{Score: &score}, nil
+}
+
+// Subescalas del KOOS en el orden del cuestionario (42 ítems de 0 a 4).
+var koosSubscales = []struct {
+	name  string
+	items int
+}{
+	{"symptoms", 7}, // S1-S7
+	{"pain", 9},     // P1-P9
+	{"adl", 17},     // A1-A17
+	{"sport_rec", 5},
+	{"qol", 4},
+}
+
+// KOOS: cada subescala = 100 - (media * 100 / 4). 100 = sin síntomas.
+// Una subescala necesita al menos la mitad de sus ítems respondidos.
+func scoreKOOS(answers []*int) (Result, error) {
+	if len(answers) != 42 {
+		return Result{}, &AnswerError{Index: -1, Code: "must_have_42_answers"}
+	}
+	subs := map[string]float64{}
+	offset := 0
+	for _, s := range koosSubscales {
+		part := answers[offset : offset+s.items]
+		sum, n, err := sumAnswered(part, 0, 4)
+		if err != nil {
+			ae := err.(*AnswerError)
+			ae.Index += offset
+			return Result{}, ae
+		}
+		if n*2 < s.items {
+			return Result{}, &AnswerError{Index: -1, Code: "too_many_unanswered_in_" + s.name}
+		}
+		subs[s.name] = round1(100 - float64(sum)/float64(n)*100/4)
+		offset += s.items
+	}
+	return Result{Subscores: subs, HigherIsBetter: true}, nil
+}
+
+// Puntajes posibles por ítem del Lysholm (cojera, apoyo, bloqueo, inestabilidad,
+// dolor, tumefacción, subir escaleras, cuclillas). Total 0-100.
+var lysholmItems = [][]int{
+	{0, 3, 5},
+	{0, 2, 5},
+	{0, 2, 6, 10, 15},
+	{0, 5, 10, 15, 20, 25},
+	{0, 5, 10, 15, 20, 25},
+	{0, 2, 6, 10},
+	{0, 2, 6, 10},
+	{0, 2, 4, 5},
+}
+
+func scoreLysholm(answers []*int) (Result, error) {
+	if len(answers) != len(lysholmItems) {
+		return Result{}, &AnswerError{Index: -1, Code: "must_have_8_answers"}
+	}
+	sum := 0
+	for i, a := range answers {
+		if a == nil {
+			return Result{}, &AnswerError{Index: i, Code: "required"}
+		}
+		if !containsInt(lysholmItems[i], *a) {
+			return Result{}, &AnswerError{Index: i, Code: fmt.Sprintf("must_be_one_of_%v", lysholmItems[i])}
+		}
+		sum += *a
+	}
+	score := float64(sum)
+	return Result{Score: &score, HigherIsBetter: true}, nil
+}
+
+func sumAnswered(answers []*int, min, max int) (sum, n int, err error) {
+	for i, a := range answers {
+		if a == nil {
+			continue
+		}
+		if *a < min || *a > max {
+			return 0, 0, &AnswerError{Index: i, Code: fmt.Sprintf("must_be_between_%d_and_%d", min, max)}
+		}
+		sum += *a
+		n++
+	}
+	return sum, n, nil
+}
+
+func containsInt(xs []int, v int) bool {
+	for _, x := range xs {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,206 @@
+package scoring
+
+import (
+	"errors"
+	"testing"
+)
+
+// answers arma las respuestas; -1 = ítem sin responder.
+func answers(vals ...int) []*int {
+	out := make([]*int, len(vals))
+	for i, v := range vals {
+		if v >= 0 {
+			v := v
+			out[i] = &v
+		}
+	}
+	return out
+}
+
+func repeat(v, n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = v
+	}
+	return out
+}
+
+func with(vals []int, idx []int, v int) []int {
+	out := append([]int(nil), vals...)
+	for _, i := range idx {
+		out[i] = v
+	}
+	return out
+}
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name      string
+		q         Questionnaire
+		answers   []int
+		wantScore float64
+		wantErr   *AnswerError
+	}{
+		{name: "odi: todo 0", q: Oswestry, answers: repeat(0, 10), wantScore: 0},
+		{name: "odi: todo 5", q: Oswestry, answers: repeat(5, 10), wantScore: 100},
+		{name: "odi: suma sobre 50", q: Oswestry, answers: []int{5, 4, 3, 2, 1, 0, 0, 1, 2, 3}, wantScore: 42},
+		{name: "odi: una sin responder cambia el denominador", q: Oswestry, answers: with(repeat(2, 10), []int{9}, -1), wantScore: 40},
+		{name: "odi: dos sin responder", q: Oswestry, answers: with(repeat(2, 10), []int{0, 9}, -1), wantErr: &AnswerError{Index: -1, Code: "at_most_1_unanswered"}},
+		{name: "odi: fuera de rango", q: Oswestry, answers: with(repeat(2, 10), []int{4}, 6), wantErr: &AnswerError{Index: 4, Code: "must_be_between_0_and_5"}},
+		{name: "odi: cantidad", q: Oswestry, answers: repeat(2, 9), wantErr: &AnswerError{Index: -1, Code: "must_have_10_answers"}},
+
+		{name: "dash: todo 1", q: DASH, answers: repeat(1, 30), wantScore: 0},
+		{name: "dash: todo 5", q: DASH, answers: repeat(5, 30), wantScore: 100},
+		{name: "dash: media 3", q: DASH, answers: repeat(3, 30), wantScore: 50},
+		{name: "dash: redondeo", q: DASH, answers: with(repeat(2, 30), []int{0}, 3), wantScore: 25.8},
+		{name: "dash: tres sin responder", q: DASH, answers: with(repeat(2, 30), []int{0, 10, 29}, -1), wantScore: 25},
+		{name: "dash: cuatro sin responder", q: DASH, answers: with(repeat(2, 30), []int{0, 1, 2, 3}, -1), wantErr: &AnswerError{Index: -1, Code: "at_most_3_unanswered"}},
+		{name: "dash: 0 no es válido", q: DASH, answers: with(repeat(2, 30), []int{17}, 0), wantErr: &AnswerError{Index: 17, Code: "must_be_between_1_and_5"}},
+		{name: "dash: cantidad", q: DASH, answers: repeat(2, 31), wantErr: &AnswerError{Index: -1, Code: "must_have_30_answers"}},
+
+		{name: "lysholm: máximo", q: Lysholm, answers: []int{5, 5, 15, 25, 25, 10, 10, 5}, wantScore: 100},
+		{name: "lysholm: mínimo", q: Lysholm, answers: repeat(0, 8), wantScore: 0},
+		{name: "lysholm: valores intermedios", q: Lysholm, answers: []int{3, 2, 6, 20, 15, 2, 6, 4}, wantScore: 58},
+		{name: "lysholm: valor no permitido", q: Lysholm, answers: []int{4, 5, 15, 25, 25, 10, 10, 5}, wantErr: &AnswerError{Index: 0, Code: "must_be_one_of_[0 3 5]"}},
+		{name: "lysholm: valor de otro ítem", q: Lysholm, answers: []int{5, 5, 15, 25, 25, 10, 10, 6}, wantErr: &AnswerError{Index: 7, Code: "must_be_one_of_[0 2 4 5]"}},
+		{name: "lysholm: sin responder", q: Lysholm, answers: []int{5, 5, -1, 25, 25, 10, 10, 5}, wantErr: &AnswerError{Index: 2, Code: "required"}},
+		{name: "lysholm: cantidad", q: Lysholm, answers: repeat(0, 7), wantErr: &AnswerError{Index: -1, Code: "must_have_8_answers"}},
+
+		{name: "desconocido", q: "sf36", answers: repeat(0, 3), wantErr: &AnswerError{Index: -1, Code: "unknown_questionnaire"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Score(tt.q, answers(tt.answers...))
+			if tt.wantErr != nil {
+				assertAnswerError(t, err, *tt.wantErr)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Score == nil || *got.Score != tt.wantScore {
+				t.Fatalf("score = %v, want %v", got.Score, tt.wantScore)
+			}
+			if want := tt.q == Lysholm; got.HigherIsBetter != want {
+				t.Fatalf("higher is better = %v, want %v", got.HigherIsBetter, want)
+			}
+		})
+	}
+}
+
+func TestScoreKOOS(t *testing.T) {
+	// Offsets: symptoms 0-6, pain 7-15, adl 16-32, sport_rec 33-37, qol 38-41.
+	tests := []struct {
+		name    string
+		answers []int
+		want    map[string]float64
+		wantErr *AnswerError
+	}{
+		{
+			name:    "sin síntomas",
+			answers: repeat(0, 42),
+			want:    map[string]float64{"symptoms": 100, "pain": 100, "adl": 100, "sport_rec": 100, "qol": 100},
+		},
+		{
+			name:    "máximo en todo",
+			answers: repeat(4, 42),
+			want:    map[string]float64{"symptoms": 0, "pain": 0, "adl": 0, "sport_rec": 0, "qol": 0},
+		},
+		{
+			name:    "cada subescala con su media",
+			answers: append(append(append(append(repeat(2, 7), repeat(1, 9)...), repeat(3, 17)...), repeat(4, 5)...), 0, 1, 2, 3),
+			want:    map[string]float64{"symptoms": 50, "pain": 75, "adl": 25, "sport_rec": 0, "qol": 62.5},
+		},
+		{
+			name:    "la mitad de una subescala alcanza",
+			answers: with(with(repeat(0, 42), []int{0, 1, 2}, -1), []int{3, 4, 5, 6}, 2),
+			want:    map[string]float64{"symptoms": 50, "pain": 100, "adl": 100, "sport_rec": 100, "qol": 100},
+		},
+		{
+			name:    "calidad de vida con dos de cuatro",
+			answers: with(repeat(0, 42), []int{38, 39}, -1),
+			want:    map[string]float64{"symptoms": 100, "pain": 100, "adl": 100, "sport_rec": 100, "qol": 100},
+		},
+		{
+			name:    "menos de la mitad en síntomas",
+			answers: with(repeat(0, 42), []int{0, 1, 2, 3}, -1),
+			wantErr: &AnswerError{Index: -1, Code: "too_many_unanswered_in_symptoms"},
+		},
+		{
+			name:    "menos de la mitad en dolor",
+			answers: with(repeat(0, 42), []int{7, 8, 9, 10, 11}, -1),
+			wantErr: &AnswerError{Index: -1, Code: "too_many_unanswered_in_pain"},
+		},
+		{
+			name:    "menos de la mitad en deporte",
+			answers: with(repeat(0, 42), []int{33, 34, 35}, -1),
+			wantErr: &AnswerError{Index: -1, Code: "too_many_unanswered_in_sport_rec"},
+		},
+		{
+			name:    "índice inválido en síntomas",
+			answers: with(repeat(0, 42), []int{6}, 5),
+			wantErr: &AnswerError{Index: 6, Code: "must_be_between_0_and_4"},
+		},
+		{
+			name:    "índice inválido en dolor se corre 7",
+			answers: with(repeat(0, 42), []int{7}, 5),
+			wantErr: &AnswerError{Index: 7, Code: "must_be_between_0_and_4"},
+		},
+		{
+			name:    "índice inválido en actividades diarias se corre 16",
+			answers: with(repeat(0, 42), []int{18}, 5),
+			wantErr: &AnswerError{Index: 18, Code: "must_be_between_0_and_4"},
+		},
+		{
+			name:    "índice inválido en deporte se corre 33",
+			answers: with(repeat(0, 42), []int{37}, 9),
+			wantErr: &AnswerError{Index: 37, Code: "must_be_between_0_and_4"},
+		},
+		{
+			name:    "índice inválido en calidad de vida se corre 38",
+			answers: with(repeat(0, 42), []int{41}, 5),
+			wantErr: &AnswerError{Index: 41, Code: "must_be_between_0_and_4"},
+		},
+		{
+			name:    "cantidad",
+			answers: repeat(0, 41),
+			wantErr: &AnswerError{Index: -1, Code: "must_have_42_answers"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Score(KOOS, answers(tt.answers...))
+			if tt.wantErr != nil {
+				assertAnswerError(t, err, *tt.wantErr)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Score != nil || !got.HigherIsBetter {
+				t.Fatalf("KOOS result = %+v, want subscores only and higher is better", got)
+			}
+			if len(got.Subscores) != len(tt.want) {
+				t.Fatalf("subscores = %v, want %v", got.Subscores, tt.want)
+			}
+			for name, want := range tt.want {
+				if got.Subscores[name] != want {
+					t.Fatalf("%s = %v, want %v (all: %v)", name, got.Subscores[name], want, got.Subscores)
+				}
+			}
+		})
+	}
+}
+
+func assertAnswerError(t *testing.T, err error, want AnswerError) {
+	t.Helper()
+	var ae *AnswerError
+	if !errors.As(err, &ae) {
+		t.Fatalf("err = %v, want AnswerError %+v", err, want)
+	}
+	if *ae != want {
+		t.Fatalf("err = %+v, want %+v", *ae, want)
+	}
+}
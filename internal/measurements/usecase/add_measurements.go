@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/measurements/domain"
+	"github.com/javiacuna/kinesio-backend/internal/measurements/scoring"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+const maxMeasurementsPerRequest = 100
+
+type MeasurementInput struct {
+	Kind  string  `json:"kind"` // goniometry|strength|questionnaire
+	Side  *string `json:"side"` // left|right|none
+	Notes *string `json:"notes"`
+
+	// goniometry
+	Joint    *string  `json:"joint"`
+	Movement *string  `json:"movement"`
+	Degrees  *float64 `json:"degrees"`
+
+	// strength (MRC)
+	Muscle *string `json:"muscle"`
+	Grade  *int    `json:"grade"`
+
+	// questionnaire
+	Questionnaire *string `json:"questionnaire"`
+	Answers       []*int  `json:"answers"`
+}
+
+type AddMeasurementsUseCase struct {
+	repo       domain.Repository
+	evolutions domain.Evolutions
+}
+
+func NewAddMeasurementsUseCase(repo domain.Repository, evolutions domain.Evolutions) *AddMeasurementsUseCase {
+	return &AddMeasurementsUseCase{repo: repo, evolutions: evolutions}
+}
+
+func (uc *AddMeasurementsUseCase) Execute(ctx context.Context, evolutionID string, items []MeasurementInput) ([]domain.Measurement, *validation.Errors, error) {
+	validation := validation.New()
+
+	eid, err := uuid.Parse(strings.TrimSpace(evolutionID))
+	if err != nil {
+		validation.Add("evolution_id", "invalid_uuid")
+		return nil, validation, domain.ErrValidation
+	}
+
+	switch {
+	case len(items) == 0:
+		validation.Add("measurements", "required")
+	case len(items) > maxMeasurementsPerRequest:
+		validation.Add("measurements", fmt.Sprintf("max_%d_items", maxMeasurementsPerRequest))
+	}
+	if !validation.Empty() {
+		return nil, validation, domain.ErrValidation
+	}
+
+	evo, found, err := uc.evolutions.EvolutionInfo(ctx, eid)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !found {
+		return nil, nil, domain.ErrNotFound
+	}
+	// Las mediciones son parte de la evolución: una vez firmada o reemplazada no se agregan más.
+	switch {
+	case evo.Signed:
+		return nil, nil, domain.ErrEvolutionSigned
+	case !evo.IsLatest:
+		return nil, nil, domain.ErrEvolutionNotLatest
+	}
+
+	now := time.Now().UTC()
+	ms := make([]domain.Measurement, 0, len(items))
+	for i, in := range items {
+		m := domain.Measurement{
+			ID:          uuid.New(),
+			EvolutionID: eid,
+			PatientID:   evo.PatientID,
+			Notes:       trimPtr(in.Notes),
+			MeasuredAt:  evo.CreatedAt,
+			CreatedAt:   now,
+		}
+		in.apply(&m, fmt.Sprintf("measurements[%d].", i), validation)
+		ms = append(ms, m)
+	}
+	if !validation.Empty() {
+		return nil, validation, domain.ErrValidation
+	}
+
+	out, err := uc.repo.CreateBatch(ctx, ms)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, nil, nil
+}
+
+func (in MeasurementInput) apply(m *domain.Measurement, prefix string, validation *validation.Errors) {
+	m.Kind = domain.Kind(strings.TrimSpace(in.Kind))
+
+	switch m.Kind {
+	case domain.KindGoniometry:
+		m.Metric = "rom"
+		m.Joint = lowerPtr(in.Joint)
+		m.Movement = lowerPtr(in.Movement)
+		switch {
+		case m.Joint == nil:
+			validation.Add(prefix+"joint", "required")
+		case domain.JointMovements[*m.Joint] == nil:
+			validation.Add(prefix+"joint", "invalid_joint")
+		case m.Movement == nil:
+			validation.Add(prefix+"movement", "required")
+		case !domain.ValidMovement(*m.Joint, *m.Movement):
+			validation.Add(prefix+"movement", "invalid_movement_for_joint")
+		}
+		if in.Degrees == nil {
+			validation.Add(prefix+"degrees", "required")
+		} else if *in.Degrees < domain.MinDegrees || *in.Degrees > domain.MaxDegrees {
+			validation.Add(prefix+"degrees", fmt.Sprintf("must_be_between_%d_and_%d", domain.MinDegrees, domain.MaxDegrees))
+		}
+		m.Value = in.Degrees
+		m.Side = parseSide(in.Side, prefix, validation)
+
+	case domain.KindStrength:
+		m.Metric = "mrc"
+		m.Muscle = lowerPtr(in.Muscle)
+		if m.Muscle == nil {
+			validation.Add(prefix+"muscle", "required")
+		}
+		if in.Grade == nil {
+			validation.Add(prefix+"grade", "required")
+		} else if *in.Grade < 0 || *in.Grade > 5 {
+			validation.Add(prefix+"grade", "must_be_between_0_and_5")
+		} else {
+			v := float64(*in.Grade)
+			m.Value = &v
+		}
+		m.Side = parseSide(in.Side, prefix, validation)
+
+	case domain.KindQuestionnaire:
+		q := scoring.Questionnaire("")
+		if v := lowerPtr(in.Questionnaire); v != nil {
+			q = scoring.Questionnaire(*v)
+		}
+		if !q.Valid() {
+			validation.Add(prefix+"questionnaire", "must_be_oswestry_dash_koos_or_lysholm")
+			return
+		}
+		m.Metric = string(q)
+		m.Answers = in.Answers
+
+		res, err := scoring.Score(q, in.Answers)
+		if err != nil {
+			var ae *scoring.AnswerError
+			if errors.As(err, &ae) && ae.Index >= 0 {
+				validation.Add(fmt.Sprintf("%sanswers[%d]", prefix, ae.Index), ae.Code)
+			} else {
+				validation.Add(prefix+"answers", err.Error())
+			}
+			return
+		}
+		m.Value = res.Score
+		m.Subscores = res.Subscores
+
+	default:
+		validation.Add(prefix+"kind", "must_be_goniometry_strength_or_questionnaire")
+	}
+}
+
+func parseSide(s *string, prefix string, validation *validation.Errors) *domain.Side {
+	v := lowerPtr(s)
+	if v == nil {
+		validation.Add(prefix+"side", "required")
+		return nil
+	}
+	side := domain.Side(*v)
+	if !side.Valid() {
+		validation.Add(prefix+"side", "must_be_left_right_or_none")
+		return nil
+	}
+	return &side
+}
+
+func lowerPtr(s *string) *string {
+	v := trimPtr(s)
+	if v == nil {
+		return nil
+	}
+	l := strings.ToLower(*v)
+	return &l
+}
+
+func trimPtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := strings.TrimSpace(*s)
+	if v == "" {
+		return nil
+	}
+	return &v
+}
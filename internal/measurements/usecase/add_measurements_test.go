@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/measurements/domain"
+)
+
+type fakeEvolutions struct {
+	info  domain.EvolutionInfo
+	found bool
+}
+
+func (f fakeEvolutions) EvolutionInfo(ctx context.Context, evolutionID uuid.UUID) (domain.EvolutionInfo, bool, error) {
+	return f.info, f.found, nil
+}
+
+type fakeRepo struct {
+	created []domain.Measurement
+}
+
+func (r *fakeRepo) CreateBatch(ctx context.Context, ms []domain.Measurement) ([]domain.Measurement, error) {
+	r.created = append(r.created, ms...)
+	return ms, nil
+}
+
+func (r *fakeRepo) ListByEvolution(ctx context.Context, evolutionID uuid.UUID) ([]domain.Measurement, error) {
+	return nil, nil
+}
+
+func (r *fakeRepo) Series(ctx context.Context, q domain.SeriesQuery) ([]domain.SeriesPoint, error) {
+	return nil, nil
+}
+
+func TestAddMeasurements_OnlyOnLatestDraft(t *testing.T) {
+	degrees := 90.0
+	joint, movement, side := "knee", "flexion", "left"
+	items := []MeasurementInput{{Kind: "goniometry", Side: &side, Joint: &joint, Movement: &movement, Degrees: &degrees}}
+
+	tests := []struct {
+		name    string
+		info    domain.EvolutionInfo
+		found   bool
+		wantErr error
+	}{
+		{name: "latest draft", info: domain.EvolutionInfo{IsLatest: true}, found: true},
+		{name: "signed", info: domain.EvolutionInfo{Signed: true, IsLatest: true}, found: true, wantErr: domain.ErrEvolutionSigned},
+		{name: "superseded signed", info: domain.EvolutionInfo{Signed: true}, found: true, wantErr: domain.ErrEvolutionSigned},
+		{name: "draft not latest", info: domain.EvolutionInfo{}, found: true, wantErr: domain.ErrEvolutionNotLatest},
+		{name: "not found", wantErr: domain.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.info.PatientID = uuid.New()
+			tt.info.CreatedAt = time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+			repo := &fakeRepo{}
+			uc := NewAddMeasurementsUseCase(repo, fakeEvolutions{info: tt.info, found: tt.found})
+
+			out, _, err := uc.Execute(context.Background(), uuid.NewString(), items)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(repo.created) != 0 {
+					t.Fatalf("created %d measurements, want none", len(repo.created))
+				}
+				return
+			}
+			if len(out) != 1 || out[0].PatientID != tt.info.PatientID || !out[0].MeasuredAt.Equal(tt.info.CreatedAt) {
+				t.Fatalf("unexpected measurements: %+v", out)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/measurements/domain"
+)
+
+type ListMeasurementsByEvolutionUseCase struct {
+	repo domain.Repository
+}
+
+func NewListMeasurementsByEvolutionUseCase(repo domain.Repository) *ListMeasurementsByEvolutionUseCase {
+	return &ListMeasurementsByEvolutionUseCase{repo: repo}
+}
+
+func (uc *ListMeasurementsByEvolutionUseCase) Execute(ctx context.Context, evolutionID uuid.UUID) ([]domain.Measurement, error) {
+	return uc.repo.ListByEvolution(ctx, evolutionID)
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/measurements/domain"
+	"github.com/javiacuna/kinesio-backend/internal/measurements/scoring"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+type SeriesInput struct {
+	Metric   string // rom|mrc|oswestry|dash|koos|lysholm
+	Joint    *string
+	Movement *string
+	Muscle   *string
+	Side     *string
+	Subscale *string // koos: symptoms|pain|adl|sport_rec|qol
+	From     *string // YYYY-MM-DD
+	To       *string // YYYY-MM-DD (inclusive)
+}
+
+type GetSeriesUseCase struct {
+	repo domain.Repository
+}
+
+func NewGetSeriesUseCase(repo domain.Repository) *GetSeriesUseCase {
+	return &GetSeriesUseCase{repo: repo}
+}
+
+func (uc *GetSeriesUseCase) Execute(ctx context.Context, patientID string, in SeriesInput) ([]domain.SeriesPoint, *validation.Errors, error) {
+	validation := validation.New()
+
+	pid, err := uuid.Parse(strings.TrimSpace(patientID))
+	if err != nil {
+		validation.Add("patient_id", "invalid_uuid")
+	}
+
+	q := domain.SeriesQuery{
+		PatientID: pid,
+		Metric:    strings.ToLower(strings.TrimSpace(in.Metric)),
+		Joint:     lowerPtr(in.Joint),
+		Movement:  lowerPtr(in.Movement),
+		Muscle:    lowerPtr(in.Muscle),
+		Subscale:  lowerPtr(in.Subscale),
+	}
+
+	switch q.Metric {
+	case "rom":
+		if q.Joint == nil {
+			validation.Add("joint", "required")
+		}
+		if q.Movement == nil {
+			validation.Add("movement", "required")
+		}
+	case "mrc":
+		if q.Muscle == nil {
+			validation.Add("muscle", "required")
+		}
+	case string(scoring.KOOS):
+		if q.Subscale == nil {
+			validation.Add("subscale", "required")
+		}
+	case "":
+		validation.Add("metric", "required")
+	default:
+		if !scoring.Questionnaire(q.Metric).Valid() {
+			validation.Add("metric", "invalid_metric")
+		}
+	}
+
+	if v := lowerPtr(in.Side); v != nil {
+		side := domain.Side(*v)
+		if !side.Valid() {
+			validation.Add("side", "must_be_left_right_or_none")
+		}
+		q.Side = &side
+	}
+	q.From = parseDay("from", in.From, validation)
+	if to := parseDay("to", in.To, validation); to != nil {
+		end := to.AddDate(0, 0, 1)
+		q.To = &end
+	}
+
+	if !validation.Empty() {
+		return nil, validation, domain.ErrValidation
+	}
+
+	points, err := uc.repo.Series(ctx, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return points, nil, nil
+}
+
+func parseDay(field string, s *string, validation *validation.Errors) *time.Time {
+	v := trimPtr(s)
+	if v == nil {
+		return nil
+	}
+	tm, err := time.Parse("2006-01-02", *v)
+	if err != nil {
+		validation.Add(field, "invalid_date_(YYYY-MM-DD)")
+		return nil
+	}
+	return &tm
+}
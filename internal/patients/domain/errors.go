@@ -6,4 +6,5 @@ var (
 	ErrDuplicateDNI   = errors.New("duplicate dni")
 	ErrDuplicateEmail = errors.New("duplicate email")
 	ErrValidation     = errors.New("validation error")
+	ErrNotFound       = errors.New("not found")
 )
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+)
+
+// HealthInsurer es una obra social / prepaga con la regla de formato de su número de afiliado.
+type HealthInsurer struct {
+	Code              string
+	Name              string
+	RequiresAffiliate bool
+	AffiliateFormat   string // descripción legible para el front ("11 dígitos", ...)
+	affiliateRe       *regexp.Regexp
+	keepHyphens       bool // el formato admite guiones
+}
+
+const InsurerNone = "PARTICULAR"
+
+// Catálogo de obras sociales con las que trabaja el consultorio.
+// Los formatos se validan sin espacios ni guiones salvo que el formato los incluya.
+var insurers = []HealthInsurer{
+	{Code: InsurerNone, Name: "Particular (sin obra social)"},
+	{Code: "OSDE", Name: "OSDE", RequiresAffiliate: true, AffiliateFormat: "11 dígitos", affiliateRe: regexp.MustCompile(`^\d{11}$`)},
+	{Code: "SWISS_MEDICAL", Name: "Swiss Medical", RequiresAffiliate: true, AffiliateFormat: "7 a 13 dígitos", affiliateRe: regexp.MustCompile(`^\d{7,13}$`)},
+	{Code: "GALENO", Name: "Galeno", RequiresAffiliate: true, AffiliateFormat: "8 a 12 dígitos", affiliateRe: regexp.MustCompile(`^\d{8,12}$`)},
+	{Code: "MEDICUS", Name: "Medicus", RequiresAffiliate: true, AffiliateFormat: "8 a 10 dígitos", affiliateRe: regexp.MustCompile(`^\d{8,10}$`)},
+	{Code: "OMINT", Name: "OMINT", RequiresAffiliate: true, AffiliateFormat: "6 a 10 dígitos", affiliateRe: regexp.MustCompile(`^\d{6,10}$`)},
+	{Code: "SANCOR_SALUD", Name: "Sancor Salud", RequiresAffiliate: true, AffiliateFormat: "8 a 12 dígitos", affiliateRe: regexp.MustCompile(`^\d{8,12}$`)},
+	{Code: "IOMA", Name: "IOMA", RequiresAffiliate: true, AffiliateFormat: "9 dígitos / 2 dígitos (ej: 123456789/00)", affiliateRe: regexp.MustCompile(`^\d{9}/\d{2}$`)},
+	{Code: "PAMI", Name: "PAMI", RequiresAffiliate: true, AffiliateFormat: "14 dígitos (beneficio + parentesco)", affiliateRe: regexp.MustCompile(`^\d{14}$`)},
+	{Code: "OSECAC", Name: "OSECAC", RequiresAffiliate: true, AffiliateFormat: "CUIL de 11 dígitos + 2 dígitos de parentesco", affiliateRe: regexp.MustCompile(`^\d{13}$`)},
+	{Code: "UNION_PERSONAL", Name: "Unión Personal", RequiresAffiliate: true, AffiliateFormat: "8 a 12 dígitos", affiliateRe: regexp.MustCompile(`^\d{8,12}$`)},
+	{Code: "OTHER", Name: "Otra", RequiresAffiliate: true, AffiliateFormat: "alfanumérico (3 a 30)", affiliateRe: regexp.MustCompile(`^[A-Za-z0-9/\-]{3,30}$`), keepHyphens: true},
+}
+
+func Insurers() []HealthInsurer {
+	out := make([]HealthInsurer, len(insurers))
+	copy(out, insurers)
+	return out
+}
+
+func FindInsurer(code string) (HealthInsurer, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	for _, in := range insurers {
+		if in.Code == code {
+			return in, true
+		}
+	}
+	return HealthInsurer{}, false
+}
+
+// NormalizeAffiliate saca espacios y guiones (salvo que el formato de la obra social los necesite).
+func (i HealthInsurer) NormalizeAffiliate(n string) string {
+	n = strings.TrimSpace(n)
+	n = strings.ReplaceAll(n, " ", "")
+	if i.keepHyphens {
+		return n
+	}
+	return strings.ReplaceAll(n, "-", "")
+}
+
+func (i HealthInsurer) ValidAffiliate(n string) bool {
+	if i.affiliateRe == nil {
+		return true
+	}
+	return i.affiliateRe.MatchString(n)
+}
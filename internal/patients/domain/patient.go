@@ -16,8 +16,32 @@ type Patient struct {
 	Phone         *string
 	BirthDate     *time.Time
 	ClinicalNotes *string
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+
+	Insurance        *HealthInsurance
+	Address          Address
+	EmergencyContact *EmergencyContact
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type HealthInsurance struct {
+	InsurerCode     string
+	AffiliateNumber *string
+	Plan            *string
+}
+
+type Address struct {
+	Street     *string
+	City       *string
+	Province   *string
+	PostalCode *string
+}
+
+type EmergencyContact struct {
+	Name         string
+	Relationship *string
+	Phone        string
 }
 
 func NewPatient(dni, firstName, lastName, email string, phone *string, birthDate *time.Time, notes *string) Patient {
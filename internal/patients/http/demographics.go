@@ -0,0 +1,123 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/javiacuna/kinesio-backend/internal/patients/domain"
+	"github.com/javiacuna/kinesio-backend/internal/patients/usecase"
+)
+
+type healthInsuranceResponse struct {
+	InsurerCode     string  `json:"insurer_code"`
+	InsurerName     string  `json:"insurer_name"`
+	AffiliateNumber *string `json:"affiliate_number,omitempty"`
+	Plan            *string `json:"plan,omitempty"`
+}
+
+type addressResponse struct {
+	Street     *string `json:"street,omitempty"`
+	City       *string `json:"city,omitempty"`
+	Province   *string `json:"province,omitempty"`
+	PostalCode *string `json:"postal_code,omitempty"`
+}
+
+type emergencyContactResponse struct {
+	Name         string  `json:"name"`
+	Relationship *string `json:"relationship,omitempty"`
+	Phone        string  `json:"phone"`
+}
+
+type insurerResponse struct {
+	Code              string `json:"code"`
+	Name              string `json:"name"`
+	RequiresAffiliate bool   `json:"requires_affiliate"`
+	AffiliateFormat   string `json:"affiliate_format,omitempty"`
+}
+
+type updatePatientRequest struct {
+	Phone            *string                        `json:"phone"`
+	HealthInsurance  *usecase.InsuranceInput        `json:"health_insurance"`
+	Address          *usecase.AddressInput          `json:"address"`
+	EmergencyContact *usecase.EmergencyContactInput `json:"emergency_contact"`
+}
+
+// UpdatePatient: PATCH parcial de los datos de contacto / cobertura.
+func (h *Handler) UpdatePatient(c *gin.Context) {
+	if !isReceptionist(c.GetHeader("Authorization")) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req updatePatientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	out, validation, err := h.updateUC.Execute(c.Request.Context(), c.Param("patient_id"), usecase.UpdatePatientInput{
+		Phone:            req.Phone,
+		Insurance:        req.HealthInsurance,
+		Address:          req.Address,
+		EmergencyContact: req.EmergencyContact,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrValidation):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toResponse(out))
+}
+
+func (h *Handler) ListInsurers(c *gin.Context) {
+	items := h.insurers.Execute()
+	out := make([]insurerResponse, 0, len(items))
+	for _, i := range items {
+		out = append(out, insurerResponse{
+			Code:              i.Code,
+			Name:              i.Name,
+			RequiresAffiliate: i.RequiresAffiliate,
+			AffiliateFormat:   i.AffiliateFormat,
+		})
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+func toInsuranceResponse(in *domain.HealthInsurance) *healthInsuranceResponse {
+	if in == nil {
+		return nil
+	}
+	name := in.InsurerCode
+	if insurer, ok := domain.FindInsurer(in.InsurerCode); ok {
+		name = insurer.Name
+	}
+	return &healthInsuranceResponse{
+		InsurerCode:     in.InsurerCode,
+		InsurerName:     name,
+		AffiliateNumber: in.AffiliateNumber,
+		Plan:            in.Plan,
+	}
+}
+
+func toAddressResponse(a domain.Address) *addressResponse {
+	if a.Street == nil && a.City == nil && a.Province == nil && a.PostalCode == nil {
+		return nil
+	}
+	return &addressResponse{Street: a.Street, City: a.City, Province: a.Province, PostalCode: a.PostalCode}
+}
+
+func toEmergencyContactResponse(ec *domain.EmergencyContact) *emergencyContactResponse {
+	if ec == nil {
+		return nil
+	}
+	return &emergencyContactResponse{Name: ec.Name, Relationship: ec.Relationship, Phone: ec.Phone}
+}
@@ -15,11 +15,16 @@ type Handler struct {
 	register *usecase.RegisterPatientUseCase
 	getByID  *usecase.GetPatientByIDUseCase
 	searchUC *usecase.SearchPatientsUseCase
+	importUC *usecase.ImportPatientsUseCase
+	updateUC *usecase.UpdatePatientUseCase
+	insurers *usecase.ListInsurersUseCase
 }
 
 func NewHandler(register *usecase.RegisterPatientUseCase, getByID *usecase.GetPatientByIDUseCase,
-	searchUC *usecase.SearchPatientsUseCase) *Handler {
-	return &Handler{register: register, getByID: getByID, searchUC: searchUC}
+	searchUC *usecase.SearchPatientsUseCase, importUC *usecase.ImportPatientsUseCase,
+	updateUC *usecase.UpdatePatientUseCase, insurers *usecase.ListInsurersUseCase) *Handler {
+	return &Handler{register: register, getByID: getByID, searchUC: searchUC, importUC: importUC,
+		updateUC: updateUC, insurers: insurers}
 }
 
 type registerPatientRequest struct {
@@ -30,6 +35,10 @@ type registerPatientRequest struct {
 	Phone         *string `json:"phone"`
 	BirthDate     *string `json:"birth_date"` // YYYY-MM-DD
 	ClinicalNotes *string `json:"clinical_notes"`
+
+	HealthInsurance  *usecase.InsuranceInput        `json:"health_insurance"`
+	Address          *usecase.AddressInput          `json:"address"`
+	EmergencyContact *usecase.EmergencyContactInput `json:"emergency_contact"`
 }
 
 type patientResponse struct {
@@ -41,8 +50,13 @@ type patientResponse struct {
 	Phone         *string `json:"phone,omitempty"`
 	BirthDate     *string `json:"birth_date,omitempty"`
 	ClinicalNotes *string `json:"clinical_notes,omitempty"`
-	CreatedAt     string  `json:"created_at"`
-	UpdatedAt     string  `json:"updated_at"`
+
+	HealthInsurance  *healthInsuranceResponse  `json:"health_insurance,omitempty"`
+	Address          *addressResponse          `json:"address,omitempty"`
+	EmergencyContact *emergencyContactResponse `json:"emergency_contact,omitempty"`
+
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
 }
 
 func (h *Handler) RegisterPatient(c *gin.Context) {
@@ -67,6 +81,10 @@ func (h *Handler) RegisterPatient(c *gin.Context) {
 		Phone:         req.Phone,
 		BirthDate:     req.BirthDate,
 		ClinicalNotes: req.ClinicalNotes,
+
+		Insurance:        req.HealthInsurance,
+		Address:          req.Address,
+		EmergencyContact: req.EmergencyContact,
 	})
 
 	if err != nil {
@@ -106,8 +124,13 @@ func toResponse(p domain.Patient) patientResponse {
 		Phone:         p.Phone,
 		BirthDate:     birth,
 		ClinicalNotes: p.ClinicalNotes,
-		CreatedAt:     p.CreatedAt.UTC().Format(timeRFC3339()),
-		UpdatedAt:     p.UpdatedAt.UTC().Format(timeRFC3339()),
+
+		HealthInsurance:  toInsuranceResponse(p.Insurance),
+		Address:          toAddressResponse(p.Address),
+		EmergencyContact: toEmergencyContactResponse(p.EmergencyContact),
+
+		CreatedAt: p.CreatedAt.UTC().Format(timeRFC3339()),
+		UpdatedAt: p.UpdatedAt.UTC().Format(timeRFC3339()),
 	}
 }
 
@@ -122,7 +145,7 @@ func isReceptionist(auth string) bool {
 func (h *Handler) GetPatientByID(c *gin.Context) {
 	// Por ahora lo dejo SIN auth (útil para debug y para frontend).
 	// Lo cerramos por rol en el siguiente paso.
-	id := c.Param("id")
+	id := c.Param("patient_id")
 
 	p, found, err := h.getByID.Execute(c.Request.Context(), id)
 	if err != nil {
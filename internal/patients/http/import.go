@@ -0,0 +1,138 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/javiacuna/kinesio-backend/internal/patients/infra/spreadsheet"
+	"github.com/javiacuna/kinesio-backend/internal/patients/usecase"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+const maxImportFileBytes = 10 << 20 // 10 MB
+
+type importRowResponse struct {
+	Line      int                `json:"line"`
+	DNI       string             `json:"dni"`
+	Email     string             `json:"email"`
+	Status    string             `json:"status"`
+	PatientID *string            `json:"patient_id,omitempty"`
+	Errors    *validation.Errors `json:"errors,omitempty"`
+}
+
+type importReportResponse struct {
+	DryRun     bool                `json:"dry_run"`
+	Total      int                 `json:"total"`
+	Valid      int                 `json:"valid"`
+	Invalid    int                 `json:"invalid"`
+	Created    int                 `json:"created"`
+	Failed     int                 `json:"failed"`
+	StartedAt  string              `json:"started_at"`
+	FinishedAt string              `json:"finished_at"`
+	Rows       []importRowResponse `json:"rows"`
+}
+
+// Import recibe un CSV/XLSX (multipart, campo "file").
+// Query: dry_run=true|false, batch_size=N, format=json|csv (csv se descarga como adjunto).
+func (h *Handler) Import(c *gin.Context) {
+	if !isReceptionist(c.GetHeader("Authorization")) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileBytes)
+	fh, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file_too_large"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing_file"})
+		return
+	}
+	f, err := fh.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_file"})
+		return
+	}
+	defer f.Close()
+
+	records, err := spreadsheet.Read(f, fh.Filename)
+	if err != nil {
+		if errors.Is(err, spreadsheet.ErrUnsupportedFormat) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "unsupported_format"})
+			return
+		}
+		_ = c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation.Field("file", "invalid_format")})
+		return
+	}
+
+	batchSize := 0
+	if s := strings.TrimSpace(c.Query("batch_size")); s != "" {
+		if n, err := strconv.Atoi(s); err == nil {
+			batchSize = n
+		}
+	}
+
+	rep, err := h.importUC.Execute(c.Request.Context(), usecase.ImportPatientsInput{
+		Rows:      spreadsheet.ToImportRows(records),
+		DryRun:    strings.EqualFold(strings.TrimSpace(c.Query("dry_run")), "true"),
+		BatchSize: batchSize,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	if strings.EqualFold(strings.TrimSpace(c.Query("format")), "csv") {
+		name := fmt.Sprintf("patients-import-%s.csv", rep.StartedAt.Format("20060102-150405"))
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", `attachment; filename="`+name+`"`)
+		c.Status(http.StatusOK)
+		if err := spreadsheet.WriteReportCSV(c.Writer, rep); err != nil {
+			_ = c.Error(err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toImportReportResponse(rep))
+}
+
+func toImportReportResponse(rep usecase.ImportPatientsReport) importReportResponse {
+	rows := make([]importRowResponse, 0, len(rep.Rows))
+	for _, r := range rep.Rows {
+		var pid *string
+		if r.PatientID != nil {
+			s := r.PatientID.String()
+			pid = &s
+		}
+		rows = append(rows, importRowResponse{
+			Line:      r.Line,
+			DNI:       r.DNI,
+			Email:     r.Email,
+			Status:    string(r.Status),
+			PatientID: pid,
+			Errors:    r.Errors,
+		})
+	}
+
+	return importReportResponse{
+		DryRun:     rep.DryRun,
+		Total:      rep.Total,
+		Valid:      rep.Valid,
+		Invalid:    rep.Invalid,
+		Created:    rep.Created,
+		Failed:     rep.Failed,
+		StartedAt:  rep.StartedAt.Format(time.RFC3339),
+		FinishedAt: rep.FinishedAt.Format(time.RFC3339),
+		Rows:       rows,
+	}
+}
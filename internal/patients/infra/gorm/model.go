@@ -15,8 +15,21 @@ type PatientModel struct {
 	Phone         *string    `gorm:"column:phone"`
 	BirthDate     *time.Time `gorm:"column:birth_date"`
 	ClinicalNotes *string    `gorm:"column:clinical_notes"`
-	CreatedAt     time.Time  `gorm:"column:created_at;autoCreateTime"`
-	UpdatedAt     time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+
+	HealthInsurerCode *string `gorm:"column:health_insurer_code"`
+	AffiliateNumber   *string `gorm:"column:affiliate_number"`
+	InsurancePlan     *string `gorm:"column:insurance_plan"`
+
+	AddressStreet     *string `gorm:"column:address_street"`
+	AddressCity       *string `gorm:"column:address_city"`
+	AddressProvince   *string `gorm:"column:address_province"`
+	AddressPostalCode *string `gorm:"column:address_postal_code"`
+
+	EmergencyContactName         *string   `gorm:"column:emergency_contact_name"`
+	EmergencyContactRelationship *string   `gorm:"column:emergency_contact_relationship"`
+	EmergencyContactPhone        *string   `gorm:"column:emergency_contact_phone"`
+	CreatedAt                    time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt                    time.Time `gorm:"column:updated_at;autoUpdateTime"`
 }
 
 func (PatientModel) TableName() string { return "patients" }
@@ -22,16 +22,7 @@ func New(db *gorm.DB) *Repository {
 }
 
 func (r *Repository) Create(ctx context.Context, p domain.Patient) (domain.Patient, error) {
-	m := PatientModel{
-		ID:            p.ID,
-		DNI:           p.DNI,
-		FirstName:     p.FirstName,
-		LastName:      p.LastName,
-		Email:         p.Email,
-		Phone:         p.Phone,
-		BirthDate:     p.BirthDate,
-		ClinicalNotes: p.ClinicalNotes,
-	}
+	m := toModel(p)
 
 	if err := r.db.WithContext(ctx).Create(&m).Error; err != nil {
 		return domain.Patient{}, err
@@ -42,6 +33,30 @@ func (r *Repository) Create(ctx context.Context, p domain.Patient) (domain.Patie
 	return p, nil
 }
 
+func (r *Repository) CreateBatch(ctx context.Context, ps []domain.Patient) ([]domain.Patient, error) {
+	if len(ps) == 0 {
+		return []domain.Patient{}, nil
+	}
+
+	ms := make([]PatientModel, 0, len(ps))
+	for _, p := range ps {
+		ms = append(ms, toModel(p))
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(&ms, len(ms)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]domain.Patient, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, m.ToDomain())
+	}
+	return out, nil
+}
+
 func (r *Repository) ExistsByDNI(ctx context.Context, dni string) (bool, error) {
 	var count int64
 	if err := r.db.WithContext(ctx).
@@ -74,19 +89,19 @@ func (r *Repository) GetByID(ctx context.Context, id string) (domain.Patient, bo
 		return domain.Patient{}, false, err
 	}
 
-	p := domain.Patient{
-		ID:            m.ID,
-		DNI:           m.DNI,
-		FirstName:     m.FirstName,
-		LastName:      m.LastName,
-		Email:         m.Email,
-		Phone:         m.Phone,
-		BirthDate:     m.BirthDate,
-		ClinicalNotes: m.ClinicalNotes,
-		CreatedAt:     m.CreatedAt,
-		UpdatedAt:     m.UpdatedAt,
+	return m.ToDomain(), true, nil
+}
+
+func (r *Repository) Update(ctx context.Context, p domain.Patient) (domain.Patient, error) {
+	m := toModel(p)
+	m.CreatedAt = p.CreatedAt
+
+	if err := r.db.WithContext(ctx).Save(&m).Error; err != nil {
+		return domain.Patient{}, err
 	}
-	return p, true, nil
+
+	p.UpdatedAt = m.UpdatedAt
+	return p, nil
 }
 
 func (r *Repository) Search(ctx context.Context, query string, limit int) ([]domain.Patient, error) {
@@ -125,7 +140,7 @@ func (r *Repository) Search(ctx context.Context, query string, limit int) ([]dom
 }
 
 func (m PatientModel) ToDomain() domain.Patient {
-	return domain.Patient{
+	p := domain.Patient{
 		ID:            m.ID,
 		DNI:           m.DNI,
 		FirstName:     m.FirstName,
@@ -134,7 +149,58 @@ func (m PatientModel) ToDomain() domain.Patient {
 		Phone:         m.Phone,
 		BirthDate:     m.BirthDate,
 		ClinicalNotes: m.ClinicalNotes,
-		CreatedAt:     m.CreatedAt,
-		UpdatedAt:     m.UpdatedAt,
+		Address: domain.Address{
+			Street:     m.AddressStreet,
+			City:       m.AddressCity,
+			Province:   m.AddressProvince,
+			PostalCode: m.AddressPostalCode,
+		},
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+	if m.HealthInsurerCode != nil {
+		p.Insurance = &domain.HealthInsurance{
+			InsurerCode:     *m.HealthInsurerCode,
+			AffiliateNumber: m.AffiliateNumber,
+			Plan:            m.InsurancePlan,
+		}
+	}
+	if m.EmergencyContactName != nil && m.EmergencyContactPhone != nil {
+		p.EmergencyContact = &domain.EmergencyContact{
+			Name:         *m.EmergencyContactName,
+			Relationship: m.EmergencyContactRelationship,
+			Phone:        *m.EmergencyContactPhone,
+		}
+	}
+	return p
+}
+
+func toModel(p domain.Patient) PatientModel {
+	m := PatientModel{
+		ID:                p.ID,
+		DNI:               p.DNI,
+		FirstName:         p.FirstName,
+		LastName:          p.LastName,
+		Email:             p.Email,
+		Phone:             p.Phone,
+		BirthDate:         p.BirthDate,
+		ClinicalNotes:     p.ClinicalNotes,
+		AddressStreet:     p.Address.Street,
+		AddressCity:       p.Address.City,
+		AddressProvince:   p.Address.Province,
+		AddressPostalCode: p.Address.PostalCode,
+	}
+	if p.Insurance != nil {
+		code := p.Insurance.InsurerCode
+		m.HealthInsurerCode = &code
+		m.AffiliateNumber = p.Insurance.AffiliateNumber
+		m.InsurancePlan = p.Insurance.Plan
+	}
+	if p.EmergencyContact != nil {
+		name, phone := p.EmergencyContact.Name, p.EmergencyContact.Phone
+		m.EmergencyContactName = &name
+		m.EmergencyContactRelationship = p.EmergencyContact.Relationship
+		m.EmergencyContactPhone = &phone
 	}
+	return m
 }
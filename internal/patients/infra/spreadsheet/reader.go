@@ -0,0 +1,215 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/javiacuna/kinesio-backend/internal/patients/usecase"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported file format")
+	ErrMissingHeader     = errors.New("missing header row")
+)
+
+// Record es una fila del archivo, indexada por nombre de columna normalizado.
+type Record struct {
+	Line   int
+	Values map[string]string
+}
+
+// Alias de columnas aceptados (las planillas vienen en castellano).
+var headerAliases = map[string]string{
+	"documento":           "dni",
+	"nombre":              "first_name",
+	"nombres":             "first_name",
+	"apellido":            "last_name",
+	"apellidos":           "last_name",
+	"mail":                "email",
+	"correo":              "email",
+	"telefono":            "phone",
+	"teléfono":            "phone",
+	"celular":             "phone",
+	"fecha_nacimiento":    "birth_date",
+	"nacimiento":          "birth_date",
+	"notas":               "clinical_notes",
+	"observaciones":       "clinical_notes",
+	"obra_social":         "insurer_code",
+	"prepaga":             "insurer_code",
+	"nro_afiliado":        "affiliate_number",
+	"afiliado":            "affiliate_number",
+	"plan":                "insurance_plan",
+	"domicilio":           "street",
+	"direccion":           "street",
+	"dirección":           "street",
+	"localidad":           "city",
+	"ciudad":              "city",
+	"provincia":           "province",
+	"codigo_postal":       "postal_code",
+	"código_postal":       "postal_code",
+	"cp":                  "postal_code",
+	"contacto_emergencia": "emergency_name",
+	"parentesco":          "emergency_relationship",
+	"telefono_emergencia": "emergency_phone",
+	"teléfono_emergencia": "emergency_phone",
+}
+
+// Read parsea un CSV o XLSX (según la extensión de filename). La primera fila es el header.
+func Read(r io.Reader, filename string) ([]Record, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return readCSV(r)
+	case ".xlsx":
+		return readXLSX(r)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+func readCSV(r io.Reader) ([]Record, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	cr := csv.NewReader(bytes.NewReader(data))
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	// Excel en castellano suele exportar con ';' en lugar de ','
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		cr.Comma = ';'
+	}
+
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	return toRecords(rows, false)
+}
+
+func readXLSX(r io.Reader) ([]Record, error) {
+	f, err := excelize.OpenReader(r, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, ErrMissingHeader
+	}
+	rows, err := f.GetRows(sheets[0])
+	if err != nil {
+		return nil, err
+	}
+	return toRecords(rows, true)
+}
+
+func toRecords(rows [][]string, excelDates bool) ([]Record, error) {
+	if len(rows) == 0 {
+		return nil, ErrMissingHeader
+	}
+
+	header := make([]string, len(rows[0]))
+	for i, h := range rows[0] {
+		header[i] = normalizeHeader(h)
+	}
+
+	out := make([]Record, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		rec := Record{Line: i + 2, Values: map[string]string{}}
+		empty := true
+		for j, v := range row {
+			if j >= len(header) || header[j] == "" {
+				continue
+			}
+			v = strings.TrimSpace(v)
+			if v != "" {
+				empty = false
+			}
+			if excelDates && header[j] == "birth_date" {
+				v = excelSerialToDate(v)
+			}
+			rec.Values[header[j]] = v
+		}
+		if empty {
+			continue
+		}
+		out = append(out, rec)
+	}
+	return out, nil
+}
+
+func normalizeHeader(h string) string {
+	h = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+	h = strings.ReplaceAll(h, " ", "_")
+	if alias, ok := headerAliases[h]; ok {
+		return alias
+	}
+	return h
+}
+
+// En XLSX las fechas vienen como número de serie de Excel (sin formato).
+func excelSerialToDate(v string) string {
+	serial, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return v
+	}
+	tm, err := excelize.ExcelDateToTime(serial, false)
+	if err != nil {
+		return v
+	}
+	return tm.Format("2006-01-02")
+}
+
+// ToImportRows mapea los registros al input de la importación.
+func ToImportRows(records []Record) []usecase.ImportPatientRow {
+	out := make([]usecase.ImportPatientRow, 0, len(records))
+	for _, rec := range records {
+		out = append(out, usecase.ImportPatientRow{
+			Line: rec.Line,
+			RegisterPatientInput: usecase.RegisterPatientInput{
+				DNI:           rec.Values["dni"],
+				FirstName:     rec.Values["first_name"],
+				LastName:      rec.Values["last_name"],
+				Email:         rec.Values["email"],
+				Phone:         optional(rec.Values["phone"]),
+				BirthDate:     optional(rec.Values["birth_date"]),
+				ClinicalNotes: optional(rec.Values["clinical_notes"]),
+				Insurance: &usecase.InsuranceInput{
+					InsurerCode:     optional(rec.Values["insurer_code"]),
+					AffiliateNumber: optional(rec.Values["affiliate_number"]),
+					Plan:            optional(rec.Values["insurance_plan"]),
+				},
+				Address: &usecase.AddressInput{
+					Street:     optional(rec.Values["street"]),
+					City:       optional(rec.Values["city"]),
+					Province:   optional(rec.Values["province"]),
+					PostalCode: optional(rec.Values["postal_code"]),
+				},
+				EmergencyContact: &usecase.EmergencyContactInput{
+					Name:         optional(rec.Values["emergency_name"]),
+					Relationship: optional(rec.Values["emergency_relationship"]),
+					Phone:        optional(rec.Values["emergency_phone"]),
+				},
+			},
+		})
+	}
+	return out
+}
+
+func optional(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
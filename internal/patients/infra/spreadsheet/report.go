@@ -0,0 +1,52 @@
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/javiacuna/kinesio-backend/internal/patients/usecase"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+// WriteReportCSV escribe el reporte de importación: una fila por registro del archivo.
+func WriteReportCSV(w io.Writer, rep usecase.ImportPatientsReport) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"line", "dni", "email", "status", "patient_id", "errors"}); err != nil {
+		return err
+	}
+
+	for _, r := range rep.Rows {
+		var pid string
+		if r.PatientID != nil {
+			pid = r.PatientID.String()
+		}
+		if err := cw.Write([]string{
+			strconv.Itoa(r.Line),
+			r.DNI,
+			r.Email,
+			string(r.Status),
+			pid,
+			formatErrors(r.Errors),
+		}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatErrors(errs *validation.Errors) string {
+	fields := errs.Fields()
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Field < fields[j].Field })
+
+	parts := make([]string, 0, len(fields))
+	for _, fe := range fields {
+		parts = append(parts, fe.Field+": "+fe.Message)
+	}
+	return strings.Join(parts, "; ")
+}
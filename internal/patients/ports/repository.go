@@ -8,8 +8,11 @@ import (
 
 type Repository interface {
 	Create(ctx context.Context, p domain.Patient) (domain.Patient, error)
+	// CreateBatch inserta todos los pacientes en una única transacción (todo o nada).
+	CreateBatch(ctx context.Context, ps []domain.Patient) ([]domain.Patient, error)
 	ExistsByDNI(ctx context.Context, dni string) (bool, error)
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
+	Update(ctx context.Context, p domain.Patient) (domain.Patient, error)
 	GetByID(ctx context.Context, id string) (domain.Patient, bool, error)
 	Search(ctx context.Context, query string, limit int) ([]domain.Patient, error)
 }
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"strings"
+
+	"github.com/javiacuna/kinesio-backend/internal/patients/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+type InsuranceInput struct {
+	InsurerCode     *string `json:"insurer_code"`
+	AffiliateNumber *string `json:"affiliate_number"`
+	Plan            *string `json:"plan"`
+}
+
+type AddressInput struct {
+	Street     *string `json:"street"`
+	City       *string `json:"city"`
+	Province   *string `json:"province"`
+	PostalCode *string `json:"postal_code"`
+}
+
+type EmergencyContactInput struct {
+	Name         *string `json:"name"`
+	Relationship *string `json:"relationship"`
+	Phone        *string `json:"phone"`
+}
+
+// buildInsurance valida obra social + número de afiliado según las reglas de cada obra social.
+// Devuelve nil si no se informó obra social.
+func buildInsurance(in *InsuranceInput, errs *validation.Errors) *domain.HealthInsurance {
+	if in == nil {
+		return nil
+	}
+	code := trimPtr(in.InsurerCode)
+	affiliate := trimPtr(in.AffiliateNumber)
+	if code == nil {
+		if affiliate != nil {
+			errs.AddMessage("health_insurance.insurer_code", "required", "Campo obligatorio si se informa afiliado")
+		}
+		return nil
+	}
+
+	insurer, ok := domain.FindInsurer(*code)
+	if !ok {
+		errs.AddMessage("health_insurance.insurer_code", "not_found", "Obra social desconocida")
+		return nil
+	}
+
+	out := &domain.HealthInsurance{InsurerCode: insurer.Code, Plan: trimPtr(in.Plan)}
+	if affiliate != nil {
+		n := insurer.NormalizeAffiliate(*affiliate)
+		affiliate = &n
+	}
+
+	switch {
+	case insurer.Code == domain.InsurerNone:
+		if affiliate != nil {
+			errs.AddMessage("health_insurance.affiliate_number", "not_allowed", "No corresponde para pacientes particulares")
+		}
+	case affiliate == nil && insurer.RequiresAffiliate:
+		errs.AddMessage("health_insurance.affiliate_number", "required", "Campo obligatorio para "+insurer.Name)
+	case affiliate != nil && !insurer.ValidAffiliate(*affiliate):
+		errs.AddMessage("health_insurance.affiliate_number", "invalid_format", "Formato inválido para "+insurer.Name+" ("+insurer.AffiliateFormat+")")
+	}
+
+	out.AffiliateNumber = affiliate
+	return out
+}
+
+func buildAddress(in *AddressInput, errs *validation.Errors) domain.Address {
+	if in == nil {
+		return domain.Address{}
+	}
+	a := domain.Address{
+		Street:     trimPtr(in.Street),
+		City:       trimPtr(in.City),
+		Province:   trimPtr(in.Province),
+		PostalCode: trimPtr(in.PostalCode),
+	}
+	if a.PostalCode != nil {
+		// CPA (C1425ABC) o código postal viejo de 4 dígitos
+		pc := strings.ToUpper(*a.PostalCode)
+		if !postalCodeOK(pc) {
+			errs.AddMessage("address.postal_code", "invalid_format", "Formato inválido (ej: 1425 o C1425ABC)")
+		}
+		a.PostalCode = &pc
+	}
+	return a
+}
+
+func postalCodeOK(pc string) bool {
+	isDigits := func(s string) bool {
+		for _, ch := range s {
+			if ch < '0' || ch > '9' {
+				return false
+			}
+		}
+		return true
+	}
+	isLetters := func(s string) bool {
+		for _, ch := range s {
+			if ch < 'A' || ch > 'Z' {
+				return false
+			}
+		}
+		return true
+	}
+	switch len(pc) {
+	case 4:
+		return isDigits(pc)
+	case 8:
+		return isLetters(pc[:1]) && isDigits(pc[1:5]) && isLetters(pc[5:])
+	}
+	return false
+}
+
+// buildEmergencyContact: si se informa algún dato, nombre y teléfono son obligatorios.
+func buildEmergencyContact(in *EmergencyContactInput, errs *validation.Errors) *domain.EmergencyContact {
+	if in == nil {
+		return nil
+	}
+	name := trimPtr(in.Name)
+	phone := trimPtr(in.Phone)
+	rel := trimPtr(in.Relationship)
+	if name == nil && phone == nil && rel == nil {
+		return nil
+	}
+	if name == nil {
+		errs.Add("emergency_contact.name", "required")
+	}
+	if phone == nil {
+		errs.Add("emergency_contact.phone", "required")
+	}
+	if name == nil || phone == nil {
+		return nil
+	}
+	return &domain.EmergencyContact{Name: *name, Relationship: rel, Phone: *phone}
+}
+
+func trimPtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := strings.TrimSpace(*s)
+	if v == "" {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/patients/domain"
+	"github.com/javiacuna/kinesio-backend/internal/patients/ports"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+const defaultImportBatchSize = 100
+
+type ImportRowStatus string
+
+const (
+	ImportRowValid   ImportRowStatus = "valid"   // pasó validación (dry-run)
+	ImportRowCreated ImportRowStatus = "created" // insertado
+	ImportRowInvalid ImportRowStatus = "invalid" // no pasó validación
+	ImportRowFailed  ImportRowStatus = "failed"  // válido, pero falló el insert del lote
+)
+
+type ImportPatientRow struct {
+	Line int // línea/fila del archivo original (para el reporte)
+	RegisterPatientInput
+}
+
+type ImportPatientsInput struct {
+	Rows      []ImportPatientRow
+	DryRun    bool
+	BatchSize int
+}
+
+type ImportRowResult struct {
+	Line      int
+	DNI       string
+	Email     string
+	Status    ImportRowStatus
+	Errors    *validation.Errors
+	PatientID *uuid.UUID
+}
+
+type ImportPatientsReport struct {
+	DryRun     bool
+	Total      int
+	Valid      int
+	Invalid    int
+	Created    int
+	Failed     int
+	Rows       []ImportRowResult
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+type ImportPatientsUseCase struct {
+	repo ports.Repository
+}
+
+func NewImportPatientsUseCase(repo ports.Repository) *ImportPatientsUseCase {
+	return &ImportPatientsUseCase{repo: repo}
+}
+
+// Execute valida cada fila con las mismas reglas que RegisterPatientUseCase y,
+// si no es dry-run, inserta las filas válidas en lotes (cada lote en una transacción).
+func (uc *ImportPatientsUseCase) Execute(ctx context.Context, in ImportPatientsInput) (ImportPatientsReport, error) {
+	rep := ImportPatientsReport{
+		DryRun:    in.DryRun,
+		Total:     len(in.Rows),
+		Rows:      make([]ImportRowResult, 0, len(in.Rows)),
+		StartedAt: time.Now().UTC(),
+	}
+
+	batchSize := in.BatchSize
+	if batchSize <= 0 || batchSize > 1000 {
+		batchSize = defaultImportBatchSize
+	}
+
+	// DNI/email ya vistos en el archivo -> línea donde aparecieron
+	seenDNI := map[string]int{}
+	seenEmail := map[string]int{}
+
+	valid := make([]domain.Patient, 0, len(in.Rows))
+	validIdx := make([]int, 0, len(in.Rows)) // posición en rep.Rows de cada paciente válido
+
+	for _, row := range in.Rows {
+		res := ImportRowResult{
+			Line:  row.Line,
+			DNI:   strings.TrimSpace(row.DNI),
+			Email: strings.ToLower(strings.TrimSpace(row.Email)),
+		}
+
+		p, errs := buildPatient(row.RegisterPatientInput)
+		if errs == nil {
+			errs = validation.New()
+		}
+
+		if !errs.Has("dni") && res.DNI != "" {
+			if line, dup := seenDNI[res.DNI]; dup {
+				errs.AddMessage("dni", "duplicated", fmt.Sprintf("DNI repetido en el archivo (línea %d)", line))
+			} else if exists, err := uc.repo.ExistsByDNI(ctx, res.DNI); err != nil {
+				return ImportPatientsReport{}, err
+			} else if exists {
+				errs.AddMessage("dni", "already_exists", "DNI ya registrado")
+			}
+		}
+		if !errs.Has("email") && res.Email != "" {
+			if line, dup := seenEmail[res.Email]; dup {
+				errs.AddMessage("email", "duplicated", fmt.Sprintf("Email repetido en el archivo (línea %d)", line))
+			} else if exists, err := uc.repo.ExistsByEmail(ctx, res.Email); err != nil {
+				return ImportPatientsReport{}, err
+			} else if exists {
+				errs.AddMessage("email", "already_exists", "Email ya registrado")
+			}
+		}
+
+		if res.DNI != "" {
+			if _, ok := seenDNI[res.DNI]; !ok {
+				seenDNI[res.DNI] = row.Line
+			}
+		}
+		if res.Email != "" {
+			if _, ok := seenEmail[res.Email]; !ok {
+				seenEmail[res.Email] = row.Line
+			}
+		}
+
+		if !errs.Empty() {
+			res.Status = ImportRowInvalid
+			res.Errors = errs
+			rep.Invalid++
+		} else {
+			res.Status = ImportRowValid
+			rep.Valid++
+			valid = append(valid, p)
+			validIdx = append(validIdx, len(rep.Rows))
+		}
+		rep.Rows = append(rep.Rows, res)
+	}
+
+	if in.DryRun {
+		rep.FinishedAt = time.Now().UTC()
+		return rep, nil
+	}
+
+	for start := 0; start < len(valid); start += batchSize {
+		end := start + batchSize
+		if end > len(valid) {
+			end = len(valid)
+		}
+
+		created, err := uc.repo.CreateBatch(ctx, valid[start:end])
+		for i := start; i < end; i++ {
+			r := &rep.Rows[validIdx[i]]
+			if err != nil {
+				r.Status = ImportRowFailed
+				r.Errors = validation.New()
+				r.Errors.AddMessage("batch", "batch_failed", err.Error())
+				rep.Failed++
+				continue
+			}
+			id := created[i-start].ID
+			r.Status = ImportRowCreated
+			r.PatientID = &id
+			rep.Created++
+		}
+	}
+
+	rep.FinishedAt = time.Now().UTC()
+	return rep, nil
+}
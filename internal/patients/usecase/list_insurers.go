@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"github.com/javiacuna/kinesio-backend/internal/patients/domain"
+)
+
+type ListInsurersUseCase struct{}
+
+func NewListInsurersUseCase() *ListInsurersUseCase {
+	return &ListInsurersUseCase{}
+}
+
+func (uc *ListInsurersUseCase) Execute() []domain.HealthInsurer {
+	return domain.Insurers()
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/javiacuna/kinesio-backend/internal/patients/domain"
 	"github.com/javiacuna/kinesio-backend/internal/patients/ports"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
 )
 
 type RegisterPatientInput struct {
@@ -17,6 +18,10 @@ type RegisterPatientInput struct {
 	Phone         *string
 	BirthDate     *string // YYYY-MM-DD (string) para parsear acá o en handler; acá lo parseamos.
 	ClinicalNotes *string
+
+	Insurance        *InsuranceInput
+	Address          *AddressInput
+	EmergencyContact *EmergencyContactInput
 }
 
 type RegisterPatientUseCase struct {
@@ -27,9 +32,48 @@ func NewRegisterPatientUseCase(repo ports.Repository) *RegisterPatientUseCase {
 	return &RegisterPatientUseCase{repo: repo}
 }
 
-func (uc *RegisterPatientUseCase) Execute(ctx context.Context, in RegisterPatientInput) (domain.Patient, map[string]string, error) {
-	// Validación (reglas mínimas)
-	errs := map[string]string{}
+func (uc *RegisterPatientUseCase) Execute(ctx context.Context, in RegisterPatientInput) (domain.Patient, *validation.Errors, error) {
+	p, errs := buildPatient(in)
+	if !errs.Empty() {
+		return domain.Patient{}, errs, domain.ErrValidation
+	}
+
+	if err := uc.checkUnique(ctx, p); err != nil {
+		return domain.Patient{}, nil, err
+	}
+
+	created, err := uc.repo.Create(ctx, p)
+	if err != nil {
+		return domain.Patient{}, nil, err
+	}
+
+	return created, nil, nil
+}
+
+// checkUnique devuelve ErrDuplicateDNI / ErrDuplicateEmail si ya existe un paciente con esos datos.
+func (uc *RegisterPatientUseCase) checkUnique(ctx context.Context, p domain.Patient) error {
+	exists, err := uc.repo.ExistsByDNI(ctx, p.DNI)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrDuplicateDNI
+	}
+
+	exists, err = uc.repo.ExistsByEmail(ctx, p.Email)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrDuplicateEmail
+	}
+	return nil
+}
+
+// buildPatient aplica las validaciones de alta (reglas mínimas) y arma el paciente.
+// La usan tanto el alta individual como la importación masiva.
+func buildPatient(in RegisterPatientInput) (domain.Patient, *validation.Errors) {
+	errs := validation.New()
 
 	in.DNI = strings.TrimSpace(in.DNI)
 	in.FirstName = strings.TrimSpace(in.FirstName)
@@ -37,23 +81,23 @@ func (uc *RegisterPatientUseCase) Execute(ctx context.Context, in RegisterPatien
 	in.Email = strings.TrimSpace(in.Email)
 
 	if in.DNI == "" {
-		errs["dni"] = "Campo obligatorio"
+		errs.Add("dni", "required")
 	}
 	if in.FirstName == "" {
-		errs["first_name"] = "Campo obligatorio"
+		errs.Add("first_name", "required")
 	}
 	if in.LastName == "" {
-		errs["last_name"] = "Campo obligatorio"
+		errs.Add("last_name", "required")
 	}
 	if in.Email == "" {
-		errs["email"] = "Campo obligatorio"
+		errs.Add("email", "required")
 	} else if !strings.Contains(in.Email, "@") {
-		errs["email"] = "Formato inválido"
+		errs.Add("email", "invalid_format")
 	}
 
 	for _, ch := range in.DNI {
 		if ch < '0' || ch > '9' {
-			errs["dni"] = "Debe ser numérico (sin puntos ni guiones)"
+			errs.AddMessage("dni", "must_be_number", "Debe ser numérico (sin puntos ni guiones)")
 			break
 		}
 	}
@@ -62,39 +106,24 @@ func (uc *RegisterPatientUseCase) Execute(ctx context.Context, in RegisterPatien
 	if in.BirthDate != nil && strings.TrimSpace(*in.BirthDate) != "" {
 		tm, e := time.Parse("2006-01-02", strings.TrimSpace(*in.BirthDate))
 		if e != nil {
-			errs["birth_date"] = "Formato inválido (usar YYYY-MM-DD)"
+			errs.Add("birth_date", "invalid_date_(YYYY-MM-DD)")
 		} else {
 			utc := tm.UTC()
 			birthDatePtr = &utc
 		}
 	}
 
-	if len(errs) > 0 {
-		return domain.Patient{}, errs, domain.ErrValidation
-	}
-
-	// Chequeo unicidad
-	exists, err := uc.repo.ExistsByDNI(ctx, in.DNI)
-	if err != nil {
-		return domain.Patient{}, nil, err
-	}
-	if exists {
-		return domain.Patient{}, nil, domain.ErrDuplicateDNI
-	}
+	insurance := buildInsurance(in.Insurance, errs)
+	address := buildAddress(in.Address, errs)
+	contact := buildEmergencyContact(in.EmergencyContact, errs)
 
-	exists, err = uc.repo.ExistsByEmail(ctx, in.Email)
-	if err != nil {
-		return domain.Patient{}, nil, err
-	}
-	if exists {
-		return domain.Patient{}, nil, domain.ErrDuplicateEmail
+	if !errs.Empty() {
+		return domain.Patient{}, errs
 	}
 
 	p := domain.NewPatient(in.DNI, in.FirstName, in.LastName, in.Email, in.Phone, birthDatePtr, in.ClinicalNotes)
-	created, err := uc.repo.Create(ctx, p)
-	if err != nil {
-		return domain.Patient{}, nil, err
-	}
-
-	return created, nil, nil
+	p.Insurance = insurance
+	p.Address = address
+	p.EmergencyContact = contact
+	return p, nil
 }
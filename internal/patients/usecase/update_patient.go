@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/javiacuna/kinesio-backend/internal/patients/domain"
+	"github.com/javiacuna/kinesio-backend/internal/patients/ports"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+// UpdatePatientInput: los campos en nil no se modifican.
+// Para quitar la obra social se manda health_insurance con insurer_code vacío.
+type UpdatePatientInput struct {
+	Phone            *string
+	Insurance        *InsuranceInput
+	Address          *AddressInput
+	EmergencyContact *EmergencyContactInput
+}
+
+type UpdatePatientUseCase struct {
+	repo ports.Repository
+}
+
+func NewUpdatePatientUseCase(repo ports.Repository) *UpdatePatientUseCase {
+	return &UpdatePatientUseCase{repo: repo}
+}
+
+func (uc *UpdatePatientUseCase) Execute(ctx context.Context, id string, in UpdatePatientInput) (domain.Patient, *validation.Errors, error) {
+	p, found, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.Patient{}, nil, err
+	}
+	if !found {
+		return domain.Patient{}, nil, domain.ErrNotFound
+	}
+
+	errs := validation.New()
+	if in.Phone != nil {
+		p.Phone = trimPtr(in.Phone)
+	}
+	if in.Insurance != nil {
+		p.Insurance = buildInsurance(in.Insurance, errs)
+	}
+	if in.Address != nil {
+		p.Address = buildAddress(in.Address, errs)
+	}
+	if in.EmergencyContact != nil {
+		p.EmergencyContact = buildEmergencyContact(in.EmergencyContact, errs)
+	}
+	if !errs.Empty() {
+		return domain.Patient{}, errs, domain.ErrValidation
+	}
+
+	out, err := uc.repo.Update(ctx, p)
+	if err != nil {
+		return domain.Patient{}, nil, err
+	}
+	return out, nil, nil
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Prescription es la orden médica: la obra social autoriza una cantidad fija de sesiones
+// dentro de un período de vigencia.
+type Prescription struct {
+	ID        uuid.UUID
+	PatientID uuid.UUID
+
+	PrescribingDoctor string
+	DoctorLicense     *string // matrícula
+
+	Diagnosis   string
+	DiagnosisID *uuid.UUID // vínculo opcional con la historia clínica
+
+	AuthorizedSessions int
+	UsedSessions       int // calculado a partir de las sesiones consumidas
+
+	InsurerCode       *string
+	AuthorizationCode *string
+
+	IssuedAt   time.Time
+	ValidFrom  time.Time
+	ValidUntil time.Time
+
+	Notes *string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (p Prescription) RemainingSessions() int {
+	if r := p.AuthorizedSessions - p.UsedSessions; r > 0 {
+		return r
+	}
+	return 0
+}
+
+// IsValidOn compara por día (las fechas de vigencia son inclusivas).
+func (p Prescription) IsValidOn(t time.Time) bool {
+	day := truncateDay(t)
+	return !day.Before(truncateDay(p.ValidFrom)) && !day.After(truncateDay(p.ValidUntil))
+}
+
+// Session es una sesión consumida por un turno atendido.
+type Session struct {
+	ID             uuid.UUID
+	PrescriptionID uuid.UUID
+	AppointmentID  uuid.UUID
+	SessionDate    time.Time
+	CreatedAt      time.Time
+}
+
+// Avisos para recepción al dar un turno.
+const (
+	WarningNoPrescription    = "no_valid_prescription"
+	WarningExpired           = "prescription_expired"
+	WarningSessionsExhausted = "sessions_exhausted"
+	WarningLastSession       = "last_authorized_session"
+)
+
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
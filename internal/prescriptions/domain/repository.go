@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, p Prescription) (Prescription, error)
+	GetByID(ctx context.Context, id uuid.UUID) (Prescription, bool, error)
+	ListByPatient(ctx context.Context, patientID uuid.UUID) ([]Prescription, error)
+	ListSessions(ctx context.Context, prescriptionID uuid.UUID) ([]Session, error)
+
+	// ConsumeSession descuenta una sesión de la orden vigente más próxima a vencer que tenga cupo.
+	// Es idempotente por turno. Devuelve false si no había ninguna orden con cupo.
+	ConsumeSession(ctx context.Context, patientID, appointmentID uuid.UUID, on time.Time) (bool, error)
+	// ReleaseSession devuelve la sesión consumida por el turno (si la hubo).
+	ReleaseSession(ctx context.Context, appointmentID uuid.UUID) error
+}
+
+// ClinicalRecord valida el diagnóstico vinculado contra la historia clínica.
+type ClinicalRecord interface {
+	DiagnosisBelongsTo(ctx context.Context, diagnosisID, patientID uuid.UUID) (bool, error)
+}
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/prescriptions/domain"
+	"github.com/javiacuna/kinesio-backend/internal/prescriptions/usecase"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+type Handler struct {
+	createUC *usecase.CreatePrescriptionUseCase
+	listUC   *usecase.ListPrescriptionsByPatientUseCase
+	getUC    *usecase.GetPrescriptionUseCase
+}
+
+func NewHandler(createUC *usecase.CreatePrescriptionUseCase, listUC *usecase.ListPrescriptionsByPatientUseCase, getUC *usecase.GetPrescriptionUseCase) *Handler {
+	return &Handler{createUC: createUC, listUC: listUC, getUC: getUC}
+}
+
+type prescriptionResponse struct {
+	ID                 string  `json:"id"`
+	PatientID          string  `json:"patient_id"`
+	PrescribingDoctor  string  `json:"prescribing_doctor"`
+	DoctorLicense      *string `json:"doctor_license,omitempty"`
+	Diagnosis          string  `json:"diagnosis"`
+	DiagnosisID        *string `json:"diagnosis_id,omitempty"`
+	AuthorizedSessions int     `json:"authorized_sessions"`
+	UsedSessions       int     `json:"used_sessions"`
+	RemainingSessions  int     `json:"remaining_sessions"`
+	InsurerCode        *string `json:"insurer_code,omitempty"`
+	AuthorizationCode  *string `json:"authorization_code,omitempty"`
+	IssuedAt           string  `json:"issued_at"`
+	ValidFrom          string  `json:"valid_from"`
+	ValidUntil         string  `json:"valid_until"`
+	Active             bool    `json:"active"`
+	Notes              *string `json:"notes,omitempty"`
+	CreatedAt          string  `json:"created_at"`
+	UpdatedAt          string  `json:"updated_at"`
+}
+
+type sessionResponse struct {
+	AppointmentID string `json:"appointment_id"`
+	SessionDate   string `json:"session_date"`
+}
+
+type prescriptionDetailResponse struct {
+	prescriptionResponse
+	Sessions []sessionResponse `json:"sessions"`
+}
+
+func (h *Handler) CreateForPatient(c *gin.Context) {
+	var req usecase.CreatePrescriptionInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_json"})
+		return
+	}
+
+	out, validation, err := h.createUC.Execute(c.Request.Context(), c.Param("patient_id"), req)
+	if err != nil {
+		writeError(c, err, validation)
+		return
+	}
+	c.JSON(http.StatusCreated, toResponse(out))
+}
+
+func (h *Handler) ListByPatient(c *gin.Context) {
+	pid, err := uuid.Parse(c.Param("patient_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_patient_id"})
+		return
+	}
+
+	items, err := h.listUC.Execute(c.Request.Context(), pid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	out := make([]prescriptionResponse, 0, len(items))
+	for _, p := range items {
+		out = append(out, toResponse(p))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+func (h *Handler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	p, sessions, err := h.getUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		writeError(c, err, nil)
+		return
+	}
+
+	out := prescriptionDetailResponse{prescriptionResponse: toResponse(p), Sessions: make([]sessionResponse, 0, len(sessions))}
+	for _, s := range sessions {
+		out.Sessions = append(out.Sessions, sessionResponse{
+			AppointmentID: s.AppointmentID.String(),
+			SessionDate:   s.SessionDate.Format("2006-01-02"),
+		})
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+func writeError(c *gin.Context, err error, validation *validation.Errors) {
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+	}
+}
+
+func toResponse(p domain.Prescription) prescriptionResponse {
+	var diagnosisID *string
+	if p.DiagnosisID != nil {
+		s := p.DiagnosisID.String()
+		diagnosisID = &s
+	}
+	return prescriptionResponse{
+		ID:                 p.ID.String(),
+		PatientID:          p.PatientID.String(),
+		PrescribingDoctor:  p.PrescribingDoctor,
+		DoctorLicense:      p.DoctorLicense,
+		Diagnosis:          p.Diagnosis,
+		DiagnosisID:        diagnosisID,
+		AuthorizedSessions: p.AuthorizedSessions,
+		UsedSessions:       p.UsedSessions,
+		RemainingSessions:  p.RemainingSessions(),
+		InsurerCode:        p.InsurerCode,
+		AuthorizationCode:  p.AuthorizationCode,
+		IssuedAt:           p.IssuedAt.Format("2006-01-02"),
+		ValidFrom:          p.ValidFrom.Format("2006-01-02"),
+		ValidUntil:         p.ValidUntil.Format("2006-01-02"),
+		Active:             p.IsValidOn(time.Now()) && p.RemainingSessions() > 0,
+		Notes:              p.Notes,
+		CreatedAt:          p.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:          p.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
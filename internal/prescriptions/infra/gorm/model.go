@@ -0,0 +1,36 @@
+package gorm
+
+import "time"
+
+type PrescriptionModel struct {
+	ID                 string `gorm:"type:uuid;primaryKey"`
+	PatientID          string `gorm:"type:uuid;not null"`
+	PrescribingDoctor  string `gorm:"not null"`
+	DoctorLicense      *string
+	Diagnosis          string  `gorm:"not null"`
+	DiagnosisID        *string `gorm:"type:uuid"`
+	AuthorizedSessions int     `gorm:"not null"`
+	InsurerCode        *string
+	AuthorizationCode  *string
+	IssuedAt           time.Time `gorm:"type:date;not null"`
+	ValidFrom          time.Time `gorm:"type:date;not null"`
+	ValidUntil         time.Time `gorm:"type:date;not null"`
+	Notes              *string
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+
+	// Solo lectura: se calcula con un subselect sobre prescription_sessions.
+	UsedSessions int `gorm:"->;column:used_sessions"`
+}
+
+func (PrescriptionModel) TableName() string { return "prescriptions" }
+
+type SessionModel struct {
+	ID             string    `gorm:"type:uuid;primaryKey"`
+	PrescriptionID string    `gorm:"type:uuid;not null"`
+	AppointmentID  string    `gorm:"type:uuid;not null"`
+	SessionDate    time.Time `gorm:"type:date;not null"`
+	CreatedAt      time.Time
+}
+
+func (SessionModel) TableName() string { return "prescription_sessions" }
@@ -0,0 +1,209 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/javiacuna/kinesio-backend/internal/prescriptions/domain"
+)
+
+var _ domain.Repository = (*Repository)(nil)
+
+const selectWithUsed = `prescriptions.*,
+	(SELECT count(*) FROM prescription_sessions s WHERE s.prescription_id = prescriptions.id) AS used_sessions`
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(ctx context.Context, p domain.Prescription) (domain.Prescription, error) {
+	m := toModel(p)
+	if err := r.db.WithContext(ctx).Create(&m).Error; err != nil {
+		return domain.Prescription{}, err
+	}
+	return toDomain(m), nil
+}
+
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (domain.Prescription, bool, error) {
+	var m PrescriptionModel
+	err := r.db.WithContext(ctx).
+		Model(&PrescriptionModel{}).
+		Select(selectWithUsed).
+		Where("prescriptions.id = ?", id.String()).
+		First(&m).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.Prescription{}, false, nil
+		}
+		return domain.Prescription{}, false, err
+	}
+	return toDomain(m), true, nil
+}
+
+func (r *Repository) ListByPatient(ctx context.Context, patientID uuid.UUID) ([]domain.Prescription, error) {
+	var ms []PrescriptionModel
+	err := r.db.WithContext(ctx).
+		Model(&PrescriptionModel{}).
+		Select(selectWithUsed).
+		Where("prescriptions.patient_id = ?", patientID.String()).
+		Order("valid_until desc, created_at desc").
+		Find(&ms).Error
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]domain.Prescription, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, toDomain(m))
+	}
+	return out, nil
+}
+
+func (r *Repository) ListSessions(ctx context.Context, prescriptionID uuid.UUID) ([]domain.Session, error) {
+	var ms []SessionModel
+	err := r.db.WithContext(ctx).
+		Where("prescription_id = ?", prescriptionID.String()).
+		Order("session_date asc, created_at asc").
+		Find(&ms).Error
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]domain.Session, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, domain.Session{
+			ID:             uuid.MustParse(m.ID),
+			PrescriptionID: uuid.MustParse(m.PrescriptionID),
+			AppointmentID:  uuid.MustParse(m.AppointmentID),
+			SessionDate:    m.SessionDate,
+			CreatedAt:      m.CreatedAt,
+		})
+	}
+	return out, nil
+}
+
+func (r *Repository) ConsumeSession(ctx context.Context, patientID, appointmentID uuid.UUID, on time.Time) (bool, error) {
+	day := on.UTC().Format("2006-01-02")
+	consumed := false
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Bloqueamos las órdenes vigentes del paciente para que dos turnos atendidos
+		// en simultáneo no excedan las sesiones autorizadas.
+		var ms []PrescriptionModel
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("patient_id = ? AND valid_from <= ? AND valid_until >= ?", patientID.String(), day, day).
+			Order("valid_until asc, created_at asc").
+			Find(&ms).Error
+		if err != nil {
+			return err
+		}
+
+		// Se mira después del bloqueo para ver la sesión de otro PATCH del mismo turno.
+		var already int64
+		if err := tx.Model(&SessionModel{}).Where("appointment_id = ?", appointmentID.String()).Count(&already).Error; err != nil {
+			return err
+		}
+		if already > 0 {
+			consumed = true
+			return nil
+		}
+
+		for _, m := range ms {
+			var used int64
+			if err := tx.Model(&SessionModel{}).Where("prescription_id = ?", m.ID).Count(&used).Error; err != nil {
+				return err
+			}
+			if int(used) >= m.AuthorizedSessions {
+				continue
+			}
+
+			s := SessionModel{
+				ID:             uuid.NewString(),
+				PrescriptionID: m.ID,
+				AppointmentID:  appointmentID.String(),
+				SessionDate:    on.UTC(),
+			}
+			// Si dos PATCH del mismo turno bloquearon órdenes distintas (cambió la fecha),
+			// decide el índice único por turno y el segundo no inserta nada.
+			err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "appointment_id"}}, DoNothing: true}).
+				Create(&s).Error
+			if err != nil {
+				return err
+			}
+			consumed = true
+			return nil
+		}
+		return nil
+	})
+	return consumed, err
+}
+
+func (r *Repository) ReleaseSession(ctx context.Context, appointmentID uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("appointment_id = ?", appointmentID.String()).Delete(&SessionModel{}).Error
+}
+
+func toModel(p domain.Prescription) PrescriptionModel {
+	return PrescriptionModel{
+		ID:                 p.ID.String(),
+		PatientID:          p.PatientID.String(),
+		PrescribingDoctor:  p.PrescribingDoctor,
+		DoctorLicense:      p.DoctorLicense,
+		Diagnosis:          p.Diagnosis,
+		DiagnosisID:        uuidPtrToString(p.DiagnosisID),
+		AuthorizedSessions: p.AuthorizedSessions,
+		InsurerCode:        p.InsurerCode,
+		AuthorizationCode:  p.AuthorizationCode,
+		IssuedAt:           p.IssuedAt,
+		ValidFrom:          p.ValidFrom,
+		ValidUntil:         p.ValidUntil,
+		Notes:              p.Notes,
+		CreatedAt:          p.CreatedAt,
+		UpdatedAt:          p.UpdatedAt,
+	}
+}
+
+func toDomain(m PrescriptionModel) domain.Prescription {
+	return domain.Prescription{
+		ID:                 uuid.MustParse(m.ID),
+		PatientID:          uuid.MustParse(m.PatientID),
+		PrescribingDoctor:  m.PrescribingDoctor,
+		DoctorLicense:      m.DoctorLicense,
+		Diagnosis:          m.Diagnosis,
+		DiagnosisID:        stringPtrToUUID(m.DiagnosisID),
+		AuthorizedSessions: m.AuthorizedSessions,
+		UsedSessions:       m.UsedSessions,
+		InsurerCode:        m.InsurerCode,
+		AuthorizationCode:  m.AuthorizationCode,
+		IssuedAt:           m.IssuedAt,
+		ValidFrom:          m.ValidFrom,
+		ValidUntil:         m.ValidUntil,
+		Notes:              m.Notes,
+		CreatedAt:          m.CreatedAt,
+		UpdatedAt:          m.UpdatedAt,
+	}
+}
+
+func uuidPtrToString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func stringPtrToUUID(s *string) *uuid.UUID {
+	if s == nil || *s == "" {
+		return nil
+	}
+	id := uuid.MustParse(*s)
+	return &id
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/clinictime"
+	"github.com/javiacuna/kinesio-backend/internal/prescriptions/domain"
+)
+
+// Coverage lo usa el módulo de turnos: avisa a recepción si el paciente no tiene
+// una orden vigente con sesiones disponibles y consume sesiones al marcar un turno como atendido.
+// Los turnos se guardan en UTC; la vigencia se compara con el día del turno en el consultorio.
+type Coverage struct {
+	repo domain.Repository
+	loc  *time.Location
+}
+
+func NewCoverage(repo domain.Repository, loc *time.Location) *Coverage {
+	return &Coverage{repo: repo, loc: loc}
+}
+
+// clinicDay devuelve el día local del turno como fecha (00:00 UTC), que es como
+// se guardan la vigencia de las órdenes y las sesiones.
+func (c *Coverage) clinicDay(at time.Time) time.Time {
+	return clinictime.Day(at, c.loc)
+}
+
+func (c *Coverage) CoverageWarnings(ctx context.Context, patientID uuid.UUID, at time.Time) ([]string, error) {
+	items, err := c.repo.ListByPatient(ctx, patientID)
+	if err != nil {
+		return nil, err
+	}
+	at = c.clinicDay(at)
+
+	var valid, expired int
+	remaining := 0
+	for _, p := range items {
+		switch {
+		case p.IsValidOn(at):
+			valid++
+			remaining += p.RemainingSessions()
+		case p.ValidUntil.Before(at) && p.RemainingSessions() > 0:
+			expired++
+		}
+	}
+
+	switch {
+	case valid == 0 && expired > 0:
+		return []string{domain.WarningExpired}, nil
+	case valid == 0:
+		return []string{domain.WarningNoPrescription}, nil
+	case remaining == 0:
+		return []string{domain.WarningSessionsExhausted}, nil
+	case remaining == 1:
+		return []string{domain.WarningLastSession}, nil
+	}
+	return nil, nil
+}
+
+func (c *Coverage) ConsumeSession(ctx context.Context, patientID, appointmentID uuid.UUID, at time.Time) (bool, error) {
+	return c.repo.ConsumeSession(ctx, patientID, appointmentID, c.clinicDay(at))
+}
+
+func (c *Coverage) ReleaseSession(ctx context.Context, appointmentID uuid.UUID) error {
+	return c.repo.ReleaseSession(ctx, appointmentID)
+}
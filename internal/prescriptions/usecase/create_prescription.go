@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/prescriptions/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+type CreatePrescriptionInput struct {
+	PrescribingDoctor  string  `json:"prescribing_doctor"`
+	DoctorLicense      *string `json:"doctor_license"`
+	Diagnosis          string  `json:"diagnosis"`
+	DiagnosisID        *string `json:"diagnosis_id"`
+	AuthorizedSessions int     `json:"authorized_sessions"`
+	InsurerCode        *string `json:"insurer_code"`
+	AuthorizationCode  *string `json:"authorization_code"`
+	IssuedAt           string  `json:"issued_at"`   // YYYY-MM-DD
+	ValidFrom          string  `json:"valid_from"`  // YYYY-MM-DD (default issued_at)
+	ValidUntil         string  `json:"valid_until"` // YYYY-MM-DD
+	Notes              *string `json:"notes"`
+}
+
+type CreatePrescriptionUseCase struct {
+	repo     domain.Repository
+	clinical domain.ClinicalRecord
+}
+
+func NewCreatePrescriptionUseCase(repo domain.Repository, clinical domain.ClinicalRecord) *CreatePrescriptionUseCase {
+	return &CreatePrescriptionUseCase{repo: repo, clinical: clinical}
+}
+
+func (uc *CreatePrescriptionUseCase) Execute(ctx context.Context, patientID string, in CreatePrescriptionInput) (domain.Prescription, *validation.Errors, error) {
+	validation := validation.New()
+
+	pid, err := uuid.Parse(strings.TrimSpace(patientID))
+	if err != nil {
+		validation.Add("patient_id", "invalid_uuid")
+	}
+
+	doctor := strings.TrimSpace(in.PrescribingDoctor)
+	if doctor == "" {
+		validation.Add("prescribing_doctor", "required")
+	}
+	diagnosis := strings.TrimSpace(in.Diagnosis)
+	if diagnosis == "" {
+		validation.Add("diagnosis", "required")
+	}
+	diagnosisID := validation.OptionalUUID("diagnosis_id", in.DiagnosisID)
+
+	if in.AuthorizedSessions <= 0 {
+		validation.Add("authorized_sessions", "must_be_>_0")
+	}
+
+	issuedAt := parseRequiredDate("issued_at", in.IssuedAt, validation)
+	validFrom := issuedAt
+	if strings.TrimSpace(in.ValidFrom) != "" {
+		validFrom = parseRequiredDate("valid_from", in.ValidFrom, validation)
+	}
+	validUntil := parseRequiredDate("valid_until", in.ValidUntil, validation)
+	if !validation.Has("valid_until") && !validUntil.IsZero() && validUntil.Before(validFrom) {
+		validation.Add("valid_until", "must_be_>=_valid_from")
+	}
+
+	var insurer *string
+	if v := trimPtr(in.InsurerCode); v != nil {
+		u := strings.ToUpper(*v)
+		insurer = &u
+	}
+
+	if validation.Empty() && diagnosisID != nil {
+		ok, err := uc.clinical.DiagnosisBelongsTo(ctx, *diagnosisID, pid)
+		if err != nil {
+			return domain.Prescription{}, nil, err
+		}
+		if !ok {
+			validation.Add("diagnosis_id", "not_found")
+		}
+	}
+
+	if !validation.Empty() {
+		return domain.Prescription{}, validation, domain.ErrValidation
+	}
+
+	now := time.Now().UTC()
+	p := domain.Prescription{
+		ID:                 uuid.New(),
+		PatientID:          pid,
+		PrescribingDoctor:  doctor,
+		DoctorLicense:      trimPtr(in.DoctorLicense),
+		Diagnosis:          diagnosis,
+		DiagnosisID:        diagnosisID,
+		AuthorizedSessions: in.AuthorizedSessions,
+		InsurerCode:        insurer,
+		AuthorizationCode:  trimPtr(in.AuthorizationCode),
+		IssuedAt:           issuedAt,
+		ValidFrom:          validFrom,
+		ValidUntil:         validUntil,
+		Notes:              trimPtr(in.Notes),
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+
+	out, err := uc.repo.Create(ctx, p)
+	if err != nil {
+		return domain.Prescription{}, nil, err
+	}
+	return out, nil, nil
+}
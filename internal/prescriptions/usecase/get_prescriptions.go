@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/prescriptions/domain"
+)
+
+type ListPrescriptionsByPatientUseCase struct {
+	repo domain.Repository
+}
+
+func NewListPrescriptionsByPatientUseCase(repo domain.Repository) *ListPrescriptionsByPatientUseCase {
+	return &ListPrescriptionsByPatientUseCase{repo: repo}
+}
+
+func (uc *ListPrescriptionsByPatientUseCase) Execute(ctx context.Context, patientID uuid.UUID) ([]domain.Prescription, error) {
+	return uc.repo.ListByPatient(ctx, patientID)
+}
+
+type GetPrescriptionUseCase struct {
+	repo domain.Repository
+}
+
+func NewGetPrescriptionUseCase(repo domain.Repository) *GetPrescriptionUseCase {
+	return &GetPrescriptionUseCase{repo: repo}
+}
+
+// Execute devuelve la orden con el detalle de sesiones consumidas.
+func (uc *GetPrescriptionUseCase) Execute(ctx context.Context, id uuid.UUID) (domain.Prescription, []domain.Session, error) {
+	p, found, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.Prescription{}, nil, err
+	}
+	if !found {
+		return domain.Prescription{}, nil, domain.ErrNotFound
+	}
+
+	sessions, err := uc.repo.ListSessions(ctx, id)
+	if err != nil {
+		return domain.Prescription{}, nil, err
+	}
+	return p, sessions, nil
+}
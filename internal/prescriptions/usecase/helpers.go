@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"strings"
+	"time"
+
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+func trimPtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := strings.TrimSpace(*s)
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+func parseRequiredDate(field, s string, validation *validation.Errors) time.Time {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		validation.Add(field, "required")
+		return time.Time{}
+	}
+	tm, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		validation.Add(field, "invalid_date_(YYYY-MM-DD)")
+		return time.Time{}
+	}
+	return tm.UTC()
+}
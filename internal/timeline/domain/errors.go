@@ -0,0 +1,7 @@
+package domain
+
+import "errors"
+
+var (
+	ErrValidation = errors.New("validation_error")
+)
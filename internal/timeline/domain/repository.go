@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	// ListEvents devuelve hasta q.Limit eventos, del más reciente al más antiguo.
+	ListEvents(ctx context.Context, q Query) ([]Event, error)
+	PainTrend(ctx context.Context, patientID uuid.UUID, from, to *time.Time) ([]PainPoint, error)
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	EventAppointment    EventType = "appointment"
+	EventEvolution      EventType = "evolution"
+	EventExercisePlan   EventType = "exercise_plan"
+	EventMaterialLoan   EventType = "material_loan"
+	EventMaterialReturn EventType = "material_return"
+)
+
+var AllEventTypes = []EventType{EventAppointment, EventEvolution, EventExercisePlan, EventMaterialLoan, EventMaterialReturn}
+
+func (t EventType) Valid() bool {
+	for _, v := range AllEventTypes {
+		if t == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Event es una entrada del timeline. SourceID es el id del turno / evolución / plan / préstamo.
+type Event struct {
+	Type            EventType
+	SourceID        uuid.UUID
+	OccurredAt      time.Time
+	KinesiologistID *uuid.UUID
+	Status          *string
+	Summary         string
+	PainLevel       *int // solo evoluciones
+}
+
+// Cursor marca la última entrada devuelta. El timeline se ordena por
+// (occurred_at, type, source_id) descendente, así que el cursor es estable aunque haya empates.
+type Cursor struct {
+	OccurredAt time.Time
+	Type       EventType
+	SourceID   uuid.UUID
+}
+
+var ErrInvalidCursor = errors.New("invalid_cursor")
+
+func CursorFor(e Event) Cursor {
+	return Cursor{OccurredAt: e.OccurredAt, Type: e.Type, SourceID: e.SourceID}
+}
+
+func (c Cursor) Encode() string {
+	raw := strconv.FormatInt(c.OccurredAt.UnixNano(), 10) + "|" + string(c.Type) + "|" + c.SourceID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeCursor(s string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 3 {
+		return Cursor{}, ErrInvalidCursor
+	}
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	t := EventType(parts[1])
+	if !t.Valid() {
+		return Cursor{}, ErrInvalidCursor
+	}
+	id, err := uuid.Parse(parts[2])
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return Cursor{OccurredAt: time.Unix(0, nanos).UTC(), Type: t, SourceID: id}, nil
+}
+
+type Query struct {
+	PatientID uuid.UUID
+	Types     []EventType // vacío = todos
+	From      *time.Time  // inclusive
+	To        *time.Time  // exclusive
+	After     *Cursor
+	Limit     int
+}
+
+// PainPoint: nivel de dolor registrado en una evolución (última versión).
+type PainPoint struct {
+	EvolutionID uuid.UUID
+	RecordedAt  time.Time
+	PainLevel   int
+}
+
+type Page struct {
+	Items      []Event
+	NextCursor *string
+	// PainTrend va completo (orden cronológico) y solo en la primera página.
+	PainTrend []PainPoint
+}
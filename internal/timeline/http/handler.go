@@ -0,0 +1,107 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/javiacuna/kinesio-backend/internal/timeline/domain"
+	"github.com/javiacuna/kinesio-backend/internal/timeline/usecase"
+)
+
+type Handler struct {
+	getUC *usecase.GetTimelineUseCase
+}
+
+func NewHandler(getUC *usecase.GetTimelineUseCase) *Handler {
+	return &Handler{getUC: getUC}
+}
+
+type eventResponse struct {
+	Type            string  `json:"type"`
+	SourceID        string  `json:"source_id"`
+	OccurredAt      string  `json:"occurred_at"`
+	KinesiologistID *string `json:"kinesiologist_id,omitempty"`
+	Status          *string `json:"status,omitempty"`
+	Summary         string  `json:"summary"`
+	PainLevel       *int    `json:"pain_level,omitempty"`
+}
+
+type painPointResponse struct {
+	EvolutionID string `json:"evolution_id"`
+	RecordedAt  string `json:"recorded_at"`
+	PainLevel   int    `json:"pain_level"`
+}
+
+type timelineResponse struct {
+	Items      []eventResponse     `json:"items"`
+	NextCursor *string             `json:"next_cursor"`
+	PainTrend  []painPointResponse `json:"pain_trend,omitempty"`
+}
+
+// Get: ?types=appointment,evolution&from=2025-01-01&to=2025-06-30&limit=50&cursor=...
+// types: appointment|evolution|exercise_plan|material_loan|material_return (todos por defecto).
+func (h *Handler) Get(c *gin.Context) {
+	limit := 0
+	if s := c.Query("limit"); s != "" {
+		if n, err := strconv.Atoi(s); err == nil {
+			limit = n
+		}
+	}
+
+	page, validation, err := h.getUC.Execute(c.Request.Context(), c.Param("patient_id"), usecase.GetTimelineInput{
+		Types:  queryPtr(c, "types"),
+		From:   queryPtr(c, "from"),
+		To:     queryPtr(c, "to"),
+		Cursor: queryPtr(c, "cursor"),
+		Limit:  limit,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrValidation) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": validation})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	out := timelineResponse{
+		Items:      make([]eventResponse, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+	}
+	for _, e := range page.Items {
+		var kID *string
+		if e.KinesiologistID != nil {
+			s := e.KinesiologistID.String()
+			kID = &s
+		}
+		out.Items = append(out.Items, eventResponse{
+			Type:            string(e.Type),
+			SourceID:        e.SourceID.String(),
+			OccurredAt:      e.OccurredAt.UTC().Format(time.RFC3339),
+			KinesiologistID: kID,
+			Status:          e.Status,
+			Summary:         e.Summary,
+			PainLevel:       e.PainLevel,
+		})
+	}
+	for _, p := range page.PainTrend {
+		out.PainTrend = append(out.PainTrend, painPointResponse{
+			EvolutionID: p.EvolutionID.String(),
+			RecordedAt:  p.RecordedAt.UTC().Format(time.RFC3339),
+			PainLevel:   p.PainLevel,
+		})
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+func queryPtr(c *gin.Context, key string) *string {
+	v, ok := c.GetQuery(key)
+	if !ok {
+		return nil
+	}
+	return &v
+}
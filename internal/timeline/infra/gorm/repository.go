@@ -0,0 +1,151 @@
+package gorm
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/javiacuna/kinesio-backend/internal/timeline/domain"
+)
+
+var _ domain.Repository = (*Repository)(nil)
+
+// Repository arma el timeline leyendo directo las tablas de cada módulo (solo lectura).
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Cada fuente devuelve las mismas columnas (con alias, por si queda primera en el UNION ALL).
+// Las evoluciones se fechan con la versión 1 (root): una corrección no mueve la sesión de día.
+var eventSources = map[domain.EventType]string{
+	domain.EventAppointment: `
+		SELECT 'appointment'::text AS event_type, a.id AS source_id, a.start_at AS occurred_at,
+		       a.kinesiologist_id, a.status, COALESCE(a.notes, '') AS summary, NULL::int AS pain_level
+		FROM appointments a WHERE a.patient_id = @patient`,
+	domain.EventEvolution: `
+		SELECT 'evolution'::text AS event_type, e.id AS source_id, r.created_at AS occurred_at,
+		       e.kinesiologist_id, e.status, e.notes AS summary, e.pain_level
+		FROM patient_evolutions e JOIN patient_evolutions r ON r.id = e.root_id
+		WHERE e.patient_id = @patient AND e.is_latest`,
+	domain.EventExercisePlan: `
+		SELECT 'exercise_plan'::text AS event_type, p.id AS source_id, p.created_at AS occurred_at,
+		       p.kinesiologist_id, p.status, concat(p.frequency, ', ', p.duration_weeks, ' semana(s)') AS summary, NULL::int AS pain_level
+		FROM exercise_plans p WHERE p.patient_id = @patient`,
+	domain.EventMaterialLoan: `
+		SELECT 'material_loan'::text AS event_type, l.id AS source_id, l.loaned_at AS occurred_at,
+		       l.kinesiologist_id, NULL::text AS status, concat(m.name, ' x', l.qty) AS summary, NULL::int AS pain_level
+		FROM material_loans l JOIN materials m ON m.id = l.material_id WHERE l.patient_id = @patient`,
+	domain.EventMaterialReturn: `
+		SELECT 'material_return'::text AS event_type, l.id AS source_id, l.returned_at AS occurred_at,
+		       l.kinesiologist_id, NULL::text AS status, concat(m.name, ' x', l.qty) AS summary, NULL::int AS pain_level
+		FROM material_loans l JOIN materials m ON m.id = l.material_id
+		WHERE l.patient_id = @patient AND l.returned_at IS NOT NULL`,
+}
+
+type eventRow struct {
+	EventType       string
+	SourceID        string
+	OccurredAt      time.Time
+	KinesiologistID *string
+	Status          *string
+	Summary         string
+	PainLevel       *int
+}
+
+func (r *Repository) ListEvents(ctx context.Context, q domain.Query) ([]domain.Event, error) {
+	types := q.Types
+	if len(types) == 0 {
+		types = domain.AllEventTypes
+	}
+	parts := make([]string, 0, len(types))
+	for _, t := range types {
+		parts = append(parts, eventSources[t])
+	}
+
+	args := map[string]any{"patient": q.PatientID.String(), "limit": q.Limit}
+	var where []string
+	if q.From != nil {
+		where = append(where, "occurred_at >= @from")
+		args["from"] = *q.From
+	}
+	if q.To != nil {
+		where = append(where, "occurred_at < @to")
+		args["to"] = *q.To
+	}
+	if q.After != nil {
+		where = append(where, "(occurred_at, event_type, source_id) < (@after_at, @after_type, CAST(@after_id AS uuid))")
+		args["after_at"] = q.After.OccurredAt
+		args["after_type"] = string(q.After.Type)
+		args["after_id"] = q.After.SourceID.String()
+	}
+
+	sql := "SELECT * FROM (" + strings.Join(parts, "\n\t\tUNION ALL") + "\n) t"
+	if len(where) > 0 {
+		sql += " WHERE " + strings.Join(where, " AND ")
+	}
+	sql += " ORDER BY occurred_at DESC, event_type DESC, source_id DESC LIMIT @limit"
+
+	var rows []eventRow
+	if err := r.db.WithContext(ctx).Raw(sql, args).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	out := make([]domain.Event, 0, len(rows))
+	for _, row := range rows {
+		e := domain.Event{
+			Type:       domain.EventType(row.EventType),
+			SourceID:   uuid.MustParse(row.SourceID),
+			OccurredAt: row.OccurredAt,
+			Status:     row.Status,
+			Summary:    row.Summary,
+			PainLevel:  row.PainLevel,
+		}
+		if row.KinesiologistID != nil {
+			id := uuid.MustParse(*row.KinesiologistID)
+			e.KinesiologistID = &id
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+// PainTrend usa el dolor de la versión vigente con la fecha de la versión 1 (root).
+func (r *Repository) PainTrend(ctx context.Context, patientID uuid.UUID, from, to *time.Time) ([]domain.PainPoint, error) {
+	q := r.db.WithContext(ctx).
+		Table("patient_evolutions e").
+		Joins("JOIN patient_evolutions r ON r.id = e.root_id").
+		Select("e.id, r.created_at, e.pain_level").
+		Where("e.patient_id = ? AND e.is_latest AND e.pain_level IS NOT NULL", patientID.String())
+	if from != nil {
+		q = q.Where("r.created_at >= ?", *from)
+	}
+	if to != nil {
+		q = q.Where("r.created_at < ?", *to)
+	}
+
+	var rows []struct {
+		ID        string
+		CreatedAt time.Time
+		PainLevel int
+	}
+	if err := q.Order("r.created_at asc, e.id asc").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	out := make([]domain.PainPoint, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, domain.PainPoint{
+			EvolutionID: uuid.MustParse(row.ID),
+			RecordedAt:  row.CreatedAt,
+			PainLevel:   row.PainLevel,
+		})
+	}
+	return out, nil
+}
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/javiacuna/kinesio-backend/internal/timeline/domain"
+	"github.com/javiacuna/kinesio-backend/internal/validation"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 200
+)
+
+type GetTimelineInput struct {
+	Types  *string // lista separada por comas (appointment,evolution,...)
+	From   *string // YYYY-MM-DD
+	To     *string // YYYY-MM-DD (inclusive)
+	Cursor *string
+	Limit  int
+}
+
+type GetTimelineUseCase struct {
+	repo domain.Repository
+}
+
+func NewGetTimelineUseCase(repo domain.Repository) *GetTimelineUseCase {
+	return &GetTimelineUseCase{repo: repo}
+}
+
+func (uc *GetTimelineUseCase) Execute(ctx context.Context, patientID string, in GetTimelineInput) (domain.Page, *validation.Errors, error) {
+	validation := validation.New()
+
+	pid, err := uuid.Parse(strings.TrimSpace(patientID))
+	if err != nil {
+		validation.Add("patient_id", "invalid_uuid")
+	}
+
+	q := domain.Query{PatientID: pid, Limit: in.Limit}
+	if q.Limit <= 0 {
+		q.Limit = defaultLimit
+	}
+	if q.Limit > maxLimit {
+		q.Limit = maxLimit
+	}
+
+	if in.Types != nil {
+		for _, s := range strings.Split(*in.Types, ",") {
+			s = strings.ToLower(strings.TrimSpace(s))
+			if s == "" {
+				continue
+			}
+			t := domain.EventType(s)
+			if !t.Valid() {
+				validation.Add("types", "invalid_type")
+				break
+			}
+			q.Types = append(q.Types, t)
+		}
+	}
+
+	q.From = parseDay("from", in.From, validation)
+	if to := parseDay("to", in.To, validation); to != nil {
+		end := to.AddDate(0, 0, 1)
+		q.To = &end
+	}
+
+	if in.Cursor != nil && strings.TrimSpace(*in.Cursor) != "" {
+		c, err := domain.DecodeCursor(strings.TrimSpace(*in.Cursor))
+		if err != nil {
+			validation.Add("cursor", "invalid_cursor")
+		} else {
+			q.After = &c
+		}
+	}
+
+	if !validation.Empty() {
+		return domain.Page{}, validation, domain.ErrValidation
+	}
+
+	// Se pide uno de más para saber si hay otra página.
+	limit := q.Limit
+	q.Limit = limit + 1
+	items, err := uc.repo.ListEvents(ctx, q)
+	if err != nil {
+		return domain.Page{}, nil, err
+	}
+
+	page := domain.Page{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		next := domain.CursorFor(page.Items[limit-1]).Encode()
+		page.NextCursor = &next
+	}
+
+	if q.After == nil && wantsEvolutions(q.Types) {
+		trend, err := uc.repo.PainTrend(ctx, pid, q.From, q.To)
+		if err != nil {
+			return domain.Page{}, nil, err
+		}
+		page.PainTrend = trend
+	}
+	return page, nil, nil
+}
+
+func wantsEvolutions(types []domain.EventType) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if t == domain.EventEvolution {
+			return true
+		}
+	}
+	return false
+}
+
+func parseDay(field string, s *string, validation *validation.Errors) *time.Time {
+	if s == nil || strings.TrimSpace(*s) == "" {
+		return nil
+	}
+	tm, err := time.Parse("2006-01-02", strings.TrimSpace(*s))
+	if err != nil {
+		validation.Add(field, "invalid_date_(YYYY-MM-DD)")
+		return nil
+	}
+	return &tm
+}
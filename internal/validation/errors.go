@@ -0,0 +1,96 @@
+// Package validation define el formato común de los errores de validación que devuelven los
+// casos de uso: una lista de {field, code, message} con paths del estilo "items[3].name".
+package validation
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// FieldError: error de un campo. Code es estable (lo usa el frontend); Message es para mostrar.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Errors acumula los errores de un request en el orden en que se detectan.
+// Un campo tiene a lo sumo un error: agregar otro para el mismo campo lo reemplaza.
+type Errors struct {
+	list []FieldError
+}
+
+func New() *Errors {
+	return &Errors{}
+}
+
+// Field arma un resultado con un único error.
+func Field(field, code string) *Errors {
+	e := New()
+	e.Add(field, code)
+	return e
+}
+
+// Add registra un error con el mensaje estándar del código.
+func (e *Errors) Add(field, code string) {
+	e.AddMessage(field, code, Message(code))
+}
+
+// AddMessage registra un error con un mensaje propio.
+func (e *Errors) AddMessage(field, code, message string) {
+	fe := FieldError{Field: field, Code: code, Message: message}
+	for i := range e.list {
+		if e.list[i].Field == field {
+			e.list[i] = fe
+			return
+		}
+	}
+	e.list = append(e.list, fe)
+}
+
+func (e *Errors) Has(field string) bool {
+	if e == nil {
+		return false
+	}
+	for _, fe := range e.list {
+		if fe.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Errors) Empty() bool {
+	return e == nil || len(e.list) == 0
+}
+
+// Fields devuelve una copia de los errores, en orden.
+func (e *Errors) Fields() []FieldError {
+	if e == nil {
+		return nil
+	}
+	return append([]FieldError(nil), e.list...)
+}
+
+func (e *Errors) MarshalJSON() ([]byte, error) {
+	if e == nil || e.list == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(e.list)
+}
+
+// OptionalUUID parsea un id opcional: nil o vacío devuelve nil; si es inválido
+// registra invalid_uuid en field.
+func (e *Errors) OptionalUUID(field string, s *string) *uuid.UUID {
+	if s == nil || strings.TrimSpace(*s) == "" {
+		return nil
+	}
+	id, err := uuid.Parse(strings.TrimSpace(*s))
+	if err != nil {
+		e.Add(field, "invalid_uuid")
+		return nil
+	}
+	return &id
+}
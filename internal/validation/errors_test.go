@@ -0,0 +1,139 @@
+package validation
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestErrors_AddReplacesSameField(t *testing.T) {
+	e := New()
+	e.Add("name", "required")
+	e.Add("email", "invalid_format")
+	e.AddMessage("name", "too_large", "Máximo 80 caracteres")
+
+	got := e.Fields()
+	want := []FieldError{
+		{Field: "name", Code: "too_large", Message: "Máximo 80 caracteres"},
+		{Field: "email", Code: "invalid_format", Message: "Formato inválido"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("fields = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("fields[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestErrors_HasAndEmpty(t *testing.T) {
+	var nilErrs *Errors
+	if !nilErrs.Empty() || nilErrs.Has("name") || nilErrs.Fields() != nil {
+		t.Fatal("nil Errors must behave as empty")
+	}
+
+	e := New()
+	if !e.Empty() {
+		t.Fatal("New() must be empty")
+	}
+	e.Add("items[0].name", "required")
+	if e.Empty() {
+		t.Fatal("expected errors")
+	}
+	if !e.Has("items[0].name") || e.Has("items[1].name") {
+		t.Fatalf("Has mismatch: %+v", e.Fields())
+	}
+
+	f := Field("dni", "already_exists")
+	if !f.Has("dni") || len(f.Fields()) != 1 || f.Fields()[0].Message != "Ya existe" {
+		t.Fatalf("Field = %+v", f.Fields())
+	}
+}
+
+func TestErrors_FieldsReturnsCopy(t *testing.T) {
+	e := Field("name", "required")
+	fs := e.Fields()
+	fs[0].Code = "changed"
+	if e.Fields()[0].Code != "required" {
+		t.Fatal("Fields must not expose the internal slice")
+	}
+}
+
+func TestErrors_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		errs *Errors
+		want string
+	}{
+		{name: "nil", errs: nil, want: `[]`},
+		{name: "vacío", errs: New(), want: `[]`},
+		{name: "con errores", errs: Field("name", "required"), want: `[{"field":"name","code":"required","message":"Campo obligatorio"}]`},
+	}
+	// Así es como lo serializan los handlers: gin.H{"details": validation}.
+	if b, _ := json.Marshal(map[string]any{"details": New()}); string(b) != `{"details":[]}` {
+		t.Fatalf("json = %s", b)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := tt.errs.MarshalJSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.want {
+				t.Fatalf("json = %s, want %s", b, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrors_OptionalUUID(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name    string
+		in      *string
+		wantID  string
+		wantErr bool
+	}{
+		{name: "nil", in: nil},
+		{name: "vacío", in: str("  ")},
+		{name: "válido con espacios", in: str(" 0b5c3a8e-2f7d-4a86-9d53-3f1c0e6a9b21 "), wantID: "0b5c3a8e-2f7d-4a86-9d53-3f1c0e6a9b21"},
+		{name: "inválido", in: str("abc"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := New()
+			id := e.OptionalUUID("patient_id", tt.in)
+			switch {
+			case tt.wantID == "" && id != nil:
+				t.Fatalf("id = %v, want nil", id)
+			case tt.wantID != "" && (id == nil || id.String() != tt.wantID):
+				t.Fatalf("id = %v, want %s", id, tt.wantID)
+			}
+			if e.Has("patient_id") != tt.wantErr {
+				t.Fatalf("errors = %+v, wantErr %v", e.Fields(), tt.wantErr)
+			}
+			if tt.wantErr && e.Fields()[0].Code != "invalid_uuid" {
+				t.Fatalf("code = %s, want invalid_uuid", e.Fields()[0].Code)
+			}
+		})
+	}
+}
+
+func TestMessage(t *testing.T) {
+	tests := map[string]string{
+		"required":                 "Campo obligatorio",
+		"must_be_number":           "Debe ser numérico",
+		"must_be_between_0_and_10": "Debe estar entre 0 y 10",
+		"must_be_>=_1":             "Debe ser mayor o igual a 1",
+		"max_20_items":             "Máximo 20 elementos",
+		"requires_sets":            "Requiere sets",
+		"must_be_one_of_a|b":       "Valor no permitido",
+		"whatever":                 "Valor inválido",
+	}
+	for code, want := range tests {
+		if got := Message(code); got != want {
+			t.Errorf("Message(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package validation
+
+import (
+	"strings"
+)
+
+var messages = map[string]string{
+	"required":                    "Campo obligatorio",
+	"invalid_uuid":                "UUID inválido",
+	"invalid":                     "Valor inválido",
+	"invalid_format":              "Formato inválido",
+	"invalid_date_(YYYY-MM-DD)":   "Formato inválido (usar YYYY-MM-DD)",
+	"invalid_datetime_(RFC3339)":  "Formato inválido (usar RFC3339)",
+	"invalid_url":                 "URL inválida",
+	"invalid_cursor":              "Cursor inválido",
+	"not_found":                   "No existe",
+	"already_exists":              "Ya existe",
+	"duplicated":                  "Valor repetido",
+	"inactive":                    "Está inactivo",
+	"empty":                       "No puede estar vacío",
+	"unknown_field":               "Campo desconocido",
+	"unsupported":                 "No soportado",
+	"too_large":                   "Supera el tamaño máximo",
+	"out_of_range":                "Fuera de rango",
+	"must_be_number":              "Debe ser numérico",
+	"must_be_integer":             "Debe ser un número entero",
+	"must_be_positive":            "Debe ser mayor a 0",
+	"must_be_positive_integer":    "Debe ser un entero mayor a 0",
+	"must_be_boolean":             "Debe ser verdadero o falso",
+	"must_be_text":                "Debe ser texto",
+	"must_not_be_in_the_future":   "No puede ser una fecha futura",
+	"must_not_be_in_the_past":     "No puede ser una fecha pasada",
+	"must_not_overlap":            "No se pueden superponer",
+	"must_have_at_least_one_item": "Tiene que tener al menos un elemento",
+	"outside_plan_period":         "Fuera del período del plan",
+	"belongs_to_other_patient":    "Pertenece a otro paciente",
+	"insufficient_stock":          "Stock insuficiente",
+	"not_available":               "No está disponible",
+	"unit_tracked":                "El stock se maneja por unidades",
+}
+
+// Message devuelve el mensaje estándar de un código. Los códigos con parámetros
+// ("must_be_between_0_and_10", "must_be_>=_1", "requires_sets") se arman a partir del código.
+func Message(code string) string {
+	if m, ok := messages[code]; ok {
+		return m
+	}
+	switch {
+	case strings.HasPrefix(code, "must_be_between_"):
+		if a, b, ok := strings.Cut(strings.TrimPrefix(code, "must_be_between_"), "_and_"); ok {
+			return "Debe estar entre " + a + " y " + b
+		}
+	case strings.HasPrefix(code, "must_be_>=_"):
+		return "Debe ser mayor o igual a " + strings.TrimPrefix(code, "must_be_>=_")
+	case strings.HasPrefix(code, "must_be_>_"):
+		return "Debe ser mayor a " + strings.TrimPrefix(code, "must_be_>_")
+	case strings.HasPrefix(code, "must_be_<=_"):
+		return "Debe ser menor o igual a " + strings.TrimPrefix(code, "must_be_<=_")
+	case strings.HasPrefix(code, "max_") && strings.HasSuffix(code, "_items"):
+		return "Máximo " + strings.TrimSuffix(strings.TrimPrefix(code, "max_"), "_items") + " elementos"
+	case strings.HasPrefix(code, "requires_"):
+		return "Requiere " + strings.TrimPrefix(code, "requires_")
+	case strings.HasPrefix(code, "must_be_"), strings.HasPrefix(code, "invalid_"):
+		return "Valor no permitido"
+	}
+	return "Valor inválido"
+}
@@ -0,0 +1,129 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS patient_referring_physicians (
+  id UUID PRIMARY KEY,
+  patient_id UUID NOT NULL REFERENCES patients(id),
+  full_name TEXT NOT NULL,
+  license_number TEXT NULL,
+  specialty TEXT NULL,
+  phone TEXT NULL,
+  email TEXT NULL,
+  notes TEXT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_patient_referring_physicians_patient ON patient_referring_physicians(patient_id);
+
+CREATE TABLE IF NOT EXISTS patient_diagnoses (
+  id UUID PRIMARY KEY,
+  patient_id UUID NOT NULL REFERENCES patients(id),
+  icd10_code TEXT NOT NULL,                 -- CIE-10, ej: M54.5
+  description TEXT NOT NULL,
+  status TEXT NOT NULL DEFAULT 'active',    -- "active" | "resolved"
+  diagnosed_at DATE NULL,
+  referring_physician_id UUID NULL REFERENCES patient_referring_physicians(id),
+  notes TEXT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_patient_diagnoses_patient ON patient_diagnoses(patient_id);
+CREATE INDEX IF NOT EXISTS idx_patient_diagnoses_icd10 ON patient_diagnoses(icd10_code);
+
+CREATE TABLE IF NOT EXISTS patient_injuries (
+  id UUID PRIMARY KEY,
+  patient_id UUID NOT NULL REFERENCES patients(id),
+  diagnosis_id UUID NULL REFERENCES patient_diagnoses(id),
+  site TEXT NOT NULL,
+  laterality TEXT NOT NULL,                 -- "left" | "right" | "bilateral" | "not_applicable"
+  onset_date DATE NULL,
+  mechanism TEXT NULL,
+  notes TEXT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_patient_injuries_patient ON patient_injuries(patient_id);
+
+CREATE TABLE IF NOT EXISTS patient_surgeries (
+  id UUID PRIMARY KEY,
+  patient_id UUID NOT NULL REFERENCES patients(id),
+  procedure TEXT NOT NULL,
+  laterality TEXT NULL,
+  performed_at DATE NULL,
+  surgeon TEXT NULL,
+  notes TEXT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_patient_surgeries_patient ON patient_surgeries(patient_id);
+
+CREATE TABLE IF NOT EXISTS patient_medications (
+  id UUID PRIMARY KEY,
+  patient_id UUID NOT NULL REFERENCES patients(id),
+  name TEXT NOT NULL,
+  dose TEXT NULL,
+  frequency TEXT NULL,
+  start_date DATE NULL,
+  end_date DATE NULL,
+  notes TEXT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_patient_medications_patient ON patient_medications(patient_id);
+
+CREATE TABLE IF NOT EXISTS patient_allergies (
+  id UUID PRIMARY KEY,
+  patient_id UUID NOT NULL REFERENCES patients(id),
+  substance TEXT NOT NULL,
+  reaction TEXT NULL,
+  severity TEXT NOT NULL,                   -- "mild" | "moderate" | "severe"
+  notes TEXT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_patient_allergies_patient ON patient_allergies(patient_id);
+
+CREATE TABLE IF NOT EXISTS patient_contraindications (
+  id UUID PRIMARY KEY,
+  patient_id UUID NOT NULL REFERENCES patients(id),
+  description TEXT NOT NULL,
+  kind TEXT NOT NULL,                       -- "absolute" | "relative"
+  notes TEXT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_patient_contraindications_patient ON patient_contraindications(patient_id);
+
+-- Vínculo de planes y evoluciones con la historia clínica
+ALTER TABLE exercise_plans
+  ADD COLUMN IF NOT EXISTS diagnosis_id UUID NULL REFERENCES patient_diagnoses(id),
+  ADD COLUMN IF NOT EXISTS injury_id UUID NULL REFERENCES patient_injuries(id);
+
+ALTER TABLE patient_evolutions
+  ADD COLUMN IF NOT EXISTS diagnosis_id UUID NULL REFERENCES patient_diagnoses(id),
+  ADD COLUMN IF NOT EXISTS injury_id UUID NULL REFERENCES patient_injuries(id);
+
+CREATE INDEX IF NOT EXISTS idx_exercise_plans_diagnosis ON exercise_plans(diagnosis_id);
+CREATE INDEX IF NOT EXISTS idx_patient_evolutions_diagnosis ON patient_evolutions(diagnosis_id);
+
+-- +goose Down
+ALTER TABLE patient_evolutions
+  DROP COLUMN IF EXISTS injury_id,
+  DROP COLUMN IF EXISTS diagnosis_id;
+
+ALTER TABLE exercise_plans
+  DROP COLUMN IF EXISTS injury_id,
+  DROP COLUMN IF EXISTS diagnosis_id;
+
+DROP TABLE IF EXISTS patient_contraindications;
+DROP TABLE IF EXISTS patient_allergies;
+DROP TABLE IF EXISTS patient_medications;
+DROP TABLE IF EXISTS patient_surgeries;
+DROP TABLE IF EXISTS patient_injuries;
+DROP TABLE IF EXISTS patient_diagnoses;
+DROP TABLE IF EXISTS patient_referring_physicians;
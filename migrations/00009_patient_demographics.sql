@@ -0,0 +1,34 @@
+-- +goose Up
+ALTER TABLE patients
+  ADD COLUMN IF NOT EXISTS health_insurer_code TEXT NULL,
+  ADD COLUMN IF NOT EXISTS affiliate_number TEXT NULL,
+  ADD COLUMN IF NOT EXISTS insurance_plan TEXT NULL,
+  ADD COLUMN IF NOT EXISTS address_street TEXT NULL,
+  ADD COLUMN IF NOT EXISTS address_city TEXT NULL,
+  ADD COLUMN IF NOT EXISTS address_province TEXT NULL,
+  ADD COLUMN IF NOT EXISTS address_postal_code TEXT NULL,
+  ADD COLUMN IF NOT EXISTS emergency_contact_name TEXT NULL,
+  ADD COLUMN IF NOT EXISTS emergency_contact_relationship TEXT NULL,
+  ADD COLUMN IF NOT EXISTS emergency_contact_phone TEXT NULL;
+
+-- Nombre y teléfono del contacto de emergencia van juntos
+ALTER TABLE patients
+  ADD CONSTRAINT ck_patients_emergency_contact
+  CHECK ((emergency_contact_name IS NULL) = (emergency_contact_phone IS NULL));
+
+CREATE INDEX IF NOT EXISTS ix_patients_health_insurer ON patients (health_insurer_code);
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_patients_health_insurer;
+ALTER TABLE patients DROP CONSTRAINT IF EXISTS ck_patients_emergency_contact;
+ALTER TABLE patients
+  DROP COLUMN IF EXISTS emergency_contact_phone,
+  DROP COLUMN IF EXISTS emergency_contact_relationship,
+  DROP COLUMN IF EXISTS emergency_contact_name,
+  DROP COLUMN IF EXISTS address_postal_code,
+  DROP COLUMN IF EXISTS address_province,
+  DROP COLUMN IF EXISTS address_city,
+  DROP COLUMN IF EXISTS address_street,
+  DROP COLUMN IF EXISTS insurance_plan,
+  DROP COLUMN IF EXISTS affiliate_number,
+  DROP COLUMN IF EXISTS health_insurer_code;
@@ -0,0 +1,23 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS patient_attachments (
+  id UUID PRIMARY KEY,
+  patient_id UUID NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+  evolution_id UUID NULL REFERENCES patient_evolutions(id) ON DELETE SET NULL,
+  appointment_id UUID NULL REFERENCES appointments(id) ON DELETE SET NULL,
+  kind TEXT NOT NULL,
+  file_name TEXT NOT NULL,
+  content_type TEXT NOT NULL,
+  size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+  checksum TEXT NOT NULL,
+  storage_key TEXT NOT NULL,
+  description TEXT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS ix_patient_attachments_patient ON patient_attachments (patient_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS ix_patient_attachments_storage_key ON patient_attachments (storage_key);
+-- Deduplicación por contenido dentro de cada paciente
+CREATE UNIQUE INDEX IF NOT EXISTS ux_patient_attachments_checksum ON patient_attachments (patient_id, checksum);
+
+-- +goose Down
+DROP TABLE IF EXISTS patient_attachments;
@@ -0,0 +1,37 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS prescriptions (
+  id UUID PRIMARY KEY,
+  patient_id UUID NOT NULL REFERENCES patients(id),
+  prescribing_doctor TEXT NOT NULL,
+  doctor_license TEXT NULL,
+  diagnosis TEXT NOT NULL,
+  diagnosis_id UUID NULL REFERENCES patient_diagnoses(id),
+  authorized_sessions INT NOT NULL CHECK (authorized_sessions > 0),
+  insurer_code TEXT NULL,
+  authorization_code TEXT NULL,
+  issued_at DATE NOT NULL,
+  valid_from DATE NOT NULL,
+  valid_until DATE NOT NULL,
+  notes TEXT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT ck_prescriptions_validity CHECK (valid_until >= valid_from)
+);
+
+CREATE INDEX IF NOT EXISTS ix_prescriptions_patient_validity ON prescriptions (patient_id, valid_from, valid_until);
+
+-- Una fila por turno atendido que consumió una sesión de la orden
+CREATE TABLE IF NOT EXISTS prescription_sessions (
+  id UUID PRIMARY KEY,
+  prescription_id UUID NOT NULL REFERENCES prescriptions(id) ON DELETE CASCADE,
+  appointment_id UUID NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+  session_date DATE NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_prescription_sessions_appointment ON prescription_sessions (appointment_id);
+CREATE INDEX IF NOT EXISTS ix_prescription_sessions_prescription ON prescription_sessions (prescription_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS prescription_sessions;
+DROP TABLE IF EXISTS prescriptions;
@@ -0,0 +1,61 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS evolution_templates (
+  id UUID PRIMARY KEY,
+  code TEXT NOT NULL,
+  name TEXT NOT NULL,
+  treatment_type TEXT NOT NULL,
+  description TEXT NULL,
+  fields JSONB NOT NULL,
+  active BOOLEAN NOT NULL DEFAULT true,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_evolution_templates_code ON evolution_templates (code);
+CREATE INDEX IF NOT EXISTS idx_evolution_templates_treatment ON evolution_templates (treatment_type);
+
+-- SOAP + plantilla. Las evoluciones existentes quedan con estas columnas en NULL (texto libre en notes).
+ALTER TABLE patient_evolutions
+  ADD COLUMN IF NOT EXISTS subjective TEXT NULL,
+  ADD COLUMN IF NOT EXISTS objective TEXT NULL,
+  ADD COLUMN IF NOT EXISTS assessment TEXT NULL,
+  ADD COLUMN IF NOT EXISTS plan TEXT NULL,
+  ADD COLUMN IF NOT EXISTS template_id UUID NULL REFERENCES evolution_templates(id),
+  ADD COLUMN IF NOT EXISTS template_values JSONB NULL;
+
+-- Plantillas iniciales
+INSERT INTO evolution_templates (id, code, name, treatment_type, description, fields) VALUES
+(
+  'b6f3a1c2-5d4e-4f10-9a61-0c1d2e3f4a01', 'post_lca', 'Post quirúrgico de LCA', 'post_quirurgico',
+  'Seguimiento de reconstrucción de ligamento cruzado anterior',
+  '[
+    {"key":"semana_post_op","label":"Semana post operatoria","section":"subjective","type":"integer","required":true,"min":0,"max":104},
+    {"key":"derrame","label":"Derrame articular","section":"objective","type":"select","required":true,"options":["ninguno","leve","moderado","severo"]},
+    {"key":"flexion_rodilla","label":"Flexión de rodilla","section":"objective","type":"number","required":true,"min":0,"max":160,"unit":"°"},
+    {"key":"extension_rodilla","label":"Déficit de extensión","section":"objective","type":"number","required":false,"min":-20,"max":20,"unit":"°"},
+    {"key":"activacion_cuadriceps","label":"Activación de cuádriceps","section":"objective","type":"boolean","required":false},
+    {"key":"marcha","label":"Marcha","section":"assessment","type":"select","required":false,"options":["con_muletas","sin_asistencia","claudicante","normal"]}
+  ]'::jsonb
+),
+(
+  'b6f3a1c2-5d4e-4f10-9a61-0c1d2e3f4a02', 'lumbalgia', 'Lumbalgia', 'lumbalgia',
+  'Dolor lumbar agudo / crónico',
+  '[
+    {"key":"irradiacion","label":"Irradiación","section":"subjective","type":"select","required":true,"options":["sin_irradiacion","gluteo","muslo","bajo_rodilla"]},
+    {"key":"lasegue","label":"Lasègue positivo","section":"objective","type":"boolean","required":false},
+    {"key":"schober","label":"Test de Schober","section":"objective","type":"number","required":false,"min":0,"max":10,"unit":"cm"},
+    {"key":"distancia_dedos_suelo","label":"Distancia dedos-suelo","section":"objective","type":"number","required":false,"min":0,"max":60,"unit":"cm"},
+    {"key":"banderas_rojas","label":"Banderas rojas","section":"assessment","type":"text","required":false}
+  ]'::jsonb
+)
+ON CONFLICT (code) DO NOTHING;
+
+-- +goose Down
+ALTER TABLE patient_evolutions
+  DROP COLUMN IF EXISTS template_values,
+  DROP COLUMN IF EXISTS template_id,
+  DROP COLUMN IF EXISTS plan,
+  DROP COLUMN IF EXISTS assessment,
+  DROP COLUMN IF EXISTS objective,
+  DROP COLUMN IF EXISTS subjective;
+DROP TABLE IF EXISTS evolution_templates;
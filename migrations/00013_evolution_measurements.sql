@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS evolution_measurements (
+  id UUID PRIMARY KEY,
+  evolution_id UUID NOT NULL REFERENCES patient_evolutions(id) ON DELETE CASCADE,
+  patient_id UUID NOT NULL REFERENCES patients(id),
+  kind TEXT NOT NULL,            -- goniometry | strength | questionnaire
+  metric TEXT NOT NULL,          -- rom | mrc | oswestry | dash | koos | lysholm
+  joint TEXT NULL,
+  movement TEXT NULL,
+  muscle TEXT NULL,
+  side TEXT NULL,                -- left | right | none
+  answers JSONB NULL,
+  subscores JSONB NULL,
+  value DOUBLE PRECISION NULL,
+  notes TEXT NULL,
+  measured_at TIMESTAMPTZ NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_evolution_measurements_evolution ON evolution_measurements (evolution_id);
+CREATE INDEX IF NOT EXISTS idx_evolution_measurements_series ON evolution_measurements (patient_id, metric, measured_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS evolution_measurements;
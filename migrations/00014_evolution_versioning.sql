@@ -0,0 +1,87 @@
+-- +goose Up
+-- Ciclo de vida: borrador -> firmada. Una firmada no se edita: se corrige creando una versión nueva.
+ALTER TABLE patient_evolutions
+  ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'draft',
+  ADD COLUMN IF NOT EXISTS signed_at TIMESTAMPTZ NULL,
+  ADD COLUMN IF NOT EXISTS root_id UUID NULL,
+  ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1,
+  ADD COLUMN IF NOT EXISTS previous_version_id UUID NULL REFERENCES patient_evolutions(id),
+  ADD COLUMN IF NOT EXISTS correction_reason TEXT NULL,
+  ADD COLUMN IF NOT EXISTS is_latest BOOLEAN NOT NULL DEFAULT true;
+
+-- Las evoluciones cargadas antes de este cambio se consideran firmadas (versión 1).
+UPDATE patient_evolutions
+SET status = 'signed', signed_at = created_at, root_id = id, version = 1, is_latest = true
+WHERE root_id IS NULL;
+
+ALTER TABLE patient_evolutions ALTER COLUMN root_id SET NOT NULL;
+ALTER TABLE patient_evolutions
+  ADD CONSTRAINT chk_patient_evolutions_status CHECK (status IN ('draft', 'signed'));
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_patient_evolutions_root_version ON patient_evolutions (root_id, version);
+CREATE UNIQUE INDEX IF NOT EXISTS ux_patient_evolutions_root_latest ON patient_evolutions (root_id) WHERE is_latest;
+
+CREATE TABLE IF NOT EXISTS evolution_addenda (
+  id UUID PRIMARY KEY,
+  evolution_id UUID NOT NULL REFERENCES patient_evolutions(id),
+  root_id UUID NOT NULL,
+  kinesiologist_id UUID NOT NULL,
+  text TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_evolution_addenda_root ON evolution_addenda (root_id, created_at);
+
+-- Inmutabilidad a nivel base: de una evolución firmada solo puede cambiar is_latest (al versionarla).
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION patient_evolutions_guard_signed() RETURNS trigger AS $$
+BEGIN
+  IF OLD.status = 'signed' THEN
+    IF TG_OP = 'DELETE' THEN
+      RAISE EXCEPTION 'evolution % is signed and cannot be deleted', OLD.id;
+    END IF;
+    IF (to_jsonb(NEW) - 'is_latest' - 'updated_at') <> (to_jsonb(OLD) - 'is_latest' - 'updated_at') THEN
+      RAISE EXCEPTION 'evolution % is signed and cannot be modified', OLD.id;
+    END IF;
+  END IF;
+  IF TG_OP = 'DELETE' THEN
+    RETURN OLD;
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION evolution_addenda_append_only() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'evolution addenda are append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_patient_evolutions_guard_signed
+  BEFORE UPDATE OR DELETE ON patient_evolutions
+  FOR EACH ROW EXECUTE FUNCTION patient_evolutions_guard_signed();
+
+CREATE TRIGGER trg_evolution_addenda_append_only
+  BEFORE UPDATE OR DELETE ON evolution_addenda
+  FOR EACH ROW EXECUTE FUNCTION evolution_addenda_append_only();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trg_evolution_addenda_append_only ON evolution_addenda;
+DROP TRIGGER IF EXISTS trg_patient_evolutions_guard_signed ON patient_evolutions;
+DROP FUNCTION IF EXISTS evolution_addenda_append_only();
+DROP FUNCTION IF EXISTS patient_evolutions_guard_signed();
+DROP TABLE IF EXISTS evolution_addenda;
+DROP INDEX IF EXISTS ux_patient_evolutions_root_latest;
+DROP INDEX IF EXISTS ux_patient_evolutions_root_version;
+ALTER TABLE patient_evolutions
+  DROP CONSTRAINT IF EXISTS chk_patient_evolutions_status,
+  DROP COLUMN IF EXISTS is_latest,
+  DROP COLUMN IF EXISTS correction_reason,
+  DROP COLUMN IF EXISTS previous_version_id,
+  DROP COLUMN IF EXISTS version,
+  DROP COLUMN IF EXISTS root_id,
+  DROP COLUMN IF EXISTS signed_at,
+  DROP COLUMN IF EXISTS status;
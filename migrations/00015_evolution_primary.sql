@@ -0,0 +1,27 @@
+-- +goose Up
+ALTER TABLE patient_evolutions
+  ADD COLUMN IF NOT EXISTS is_primary BOOLEAN NOT NULL DEFAULT false;
+
+-- La evolución más antigua de cada turno pasa a ser la principal.
+-- El backfill toca evoluciones firmadas, así que se suspende el trigger de inmutabilidad.
+ALTER TABLE patient_evolutions DISABLE TRIGGER trg_patient_evolutions_guard_signed;
+
+UPDATE patient_evolutions pe
+SET is_primary = true
+FROM (
+  SELECT DISTINCT ON (appointment_id) id
+  FROM patient_evolutions
+  WHERE appointment_id IS NOT NULL AND is_latest
+  ORDER BY appointment_id, created_at ASC
+) first
+WHERE pe.id = first.id;
+
+ALTER TABLE patient_evolutions ENABLE TRIGGER trg_patient_evolutions_guard_signed;
+
+-- A lo sumo una evolución principal (vigente) por turno.
+CREATE UNIQUE INDEX IF NOT EXISTS ux_patient_evolutions_appointment_primary
+  ON patient_evolutions (appointment_id) WHERE is_primary AND is_latest;
+
+-- +goose Down
+DROP INDEX IF EXISTS ux_patient_evolutions_appointment_primary;
+ALTER TABLE patient_evolutions DROP COLUMN IF EXISTS is_primary;
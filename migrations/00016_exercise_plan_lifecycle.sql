@@ -0,0 +1,32 @@
+-- +goose Up
+ALTER TABLE exercise_plans
+  ADD COLUMN IF NOT EXISTS closed_at TIMESTAMPTZ NULL,
+  ADD COLUMN IF NOT EXISTS close_reason TEXT NULL,
+  ADD COLUMN IF NOT EXISTS previous_plan_id UUID NULL REFERENCES exercise_plans(id),
+  ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+
+CREATE INDEX IF NOT EXISTS idx_exercise_plans_previous ON exercise_plans(previous_plan_id);
+-- Para el job de cierre automático
+CREATE INDEX IF NOT EXISTS idx_exercise_plans_active ON exercise_plans(created_at) WHERE status = 'active';
+
+ALTER TABLE exercise_plan_items
+  ADD COLUMN IF NOT EXISTS position INT NOT NULL DEFAULT 0;
+
+-- Los ítems existentes quedan en el orden en que se cargaron.
+UPDATE exercise_plan_items i
+SET position = o.pos
+FROM (
+  SELECT id, row_number() OVER (PARTITION BY plan_id ORDER BY created_at, id) - 1 AS pos
+  FROM exercise_plan_items
+) o
+WHERE i.id = o.id;
+
+-- +goose Down
+ALTER TABLE exercise_plan_items DROP COLUMN IF EXISTS position;
+DROP INDEX IF EXISTS idx_exercise_plans_active;
+DROP INDEX IF EXISTS idx_exercise_plans_previous;
+ALTER TABLE exercise_plans
+  DROP COLUMN IF EXISTS version,
+  DROP COLUMN IF EXISTS previous_plan_id,
+  DROP COLUMN IF EXISTS close_reason,
+  DROP COLUMN IF EXISTS closed_at;
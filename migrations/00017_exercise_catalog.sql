@@ -0,0 +1,34 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS exercises (
+  id UUID PRIMARY KEY,
+  name TEXT NOT NULL,
+  description TEXT NULL,
+  body_region TEXT NOT NULL,
+  goal TEXT NOT NULL,
+  equipment JSONB NOT NULL DEFAULT '[]'::jsonb,
+  tags JSONB NOT NULL DEFAULT '[]'::jsonb,
+  video_url TEXT NULL,
+  guide_url TEXT NULL,
+  default_sets INT NULL,
+  default_reps INT NULL,
+  default_minutes INT NOT NULL DEFAULT 10,
+  active BOOLEAN NOT NULL DEFAULT true,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_exercises_name ON exercises (lower(name));
+CREATE INDEX IF NOT EXISTS idx_exercises_region_goal ON exercises (body_region, goal);
+CREATE INDEX IF NOT EXISTS idx_exercises_tags ON exercises USING GIN (tags);
+CREATE INDEX IF NOT EXISTS idx_exercises_equipment ON exercises USING GIN (equipment);
+
+-- Los ítems pueden referenciar un ejercicio del catálogo (los valores se copian al plan).
+ALTER TABLE exercise_plan_items
+  ADD COLUMN IF NOT EXISTS exercise_id UUID NULL REFERENCES exercises(id);
+
+CREATE INDEX IF NOT EXISTS idx_exercise_plan_items_exercise ON exercise_plan_items(exercise_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_exercise_plan_items_exercise;
+ALTER TABLE exercise_plan_items DROP COLUMN IF EXISTS exercise_id;
+DROP TABLE IF EXISTS exercises;
@@ -0,0 +1,27 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS exercise_plan_templates (
+  id UUID PRIMARY KEY,
+  code TEXT NOT NULL,
+  version INT NOT NULL,
+  is_latest BOOLEAN NOT NULL DEFAULT true,
+  name TEXT NOT NULL,
+  description TEXT NULL,
+  frequency TEXT NOT NULL CHECK (frequency IN ('daily','weekly')),
+  phases JSONB NOT NULL DEFAULT '[]'::jsonb,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_exercise_plan_templates_code_version ON exercise_plan_templates(code, version);
+-- Una sola versión vigente por plantilla.
+CREATE UNIQUE INDEX IF NOT EXISTS ux_exercise_plan_templates_latest ON exercise_plan_templates(code) WHERE is_latest;
+
+-- El plan guarda la versión exacta de la plantilla de la que salió.
+ALTER TABLE exercise_plans
+  ADD COLUMN IF NOT EXISTS template_id UUID NULL REFERENCES exercise_plan_templates(id);
+
+CREATE INDEX IF NOT EXISTS idx_exercise_plans_template ON exercise_plans(template_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_exercise_plans_template;
+ALTER TABLE exercise_plans DROP COLUMN IF EXISTS template_id;
+DROP TABLE IF EXISTS exercise_plan_templates;
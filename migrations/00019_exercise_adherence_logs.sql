@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS exercise_adherence_logs (
+  id UUID PRIMARY KEY,
+  plan_id UUID NOT NULL REFERENCES exercise_plans(id) ON DELETE CASCADE,
+  -- Si el ejercicio se quita del plan, sus registros dejan de contar.
+  item_id UUID NOT NULL REFERENCES exercise_plan_items(id) ON DELETE CASCADE,
+  patient_id UUID NOT NULL REFERENCES patients(id),
+  date DATE NOT NULL,
+  sets_completed INT NULL CHECK (sets_completed >= 0),
+  reps_completed INT NULL CHECK (reps_completed >= 0),
+  difficulty INT NULL CHECK (difficulty BETWEEN 1 AND 10),
+  pain_level INT NULL CHECK (pain_level BETWEEN 0 AND 10),
+  comment TEXT NULL,
+  reported_by TEXT NOT NULL CHECK (reported_by IN ('patient','receptionist')),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_exercise_adherence_logs_plan_date ON exercise_adherence_logs(plan_id, date);
+CREATE INDEX IF NOT EXISTS idx_exercise_adherence_logs_patient ON exercise_adherence_logs(patient_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS exercise_adherence_logs;
@@ -0,0 +1,21 @@
+-- +goose Up
+ALTER TABLE exercise_plans
+  ADD COLUMN IF NOT EXISTS phases JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+-- Programación por ejercicio: días de la semana, fase del plan, carga y progresión semanal.
+ALTER TABLE exercise_plan_items
+  ADD COLUMN IF NOT EXISTS load NUMERIC(8,2) NULL CHECK (load >= 0),
+  ADD COLUMN IF NOT EXISTS load_unit TEXT NULL,
+  ADD COLUMN IF NOT EXISTS weekdays JSONB NOT NULL DEFAULT '[]'::jsonb,
+  ADD COLUMN IF NOT EXISTS phase TEXT NULL,
+  ADD COLUMN IF NOT EXISTS progression JSONB NULL;
+
+-- +goose Down
+ALTER TABLE exercise_plan_items
+  DROP COLUMN IF EXISTS progression,
+  DROP COLUMN IF EXISTS phase,
+  DROP COLUMN IF EXISTS weekdays,
+  DROP COLUMN IF EXISTS load_unit,
+  DROP COLUMN IF EXISTS load;
+
+ALTER TABLE exercise_plans DROP COLUMN IF EXISTS phases;
@@ -0,0 +1,38 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS material_stock_movements (
+  id UUID PRIMARY KEY,
+  material_id UUID NOT NULL REFERENCES materials(id),
+  kind TEXT NOT NULL CHECK (kind IN ('initial','restock','write_off','loan','return','adjustment')),
+  total_delta INT NOT NULL DEFAULT 0,
+  available_delta INT NOT NULL DEFAULT 0,
+  reason TEXT NULL,
+  note TEXT NULL,
+  loan_id UUID NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_material_stock_movements_material ON material_stock_movements(material_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_material_stock_movements_loan ON material_stock_movements(loan_id);
+
+-- Reconstruye el libro de los materiales existentes a partir del stock inicial
+-- y del historial de préstamos.
+INSERT INTO material_stock_movements (id, material_id, kind, total_delta, available_delta, note, created_at)
+SELECT gen_random_uuid(), id, 'initial', total_qty, total_qty, 'migrado', created_at
+FROM materials
+WHERE total_qty > 0;
+
+INSERT INTO material_stock_movements (id, material_id, kind, available_delta, loan_id, created_at)
+SELECT gen_random_uuid(), material_id, 'loan', -qty, id, loaned_at
+FROM material_loans;
+
+INSERT INTO material_stock_movements (id, material_id, kind, available_delta, loan_id, created_at)
+SELECT gen_random_uuid(), material_id, 'return', qty, id, returned_at
+FROM material_loans
+WHERE returned_at IS NOT NULL;
+
+ALTER TABLE materials
+  ADD CONSTRAINT chk_materials_stock CHECK (available_qty >= 0 AND available_qty <= total_qty) NOT VALID;
+
+-- +goose Down
+ALTER TABLE materials DROP CONSTRAINT IF EXISTS chk_materials_stock;
+DROP TABLE IF EXISTS material_stock_movements;
@@ -0,0 +1,25 @@
+-- +goose Up
+ALTER TABLE materials
+  ADD COLUMN IF NOT EXISTS default_loan_days INT NULL CHECK (default_loan_days > 0);
+
+ALTER TABLE material_loans
+  ADD COLUMN IF NOT EXISTS due_date DATE NULL,
+  ADD COLUMN IF NOT EXISTS returned_qty INT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS overdue_notified_at TIMESTAMPTZ NULL;
+
+-- Los préstamos ya devueltos se devolvieron completos.
+UPDATE material_loans SET returned_qty = qty WHERE returned_at IS NOT NULL;
+
+ALTER TABLE material_loans
+  ADD CONSTRAINT chk_material_loans_returned_qty CHECK (returned_qty >= 0 AND returned_qty <= qty);
+
+CREATE INDEX IF NOT EXISTS idx_material_loans_due_date ON material_loans(due_date) WHERE returned_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_material_loans_due_date;
+ALTER TABLE material_loans DROP CONSTRAINT IF EXISTS chk_material_loans_returned_qty;
+ALTER TABLE material_loans
+  DROP COLUMN IF EXISTS overdue_notified_at,
+  DROP COLUMN IF EXISTS returned_qty,
+  DROP COLUMN IF EXISTS due_date;
+ALTER TABLE materials DROP COLUMN IF EXISTS default_loan_days;
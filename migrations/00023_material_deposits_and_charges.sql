@@ -0,0 +1,53 @@
+-- +goose Up
+ALTER TABLE materials
+  ADD COLUMN IF NOT EXISTS deposit_cents BIGINT NULL CHECK (deposit_cents >= 0),
+  ADD COLUMN IF NOT EXISTS rental_fee_cents BIGINT NULL CHECK (rental_fee_cents >= 0),
+  ADD COLUMN IF NOT EXISTS rental_period TEXT NULL CHECK (rental_period IN ('day','week','month')),
+  ADD COLUMN IF NOT EXISTS replacement_cost_cents BIGINT NULL CHECK (replacement_cost_cents >= 0);
+
+ALTER TABLE material_loans
+  ADD COLUMN IF NOT EXISTS deposit_cents BIGINT NOT NULL DEFAULT 0 CHECK (deposit_cents >= 0),
+  ADD COLUMN IF NOT EXISTS deposit_applied_cents BIGINT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS deposit_refunded_cents BIGINT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS rental_fee_cents BIGINT NULL,
+  ADD COLUMN IF NOT EXISTS rental_period TEXT NULL,
+  ADD COLUMN IF NOT EXISTS charged_cents BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS material_loan_returns (
+  id UUID PRIMARY KEY,
+  loan_id UUID NOT NULL REFERENCES material_loans(id),
+  qty INT NOT NULL CHECK (qty > 0),
+  condition TEXT NOT NULL CHECK (condition IN ('ok','damaged','lost')),
+  written_off BOOLEAN NOT NULL DEFAULT false,
+  notes TEXT NULL,
+  rental_cents BIGINT NOT NULL DEFAULT 0,
+  charge_cents BIGINT NOT NULL DEFAULT 0,
+  deposit_applied_cents BIGINT NOT NULL DEFAULT 0,
+  deposit_refunded_cents BIGINT NOT NULL DEFAULT 0,
+  balance_due_cents BIGINT NOT NULL DEFAULT 0,
+  returned_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_material_loan_returns_loan ON material_loan_returns(loan_id);
+
+-- Las devoluciones anteriores (tomadas del libro de stock) quedan en buen estado y sin cargos.
+INSERT INTO material_loan_returns (id, loan_id, qty, condition, returned_at)
+SELECT gen_random_uuid(), mv.loan_id, mv.available_delta, 'ok', mv.created_at
+FROM material_stock_movements mv
+JOIN material_loans l ON l.id = mv.loan_id
+WHERE mv.kind = 'return' AND mv.available_delta > 0;
+
+-- +goose Down
+DROP TABLE IF EXISTS material_loan_returns;
+ALTER TABLE material_loans
+  DROP COLUMN IF EXISTS charged_cents,
+  DROP COLUMN IF EXISTS rental_period,
+  DROP COLUMN IF EXISTS rental_fee_cents,
+  DROP COLUMN IF EXISTS deposit_refunded_cents,
+  DROP COLUMN IF EXISTS deposit_applied_cents,
+  DROP COLUMN IF EXISTS deposit_cents;
+ALTER TABLE materials
+  DROP COLUMN IF EXISTS replacement_cost_cents,
+  DROP COLUMN IF EXISTS rental_period,
+  DROP COLUMN IF EXISTS rental_fee_cents,
+  DROP COLUMN IF EXISTS deposit_cents;
@@ -0,0 +1,76 @@
+-- +goose Up
+ALTER TABLE materials
+  ADD COLUMN IF NOT EXISTS tracking_mode TEXT NOT NULL DEFAULT 'quantity' CHECK (tracking_mode IN ('quantity','unit'));
+
+CREATE TABLE IF NOT EXISTS material_units (
+  id UUID PRIMARY KEY,
+  material_id UUID NOT NULL REFERENCES materials(id),
+  serial TEXT NOT NULL,
+  status TEXT NOT NULL CHECK (status IN ('available','loaned','maintenance','retired')),
+  notes TEXT NULL,
+  acquired_at DATE NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_material_units_serial ON material_units(material_id, lower(serial));
+CREATE INDEX IF NOT EXISTS idx_material_units_material_status ON material_units(material_id, status);
+
+CREATE TABLE IF NOT EXISTS material_loan_units (
+  loan_id UUID NOT NULL REFERENCES material_loans(id),
+  unit_id UUID NOT NULL REFERENCES material_units(id),
+  return_id UUID NULL REFERENCES material_loan_returns(id),
+  returned_at TIMESTAMPTZ NULL,
+  PRIMARY KEY (loan_id, unit_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_material_loan_units_unit ON material_loan_units(unit_id);
+
+CREATE TABLE IF NOT EXISTS material_unit_maintenance (
+  id UUID PRIMARY KEY,
+  unit_id UUID NOT NULL REFERENCES material_units(id),
+  kind TEXT NOT NULL CHECK (kind IN ('inspection','repair','calibration','cleaning','other')),
+  description TEXT NULL,
+  cost_cents BIGINT NULL CHECK (cost_cents >= 0),
+  out_of_service BOOLEAN NOT NULL DEFAULT false,
+  started_at TIMESTAMPTZ NOT NULL,
+  completed_at TIMESTAMPTZ NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_material_unit_maintenance_unit ON material_unit_maintenance(unit_id, started_at DESC);
+
+-- Movimientos por unidad y entradas/salidas por mantenimiento.
+ALTER TABLE material_stock_movements
+  ADD COLUMN IF NOT EXISTS unit_id UUID NULL REFERENCES material_units(id);
+
+ALTER TABLE material_stock_movements DROP CONSTRAINT IF EXISTS material_stock_movements_kind_check;
+ALTER TABLE material_stock_movements
+  ADD CONSTRAINT material_stock_movements_kind_check
+  CHECK (kind IN ('initial','restock','write_off','loan','return','adjustment','maintenance_out','maintenance_in'));
+
+-- +goose Down
+-- Las unidades en mantenimiento vuelven a estar disponibles: el disponible de los materiales
+-- afectados se recalcula con el libro que queda sin esos movimientos.
+UPDATE materials m
+SET available_qty = l.available
+FROM (
+  SELECT material_id,
+    COALESCE(SUM(available_delta) FILTER (WHERE kind NOT IN ('maintenance_out','maintenance_in')), 0) AS available
+  FROM material_stock_movements
+  GROUP BY material_id
+  HAVING bool_or(kind IN ('maintenance_out','maintenance_in'))
+) l
+WHERE m.id = l.material_id;
+
+ALTER TABLE material_stock_movements DROP CONSTRAINT IF EXISTS material_stock_movements_kind_check;
+DELETE FROM material_stock_movements WHERE kind IN ('maintenance_out','maintenance_in');
+ALTER TABLE material_stock_movements
+  ADD CONSTRAINT material_stock_movements_kind_check
+  CHECK (kind IN ('initial','restock','write_off','loan','return','adjustment'));
+ALTER TABLE material_stock_movements DROP COLUMN IF EXISTS unit_id;
+
+DROP TABLE IF EXISTS material_unit_maintenance;
+DROP TABLE IF EXISTS material_loan_units;
+DROP TABLE IF EXISTS material_units;
+ALTER TABLE materials DROP COLUMN IF EXISTS tracking_mode;
@@ -0,0 +1,16 @@
+-- +goose Up
+ALTER TABLE materials
+  ADD COLUMN IF NOT EXISTS category TEXT NULL,
+  ADD COLUMN IF NOT EXISTS low_stock_threshold INT NULL CHECK (low_stock_threshold > 0),
+  ADD COLUMN IF NOT EXISTS low_stock_notified_at TIMESTAMPTZ NULL;
+
+CREATE INDEX IF NOT EXISTS idx_materials_category ON materials(lower(category));
+CREATE INDEX IF NOT EXISTS idx_materials_name_id ON materials(name, id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_materials_name_id;
+DROP INDEX IF EXISTS idx_materials_category;
+ALTER TABLE materials
+  DROP COLUMN IF EXISTS low_stock_notified_at,
+  DROP COLUMN IF EXISTS low_stock_threshold,
+  DROP COLUMN IF EXISTS category;